@@ -0,0 +1,48 @@
+"""渲染后处理可选组件：未选择 use_ent 时删除 ent 版本的 repo，选择时拉取 ent 并生成客户端"""
+import os
+import shutil
+import subprocess
+import sys
+
+USE_ENT = "{{ cookiecutter.use_ent }}".lower() in ("y", "yes", "true")
+ENT_VERSION = "v0.14.5"
+# entc 通过 x/tools 加载 schema，ent 依赖的旧版本读不了新工具链的标准库导出数据
+TOOLS_VERSION = "v0.49.0"
+ENT_PATHS = [
+    os.path.join("internal", "data", "ent"),
+    os.path.join("internal", "data", "{{ cookiecutter.file_name }}_ent.go"),
+]
+
+
+def remove_ent():
+    for path in ENT_PATHS:
+        if os.path.isdir(path):
+            shutil.rmtree(path)
+        elif os.path.exists(path):
+            os.remove(path)
+
+
+def generate_ent():
+    # ent 生成的客户端不提交到模板，不生成时 go mod tidy 找不到 internal/data/ent 下的包
+    steps = [
+        ["go", "get", "entgo.io/ent@" + ENT_VERSION, "golang.org/x/tools@" + TOOLS_VERSION],
+        ["go", "generate", "-tags", "entrepo", "./internal/data/ent"],
+    ]
+    for step in steps:
+        try:
+            subprocess.run(step, check=True)
+        except (OSError, subprocess.CalledProcessError) as e:
+            print("ERROR: %s: %s" % (" ".join(step), e))
+            print("ERROR: run `make ent` in the generated project once Go and network access are available")
+            sys.exit(1)
+
+
+def main():
+    if USE_ENT:
+        generate_ent()
+    else:
+        remove_ent()
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,35 @@
+"""渲染前校验端口变量：必须是 1-65535 的整数且互不相同"""
+import sys
+
+PORTS = {
+    "http_port": "{{ cookiecutter.http_port }}",
+    "grpc_port": "{{ cookiecutter.grpc_port }}",
+    "admin_port": "{{ cookiecutter.admin_port }}",
+    "metrics_port": "{{ cookiecutter.metrics_port }}",
+}
+
+
+def main():
+    seen = {}
+    errors = []
+    for name, value in PORTS.items():
+        try:
+            port = int(value)
+        except ValueError:
+            errors.append("%s=%r is not a number" % (name, value))
+            continue
+        if not 1 <= port <= 65535:
+            errors.append("%s=%d is out of range 1-65535" % (name, port))
+            continue
+        if port in seen:
+            errors.append("%s=%d conflicts with %s" % (name, port, seen[port]))
+            continue
+        seen[port] = name
+    if errors:
+        for e in errors:
+            print("ERROR: %s" % e)
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
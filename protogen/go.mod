@@ -0,0 +1,10 @@
+module github.com/snac21/cookiecutter-kratos/protogen
+
+go 1.25.3
+
+require (
+	github.com/bufbuild/protocompile v0.14.1
+	google.golang.org/protobuf v1.36.6
+)
+
+require golang.org/x/sync v0.8.0 // indirect
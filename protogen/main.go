@@ -0,0 +1,327 @@
+// protogen 生成模板中的 *.pb.go
+// 模板变量先替换为占位名再编译 proto，生成的代码再替换回模板变量：
+//   - go_package 通过插件的 M 参数传入，不写入描述符，渲染后模块名任意长度都不影响描述符
+//   - 描述符中仍含模板变量（文件路径、服务名、消息名）时以文本格式保存，初始化时编码为二进制
+//
+// 修改模板中的 proto 后执行，插件需在 PATH 中（make init 安装），不依赖 protoc：
+//
+//	cd protogen && go run . -template ..
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bufbuild/protocompile"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+var (
+	flagTemplate string
+	flagPlugins  string
+)
+
+func init() {
+	flag.StringVar(&flagTemplate, "template", "..", "template root, the directory containing cookiecutter.json")
+	flag.StringVar(&flagPlugins, "plugins", "go,go-grpc,go-http", "protoc-gen-* plugins run on api protos, internal protos only use go")
+}
+
+// vars 模板变量与编译时使用的占位名，占位名都含 zqx，生成后残留时报错
+// 同一变量有多个占位名时编译使用第一个，未导出的标识符（如 grpc 客户端结构体）按模板的约定使用 file_name
+var vars = []struct{ placeholder, name string }{
+	{"{{cookiecutter.module_name}}", "example.zqx/zqxmod"},
+	{"{{cookiecutter.service_name}}", "Zqxsvc"},
+	{"{{cookiecutter.file_name}}", "zqxfile"},
+	{"{{cookiecutter.file_name}}", "zqxsvc"},
+	{"{{cookiecutter.repo_name}}", "zqxrepo"},
+}
+
+const marker = "zqx"
+
+// group 一组 proto，与生成项目 Makefile 的 config、api 目标对应
+type group struct {
+	root    string
+	plugins []string
+}
+
+func main() {
+	flag.Parse()
+	project := filepath.Join(flagTemplate, "{{cookiecutter.repo_name}}")
+	groups := []group{
+		{root: "internal", plugins: []string{"go"}},
+		{root: "api", plugins: strings.Split(flagPlugins, ",")},
+	}
+	for _, g := range groups {
+		if err := generate(project, g); err != nil {
+			fmt.Fprintf(os.Stderr, "protogen: %s: %v\n", g.root, err)
+			os.Exit(1)
+		}
+	}
+}
+
+// generate 编译一组 proto 并依次执行插件
+func generate(project string, g group) error {
+	root := filepath.Join(project, g.root)
+	files, packages, err := collect(root)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return nil
+	}
+	thirdParty := filepath.Join(project, "third_party")
+	c := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{
+			ImportPaths: []string{root, thirdParty},
+			Accessor: func(path string) (io.ReadCloser, error) {
+				b, err := os.ReadFile(toTemplate(path))
+				if err != nil {
+					return nil, err
+				}
+				if strings.HasPrefix(path, root+string(filepath.Separator)) {
+					b = []byte(stripGoPackage(toNames(string(b))))
+				}
+				return io.NopCloser(bytes.NewReader(b)), nil
+			},
+		}),
+		SourceInfoMode: protocompile.SourceInfoStandard,
+	}
+	res, err := c.Compile(context.Background(), files...)
+	if err != nil {
+		return err
+	}
+	var all []*descriptorpb.FileDescriptorProto
+	seen := make(map[string]bool)
+	var add func(fd protoreflect.FileDescriptor)
+	add = func(fd protoreflect.FileDescriptor) {
+		if seen[fd.Path()] {
+			return
+		}
+		seen[fd.Path()] = true
+		imports := fd.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			add(imports.Get(i).FileDescriptor)
+		}
+		all = append(all, protodesc.ToFileDescriptorProto(fd))
+	}
+	for _, fd := range res {
+		add(fd)
+	}
+
+	params := []string{"paths=source_relative"}
+	for _, f := range files {
+		params = append(params, "M"+f+"="+packages[f])
+	}
+	for _, plugin := range g.plugins {
+		req := &pluginpb.CodeGeneratorRequest{
+			FileToGenerate: files,
+			Parameter:      proto.String(strings.Join(params, ",")),
+			ProtoFile:      all,
+		}
+		out, err := runPlugin("protoc-gen-"+plugin, req)
+		if err != nil {
+			return err
+		}
+		for _, f := range out {
+			content, err := finalize(f.GetContent(), all)
+			if err != nil {
+				return fmt.Errorf("%s: %w", f.GetName(), err)
+			}
+			target := filepath.Join(root, toTemplate(f.GetName()))
+			if err := os.WriteFile(target, []byte(content), 0o644); err != nil {
+				return err
+			}
+			fmt.Println(filepath.Join(g.root, toTemplate(f.GetName())))
+		}
+	}
+	return nil
+}
+
+// collect 查找目录下的 proto，返回占位名形式的相对路径和各文件的 go_package
+func collect(root string) ([]string, map[string]string, error) {
+	var files []string
+	packages := make(map[string]string)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".proto" {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		m := goPackage.FindStringSubmatch(string(b))
+		if m == nil {
+			return fmt.Errorf("%s: missing go_package", rel)
+		}
+		name := toNames(filepath.ToSlash(rel))
+		files = append(files, name)
+		packages[name] = toNames(m[1])
+		return nil
+	})
+	sort.Strings(files)
+	return files, packages, err
+}
+
+var goPackage = regexp.MustCompile(`(?m)^option go_package = "([^"]+)";\n`)
+
+// stripGoPackage 去掉 go_package，改由 M 参数传给插件
+func stripGoPackage(s string) string {
+	return goPackage.ReplaceAllString(s, "")
+}
+
+// runPlugin 执行插件，返回生成的文件
+func runPlugin(name string, req *pluginpb.CodeGeneratorRequest) ([]*pluginpb.CodeGeneratorResponse_File, error) {
+	in, err := proto.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.Command(name)
+	cmd.Stdin = bytes.NewReader(in)
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	var resp pluginpb.CodeGeneratorResponse
+	if err := proto.Unmarshal(out, &resp); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("%s: %s", name, resp.GetError())
+	}
+	return resp.File, nil
+}
+
+var rawDesc = regexp.MustCompile(`(?m)^const (file_\w+_rawDesc) = (?:.*\+\n)*.*\n`)
+
+// finalize 描述符含占位名时改为文本格式，再将占位名替换回模板变量
+func finalize(content string, files []*descriptorpb.FileDescriptorProto) (string, error) {
+	if m := rawDesc.FindStringSubmatchIndex(content); m != nil {
+		ident := content[m[2]:m[3]]
+		fd := findFile(files, ident)
+		if fd == nil {
+			return "", fmt.Errorf("no descriptor for %s", ident)
+		}
+		b, err := proto.Marshal(stripSourceInfo(fd))
+		if err != nil {
+			return "", err
+		}
+		if strings.Contains(strings.ToLower(string(b)), marker) {
+			text, err := textDesc(ident, fd)
+			if err != nil {
+				return "", err
+			}
+			content = content[:m[0]] + text + content[m[1]:]
+			content = strings.Replace(content, "import (\n", "import (\n"+
+				"\tprototext \"google.golang.org/protobuf/encoding/prototext\"\n"+
+				"\tproto \"google.golang.org/protobuf/proto\"\n"+
+				"\tdescriptorpb \"google.golang.org/protobuf/types/descriptorpb\"\n", 1)
+		}
+	}
+	src, err := format.Source([]byte(content))
+	if err != nil {
+		return "", err
+	}
+	content = toTemplate(string(src))
+	if i := strings.Index(strings.ToLower(content), marker); i >= 0 {
+		return "", fmt.Errorf("unmapped name %q", content[max(i-20, 0):min(i+20, len(content))])
+	}
+	for _, s := range []string{"{%", "{#"} {
+		if strings.Contains(content, s) {
+			return "", fmt.Errorf("output contains jinja delimiter %s", s)
+		}
+	}
+	return content, nil
+}
+
+var textKey = regexp.MustCompile(`(?m)^(\s*[\w.\[\]]+):\s+`)
+
+// textDesc 文本格式的描述符及初始化时编码的变量
+func textDesc(ident string, fd *descriptorpb.FileDescriptorProto) (string, error) {
+	b, err := prototext.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(stripSourceInfo(fd))
+	if err != nil {
+		return "", err
+	}
+	// prototext 会随机多输出一个空格，统一为一个，重新生成时内容稳定
+	b = textKey.ReplaceAll(b, []byte("$1: "))
+	if bytes.ContainsRune(b, '`') {
+		return "", fmt.Errorf("descriptor text contains a backquote")
+	}
+	return fmt.Sprintf(`// %[1]s 描述符中的路径、服务名、消息名随模板变量变化，以文本格式保存，初始化时编码
+var %[1]s = func() string {
+	fd := new(descriptorpb.FileDescriptorProto)
+	if err := prototext.Unmarshal([]byte(%[1]sText), fd); err != nil {
+		panic(err)
+	}
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(fd)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}()
+
+const %[1]sText = `+"`\n%[2]s`\n", ident, b), nil
+}
+
+// findFile 按生成代码中的变量名找到对应的描述符
+func findFile(files []*descriptorpb.FileDescriptorProto, ident string) *descriptorpb.FileDescriptorProto {
+	for _, fd := range files {
+		if "file_"+sanitize(fd.GetName())+"_rawDesc" == ident {
+			return fd
+		}
+	}
+	return nil
+}
+
+// sanitize 与 protoc-gen-go 一致，路径中非字母数字的字符替换为下划线
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') {
+			return r
+		}
+		return '_'
+	}, s)
+}
+
+// stripSourceInfo 与 protoc-gen-go 一致，描述符中不保留源码位置信息
+func stripSourceInfo(fd *descriptorpb.FileDescriptorProto) *descriptorpb.FileDescriptorProto {
+	fd = proto.Clone(fd).(*descriptorpb.FileDescriptorProto)
+	fd.SourceCodeInfo = nil
+	return fd
+}
+
+// toNames 模板变量替换为占位名
+func toNames(s string) string {
+	for _, v := range vars {
+		s = strings.ReplaceAll(s, v.placeholder, v.name)
+	}
+	return s
+}
+
+// toTemplate 占位名替换回模板变量
+func toTemplate(s string) string {
+	for _, v := range vars {
+		s = strings.ReplaceAll(s, v.name, v.placeholder)
+	}
+	return s
+}
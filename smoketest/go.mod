@@ -0,0 +1,3 @@
+module github.com/snac21/cookiecutter-kratos/smoketest
+
+go 1.25.3
@@ -0,0 +1,413 @@
+// smoketest 模板冒烟测试
+// 按 matrix.json 中的选项组合渲染模板，对每个生成的项目执行 go build ./... 和 go test ./...，
+// 任一组合失败时以非零状态码退出，可在任意 CI 中运行：
+//
+//	cd smoketest && go run . -template .. -matrix matrix.json
+//
+// 组合中以 _ 开头的键不传给 cookiecutter，用于构建维度：
+// _tags 为逗号分隔的构建标签（如 sqlx），每个标签单独执行 build、vet 和 test；
+// _goos 为交叉编译的目标系统（如 windows），只执行 build 和 vet
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	flagTemplate string
+	flagMatrix   string
+	flagOutput   string
+	flagKeep     bool
+	flagRenderer string
+)
+
+func init() {
+	flag.StringVar(&flagTemplate, "template", "..", "template root, the directory containing cookiecutter.json")
+	flag.StringVar(&flagMatrix, "matrix", "matrix.json", "option matrix, a JSON array of cookiecutter extra context")
+	flag.StringVar(&flagOutput, "output", "", "output directory, a temp directory by default")
+	flag.BoolVar(&flagKeep, "keep", false, "keep generated projects")
+	flag.StringVar(&flagRenderer, "renderer", "auto", "renderer: auto, cookiecutter or builtin")
+}
+
+// result 单个组合的执行结果
+type result struct {
+	name string
+	err  error
+}
+
+func main() {
+	flag.Parse()
+
+	defaults, copyOnly, err := loadTemplate(flagTemplate)
+	if err != nil {
+		fatal(err)
+	}
+	matrix, err := loadMatrix(flagMatrix)
+	if err != nil {
+		fatal(err)
+	}
+
+	output := flagOutput
+	if output == "" {
+		if output, err = os.MkdirTemp("", "cookiecutter-smoketest-"); err != nil {
+			fatal(err)
+		}
+	}
+	if !flagKeep {
+		defer os.RemoveAll(output)
+	}
+
+	var results []result
+	for i, extra := range matrix {
+		ctx := merge(defaults, extra)
+		name := fmt.Sprintf("#%d %s", i, describe(extra))
+		fmt.Printf("=== RUN   %s\n", name)
+		start := time.Now()
+		err := run(ctx, copyOnly, filepath.Join(output, fmt.Sprintf("case%d", i)))
+		results = append(results, result{name: name, err: err})
+		if err != nil {
+			fmt.Printf("--- FAIL: %s (%s)\n%v\n", name, time.Since(start).Round(time.Millisecond), err)
+		} else {
+			fmt.Printf("--- PASS: %s (%s)\n", name, time.Since(start).Round(time.Millisecond))
+		}
+	}
+
+	failed := 0
+	for _, r := range results {
+		if r.err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		fmt.Printf("FAIL\t%d/%d combinations failed\n", failed, len(results))
+		os.Exit(1)
+	}
+	fmt.Printf("ok\t%d combinations\n", len(results))
+}
+
+// step 在生成的项目中执行的命令
+type step struct {
+	env  []string
+	args []string
+}
+
+// run 渲染模板并构建、测试生成的项目
+func run(ctx map[string]string, copyOnly []string, dir string) error {
+	project, err := render(ctx, copyOnly, dir)
+	if err != nil {
+		return fmt.Errorf("render: %w", err)
+	}
+	steps := []step{
+		{args: []string{"go", "mod", "tidy"}},
+		{args: []string{"go", "build", "./..."}},
+		{args: []string{"go", "vet", "./..."}},
+		{args: []string{"go", "test", "./..."}},
+	}
+	// 选择 ent 时生成的客户端带构建标签，默认构建不包含，单独构建和检查
+	if ctx["use_ent"] == "y" {
+		steps = append(steps,
+			step{args: []string{"go", "build", "-tags", "entrepo", "./..."}},
+			step{args: []string{"go", "vet", "-tags", "entrepo", "./internal/data/..."}},
+		)
+	}
+	for _, tag := range strings.Split(ctx["_tags"], ",") {
+		if tag = strings.TrimSpace(tag); tag == "" {
+			continue
+		}
+		steps = append(steps,
+			step{args: []string{"go", "build", "-tags", tag, "./..."}},
+			step{args: []string{"go", "vet", "-tags", tag, "./..."}},
+			step{args: []string{"go", "test", "-tags", tag, "./..."}},
+		)
+	}
+	if goos := ctx["_goos"]; goos != "" {
+		env := []string{"GOOS=" + goos}
+		steps = append(steps,
+			step{env: env, args: []string{"go", "build", "./..."}},
+			step{env: env, args: []string{"go", "vet", "./..."}},
+		)
+	}
+	for _, st := range steps {
+		cmd := exec.Command(st.args[0], st.args[1:]...)
+		cmd.Dir = project
+		cmd.Env = append(os.Environ(), st.env...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("%s: %w\n%s", strings.Join(append(st.env, st.args...), " "), err, out)
+		}
+	}
+	return nil
+}
+
+// render 渲染模板，优先使用 cookiecutter 命令，未安装时使用内置的变量替换
+func render(ctx map[string]string, copyOnly []string, dir string) (string, error) {
+	renderer := flagRenderer
+	if renderer == "auto" {
+		renderer = "builtin"
+		if _, err := exec.LookPath("cookiecutter"); err == nil {
+			renderer = "cookiecutter"
+		}
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	switch renderer {
+	case "cookiecutter":
+		args := []string{"--no-input", "--overwrite-if-exists", "--output-dir", dir, flagTemplate}
+		for _, k := range sortedKeys(ctx) {
+			if !strings.HasPrefix(k, "_") {
+				args = append(args, k+"="+ctx[k])
+			}
+		}
+		cmd := exec.Command("cookiecutter", args...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return "", fmt.Errorf("%w\n%s", err, out)
+		}
+	case "builtin":
+		if err := renderBuiltin(ctx, copyOnly, dir); err != nil {
+			return "", err
+		}
+		if err := runHook(ctx, filepath.Join(dir, ctx["repo_name"])); err != nil {
+			return "", err
+		}
+	default:
+		return "", fmt.Errorf("unknown renderer %s", renderer)
+	}
+	return filepath.Join(dir, ctx["repo_name"]), nil
+}
+
+// varPattern cookiecutter 变量，内置渲染只支持这一种 Jinja 语法
+var varPattern = regexp.MustCompile(`^{{\s*cookiecutter\.(\w+)\s*}}`)
+
+// renderBuiltin 内置渲染，只支持 {{cookiecutter.xxx}} 变量替换，
+// 其他 Jinja 语法（表达式、{% 语句、{# 注释）和未定义的变量报错，与 cookiecutter 渲染失败的情况一致；
+// _copy_without_render 匹配的文件只渲染路径，内容原样复制
+func renderBuiltin(ctx map[string]string, copyOnly []string, dir string) error {
+	root := filepath.Join(flagTemplate, "{{cookiecutter.repo_name}}")
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(flagTemplate, path)
+		if err != nil {
+			return err
+		}
+		name, err := renderString(rel, ctx)
+		if err != nil {
+			return fmt.Errorf("%s: %w", rel, err)
+		}
+		target := filepath.Join(dir, name)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		inProject, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		if !matchAny(filepath.ToSlash(inProject), copyOnly) {
+			out, err := renderString(string(b), ctx)
+			if err != nil {
+				return fmt.Errorf("%s:%w", rel, err)
+			}
+			b = []byte(out)
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, b, info.Mode().Perm())
+	})
+}
+
+// renderString 替换模板变量，遇到其他 Jinja 语法时返回所在行号
+func renderString(s string, ctx map[string]string) (string, error) {
+	var b strings.Builder
+	line := 1
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '\n' {
+			line++
+		}
+		if c != '{' || i+1 == len(s) {
+			b.WriteByte(c)
+			continue
+		}
+		switch s[i+1] {
+		case '{':
+			m := varPattern.FindStringSubmatch(s[i:])
+			if m == nil {
+				return "", fmt.Errorf("%d: unsupported Jinja expression %q", line, snippet(s[i:]))
+			}
+			v, ok := ctx[m[1]]
+			if !ok {
+				return "", fmt.Errorf("%d: undefined variable cookiecutter.%s", line, m[1])
+			}
+			b.WriteString(v)
+			line += strings.Count(m[0], "\n")
+			i += len(m[0]) - 1
+		case '%', '#':
+			return "", fmt.Errorf("%d: unsupported Jinja syntax %q", line, snippet(s[i:]))
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String(), nil
+}
+
+// snippet 错误信息中展示的片段，截取到行尾
+func snippet(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[:i]
+	}
+	if len(s) > 40 {
+		s = s[:40] + "..."
+	}
+	return s
+}
+
+// matchAny 路径是否匹配 _copy_without_render 中的任一模式，
+// 与 cookiecutter 一样按 fnmatch 匹配，* 可以匹配路径分隔符
+func matchAny(name string, patterns []string) bool {
+	for _, p := range patterns {
+		var re strings.Builder
+		re.WriteString("^")
+		for _, r := range p {
+			switch r {
+			case '*':
+				re.WriteString(".*")
+			case '?':
+				re.WriteString(".")
+			default:
+				re.WriteString(regexp.QuoteMeta(string(r)))
+			}
+		}
+		re.WriteString("$")
+		if regexp.MustCompile(re.String()).MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// runHook 内置渲染后执行 hooks/post_gen_project.py，与 cookiecutter 一样先渲染再在生成的项目中执行
+func runHook(ctx map[string]string, project string) error {
+	b, err := os.ReadFile(filepath.Join(flagTemplate, "hooks", "post_gen_project.py"))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command("python3", "-")
+	cmd.Dir = project
+	script, err := renderString(string(b), ctx)
+	if err != nil {
+		return fmt.Errorf("post_gen_project.py:%w", err)
+	}
+	cmd.Stdin = strings.NewReader(script)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("post_gen_project.py: %w\n%s", err, out)
+	}
+	return nil
+}
+
+// loadTemplate 读取 cookiecutter.json 中的默认值和 _copy_without_render，列表类型的选项取第一个
+func loadTemplate(template string) (map[string]string, []string, error) {
+	b, err := os.ReadFile(filepath.Join(template, "cookiecutter.json"))
+	if err != nil {
+		return nil, nil, err
+	}
+	var raw map[string]interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, nil, err
+	}
+	defaults := make(map[string]string, len(raw))
+	var copyOnly []string
+	for k, v := range raw {
+		if k == "_copy_without_render" {
+			for _, p := range v.([]interface{}) {
+				copyOnly = append(copyOnly, fmt.Sprint(p))
+			}
+			continue
+		}
+		if strings.HasPrefix(k, "_") {
+			continue
+		}
+		switch v := v.(type) {
+		case []interface{}:
+			if len(v) > 0 {
+				defaults[k] = fmt.Sprint(v[0])
+			}
+		default:
+			defaults[k] = fmt.Sprint(v)
+		}
+	}
+	return defaults, copyOnly, nil
+}
+
+// loadMatrix 读取选项组合
+func loadMatrix(filename string) ([]map[string]string, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var matrix []map[string]string
+	if err := json.Unmarshal(b, &matrix); err != nil {
+		return nil, err
+	}
+	if len(matrix) == 0 {
+		matrix = append(matrix, map[string]string{})
+	}
+	return matrix, nil
+}
+
+// merge 合并默认值和组合选项
+func merge(defaults, extra map[string]string) map[string]string {
+	ctx := make(map[string]string, len(defaults)+len(extra))
+	for k, v := range defaults {
+		ctx[k] = v
+	}
+	for k, v := range extra {
+		ctx[k] = v
+	}
+	return ctx
+}
+
+// describe 组合的描述，用于输出
+func describe(extra map[string]string) string {
+	if len(extra) == 0 {
+		return "defaults"
+	}
+	parts := make([]string, 0, len(extra))
+	for _, k := range sortedKeys(extra) {
+		parts = append(parts, k+"="+extra[k])
+	}
+	return strings.Join(parts, " ")
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(2)
+}
@@ -0,0 +1,300 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: geo/geo.proto
+
+package geo
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// LatLng WGS84 坐标，单位为度
+type LatLng struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lat           float64                `protobuf:"fixed64,1,opt,name=lat,proto3" json:"lat,omitempty"` // 纬度，-90 ~ 90
+	Lng           float64                `protobuf:"fixed64,2,opt,name=lng,proto3" json:"lng,omitempty"` // 经度，-180 ~ 180
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LatLng) Reset() {
+	*x = LatLng{}
+	mi := &file_geo_geo_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LatLng) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LatLng) ProtoMessage() {}
+
+func (x *LatLng) ProtoReflect() protoreflect.Message {
+	mi := &file_geo_geo_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LatLng.ProtoReflect.Descriptor instead.
+func (*LatLng) Descriptor() ([]byte, []int) {
+	return file_geo_geo_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LatLng) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *LatLng) GetLng() float64 {
+	if x != nil {
+		return x.Lng
+	}
+	return 0
+}
+
+// BoundingBox 矩形范围，如地图可视区域
+type BoundingBox struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SouthWest     *LatLng                `protobuf:"bytes,1,opt,name=south_west,json=southWest,proto3" json:"south_west,omitempty"`
+	NorthEast     *LatLng                `protobuf:"bytes,2,opt,name=north_east,json=northEast,proto3" json:"north_east,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BoundingBox) Reset() {
+	*x = BoundingBox{}
+	mi := &file_geo_geo_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BoundingBox) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BoundingBox) ProtoMessage() {}
+
+func (x *BoundingBox) ProtoReflect() protoreflect.Message {
+	mi := &file_geo_geo_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BoundingBox.ProtoReflect.Descriptor instead.
+func (*BoundingBox) Descriptor() ([]byte, []int) {
+	return file_geo_geo_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BoundingBox) GetSouthWest() *LatLng {
+	if x != nil {
+		return x.SouthWest
+	}
+	return nil
+}
+
+func (x *BoundingBox) GetNorthEast() *LatLng {
+	if x != nil {
+		return x.NorthEast
+	}
+	return nil
+}
+
+// Circle 圆形范围，如门店配送半径
+type Circle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Center        *LatLng                `protobuf:"bytes,1,opt,name=center,proto3" json:"center,omitempty"`
+	RadiusMeters  float64                `protobuf:"fixed64,2,opt,name=radius_meters,json=radiusMeters,proto3" json:"radius_meters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Circle) Reset() {
+	*x = Circle{}
+	mi := &file_geo_geo_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Circle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Circle) ProtoMessage() {}
+
+func (x *Circle) ProtoReflect() protoreflect.Message {
+	mi := &file_geo_geo_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Circle.ProtoReflect.Descriptor instead.
+func (*Circle) Descriptor() ([]byte, []int) {
+	return file_geo_geo_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Circle) GetCenter() *LatLng {
+	if x != nil {
+		return x.Center
+	}
+	return nil
+}
+
+func (x *Circle) GetRadiusMeters() float64 {
+	if x != nil {
+		return x.RadiusMeters
+	}
+	return 0
+}
+
+// Polygon 多边形，如配送区域，首尾点可以不重复，不支持内环
+type Polygon struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Points        []*LatLng              `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Polygon) Reset() {
+	*x = Polygon{}
+	mi := &file_geo_geo_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Polygon) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Polygon) ProtoMessage() {}
+
+func (x *Polygon) ProtoReflect() protoreflect.Message {
+	mi := &file_geo_geo_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Polygon.ProtoReflect.Descriptor instead.
+func (*Polygon) Descriptor() ([]byte, []int) {
+	return file_geo_geo_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Polygon) GetPoints() []*LatLng {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+var File_geo_geo_proto protoreflect.FileDescriptor
+
+const file_geo_geo_proto_rawDesc = "" +
+	"\n" +
+	"\rgeo/geo.proto\x12\aapi.geo\",\n" +
+	"\x06LatLng\x12\x10\n" +
+	"\x03lat\x18\x01 \x01(\x01R\x03lat\x12\x10\n" +
+	"\x03lng\x18\x02 \x01(\x01R\x03lng\"m\n" +
+	"\vBoundingBox\x12.\n" +
+	"\n" +
+	"south_west\x18\x01 \x01(\v2\x0f.api.geo.LatLngR\tsouthWest\x12.\n" +
+	"\n" +
+	"north_east\x18\x02 \x01(\v2\x0f.api.geo.LatLngR\tnorthEast\"V\n" +
+	"\x06Circle\x12'\n" +
+	"\x06center\x18\x01 \x01(\v2\x0f.api.geo.LatLngR\x06center\x12#\n" +
+	"\rradius_meters\x18\x02 \x01(\x01R\fradiusMeters\"2\n" +
+	"\aPolygon\x12'\n" +
+	"\x06points\x18\x01 \x03(\v2\x0f.api.geo.LatLngR\x06pointsb\x06proto3"
+
+var (
+	file_geo_geo_proto_rawDescOnce sync.Once
+	file_geo_geo_proto_rawDescData []byte
+)
+
+func file_geo_geo_proto_rawDescGZIP() []byte {
+	file_geo_geo_proto_rawDescOnce.Do(func() {
+		file_geo_geo_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_geo_geo_proto_rawDesc), len(file_geo_geo_proto_rawDesc)))
+	})
+	return file_geo_geo_proto_rawDescData
+}
+
+var file_geo_geo_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_geo_geo_proto_goTypes = []any{
+	(*LatLng)(nil),      // 0: api.geo.LatLng
+	(*BoundingBox)(nil), // 1: api.geo.BoundingBox
+	(*Circle)(nil),      // 2: api.geo.Circle
+	(*Polygon)(nil),     // 3: api.geo.Polygon
+}
+var file_geo_geo_proto_depIdxs = []int32{
+	0, // 0: api.geo.BoundingBox.south_west:type_name -> api.geo.LatLng
+	0, // 1: api.geo.BoundingBox.north_east:type_name -> api.geo.LatLng
+	0, // 2: api.geo.Circle.center:type_name -> api.geo.LatLng
+	0, // 3: api.geo.Polygon.points:type_name -> api.geo.LatLng
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_geo_geo_proto_init() }
+func file_geo_geo_proto_init() {
+	if File_geo_geo_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_geo_geo_proto_rawDesc), len(file_geo_geo_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_geo_geo_proto_goTypes,
+		DependencyIndexes: file_geo_geo_proto_depIdxs,
+		MessageInfos:      file_geo_geo_proto_msgTypes,
+	}.Build()
+	File_geo_geo_proto = out.File
+	file_geo_geo_proto_goTypes = nil
+	file_geo_geo_proto_depIdxs = nil
+}
@@ -0,0 +1,174 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: options/deprecation.proto
+
+package options
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Deprecation 接口废弃声明，配合 deprecation 中间件返回 Deprecation/Sunset 响应头，
+// 并记录调用方和调用次数，推动客户端迁移
+//
+//	rpc SayHello (HelloRequest) returns (HelloReply) {
+//	  option deprecated = true;
+//	  option (api.options.deprecation) = {
+//	    sunset: "2025-12-31"
+//	    replacement: "/v2/hello"
+//	    link: "https://example.com/docs/migration"
+//	  };
+//	}
+type Deprecation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sunset        string                 `protobuf:"bytes,1,opt,name=sunset,proto3" json:"sunset,omitempty"`           // 下线日期，格式 2006-01-02
+	Replacement   string                 `protobuf:"bytes,2,opt,name=replacement,proto3" json:"replacement,omitempty"` // 替代接口
+	Link          string                 `protobuf:"bytes,3,opt,name=link,proto3" json:"link,omitempty"`               // 迁移文档地址
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Deprecation) Reset() {
+	*x = Deprecation{}
+	mi := &file_options_deprecation_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Deprecation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Deprecation) ProtoMessage() {}
+
+func (x *Deprecation) ProtoReflect() protoreflect.Message {
+	mi := &file_options_deprecation_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Deprecation.ProtoReflect.Descriptor instead.
+func (*Deprecation) Descriptor() ([]byte, []int) {
+	return file_options_deprecation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Deprecation) GetSunset() string {
+	if x != nil {
+		return x.Sunset
+	}
+	return ""
+}
+
+func (x *Deprecation) GetReplacement() string {
+	if x != nil {
+		return x.Replacement
+	}
+	return ""
+}
+
+func (x *Deprecation) GetLink() string {
+	if x != nil {
+		return x.Link
+	}
+	return ""
+}
+
+var file_options_deprecation_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*Deprecation)(nil),
+		Field:         50001,
+		Name:          "api.options.deprecation",
+		Tag:           "bytes,50001,opt,name=deprecation",
+		Filename:      "options/deprecation.proto",
+	},
+}
+
+// Extension fields to descriptorpb.MethodOptions.
+var (
+	// optional api.options.Deprecation deprecation = 50001;
+	E_Deprecation = &file_options_deprecation_proto_extTypes[0]
+)
+
+var File_options_deprecation_proto protoreflect.FileDescriptor
+
+const file_options_deprecation_proto_rawDesc = "" +
+	"\n" +
+	"\x19options/deprecation.proto\x12\vapi.options\x1a google/protobuf/descriptor.proto\"[\n" +
+	"\vDeprecation\x12\x16\n" +
+	"\x06sunset\x18\x01 \x01(\tR\x06sunset\x12 \n" +
+	"\vreplacement\x18\x02 \x01(\tR\vreplacement\x12\x12\n" +
+	"\x04link\x18\x03 \x01(\tR\x04link:\\\n" +
+	"\vdeprecation\x12\x1e.google.protobuf.MethodOptions\x18ц\x03 \x01(\v2\x18.api.options.DeprecationR\vdeprecationb\x06proto3"
+
+var (
+	file_options_deprecation_proto_rawDescOnce sync.Once
+	file_options_deprecation_proto_rawDescData []byte
+)
+
+func file_options_deprecation_proto_rawDescGZIP() []byte {
+	file_options_deprecation_proto_rawDescOnce.Do(func() {
+		file_options_deprecation_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_options_deprecation_proto_rawDesc), len(file_options_deprecation_proto_rawDesc)))
+	})
+	return file_options_deprecation_proto_rawDescData
+}
+
+var file_options_deprecation_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_options_deprecation_proto_goTypes = []any{
+	(*Deprecation)(nil),                // 0: api.options.Deprecation
+	(*descriptorpb.MethodOptions)(nil), // 1: google.protobuf.MethodOptions
+}
+var file_options_deprecation_proto_depIdxs = []int32{
+	1, // 0: api.options.deprecation:extendee -> google.protobuf.MethodOptions
+	0, // 1: api.options.deprecation:type_name -> api.options.Deprecation
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	1, // [1:2] is the sub-list for extension type_name
+	0, // [0:1] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_options_deprecation_proto_init() }
+func file_options_deprecation_proto_init() {
+	if File_options_deprecation_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_options_deprecation_proto_rawDesc), len(file_options_deprecation_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 1,
+			NumServices:   0,
+		},
+		GoTypes:           file_options_deprecation_proto_goTypes,
+		DependencyIndexes: file_options_deprecation_proto_depIdxs,
+		MessageInfos:      file_options_deprecation_proto_msgTypes,
+		ExtensionInfos:    file_options_deprecation_proto_extTypes,
+	}.Build()
+	File_options_deprecation_proto = out.File
+	file_options_deprecation_proto_goTypes = nil
+	file_options_deprecation_proto_depIdxs = nil
+}
@@ -0,0 +1,90 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: options/timeout.proto
+
+package options
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+var file_options_timeout_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*int64)(nil),
+		Field:         50002,
+		Name:          "api.options.timeout_ms",
+		Tag:           "varint,50002,opt,name=timeout_ms",
+		Filename:      "options/timeout.proto",
+	},
+}
+
+// Extension fields to descriptorpb.MethodOptions.
+var (
+	// timeout_ms 接口延迟预算，单位毫秒，配合 timeout 中间件使用：
+	// 服务端超过预算后取消上下文并返回 504，客户端以该值作为默认超时
+	//
+	//   rpc SayHello (HelloRequest) returns (HelloReply) {
+	//     option (api.options.timeout_ms) = 800;
+	//   }
+	//
+	// optional int64 timeout_ms = 50002;
+	E_TimeoutMs = &file_options_timeout_proto_extTypes[0]
+)
+
+var File_options_timeout_proto protoreflect.FileDescriptor
+
+const file_options_timeout_proto_rawDesc = "" +
+	"\n" +
+	"\x15options/timeout.proto\x12\vapi.options\x1a google/protobuf/descriptor.proto:?\n" +
+	"\n" +
+	"timeout_ms\x12\x1e.google.protobuf.MethodOptions\x18҆\x03 \x01(\x03R\ttimeoutMsb\x06proto3"
+
+var file_options_timeout_proto_goTypes = []any{
+	(*descriptorpb.MethodOptions)(nil), // 0: google.protobuf.MethodOptions
+}
+var file_options_timeout_proto_depIdxs = []int32{
+	0, // 0: api.options.timeout_ms:extendee -> google.protobuf.MethodOptions
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	0, // [0:1] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_options_timeout_proto_init() }
+func file_options_timeout_proto_init() {
+	if File_options_timeout_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_options_timeout_proto_rawDesc), len(file_options_timeout_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   0,
+			NumExtensions: 1,
+			NumServices:   0,
+		},
+		GoTypes:           file_options_timeout_proto_goTypes,
+		DependencyIndexes: file_options_timeout_proto_depIdxs,
+		ExtensionInfos:    file_options_timeout_proto_extTypes,
+	}.Build()
+	File_options_timeout_proto = out.File
+	file_options_timeout_proto_goTypes = nil
+	file_options_timeout_proto_depIdxs = nil
+}
@@ -1,16 +1,20 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.27.1
-// 	protoc        v3.19.3
-// source: helloworld/v1/error_reason.proto
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: {{cookiecutter.file_name}}/v1/error_reason.proto
 
 package v1
 
 import (
+	prototext "google.golang.org/protobuf/encoding/prototext"
+	proto "google.golang.org/protobuf/proto"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
 	reflect "reflect"
 	sync "sync"
+	unsafe "unsafe"
 )
 
 const (
@@ -50,11 +54,11 @@ func (x ErrorReason) String() string {
 }
 
 func (ErrorReason) Descriptor() protoreflect.EnumDescriptor {
-	return file_helloworld_v1_error_reason_proto_enumTypes[0].Descriptor()
+	return file_{{cookiecutter.file_name}}_v1_error_reason_proto_enumTypes[0].Descriptor()
 }
 
 func (ErrorReason) Type() protoreflect.EnumType {
-	return &file_helloworld_v1_error_reason_proto_enumTypes[0]
+	return &file_{{cookiecutter.file_name}}_v1_error_reason_proto_enumTypes[0]
 }
 
 func (x ErrorReason) Number() protoreflect.EnumNumber {
@@ -63,45 +67,63 @@ func (x ErrorReason) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ErrorReason.Descriptor instead.
 func (ErrorReason) EnumDescriptor() ([]byte, []int) {
-	return file_helloworld_v1_error_reason_proto_rawDescGZIP(), []int{0}
+	return file_{{cookiecutter.file_name}}_v1_error_reason_proto_rawDescGZIP(), []int{0}
 }
 
-var File_helloworld_v1_error_reason_proto protoreflect.FileDescriptor
-
-var file_helloworld_v1_error_reason_proto_rawDesc = []byte{
-	0x0a, 0x20, 0x68, 0x65, 0x6c, 0x6c, 0x6f, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x2f, 0x76, 0x31, 0x2f,
-	0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x12, 0x0d, 0x68, 0x65, 0x6c, 0x6c, 0x6f, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x2e, 0x76,
-	0x31, 0x2a, 0x39, 0x0a, 0x0b, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e,
-	0x12, 0x16, 0x0a, 0x12, 0x47, 0x45, 0x45, 0x54, 0x45, 0x52, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
-	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x55, 0x53, 0x45, 0x52,
-	0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x01, 0x42, 0x5c, 0x0a, 0x0d,
-	0x68, 0x65, 0x6c, 0x6c, 0x6f, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x2e, 0x76, 0x31, 0x50, 0x01, 0x5a,
-	0x37, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x2d, 0x6b,
-	0x72, 0x61, 0x74, 0x6f, 0x73, 0x2f, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2d, 0x6c, 0x61, 0x79,
-	0x6f, 0x75, 0x74, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x68, 0x65, 0x6c, 0x6c, 0x6f, 0x77, 0x6f, 0x72,
-	0x6c, 0x64, 0x2f, 0x76, 0x31, 0x3b, 0x76, 0x31, 0xa2, 0x02, 0x0f, 0x41, 0x50, 0x49, 0x48, 0x65,
-	0x6c, 0x6c, 0x6f, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+var File_{{cookiecutter.file_name}}_v1_error_reason_proto protoreflect.FileDescriptor
+
+// file_{{cookiecutter.file_name}}_v1_error_reason_proto_rawDesc 描述符中的路径、服务名、消息名随模板变量变化，以文本格式保存，初始化时编码
+var file_{{cookiecutter.file_name}}_v1_error_reason_proto_rawDesc = func() string {
+	fd := new(descriptorpb.FileDescriptorProto)
+	if err := prototext.Unmarshal([]byte(file_{{cookiecutter.file_name}}_v1_error_reason_proto_rawDescText), fd); err != nil {
+		panic(err)
+	}
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(fd)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}()
+
+const file_{{cookiecutter.file_name}}_v1_error_reason_proto_rawDescText = `
+name: "{{cookiecutter.file_name}}/v1/error_reason.proto"
+package: "helloworld.v1"
+enum_type: {
+  name: "ErrorReason"
+  value: {
+    name: "GEETER_UNSPECIFIED"
+    number: 0
+  }
+  value: {
+    name: "USER_NOT_FOUND"
+    number: 1
+  }
+}
+options: {
+  java_package: "{{cookiecutter.file_name}}.v1"
+  java_multiple_files: true
+  objc_class_prefix: "APIHelloworldV1"
 }
+syntax: "proto3"
+`
 
 var (
-	file_helloworld_v1_error_reason_proto_rawDescOnce sync.Once
-	file_helloworld_v1_error_reason_proto_rawDescData = file_helloworld_v1_error_reason_proto_rawDesc
+	file_{{cookiecutter.file_name}}_v1_error_reason_proto_rawDescOnce sync.Once
+	file_{{cookiecutter.file_name}}_v1_error_reason_proto_rawDescData []byte
 )
 
-func file_helloworld_v1_error_reason_proto_rawDescGZIP() []byte {
-	file_helloworld_v1_error_reason_proto_rawDescOnce.Do(func() {
-		file_helloworld_v1_error_reason_proto_rawDescData = protoimpl.X.CompressGZIP(file_helloworld_v1_error_reason_proto_rawDescData)
+func file_{{cookiecutter.file_name}}_v1_error_reason_proto_rawDescGZIP() []byte {
+	file_{{cookiecutter.file_name}}_v1_error_reason_proto_rawDescOnce.Do(func() {
+		file_{{cookiecutter.file_name}}_v1_error_reason_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_{{cookiecutter.file_name}}_v1_error_reason_proto_rawDesc), len(file_{{cookiecutter.file_name}}_v1_error_reason_proto_rawDesc)))
 	})
-	return file_helloworld_v1_error_reason_proto_rawDescData
+	return file_{{cookiecutter.file_name}}_v1_error_reason_proto_rawDescData
 }
 
-var file_helloworld_v1_error_reason_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_helloworld_v1_error_reason_proto_goTypes = []interface{}{
+var file_{{cookiecutter.file_name}}_v1_error_reason_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_{{cookiecutter.file_name}}_v1_error_reason_proto_goTypes = []any{
 	(ErrorReason)(0), // 0: helloworld.v1.ErrorReason
 }
-var file_helloworld_v1_error_reason_proto_depIdxs = []int32{
+var file_{{cookiecutter.file_name}}_v1_error_reason_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
 	0, // [0:0] is the sub-list for method input_type
 	0, // [0:0] is the sub-list for extension type_name
@@ -109,27 +131,26 @@ var file_helloworld_v1_error_reason_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for field type_name
 }
 
-func init() { file_helloworld_v1_error_reason_proto_init() }
-func file_helloworld_v1_error_reason_proto_init() {
-	if File_helloworld_v1_error_reason_proto != nil {
+func init() { file_{{cookiecutter.file_name}}_v1_error_reason_proto_init() }
+func file_{{cookiecutter.file_name}}_v1_error_reason_proto_init() {
+	if File_{{cookiecutter.file_name}}_v1_error_reason_proto != nil {
 		return
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_helloworld_v1_error_reason_proto_rawDesc,
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_{{cookiecutter.file_name}}_v1_error_reason_proto_rawDesc), len(file_{{cookiecutter.file_name}}_v1_error_reason_proto_rawDesc)),
 			NumEnums:      1,
 			NumMessages:   0,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
-		GoTypes:           file_helloworld_v1_error_reason_proto_goTypes,
-		DependencyIndexes: file_helloworld_v1_error_reason_proto_depIdxs,
-		EnumInfos:         file_helloworld_v1_error_reason_proto_enumTypes,
+		GoTypes:           file_{{cookiecutter.file_name}}_v1_error_reason_proto_goTypes,
+		DependencyIndexes: file_{{cookiecutter.file_name}}_v1_error_reason_proto_depIdxs,
+		EnumInfos:         file_{{cookiecutter.file_name}}_v1_error_reason_proto_enumTypes,
 	}.Build()
-	File_helloworld_v1_error_reason_proto = out.File
-	file_helloworld_v1_error_reason_proto_rawDesc = nil
-	file_helloworld_v1_error_reason_proto_goTypes = nil
-	file_helloworld_v1_error_reason_proto_depIdxs = nil
+	File_{{cookiecutter.file_name}}_v1_error_reason_proto = out.File
+	file_{{cookiecutter.file_name}}_v1_error_reason_proto_goTypes = nil
+	file_{{cookiecutter.file_name}}_v1_error_reason_proto_depIdxs = nil
 }
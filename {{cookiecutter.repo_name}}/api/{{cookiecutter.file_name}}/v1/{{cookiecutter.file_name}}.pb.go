@@ -1,15 +1,18 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
 // 	protoc-gen-go v1.36.6
-// 	protoc        v6.30.2
-// source: {{cookiecutter.module_name}}/v1/{{cookiecutter.module_name}}.proto
+// 	protoc        (unknown)
+// source: {{cookiecutter.file_name}}/v1/{{cookiecutter.file_name}}.proto
 
 package v1
 
 import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
+	prototext "google.golang.org/protobuf/encoding/prototext"
+	proto "google.golang.org/protobuf/proto"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -32,7 +35,7 @@ type HelloRequest struct {
 
 func (x *HelloRequest) Reset() {
 	*x = HelloRequest{}
-	mi := &file_{{cookiecutter.module_name}}_v1_{{cookiecutter.module_name}}_proto_msgTypes[0]
+	mi := &file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_msgTypes[0]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -44,7 +47,7 @@ func (x *HelloRequest) String() string {
 func (*HelloRequest) ProtoMessage() {}
 
 func (x *HelloRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_{{cookiecutter.module_name}}_v1_{{cookiecutter.module_name}}_proto_msgTypes[0]
+	mi := &file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_msgTypes[0]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -57,7 +60,7 @@ func (x *HelloRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HelloRequest.ProtoReflect.Descriptor instead.
 func (*HelloRequest) Descriptor() ([]byte, []int) {
-	return file_{{cookiecutter.module_name}}_v1_{{cookiecutter.module_name}}_proto_rawDescGZIP(), []int{0}
+	return file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_rawDescGZIP(), []int{0}
 }
 
 func (x *HelloRequest) GetName() string {
@@ -77,7 +80,7 @@ type HelloReply struct {
 
 func (x *HelloReply) Reset() {
 	*x = HelloReply{}
-	mi := &file_{{cookiecutter.module_name}}_v1_{{cookiecutter.module_name}}_proto_msgTypes[1]
+	mi := &file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -89,7 +92,7 @@ func (x *HelloReply) String() string {
 func (*HelloReply) ProtoMessage() {}
 
 func (x *HelloReply) ProtoReflect() protoreflect.Message {
-	mi := &file_{{cookiecutter.module_name}}_v1_{{cookiecutter.module_name}}_proto_msgTypes[1]
+	mi := &file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -102,7 +105,7 @@ func (x *HelloReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HelloReply.ProtoReflect.Descriptor instead.
 func (*HelloReply) Descriptor() ([]byte, []int) {
-	return file_{{cookiecutter.module_name}}_v1_{{cookiecutter.module_name}}_proto_rawDescGZIP(), []int{1}
+	return file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *HelloReply) GetMessage() string {
@@ -112,68 +115,359 @@ func (x *HelloReply) GetMessage() string {
 	return ""
 }
 
-var File_{{cookiecutter.module_name}}_v1_{{cookiecutter.module_name}}_proto protoreflect.FileDescriptor
+type Delete{{cookiecutter.service_name}}Request struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Delete{{cookiecutter.service_name}}Request) Reset() {
+	*x = Delete{{cookiecutter.service_name}}Request{}
+	mi := &file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Delete{{cookiecutter.service_name}}Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Delete{{cookiecutter.service_name}}Request) ProtoMessage() {}
+
+func (x *Delete{{cookiecutter.service_name}}Request) ProtoReflect() protoreflect.Message {
+	mi := &file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Delete{{cookiecutter.service_name}}Request.ProtoReflect.Descriptor instead.
+func (*Delete{{cookiecutter.service_name}}Request) Descriptor() ([]byte, []int) {
+	return file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Delete{{cookiecutter.service_name}}Request) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type Delete{{cookiecutter.service_name}}Reply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Delete{{cookiecutter.service_name}}Reply) Reset() {
+	*x = Delete{{cookiecutter.service_name}}Reply{}
+	mi := &file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Delete{{cookiecutter.service_name}}Reply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Delete{{cookiecutter.service_name}}Reply) ProtoMessage() {}
+
+func (x *Delete{{cookiecutter.service_name}}Reply) ProtoReflect() protoreflect.Message {
+	mi := &file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Delete{{cookiecutter.service_name}}Reply.ProtoReflect.Descriptor instead.
+func (*Delete{{cookiecutter.service_name}}Reply) Descriptor() ([]byte, []int) {
+	return file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_rawDescGZIP(), []int{3}
+}
+
+type Restore{{cookiecutter.service_name}}Request struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Restore{{cookiecutter.service_name}}Request) Reset() {
+	*x = Restore{{cookiecutter.service_name}}Request{}
+	mi := &file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Restore{{cookiecutter.service_name}}Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Restore{{cookiecutter.service_name}}Request) ProtoMessage() {}
+
+func (x *Restore{{cookiecutter.service_name}}Request) ProtoReflect() protoreflect.Message {
+	mi := &file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Restore{{cookiecutter.service_name}}Request.ProtoReflect.Descriptor instead.
+func (*Restore{{cookiecutter.service_name}}Request) Descriptor() ([]byte, []int) {
+	return file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Restore{{cookiecutter.service_name}}Request) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type Restore{{cookiecutter.service_name}}Reply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Hello         string                 `protobuf:"bytes,2,opt,name=hello,proto3" json:"hello,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Restore{{cookiecutter.service_name}}Reply) Reset() {
+	*x = Restore{{cookiecutter.service_name}}Reply{}
+	mi := &file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Restore{{cookiecutter.service_name}}Reply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Restore{{cookiecutter.service_name}}Reply) ProtoMessage() {}
+
+func (x *Restore{{cookiecutter.service_name}}Reply) ProtoReflect() protoreflect.Message {
+	mi := &file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Restore{{cookiecutter.service_name}}Reply.ProtoReflect.Descriptor instead.
+func (*Restore{{cookiecutter.service_name}}Reply) Descriptor() ([]byte, []int) {
+	return file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Restore{{cookiecutter.service_name}}Reply) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Restore{{cookiecutter.service_name}}Reply) GetHello() string {
+	if x != nil {
+		return x.Hello
+	}
+	return ""
+}
+
+var File_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto protoreflect.FileDescriptor
+
+// file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_rawDesc 描述符中的路径、服务名、消息名随模板变量变化，以文本格式保存，初始化时编码
+var file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_rawDesc = func() string {
+	fd := new(descriptorpb.FileDescriptorProto)
+	if err := prototext.Unmarshal([]byte(file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_rawDescText), fd); err != nil {
+		panic(err)
+	}
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(fd)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}()
 
-const file_{{cookiecutter.module_name}}_v1_{{cookiecutter.module_name}}_proto_rawDesc = "" +
-	"\n" +
-	"\x1e{{cookiecutter.module_name}}/v1/{{cookiecutter.module_name}}.proto\x12\rhelloworld.v1\x1a\x1cgoogle/api/annotations.proto\"\"\n" +
-	"\fHelloRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\"&\n" +
-	"\n" +
-	"HelloReply\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage2l\n" +
-	"\n" +
-	"{{cookiecutter.module_name}}\x12^\n" +
-	"\bSayHello\x12\x1b.helloworld.v1.HelloRequest\x1a\x19.helloworld.v1.HelloReply\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/{{cookiecutter.module_name}}/{name}BT\n" +
-	"\x1cdev.kratos.api.{{cookiecutter.module_name}}.v1B\x11HelloworldProtoV1P\x01Z\x1f{{cookiecutter.module_name}}/api/{{cookiecutter.module_name}}/v1;v1b\x06proto3"
+const file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_rawDescText = `
+name: "{{cookiecutter.file_name}}/v1/{{cookiecutter.file_name}}.proto"
+package: "helloworld.v1"
+dependency: "google/api/annotations.proto"
+message_type: {
+  name: "HelloRequest"
+  field: {
+    name: "name"
+    number: 1
+    label: LABEL_OPTIONAL
+    type: TYPE_STRING
+    json_name: "name"
+  }
+}
+message_type: {
+  name: "HelloReply"
+  field: {
+    name: "message"
+    number: 1
+    label: LABEL_OPTIONAL
+    type: TYPE_STRING
+    json_name: "message"
+  }
+}
+message_type: {
+  name: "Delete{{cookiecutter.service_name}}Request"
+  field: {
+    name: "id"
+    number: 1
+    label: LABEL_OPTIONAL
+    type: TYPE_INT64
+    json_name: "id"
+  }
+}
+message_type: {
+  name: "Delete{{cookiecutter.service_name}}Reply"
+}
+message_type: {
+  name: "Restore{{cookiecutter.service_name}}Request"
+  field: {
+    name: "id"
+    number: 1
+    label: LABEL_OPTIONAL
+    type: TYPE_INT64
+    json_name: "id"
+  }
+}
+message_type: {
+  name: "Restore{{cookiecutter.service_name}}Reply"
+  field: {
+    name: "id"
+    number: 1
+    label: LABEL_OPTIONAL
+    type: TYPE_INT64
+    json_name: "id"
+  }
+  field: {
+    name: "hello"
+    number: 2
+    label: LABEL_OPTIONAL
+    type: TYPE_STRING
+    json_name: "hello"
+  }
+}
+service: {
+  name: "{{cookiecutter.service_name}}"
+  method: {
+    name: "SayHello"
+    input_type: ".helloworld.v1.HelloRequest"
+    output_type: ".helloworld.v1.HelloReply"
+    options: {
+      [google.api.http]: {
+        post: "/api/list"
+        body: "*"
+      }
+    }
+  }
+  method: {
+    name: "Delete{{cookiecutter.service_name}}"
+    input_type: ".helloworld.v1.Delete{{cookiecutter.service_name}}Request"
+    output_type: ".helloworld.v1.Delete{{cookiecutter.service_name}}Reply"
+    options: {
+      [google.api.http]: {
+        delete: "/api/{{cookiecutter.file_name}}/{id}"
+      }
+    }
+  }
+  method: {
+    name: "Restore{{cookiecutter.service_name}}"
+    input_type: ".helloworld.v1.Restore{{cookiecutter.service_name}}Request"
+    output_type: ".helloworld.v1.Restore{{cookiecutter.service_name}}Reply"
+    options: {
+      [google.api.http]: {
+        post: "/api/{{cookiecutter.file_name}}/{id}/restore"
+        body: "*"
+      }
+    }
+  }
+}
+options: {
+  java_package: "dev.kratos.api.{{cookiecutter.file_name}}.v1"
+  java_outer_classname: "HelloworldProtoV1"
+  java_multiple_files: true
+}
+syntax: "proto3"
+`
 
 var (
-	file_{{cookiecutter.module_name}}_v1_{{cookiecutter.module_name}}_proto_rawDescOnce sync.Once
-	file_{{cookiecutter.module_name}}_v1_{{cookiecutter.module_name}}_proto_rawDescData []byte
+	file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_rawDescOnce sync.Once
+	file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_rawDescData []byte
 )
 
-func file_{{cookiecutter.module_name}}_v1_{{cookiecutter.module_name}}_proto_rawDescGZIP() []byte {
-	file_{{cookiecutter.module_name}}_v1_{{cookiecutter.module_name}}_proto_rawDescOnce.Do(func() {
-		file_{{cookiecutter.module_name}}_v1_{{cookiecutter.module_name}}_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_{{cookiecutter.module_name}}_v1_{{cookiecutter.module_name}}_proto_rawDesc), len(file_{{cookiecutter.module_name}}_v1_{{cookiecutter.module_name}}_proto_rawDesc)))
+func file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_rawDescGZIP() []byte {
+	file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_rawDescOnce.Do(func() {
+		file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_rawDesc), len(file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_rawDesc)))
 	})
-	return file_{{cookiecutter.module_name}}_v1_{{cookiecutter.module_name}}_proto_rawDescData
+	return file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_rawDescData
 }
 
-var file_{{cookiecutter.module_name}}_v1_{{cookiecutter.module_name}}_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
-var file_{{cookiecutter.module_name}}_v1_{{cookiecutter.module_name}}_proto_goTypes = []any{
-	(*HelloRequest)(nil), // 0: helloworld.v1.HelloRequest
-	(*HelloReply)(nil),   // 1: helloworld.v1.HelloReply
+var file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_goTypes = []any{
+	(*HelloRequest)(nil),         // 0: helloworld.v1.HelloRequest
+	(*HelloReply)(nil),           // 1: helloworld.v1.HelloReply
+	(*Delete{{cookiecutter.service_name}}Request)(nil),  // 2: helloworld.v1.Delete{{cookiecutter.service_name}}Request
+	(*Delete{{cookiecutter.service_name}}Reply)(nil),    // 3: helloworld.v1.Delete{{cookiecutter.service_name}}Reply
+	(*Restore{{cookiecutter.service_name}}Request)(nil), // 4: helloworld.v1.Restore{{cookiecutter.service_name}}Request
+	(*Restore{{cookiecutter.service_name}}Reply)(nil),   // 5: helloworld.v1.Restore{{cookiecutter.service_name}}Reply
 }
-var file_{{cookiecutter.module_name}}_v1_{{cookiecutter.module_name}}_proto_depIdxs = []int32{
-	0, // 0: helloworld.v1.{{cookiecutter.module_name}}.SayHello:input_type -> helloworld.v1.HelloRequest
-	1, // 1: helloworld.v1.{{cookiecutter.module_name}}.SayHello:output_type -> helloworld.v1.HelloReply
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
+var file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_depIdxs = []int32{
+	0, // 0: helloworld.v1.{{cookiecutter.service_name}}.SayHello:input_type -> helloworld.v1.HelloRequest
+	2, // 1: helloworld.v1.{{cookiecutter.service_name}}.Delete{{cookiecutter.service_name}}:input_type -> helloworld.v1.Delete{{cookiecutter.service_name}}Request
+	4, // 2: helloworld.v1.{{cookiecutter.service_name}}.Restore{{cookiecutter.service_name}}:input_type -> helloworld.v1.Restore{{cookiecutter.service_name}}Request
+	1, // 3: helloworld.v1.{{cookiecutter.service_name}}.SayHello:output_type -> helloworld.v1.HelloReply
+	3, // 4: helloworld.v1.{{cookiecutter.service_name}}.Delete{{cookiecutter.service_name}}:output_type -> helloworld.v1.Delete{{cookiecutter.service_name}}Reply
+	5, // 5: helloworld.v1.{{cookiecutter.service_name}}.Restore{{cookiecutter.service_name}}:output_type -> helloworld.v1.Restore{{cookiecutter.service_name}}Reply
+	3, // [3:6] is the sub-list for method output_type
+	0, // [0:3] is the sub-list for method input_type
 	0, // [0:0] is the sub-list for extension type_name
 	0, // [0:0] is the sub-list for extension extendee
 	0, // [0:0] is the sub-list for field type_name
 }
 
-func init() { file_{{cookiecutter.module_name}}_v1_{{cookiecutter.module_name}}_proto_init() }
-func file_{{cookiecutter.module_name}}_v1_{{cookiecutter.module_name}}_proto_init() {
-	if File_{{cookiecutter.module_name}}_v1_{{cookiecutter.module_name}}_proto != nil {
+func init() { file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_init() }
+func file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_init() {
+	if File_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto != nil {
 		return
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: unsafe.Slice(unsafe.StringData(file_{{cookiecutter.module_name}}_v1_{{cookiecutter.module_name}}_proto_rawDesc), len(file_{{cookiecutter.module_name}}_v1_{{cookiecutter.module_name}}_proto_rawDesc)),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_rawDesc), len(file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
-		GoTypes:           file_{{cookiecutter.module_name}}_v1_{{cookiecutter.module_name}}_proto_goTypes,
-		DependencyIndexes: file_{{cookiecutter.module_name}}_v1_{{cookiecutter.module_name}}_proto_depIdxs,
-		MessageInfos:      file_{{cookiecutter.module_name}}_v1_{{cookiecutter.module_name}}_proto_msgTypes,
+		GoTypes:           file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_goTypes,
+		DependencyIndexes: file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_depIdxs,
+		MessageInfos:      file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_msgTypes,
 	}.Build()
-	File_{{cookiecutter.module_name}}_v1_{{cookiecutter.module_name}}_proto = out.File
-	file_{{cookiecutter.module_name}}_v1_{{cookiecutter.module_name}}_proto_goTypes = nil
-	file_{{cookiecutter.module_name}}_v1_{{cookiecutter.module_name}}_proto_depIdxs = nil
+	File_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto = out.File
+	file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_goTypes = nil
+	file_{{cookiecutter.file_name}}_v1_{{cookiecutter.file_name}}_proto_depIdxs = nil
 }
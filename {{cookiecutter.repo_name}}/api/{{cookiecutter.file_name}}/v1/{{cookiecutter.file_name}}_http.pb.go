@@ -1,8 +1,8 @@
 // Code generated by protoc-gen-go-http. DO NOT EDIT.
 // versions:
 // - protoc-gen-go-http v2.8.4
-// - protoc             v6.30.2
-// source: {{cookiecutter.module_name}}/v1/{{cookiecutter.module_name}}.proto
+// - protoc             (unknown)
+// source: {{cookiecutter.file_name}}/v1/{{cookiecutter.file_name}}.proto
 
 package v1
 
@@ -19,59 +19,136 @@ var _ = binding.EncodeURL
 
 const _ = http.SupportPackageIsVersion1
 
-const Operation{{cookiecutter.module_name}}SayHello = "/helloworld.v1.{{cookiecutter.module_name}}/SayHello"
+const Operation{{cookiecutter.service_name}}Delete{{cookiecutter.service_name}} = "/helloworld.v1.{{cookiecutter.service_name}}/Delete{{cookiecutter.service_name}}"
+const Operation{{cookiecutter.service_name}}Restore{{cookiecutter.service_name}} = "/helloworld.v1.{{cookiecutter.service_name}}/Restore{{cookiecutter.service_name}}"
+const Operation{{cookiecutter.service_name}}SayHello = "/helloworld.v1.{{cookiecutter.service_name}}/SayHello"
 
-type {{cookiecutter.module_name}}HTTPServer interface {
+type {{cookiecutter.service_name}}HTTPServer interface {
+	// Delete{{cookiecutter.service_name}} Soft-deletes a greeting, it stays in the table and can be restored
+	Delete{{cookiecutter.service_name}}(context.Context, *Delete{{cookiecutter.service_name}}Request) (*Delete{{cookiecutter.service_name}}Reply, error)
+	// Restore{{cookiecutter.service_name}} Restores a soft-deleted greeting
+	Restore{{cookiecutter.service_name}}(context.Context, *Restore{{cookiecutter.service_name}}Request) (*Restore{{cookiecutter.service_name}}Reply, error)
 	// SayHello Sends a greeting
 	SayHello(context.Context, *HelloRequest) (*HelloReply, error)
 }
 
-func Register{{cookiecutter.module_name}}HTTPServer(s *http.Server, srv {{cookiecutter.module_name}}HTTPServer) {
+func Register{{cookiecutter.service_name}}HTTPServer(s *http.Server, srv {{cookiecutter.service_name}}HTTPServer) {
 	r := s.Route("/")
-	r.GET("/{{cookiecutter.module_name}}/{name}", _{{cookiecutter.module_name}}_SayHello0_HTTP_Handler(srv))
+	r.POST("/api/list", _{{cookiecutter.service_name}}_SayHello0_HTTP_Handler(srv))
+	r.DELETE("/api/{{cookiecutter.file_name}}/{id}", _{{cookiecutter.service_name}}_Delete{{cookiecutter.service_name}}0_HTTP_Handler(srv))
+	r.POST("/api/{{cookiecutter.file_name}}/{id}/restore", _{{cookiecutter.service_name}}_Restore{{cookiecutter.service_name}}0_HTTP_Handler(srv))
 }
 
-func _{{cookiecutter.module_name}}_SayHello0_HTTP_Handler(srv {{cookiecutter.module_name}}HTTPServer) func(ctx http.Context) error {
+func _{{cookiecutter.service_name}}_SayHello0_HTTP_Handler(srv {{cookiecutter.service_name}}HTTPServer) func(ctx http.Context) error {
 	return func(ctx http.Context) error {
 		var in HelloRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, Operation{{cookiecutter.service_name}}SayHello)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.SayHello(ctx, req.(*HelloRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*HelloReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _{{cookiecutter.service_name}}_Delete{{cookiecutter.service_name}}0_HTTP_Handler(srv {{cookiecutter.service_name}}HTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in Delete{{cookiecutter.service_name}}Request
 		if err := ctx.BindQuery(&in); err != nil {
 			return err
 		}
 		if err := ctx.BindVars(&in); err != nil {
 			return err
 		}
-		http.SetOperation(ctx, Operation{{cookiecutter.module_name}}SayHello)
+		http.SetOperation(ctx, Operation{{cookiecutter.service_name}}Delete{{cookiecutter.service_name}})
 		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
-			return srv.SayHello(ctx, req.(*HelloRequest))
+			return srv.Delete{{cookiecutter.service_name}}(ctx, req.(*Delete{{cookiecutter.service_name}}Request))
 		})
 		out, err := h(ctx, &in)
 		if err != nil {
 			return err
 		}
-		reply := out.(*HelloReply)
+		reply := out.(*Delete{{cookiecutter.service_name}}Reply)
 		return ctx.Result(200, reply)
 	}
 }
 
-type {{cookiecutter.module_name}}HTTPClient interface {
+func _{{cookiecutter.service_name}}_Restore{{cookiecutter.service_name}}0_HTTP_Handler(srv {{cookiecutter.service_name}}HTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in Restore{{cookiecutter.service_name}}Request
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, Operation{{cookiecutter.service_name}}Restore{{cookiecutter.service_name}})
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.Restore{{cookiecutter.service_name}}(ctx, req.(*Restore{{cookiecutter.service_name}}Request))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*Restore{{cookiecutter.service_name}}Reply)
+		return ctx.Result(200, reply)
+	}
+}
+
+type {{cookiecutter.service_name}}HTTPClient interface {
+	Delete{{cookiecutter.service_name}}(ctx context.Context, req *Delete{{cookiecutter.service_name}}Request, opts ...http.CallOption) (rsp *Delete{{cookiecutter.service_name}}Reply, err error)
+	Restore{{cookiecutter.service_name}}(ctx context.Context, req *Restore{{cookiecutter.service_name}}Request, opts ...http.CallOption) (rsp *Restore{{cookiecutter.service_name}}Reply, err error)
 	SayHello(ctx context.Context, req *HelloRequest, opts ...http.CallOption) (rsp *HelloReply, err error)
 }
 
-type {{cookiecutter.module_name}}HTTPClientImpl struct {
+type {{cookiecutter.service_name}}HTTPClientImpl struct {
 	cc *http.Client
 }
 
-func New{{cookiecutter.module_name}}HTTPClient(client *http.Client) {{cookiecutter.module_name}}HTTPClient {
-	return &{{cookiecutter.module_name}}HTTPClientImpl{client}
+func New{{cookiecutter.service_name}}HTTPClient(client *http.Client) {{cookiecutter.service_name}}HTTPClient {
+	return &{{cookiecutter.service_name}}HTTPClientImpl{client}
 }
 
-func (c *{{cookiecutter.module_name}}HTTPClientImpl) SayHello(ctx context.Context, in *HelloRequest, opts ...http.CallOption) (*HelloReply, error) {
-	var out HelloReply
-	pattern := "/{{cookiecutter.module_name}}/{name}"
+func (c *{{cookiecutter.service_name}}HTTPClientImpl) Delete{{cookiecutter.service_name}}(ctx context.Context, in *Delete{{cookiecutter.service_name}}Request, opts ...http.CallOption) (*Delete{{cookiecutter.service_name}}Reply, error) {
+	var out Delete{{cookiecutter.service_name}}Reply
+	pattern := "/api/{{cookiecutter.file_name}}/{id}"
 	path := binding.EncodeURL(pattern, in, true)
-	opts = append(opts, http.Operation(Operation{{cookiecutter.module_name}}SayHello))
+	opts = append(opts, http.Operation(Operation{{cookiecutter.service_name}}Delete{{cookiecutter.service_name}}))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "DELETE", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *{{cookiecutter.service_name}}HTTPClientImpl) Restore{{cookiecutter.service_name}}(ctx context.Context, in *Restore{{cookiecutter.service_name}}Request, opts ...http.CallOption) (*Restore{{cookiecutter.service_name}}Reply, error) {
+	var out Restore{{cookiecutter.service_name}}Reply
+	pattern := "/api/{{cookiecutter.file_name}}/{id}/restore"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(Operation{{cookiecutter.service_name}}Restore{{cookiecutter.service_name}}))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *{{cookiecutter.service_name}}HTTPClientImpl) SayHello(ctx context.Context, in *HelloRequest, opts ...http.CallOption) (*HelloReply, error) {
+	var out HelloReply
+	pattern := "/api/list"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(Operation{{cookiecutter.service_name}}SayHello))
 	opts = append(opts, http.PathTemplate(pattern))
-	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,104 @@
+// confcrypt 加解密配置值，生成的 ENC(...) 可以直接写入配置文件并提交到配置仓库
+//
+//	go run ./cmd/confcrypt genkey
+//	CONFIG_KEY=... go run ./cmd/confcrypt encrypt 'p@ssw0rd'
+//	echo -n 'p@ssw0rd' | CONFIG_KEY=... go run ./cmd/confcrypt encrypt
+//	CONFIG_KEY=... go run ./cmd/confcrypt decrypt 'ENC(...)'
+//
+// 数据密钥从 -key 或 CONFIG_KEY、CONFIG_KEY_FILE 读取，这里只支持明文密钥，KMS 加密的密钥需先解密
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"{{cookiecutter.module_name}}/internal/pkg/confcrypt"
+)
+
+var flagKey string
+
+func init() {
+	flag.StringVar(&flagKey, "key", "", "base64 AES key, defaults to $"+confcrypt.EnvKey)
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: confcrypt [-key KEY] genkey | encrypt [VALUE] | decrypt VALUE\n")
+		flag.PrintDefaults()
+	}
+}
+
+func main() {
+	flag.Parse()
+	log.SetFlags(0)
+
+	switch flag.Arg(0) {
+	case "genkey":
+		key, err := confcrypt.GenerateKey()
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(key)
+	case "encrypt":
+		value, err := input()
+		if err != nil {
+			log.Fatal(err)
+		}
+		out, err := newCipher().Encrypt(value)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(out)
+	case "decrypt":
+		value, err := input()
+		if err != nil {
+			log.Fatal(err)
+		}
+		out, err := newCipher().Decrypt(strings.TrimSpace(value))
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(out)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+// newCipher 使用 -key 或环境变量中的数据密钥
+func newCipher() *confcrypt.Cipher {
+	if flagKey != "" {
+		key, err := base64.StdEncoding.DecodeString(flagKey)
+		if err != nil {
+			log.Fatalf("confcrypt: invalid -key: %v", err)
+		}
+		c, err := confcrypt.New(key)
+		if err != nil {
+			log.Fatal(err)
+		}
+		return c
+	}
+	c, err := confcrypt.FromEnv(context.Background(), nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if c == nil {
+		log.Fatalf("confcrypt: no key: set -key or $%s, generate one with `confcrypt genkey`", confcrypt.EnvKey)
+	}
+	return c
+}
+
+// input 读取命令行参数，未指定时从标准输入读取，避免明文出现在 shell 历史中
+func input() (string, error) {
+	if flag.NArg() > 1 {
+		return flag.Arg(1), nil
+	}
+	b, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(b), "\n"), nil
+}
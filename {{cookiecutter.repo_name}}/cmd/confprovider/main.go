@@ -0,0 +1,145 @@
+// confprovider 为 conf.Bootstrap 的各个配置段生成 wire 提供者
+//
+//	go run ./cmd/confprovider -dir ./internal/conf -out provider_gen.go
+//
+// Bootstrap 中每个消息类型字段生成 Provide{Field}Conf(*Bootstrap) *{Type}，
+// 各包只依赖自己的配置段，测试时构造最小配置即可
+package main
+
+import (
+	"bytes"
+	"flag"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+var (
+	flagDir string
+	flagOut string
+)
+
+func init() {
+	flag.StringVar(&flagDir, "dir", ".", "conf package dir, eg: -dir ./internal/conf")
+	flag.StringVar(&flagOut, "out", "provider_gen.go", "output file name, relative to -dir")
+}
+
+// section Bootstrap 中的配置段
+type section struct {
+	Field string
+	Type  string
+}
+
+func main() {
+	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("confprovider: ")
+
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, flagDir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") && fi.Name() != filepath.Base(flagOut)
+	}, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(pkgs) != 1 {
+		log.Fatalf("expect exactly one package in %s, got %d", flagDir, len(pkgs))
+	}
+
+	var pkgName string
+	var sections []section
+	for name, pkg := range pkgs {
+		pkgName = name
+		for _, f := range pkg.Files {
+			if s := bootstrap(f); s != nil {
+				sections = s
+			}
+		}
+	}
+	if len(sections) == 0 {
+		log.Fatalf("Bootstrap not found in %s", flagDir)
+	}
+
+	src, err := render(pkgName, sections)
+	if err != nil {
+		log.Fatal(err)
+	}
+	out := filepath.Join(flagDir, flagOut)
+	if err := os.WriteFile(out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("%d providers written to %s", len(sections), out)
+}
+
+// bootstrap 返回 Bootstrap 结构体中的消息类型字段，按声明顺序排列
+func bootstrap(f *ast.File) []section {
+	var sections []section
+	ast.Inspect(f, func(n ast.Node) bool {
+		ts, ok := n.(*ast.TypeSpec)
+		if !ok || ts.Name.Name != "Bootstrap" {
+			return true
+		}
+		st, ok := ts.Type.(*ast.StructType)
+		if !ok {
+			return false
+		}
+		for _, fd := range st.Fields.List {
+			star, ok := fd.Type.(*ast.StarExpr)
+			if !ok {
+				continue
+			}
+			ident, ok := star.X.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			for _, name := range fd.Names {
+				if name.IsExported() {
+					sections = append(sections, section{Field: name.Name, Type: ident.Name})
+				}
+			}
+		}
+		return false
+	})
+	return sections
+}
+
+// tmpl 提供者代码模板，使用 [[ ]] 分隔符，避免与脚手架的 Jinja 渲染冲突
+var tmpl = template.Must(template.New("provider").Delims("[[", "]]").Parse(`// Code generated by confprovider. DO NOT EDIT.
+
+package [[.Pkg]]
+
+import "github.com/google/wire"
+
+// ProviderSet is conf providers.
+var ProviderSet = wire.NewSet(
+[[- range .Sections]]
+	Provide[[.Field]]Conf,
+[[- end]]
+)
+[[range .Sections]]
+// Provide[[.Field]]Conf 提供 Bootstrap.[[.Field]]，未配置时返回空配置
+func Provide[[.Field]]Conf(bc *Bootstrap) *[[.Type]] {
+	if bc.Get[[.Field]]() == nil {
+		return &[[.Type]]{}
+	}
+	return bc.[[.Field]]
+}
+[[end]]`))
+
+// render 生成提供者代码
+func render(pkg string, sections []section) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, map[string]interface{}{
+		"Pkg":      pkg,
+		"Sections": sections,
+	}); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
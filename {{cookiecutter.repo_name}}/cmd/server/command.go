@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"{{cookiecutter.module_name}}/internal/conf"
+	"{{cookiecutter.module_name}}/internal/data"
+	"{{cookiecutter.module_name}}/internal/pkg/confschema"
+	"{{cookiecutter.module_name}}/internal/pkg/confsource/layered"
+	"{{cookiecutter.module_name}}/internal/pkg/migrate"
+	"{{cookiecutter.module_name}}/internal/pkg/tenant"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// commands 可用的子命令
+const commands = "config schema, migrate up|down|status, migrate tenants up|down|status, migrate tenant <id> up|down|status, seed [dir]"
+
+// runCommand 执行子命令后退出，不启动服务
+//
+//	./bin/server config schema > config.schema.json
+//	./bin/server -conf ./configs migrate up
+//	./bin/server -conf ./configs migrate tenant acme up
+//	./bin/server -conf ./configs seed ./seeds
+func runCommand(args []string) error {
+	if args[0] == "seed" && len(args) <= 2 {
+		return runSeed(args[1:])
+	}
+	if len(args) == 4 && args[0] == "migrate" && args[1] == "tenant" && migrateAction(args[3]) {
+		return runTenantMigrations(args[3], []string{args[2]})
+	}
+	if len(args) == 3 && args[0] == "migrate" && args[1] == "tenants" && migrateAction(args[2]) {
+		return runTenantMigrations(args[2], nil)
+	}
+	switch strings.Join(args, " ") {
+	case "config schema":
+		// 配置的 JSON Schema，供流水线校验配置和编辑器补全
+		b, err := confschema.Marshal(&conf.Bootstrap{})
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(os.Stdout, string(b))
+		return err
+	case "migrate up", "migrate down", "migrate status":
+		return runMigrations(args[1])
+	default:
+		return fmt.Errorf("unknown command %q, available commands: %s", strings.Join(args, " "), commands)
+	}
+}
+
+// runMigrations 对默认数据库执行版本化迁移，数据库配置只从本地配置文件读取，
+// 与服务启动时的 run_migrations 使用同一把迁移锁，可以在发布流水线中与滚动发布并行执行
+func runMigrations(action string) error {
+	bc, err := bootstrap(layered.New(flagconf, os.Getenv(layered.EnvKey)))
+	if err != nil {
+		return err
+	}
+	logger := log.GetLogger()
+	dbs, cleanup, err := data.NewDatabases(bc.Data, logger)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	m, err := data.NewMigrator(dbs, bc.Data, logger)
+	if err != nil {
+		return err
+	}
+	return migrateRun(context.Background(), m, action)
+}
+
+// runTenantMigrations 对租户数据库执行版本化迁移，tenants 为空时迁移 data.tenancy.tenants 中的全部租户，
+// 按模板动态使用的租户需通过 migrate tenant <id> 单独迁移，请求路径上不执行迁移
+func runTenantMigrations(action string, tenants []string) error {
+	bc, err := bootstrap(layered.New(flagconf, os.Getenv(layered.EnvKey)))
+	if err != nil {
+		return err
+	}
+	logger := log.GetLogger()
+	router, cleanup, err := data.NewTenantRouter(bc.Data, logger)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	if router == nil {
+		return fmt.Errorf("data.tenancy is not enabled")
+	}
+	if len(tenants) == 0 {
+		tenants = router.Tenants()
+	}
+	ctx := context.Background()
+	for _, id := range tenants {
+		if !tenant.Valid(id) {
+			return fmt.Errorf("invalid tenant id %q", id)
+		}
+		m, closeDB, err := router.Migrator(ctx, id)
+		if err != nil {
+			return err
+		}
+		if action == "status" {
+			fmt.Fprintf(os.Stdout, "tenant %s:\n", id)
+		}
+		err = migrateRun(ctx, m, action)
+		closeDB()
+		if err != nil {
+			return fmt.Errorf("tenant %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// migrateAction 是否为迁移子命令支持的操作
+func migrateAction(action string) bool {
+	return action == "up" || action == "down" || action == "status"
+}
+
+// migrateRun 执行迁移操作
+func migrateRun(ctx context.Context, m *migrate.Runner, action string) error {
+	switch action {
+	case "up":
+		return m.Up(ctx)
+	case "down":
+		return m.Down(ctx)
+	default:
+		return m.Status(ctx, os.Stdout)
+	}
+}
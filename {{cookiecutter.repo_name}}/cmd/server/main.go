@@ -1,18 +1,44 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
+	"fmt"
+	"io"
 	"os"
+	"time"
 
 	"github.com/go-kratos/kratos/v2"
 	"github.com/go-kratos/kratos/v2/config"
-	"github.com/go-kratos/kratos/v2/config/file"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/go-kratos/kratos/v2/middleware/tracing"
+	"github.com/go-kratos/kratos/v2/transport"
 	"github.com/go-kratos/kratos/v2/transport/grpc"
 	"github.com/go-kratos/kratos/v2/transport/http"
 	"{{cookiecutter.module_name}}/internal/conf"
+	"{{cookiecutter.module_name}}/internal/data"
+	"{{cookiecutter.module_name}}/internal/pkg/audit"
+	"{{cookiecutter.module_name}}/internal/pkg/confcheck"
+	"{{cookiecutter.module_name}}/internal/pkg/confcrypt"
+	"{{cookiecutter.module_name}}/internal/pkg/confdump"
+	"{{cookiecutter.module_name}}/internal/pkg/confenv"
+	"{{cookiecutter.module_name}}/internal/pkg/confflag"
+	"{{cookiecutter.module_name}}/internal/pkg/confsource/apollo"
+	"{{cookiecutter.module_name}}/internal/pkg/confsource/etcd"
+	"{{cookiecutter.module_name}}/internal/pkg/confsource/httpconf"
+	"{{cookiecutter.module_name}}/internal/pkg/confsource/kube"
+	"{{cookiecutter.module_name}}/internal/pkg/confsource/layered"
+	"{{cookiecutter.module_name}}/internal/pkg/confsource/nacos"
+	"{{cookiecutter.module_name}}/internal/pkg/confunit"
+	"{{cookiecutter.module_name}}/internal/pkg/graceful"
+	"{{cookiecutter.module_name}}/internal/pkg/reload"
+	"{{cookiecutter.module_name}}/internal/pkg/sdnotify"
+	"{{cookiecutter.module_name}}/internal/pkg/shutdown"
+	"{{cookiecutter.module_name}}/internal/pkg/vault"
 	pkglog "{{cookiecutter.module_name}}/internal/pkg/log"
+	"{{cookiecutter.module_name}}/internal/server"
+	"google.golang.org/protobuf/proto"
 )
 
 // go build -ldflags "-X main.Version=x.y.z"
@@ -23,36 +49,329 @@ var (
 	Version string = "1.0.0"
 	// flagconf is the config flag.
 	flagconf string
+	// overrides 命令行配置覆盖，如 -set server.http.addr=:9000
+	overrides confflag.Overrides
 
 	id, _ = os.Hostname()
 )
 
 func init() {
-	flag.StringVar(&flagconf, "conf", "../../configs", "config path, eg: -conf config.yaml")
+	flag.StringVar(&flagconf, "conf", "../../configs", "config path, a file or a directory of *.yaml merged in name order, eg: -conf config.yaml")
+	flag.Var(&overrides, "set", "override config key, repeatable, eg: -set server.http.addr=:9000")
 }
 
-func newApp(logger log.Logger, hs *http.Server, gs *grpc.Server) *kratos.App {
+func newApp(logger log.Logger, reporter *shutdown.Reporter, hs *http.Server, gs *grpc.Server, as server.AdminServer, ms server.MetricsServer, or data.OutboxRelay) *kratos.App {
+	servers := []transport.Server{hs, gs}
+	// 管理端口和指标端口
+	if as.Server != nil {
+		servers = append(servers, as)
+	}
+	if ms.Server != nil {
+		servers = append(servers, ms)
+	}
+	// outbox relay 作为后台 Server 随应用启停
+	if or.Relay != nil {
+		servers = append(servers, or)
+	}
 	return kratos.New(
 		kratos.ID(id),
 		kratos.Name(Name),
 		kratos.Version(Version),
 		kratos.Metadata(map[string]string{}),
 		kratos.Logger(logger),
-		kratos.Server(
-			hs,
-			gs,
-		),
+		kratos.Server(servers...),
+		// systemd Type=notify 就绪通知和看门狗心跳
+		kratos.AfterStart(func(ctx context.Context) error {
+			go sdnotify.RunWatchdog(ctx)
+			return sdnotify.Notify(sdnotify.Ready + "\n" + sdnotify.MainPID())
+		}),
+		kratos.BeforeStop(reporter.BeforeStop),
+		kratos.BeforeStop(reporter.Hook("sdnotify", func(context.Context) error {
+			return sdnotify.Notify(sdnotify.Stopping)
+		})),
+		// 停机时等待后台任务并输出停机报告
+		kratos.AfterStop(reporter.AfterStop),
+	)
+}
+
+// newBaseLogger 根据日志配置创建日志器，返回的 closer 用于释放各输出端持有的资源
+func newBaseLogger(c *conf.Log) (log.Logger, func(), error) {
+	if c == nil {
+		// 如果没有配置日志，使用默认的标准输出
+		return log.NewStdLogger(os.Stdout), nil, nil
+	}
+
+	var closers []func()
+	closer := func() {
+		for _, fn := range closers {
+			fn()
+		}
+	}
+	logger := pkglog.NewLogger(c)
+	if l, ok := logger.(io.Closer); ok {
+		closers = append(closers, func() { _ = l.Close() })
+	}
+
+	// 远端输出开启磁盘缓冲时，先写入本地分段文件再由后台按顺序投递
+	remote := func(name string, sink log.Logger, sender pkglog.SpoolSender, close func() error) error {
+		if !c.GetSpool().GetEnable() {
+			closers = append(closers, func() { _ = close() })
+			logger = pkglog.Multi(logger, sink)
+			return nil
+		}
+		spool, err := pkglog.NewSpoolLogger(c.Spool, name, sender)
+		if err != nil {
+			_ = close()
+			return err
+		}
+		closers = append(closers, func() {
+			_ = spool.Close()
+			_ = close()
+		})
+		logger = pkglog.Multi(logger, spool)
+		return nil
+	}
+
+	// 日志发送到 Fluentd
+	if c.GetFluent().GetEnable() {
+		fluentLogger := pkglog.NewFluentLogger(c.Fluent)
+		if err := remote("fluent", fluentLogger, fluentLogger, fluentLogger.Close); err != nil {
+			closer()
+			return nil, nil, err
+		}
+	}
+
+	// 日志写入 systemd-journald
+	if c.GetJournald().GetEnable() {
+		journaldLogger, err := pkglog.NewJournaldLogger(c.Journald, Name)
+		if err != nil {
+			closer()
+			return nil, nil, err
+		}
+		closers = append(closers, func() { _ = journaldLogger.Close() })
+		logger = pkglog.Multi(logger, journaldLogger)
+	}
+
+	// 错误日志上报到 Sentry
+	if c.GetSentry().GetEnable() {
+		sc := proto.Clone(c.Sentry).(*conf.Log_Sentry)
+		if sc.Release == "" {
+			sc.Release = Version
+		}
+		sentryLogger, err := pkglog.NewSentryLogger(sc)
+		if err != nil {
+			closer()
+			return nil, nil, err
+		}
+		closers = append(closers, func() { _ = sentryLogger.Close() })
+		logger = pkglog.Multi(logger, sentryLogger)
+	}
+
+	// 日志导出到 OpenTelemetry Collector
+	if c.GetOtlp().GetEnable() {
+		otlpLogger, err := pkglog.NewOTLPLogger(c.Otlp, Name, Version, id)
+		if err != nil {
+			closer()
+			return nil, nil, err
+		}
+		if err := remote("otlp", otlpLogger, otlpLogger, otlpLogger.Close); err != nil {
+			closer()
+			return nil, nil, err
+		}
+	}
+	return logger, closer, nil
+}
+
+// units 将 3s、1m30s、100MB 等带单位的值转换为配置字段需要的格式
+var units = confunit.Resolver(&conf.Bootstrap{})
+
+// localResolver 先应用命令行覆盖，再展开环境变量占位符，最后转换带单位的值
+func localResolver(input map[string]interface{}) error {
+	if err := overrides.Resolve(input); err != nil {
+		return err
+	}
+	if err := confenv.Resolver(input); err != nil {
+		return err
+	}
+	return units(input)
+}
+
+// bootstrap 读取本地配置文件，远程配置中心和密钥管理的地址等引导配置只从本地文件读取
+func bootstrap(fileSource config.Source) (*conf.Bootstrap, error) {
+	resolver := localResolver
+	// 引导配置只能使用明文数据密钥解密，KMS 加密的数据密钥需要先连接 Vault
+	cipher, err := confcrypt.FromEnv(context.Background(), nil)
+	if err != nil && !errors.Is(err, confcrypt.ErrWrappedKey) {
+		return nil, err
+	}
+	if err == nil {
+		decrypt := confcrypt.Resolver(cipher)
+		resolver = func(input map[string]interface{}) error {
+			if err := localResolver(input); err != nil {
+				return err
+			}
+			return decrypt(input)
+		}
+	}
+	c := config.New(
+		config.WithSource(fileSource),
+		config.WithResolver(resolver),
 	)
+	defer c.Close()
+	if err := c.Load(); err != nil {
+		return nil, err
+	}
+	var bc conf.Bootstrap
+	if err := c.Scan(&bc); err != nil {
+		return nil, err
+	}
+	return &bc, nil
+}
+
+// newSources 创建配置源，本地文件之后依次追加启用的远程配置中心，后面的覆盖前面的
+func newSources(fileSource config.Source, bc *conf.Bootstrap) ([]confdump.Source, error) {
+	sources := []confdump.Source{
+		{Name: "file", Source: fileSource},
+	}
+	if bc.Remote.GetNacos().GetEnable() {
+		s, err := nacos.New(bc.Remote.Nacos)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, confdump.Source{Name: "nacos", Source: s})
+	}
+	if bc.Remote.GetApollo().GetEnable() {
+		s, err := apollo.New(bc.Remote.Apollo, log.GetLogger())
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, confdump.Source{Name: "apollo", Source: s})
+	}
+	if bc.Remote.GetEtcd().GetEnable() {
+		s, err := etcd.New(bc.Remote.Etcd)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, confdump.Source{Name: "etcd", Source: s})
+	}
+	if bc.Remote.GetKubernetes().GetEnable() {
+		s, err := kube.New(bc.Remote.Kubernetes.Dirs...)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, confdump.Source{Name: "kubernetes", Source: s})
+	}
+	if bc.Remote.GetHttp().GetEnable() {
+		s, err := httpconf.New(bc.Remote.Http)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, confdump.Source{Name: "http", Source: s})
+	}
+	return sources, nil
+}
+
+// newResolver 创建配置解析器，先应用命令行覆盖和展开环境变量占位符，再解密 ENC(...) 值，开启 Vault 时最后替换密钥引用
+func newResolver(bc *conf.Bootstrap) (config.Resolver, func(), error) {
+	var (
+		p      *vault.Provider
+		unwrap confcrypt.Unwrapper
+		err    error
+	)
+	if bc.Remote.GetVault().GetEnable() {
+		if p, err = vault.New(bc.Remote.Vault, log.GetLogger()); err != nil {
+			return nil, nil, err
+		}
+		// CONFIG_KEY 为 vault:v1:... 时使用 Transit 引擎解密数据密钥
+		transit := bc.Remote.Vault.TransitKey
+		unwrap = func(ctx context.Context, wrapped string) ([]byte, error) {
+			if transit == "" {
+				return nil, errors.New("remote.vault.transit_key is not set")
+			}
+			return p.TransitDecrypt(ctx, transit, wrapped)
+		}
+	}
+	closer := func() {
+		if p != nil {
+			_ = p.Close()
+		}
+	}
+	cipher, err := confcrypt.FromEnv(context.Background(), unwrap)
+	if err != nil {
+		closer()
+		return nil, nil, err
+	}
+	decrypt := confcrypt.Resolver(cipher)
+	resolver := func(input map[string]interface{}) error {
+		if err := localResolver(input); err != nil {
+			return err
+		}
+		if err := decrypt(input); err != nil {
+			return err
+		}
+		if p == nil {
+			return nil
+		}
+		return p.Resolve(input)
+	}
+	return resolver, closer, nil
 }
 
 func main() {
 	flag.Parse()
 
+	// 本地开发时加载项目根目录的 .env，配置中的环境变量占位符与容器中行为一致
+	if os.Getenv(layered.EnvKey) == "dev" {
+		if err := confenv.LoadDotEnv(confenv.DotEnvFile); err != nil {
+			panic(err)
+		}
+	}
+
+	// 子命令，如 config schema、migrate up
+	if flag.NArg() > 0 {
+		if err := runCommand(flag.Args()); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		return
+	}
+
 	// 加载配置
+	// 本地配置按 APP_ENV 分层，config.yaml 之上合并 config.{env}.yaml
+	fileSource := layered.New(flagconf, os.Getenv(layered.EnvKey))
+	boot, err := bootstrap(fileSource)
+	if err != nil {
+		panic(err)
+	}
+	sources, err := newSources(fileSource, boot)
+	if err != nil {
+		panic(err)
+	}
+	resolver, closeResolver, err := newResolver(boot)
+	if err != nil {
+		panic(err)
+	}
+	defer closeResolver()
+	// 配置快照历史，回滚配置源放在最后，回滚的快照覆盖其他配置源
+	history, err := confdump.NewHistory(int(boot.Server.GetConfigHistory().GetSize()), boot.Server.GetConfigHistory().GetDir(), log.GetLogger())
+	if err != nil {
+		panic(err)
+	}
+	sources = append(sources, history.Source())
+	// 记录每次热更新来自哪个配置源，用于配置变更审计
+	tracker := confdump.NewTracker()
+	srcs := make([]config.Source, 0, len(sources))
+	for _, s := range sources {
+		srcs = append(srcs, tracker.Wrap(s))
+		// 远程配置源持有的连接在退出时释放
+		if closer, ok := s.Source.(io.Closer); ok {
+			defer closer.Close()
+		}
+	}
 	c := config.New(
-		config.WithSource(
-			file.NewSource(flagconf),
-		),
+		config.WithSource(srcs...),
+		// 应用 -set 覆盖，展开 ${DB_PASSWORD}、${PORT:8000} 形式的环境变量占位符，解密 ENC(...) 值，替换 vault: 密钥引用
+		config.WithResolver(resolver),
 	)
 	defer c.Close()
 
@@ -64,33 +383,123 @@ func main() {
 	if err := c.Scan(&bc); err != nil {
 		panic(err)
 	}
+	// 启动前校验配置，一次性输出全部错误后退出
+	if err := confcheck.Validate(&bc); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 
-	// 初始化日志器
-	var baseLogger log.Logger
-	if bc.Log != nil {
-		baseLogger = pkglog.NewLogger(bc.Log)
-	} else {
-		// 如果没有配置日志，使用默认的标准输出
-		baseLogger = log.NewStdLogger(os.Stdout)
+	// 服务名、版本、主机名等资源字段由日志器写入每条日志
+	pkglog.SetResource(pkglog.Resource{Name: Name, Version: Version})
+
+	// 初始化日志器，日志配置变更时热更新
+	baseLogger, closeLogger, err := newBaseLogger(bc.Log)
+	if err != nil {
+		panic(err)
 	}
+	swapLogger := pkglog.NewSwapLogger(baseLogger, closeLogger)
+	defer swapLogger.Close()
 
-	logger := log.With(baseLogger,
+	kvs := []interface{}{
 		// 使用zap的时间
 		// "ts", log.DefaultTimestamp,
 		"caller", log.DefaultCaller,
 		"service.id", id,
-		"service.name", Name,
-		"service.version", Version,
 		"trace.id", tracing.TraceID(),
 		"span.id", tracing.SpanID(),
-	)
+	}
+	// 上下文中的 user_id、tenant_id、request_id 及自定义字段
+	kvs = append(kvs, pkglog.ContextValuers()...)
+	var appLogger log.Logger = swapLogger
+	// 请求级日志缓冲，低级别日志只在请求失败或超时时输出
+	if bc.Log.GetBuffer().GetEnable() {
+		level := log.LevelWarn
+		if bc.Log.Buffer.Level != "" {
+			level = pkglog.GetLogLevel(bc.Log.Buffer.Level)
+		}
+		appLogger = pkglog.NewBufferLogger(swapLogger, level)
+		kvs = append(kvs, pkglog.BufferValuers()...)
+	}
+	logger := log.With(appLogger, kvs...)
+	// 输出脱敏后的启动配置，密码、密钥、令牌和 DSN 不会出现在日志中
+	_ = logger.Log(log.LevelInfo, "msg", "config loaded", "config", confdump.Scrub(&bc))
 
-	app, cleanup, err := wireApp(bc.Server, bc.Data, logger)
+	// 配置热更新，各模块通过 registry.OnChange 注册关心的配置 key
+	registry := reload.New(c, logger)
+	if err := registry.OnChange("log", func(v config.Value) error {
+		var lc conf.Log
+		if err := v.Scan(&lc); err != nil {
+			return err
+		}
+		l, closer, err := newBaseLogger(&lc)
+		if err != nil {
+			return err
+		}
+		swapLogger.Swap(l, closer)
+		return nil
+	}); err != nil {
+		panic(err)
+	}
+
+	// 日志归档上传
+	if bc.Log != nil && bc.Log.Filename != "" && bc.Log.Archive.GetEnable() {
+		storage, err := pkglog.NewS3Storage(bc.Log.Archive)
+		if err != nil {
+			panic(err)
+		}
+		archiver := pkglog.NewArchiver(bc.Log, storage, logger)
+		archiver.Start()
+		defer archiver.Stop()
+	}
+
+	// 审计日志
+	if bc.Log.GetAudit().GetEnable() {
+		var sinks []audit.Sink
+		if bc.Log.Audit.RemoteUrl != "" {
+			timeout := 3 * time.Second
+			if bc.Log.Audit.RemoteTimeout != nil {
+				timeout = bc.Log.Audit.RemoteTimeout.AsDuration()
+			}
+			sinks = append(sinks, audit.NewHTTPSink(bc.Log.Audit.RemoteUrl, timeout))
+		}
+		audit.SetDefault(audit.New(pkglog.NewAuditLogger(bc.Log), logger, sinks...))
+	}
+
+	// 配置导出，用于 /debug/config，每次请求读取热更新后的生效配置
+	dumper := confdump.New(c, &bc, sources...)
+
+	// 配置变更审计，热更新生效后将脱敏的配置差异、变更来源和摘要写入审计日志，并记录配置快照
+	confAuditor, err := confdump.NewAuditor(dumper, tracker, history, logger)
+	if err != nil {
+		panic(err)
+	}
+	if err := confAuditor.Watch(registry); err != nil {
+		panic(err)
+	}
+
+	// 停机报告
+	reporter := shutdown.NewReporter(logger, 10*time.Second)
+
+	app, cleanup, err := wireApp(&bc, dumper, history, registry, reporter, logger)
 	if err != nil {
 		panic(err)
 	}
 	defer cleanup()
 
+	// 平滑重启：启动新进程移交监听后停止当前进程
+	if bc.Server.HotRestart {
+		graceful.Notify(func() {
+			helper := log.NewHelper(logger)
+			pid, err := graceful.Restart()
+			if err != nil {
+				helper.Errorf("hot restart failed: %v", err)
+				return
+			}
+			helper.Infof("hot restart: new process %d started, draining", pid)
+			_ = app.Stop()
+		})
+	}
+
 	// start and wait for stop signal
 	if err := app.Run(); err != nil {
 		panic(err)
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"{{cookiecutter.module_name}}/internal/biz"
+	"{{cookiecutter.module_name}}/internal/data"
+	"{{cookiecutter.module_name}}/internal/pkg/confsource/layered"
+	"{{cookiecutter.module_name}}/internal/pkg/seed"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// defaultSeedDir 默认的种子数据目录
+const defaultSeedDir = "./seeds"
+
+// seedEnvs 允许导入种子数据的环境，APP_ENV 为空视为本地环境
+var seedEnvs = map[string]bool{"": true, "dev": true, "test": true}
+
+// seeder 种子数据导入器，YAML 记录通过 usecase 写入，与接口调用走同样的校验和钩子
+type seeder struct {
+	*seed.Runner
+}
+
+// newSeeder 注册各实体的导入函数，新增实体时在这里注册对应的 YAML key
+func newSeeder(uc *biz.{{cookiecutter.service_name}}Usecase, dbs data.Databases, logger log.Logger) *seeder {
+	var exec seed.Exec
+	if db := dbs.Default(); db != nil {
+		exec = func(ctx context.Context, query string) error {
+			_, err := db.ExecContext(ctx, query)
+			return err
+		}
+	}
+	r := seed.New(exec, logger)
+	helper := log.NewHelper(logger)
+	r.Register("{{cookiecutter.file_name}}", func(ctx context.Context, decode func(v interface{}) error) error {
+		var items []*biz.{{cookiecutter.service_name}}
+		if err := decode(&items); err != nil {
+			return err
+		}
+		created := 0
+		for _, g := range items {
+			ok, err := uc.Ensure{{cookiecutter.service_name}}(ctx, g)
+			if err != nil {
+				return err
+			}
+			if ok {
+				created++
+			}
+		}
+		helper.Infof("seed: {{cookiecutter.file_name}} %d created, %d skipped", created, len(items)-created)
+		return nil
+	})
+	return &seeder{Runner: r}
+}
+
+// runSeed 导入种子数据，只允许在本地和测试环境执行，数据库配置只从本地配置文件读取
+//
+//	./bin/server -conf ./configs seed [dir]
+func runSeed(args []string) error {
+	env := os.Getenv(layered.EnvKey)
+	if !seedEnvs[env] {
+		return fmt.Errorf("seed is disabled in %s=%s, allowed in dev and test", layered.EnvKey, env)
+	}
+	dir := defaultSeedDir
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	bc, err := bootstrap(layered.New(flagconf, env))
+	if err != nil {
+		return err
+	}
+	s, cleanup, err := wireSeeder(bc, log.GetLogger())
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	return s.Run(context.Background(), dir)
+}
@@ -8,6 +8,9 @@ import (
 	"{{cookiecutter.module_name}}/internal/biz"
 	"{{cookiecutter.module_name}}/internal/conf"
 	"{{cookiecutter.module_name}}/internal/data"
+	"{{cookiecutter.module_name}}/internal/pkg/confdump"
+	"{{cookiecutter.module_name}}/internal/pkg/reload"
+	"{{cookiecutter.module_name}}/internal/pkg/shutdown"
 	"{{cookiecutter.module_name}}/internal/server"
 	"{{cookiecutter.module_name}}/internal/service"
 	"github.com/go-kratos/kratos/v2"
@@ -16,6 +19,11 @@ import (
 )
 
 // wireApp init kratos application.
-func wireApp(*conf.Server, *conf.Data, log.Logger) (*kratos.App, func(), error) {
-	panic(wire.Build(server.ProviderSet, data.ProviderSet, biz.ProviderSet, service.ProviderSet, newApp))
+func wireApp(*conf.Bootstrap, *confdump.Dumper, *confdump.History, *reload.Registry, *shutdown.Reporter, log.Logger) (*kratos.App, func(), error) {
+	panic(wire.Build(conf.ProviderSet, server.ProviderSet, data.ProviderSet, biz.ProviderSet, service.ProviderSet, newApp))
+}
+
+// wireSeeder init the seeder on the data layer and usecases.
+func wireSeeder(*conf.Bootstrap, log.Logger) (*seeder, func(), error) {
+	panic(wire.Build(conf.ProviderSet, data.ProviderSet, biz.ProviderSet, newSeeder))
 }
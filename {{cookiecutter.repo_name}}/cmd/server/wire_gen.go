@@ -9,6 +9,9 @@ import (
 	"{{cookiecutter.module_name}}/internal/biz"
 	"{{cookiecutter.module_name}}/internal/conf"
 	"{{cookiecutter.module_name}}/internal/data"
+	"{{cookiecutter.module_name}}/internal/pkg/confdump"
+	"{{cookiecutter.module_name}}/internal/pkg/reload"
+	"{{cookiecutter.module_name}}/internal/pkg/shutdown"
 	"{{cookiecutter.module_name}}/internal/server"
 	"{{cookiecutter.module_name}}/internal/service"
 	"github.com/go-kratos/kratos/v2"
@@ -18,18 +21,253 @@ import (
 // Injectors from wire.go:
 
 // wireApp init kratos application.
-func wireApp(confServer *conf.Server, confData *conf.Data, logger log.Logger) (*kratos.App, func(), error) {
-	dataData, cleanup, err := data.NewData(confData, logger)
+func wireApp(bootstrap *conf.Bootstrap, dumper *confdump.Dumper, history *confdump.History, registry *reload.Registry, reporter *shutdown.Reporter, logger log.Logger) (*kratos.App, func(), error) {
+	confData := conf.ProvideDataConf(bootstrap)
+	databases, cleanup, err := data.NewDatabases(confData, logger)
 	if err != nil {
 		return nil, nil, err
 	}
-	{{cookiecutter.repo_name}}Repo := data.New{{cookiecutter.service_name}}Repo(dataData, logger)
-	{{cookiecutter.repo_name}}Usecase := biz.New{{cookiecutter.service_name}}Usecase({{cookiecutter.repo_name}}Repo, logger)
-	{{cookiecutter.repo_name}}Service := service.New{{cookiecutter.service_name}}Service({{cookiecutter.repo_name}}Usecase, logger)
-	httpServer := server.NewHTTPServer(confServer, {{cookiecutter.repo_name}}Service, logger)
-	grpcServer := server.NewGRPCServer(confServer, {{cookiecutter.repo_name}}Service, logger)
-	app := newApp(logger, httpServer, grpcServer)
+	redisClients, cleanup2, err := data.NewRedisClients(confData, logger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	db, cleanup3, err := data.NewGormDB(confData, databases, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	mongo, cleanup4, err := data.NewMongo(confData, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	clickHouse, cleanup5, err := data.NewClickHouse(confData, logger)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	dataData, cleanup6, err := data.NewData(confData, databases, redisClients, db, mongo, logger)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	{{cookiecutter.file_name}}Repo := data.New{{cookiecutter.service_name}}Repo(dataData, logger)
+	transaction := data.NewTransaction(dataData)
+	eventRepo := data.NewEventRepo(clickHouse, logger)
+	outboxRepo := data.NewOutboxRepo(confData, dataData)
+	{{cookiecutter.file_name}}Usecase := biz.New{{cookiecutter.service_name}}Usecase({{cookiecutter.file_name}}Repo, transaction, eventRepo, outboxRepo, logger)
+	store := data.NewIdempotencyStore(dataData, logger)
+	{{cookiecutter.file_name}}Service := service.New{{cookiecutter.service_name}}Service({{cookiecutter.file_name}}Usecase, store, logger)
+	renderer, err := data.NewMailRenderer()
+	if err != nil {
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	confLog := conf.ProvideLogConf(bootstrap)
+	accessLog := server.NewAccessLog(confLog)
+	slowLog, err := server.NewSlowLog(confLog, registry)
+	if err != nil {
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	confServer := conf.ProvideServerConf(bootstrap)
+	versionCheck := server.NewVersionCheck(confServer)
+	deprecation := server.NewDeprecation(logger)
+	timeout := server.NewTimeout()
+	duplicate := server.NewDuplicate(confServer, logger)
+	tenancy := server.NewTenancy(confData)
+	logBuffer := server.NewLogBuffer(confLog, logger)
+	shutdownStats := server.NewShutdownStats(reporter)
+	manager, cleanup7 := server.NewOperationManager(confServer, reporter, logger)
+	collector, cleanup8 := server.NewDiagnostics(confServer, logger)
+	registry2, cleanup9, err := server.NewFeatures(confServer, registry)
+	if err != nil {
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	manager2, cleanup10, err := server.NewLicense(confServer, logger)
+	if err != nil {
+		cleanup9()
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	licenseGate := server.NewLicenseGate(confServer, manager2)
+	featureFlags := server.NewFeatureFlags(confServer, registry2)
+	httpServer, err := server.NewHTTPServer(confServer, accessLog, slowLog, versionCheck, deprecation, timeout, duplicate, tenancy, licenseGate, featureFlags, logBuffer, shutdownStats, collector, manager, renderer, {{cookiecutter.file_name}}Service, logger)
+	if err != nil {
+		cleanup10()
+		cleanup9()
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	grpcServer, err := server.NewGRPCServer(confServer, accessLog, slowLog, versionCheck, deprecation, timeout, duplicate, tenancy, licenseGate, featureFlags, logBuffer, shutdownStats, collector, {{cookiecutter.file_name}}Service, logger)
+	if err != nil {
+		cleanup10()
+		cleanup9()
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	registry3, err := server.NewModules(confServer, registry, logger)
+	if err != nil {
+		cleanup10()
+		cleanup9()
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	adminServer := server.NewAdminServer(confServer, collector, registry3, registry2, manager2, dumper, history, logger)
+	metricsServer, err := server.NewMetricsServer(confServer, logger)
+	if err != nil {
+		cleanup10()
+		cleanup9()
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	outboxRelay, err := data.NewOutboxRelay(confData, dataData, logger)
+	if err != nil {
+		cleanup10()
+		cleanup9()
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	app := newApp(logger, reporter, httpServer, grpcServer, adminServer, metricsServer, outboxRelay)
 	return app, func() {
+		cleanup10()
+		cleanup9()
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+	}, nil
+}
+
+// wireSeeder init the seeder on the data layer and usecases.
+func wireSeeder(bootstrap *conf.Bootstrap, logger log.Logger) (*seeder, func(), error) {
+	confData := conf.ProvideDataConf(bootstrap)
+	databases, cleanup, err := data.NewDatabases(confData, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	redisClients, cleanup2, err := data.NewRedisClients(confData, logger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	db, cleanup3, err := data.NewGormDB(confData, databases, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	mongo, cleanup4, err := data.NewMongo(confData, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	clickHouse, cleanup5, err := data.NewClickHouse(confData, logger)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	dataData, cleanup6, err := data.NewData(confData, databases, redisClients, db, mongo, logger)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	{{cookiecutter.file_name}}Repo := data.New{{cookiecutter.service_name}}Repo(dataData, logger)
+	transaction := data.NewTransaction(dataData)
+	eventRepo := data.NewEventRepo(clickHouse, logger)
+	outboxRepo := data.NewOutboxRepo(confData, dataData)
+	{{cookiecutter.file_name}}Usecase := biz.New{{cookiecutter.service_name}}Usecase({{cookiecutter.file_name}}Repo, transaction, eventRepo, outboxRepo, logger)
+	mainSeeder := newSeeder({{cookiecutter.file_name}}Usecase, databases, logger)
+	return mainSeeder, func() {
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
 		cleanup()
 	}, nil
 }
@@ -2,5 +2,8 @@ package biz
 
 import "github.com/google/wire"
 
+// 测试数据构造器，实体变更后执行 go generate ./... 重新生成
+//go:generate go run {{cookiecutter.module_name}}/cmd/factorygen -out ./factory
+
 // ProviderSet is biz providers.
 var ProviderSet = wire.NewSet(New{{cookiecutter.service_name}}Usecase)
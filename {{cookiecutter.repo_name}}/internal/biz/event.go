@@ -0,0 +1,19 @@
+package biz
+
+import (
+	"context"
+	"time"
+)
+
+// Event is an analytics event, such as a {{cookiecutter.service_name}} being created.
+type Event struct {
+	Name    string
+	Subject string
+	Attrs   map[string]string
+	Time    time.Time
+}
+
+// EventRepo 分析事件仓储，按批写入 ClickHouse 等分析库，未配置时丢弃
+type EventRepo interface {
+	BatchInsert(context.Context, []*Event) error
+}
@@ -0,0 +1,185 @@
+// Code generated by factorygen. DO NOT EDIT.
+
+package factory
+
+import (
+	"time"
+
+	"{{cookiecutter.module_name}}/internal/biz"
+	"github.com/brianvoe/gofakeit/v6"
+)
+
+// Seed 固定随机种子，使构造的假数据可复现
+func Seed(seed int64) {
+	gofakeit.Seed(seed)
+}
+
+// EventBuilder 构造测试用的 biz.Event，未指定的字段使用随机假数据
+type EventBuilder struct {
+	v biz.Event
+}
+
+// NewEventBuilder 创建 biz.Event 构造器
+func NewEventBuilder() *EventBuilder {
+	return &EventBuilder{v: biz.Event{
+		Name:    gofakeit.Name(),
+		Subject: gofakeit.Word(),
+		Time:    gofakeit.Date(),
+	}}
+}
+
+// WithName 设置 Name
+func (b *EventBuilder) WithName(v string) *EventBuilder {
+	b.v.Name = v
+	return b
+}
+
+// WithSubject 设置 Subject
+func (b *EventBuilder) WithSubject(v string) *EventBuilder {
+	b.v.Subject = v
+	return b
+}
+
+// WithAttrs 设置 Attrs
+func (b *EventBuilder) WithAttrs(v map[string]string) *EventBuilder {
+	b.v.Attrs = v
+	return b
+}
+
+// WithTime 设置 Time
+func (b *EventBuilder) WithTime(v time.Time) *EventBuilder {
+	b.v.Time = v
+	return b
+}
+
+// Build 返回构造的 biz.Event，可重复调用
+func (b *EventBuilder) Build() *biz.Event {
+	v := b.v
+	return &v
+}
+
+// NewEventList 构造 n 个 biz.Event，fn 用于调整每个构造器
+func NewEventList(n int, fn func(i int, b *EventBuilder)) []*biz.Event {
+	list := make([]*biz.Event, 0, n)
+	for i := 0; i < n; i++ {
+		b := NewEventBuilder()
+		if fn != nil {
+			fn(i, b)
+		}
+		list = append(list, b.Build())
+	}
+	return list
+}
+
+// {{cookiecutter.service_name}}Builder 构造测试用的 biz.{{cookiecutter.service_name}}，未指定的字段使用随机假数据
+type {{cookiecutter.service_name}}Builder struct {
+	v biz.{{cookiecutter.service_name}}
+}
+
+// New{{cookiecutter.service_name}}Builder 创建 biz.{{cookiecutter.service_name}} 构造器
+func New{{cookiecutter.service_name}}Builder() *{{cookiecutter.service_name}}Builder {
+	return &{{cookiecutter.service_name}}Builder{v: biz.{{cookiecutter.service_name}}{
+		ID:    int64(gofakeit.Number(1, 1<<30)),
+		Hello: gofakeit.Word(),
+	}}
+}
+
+// WithID 设置 ID
+func (b *{{cookiecutter.service_name}}Builder) WithID(v int64) *{{cookiecutter.service_name}}Builder {
+	b.v.ID = v
+	return b
+}
+
+// WithHello 设置 Hello
+func (b *{{cookiecutter.service_name}}Builder) WithHello(v string) *{{cookiecutter.service_name}}Builder {
+	b.v.Hello = v
+	return b
+}
+
+// WithDeletedAt 设置 DeletedAt
+func (b *{{cookiecutter.service_name}}Builder) WithDeletedAt(v *time.Time) *{{cookiecutter.service_name}}Builder {
+	b.v.DeletedAt = v
+	return b
+}
+
+// Build 返回构造的 biz.{{cookiecutter.service_name}}，可重复调用
+func (b *{{cookiecutter.service_name}}Builder) Build() *biz.{{cookiecutter.service_name}} {
+	v := b.v
+	return &v
+}
+
+// New{{cookiecutter.service_name}}List 构造 n 个 biz.{{cookiecutter.service_name}}，fn 用于调整每个构造器
+func New{{cookiecutter.service_name}}List(n int, fn func(i int, b *{{cookiecutter.service_name}}Builder)) []*biz.{{cookiecutter.service_name}} {
+	list := make([]*biz.{{cookiecutter.service_name}}, 0, n)
+	for i := 0; i < n; i++ {
+		b := New{{cookiecutter.service_name}}Builder()
+		if fn != nil {
+			fn(i, b)
+		}
+		list = append(list, b.Build())
+	}
+	return list
+}
+
+// OutboxMessageBuilder 构造测试用的 biz.OutboxMessage，未指定的字段使用随机假数据
+type OutboxMessageBuilder struct {
+	v biz.OutboxMessage
+}
+
+// NewOutboxMessageBuilder 创建 biz.OutboxMessage 构造器
+func NewOutboxMessageBuilder() *OutboxMessageBuilder {
+	return &OutboxMessageBuilder{v: biz.OutboxMessage{
+		Topic:    gofakeit.Word(),
+		Key:      gofakeit.Word(),
+		DedupKey: gofakeit.Word(),
+	}}
+}
+
+// WithTopic 设置 Topic
+func (b *OutboxMessageBuilder) WithTopic(v string) *OutboxMessageBuilder {
+	b.v.Topic = v
+	return b
+}
+
+// WithKey 设置 Key
+func (b *OutboxMessageBuilder) WithKey(v string) *OutboxMessageBuilder {
+	b.v.Key = v
+	return b
+}
+
+// WithDedupKey 设置 DedupKey
+func (b *OutboxMessageBuilder) WithDedupKey(v string) *OutboxMessageBuilder {
+	b.v.DedupKey = v
+	return b
+}
+
+// WithPayload 设置 Payload
+func (b *OutboxMessageBuilder) WithPayload(v []byte) *OutboxMessageBuilder {
+	b.v.Payload = v
+	return b
+}
+
+// WithHeaders 设置 Headers
+func (b *OutboxMessageBuilder) WithHeaders(v map[string]string) *OutboxMessageBuilder {
+	b.v.Headers = v
+	return b
+}
+
+// Build 返回构造的 biz.OutboxMessage，可重复调用
+func (b *OutboxMessageBuilder) Build() *biz.OutboxMessage {
+	v := b.v
+	return &v
+}
+
+// NewOutboxMessageList 构造 n 个 biz.OutboxMessage，fn 用于调整每个构造器
+func NewOutboxMessageList(n int, fn func(i int, b *OutboxMessageBuilder)) []*biz.OutboxMessage {
+	list := make([]*biz.OutboxMessage, 0, n)
+	for i := 0; i < n; i++ {
+		b := NewOutboxMessageBuilder()
+		if fn != nil {
+			fn(i, b)
+		}
+		list = append(list, b.Build())
+	}
+	return list
+}
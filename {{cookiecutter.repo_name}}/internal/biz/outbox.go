@@ -0,0 +1,18 @@
+package biz
+
+import "context"
+
+// OutboxMessage is a message published to the event bus after the transaction that wrote it commits.
+type OutboxMessage struct {
+	Topic string
+	Key   string
+	// DedupKey identifies the message for consumers, a random key is used when empty.
+	DedupKey string
+	Payload  []byte
+	Headers  map[string]string
+}
+
+// OutboxRepo 事务性 outbox，Add 使用 ctx 中的事务，与业务数据一起提交或回滚
+type OutboxRepo interface {
+	Add(context.Context, ...*OutboxMessage) error
+}
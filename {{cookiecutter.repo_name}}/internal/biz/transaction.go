@@ -0,0 +1,17 @@
+package biz
+
+import "context"
+
+// Transaction 事务管理，fn 中使用传入的 ctx 调用的 repo 操作在同一个事务中执行，
+// fn 返回错误或 panic 时回滚，嵌套调用加入外层事务
+//
+//	err := uc.tm.InTx(ctx, func(ctx context.Context) error {
+//		if _, err := uc.repo.Save(ctx, a); err != nil {
+//			return err
+//		}
+//		_, err := uc.orderRepo.Save(ctx, b)
+//		return err
+//	})
+type Transaction interface {
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
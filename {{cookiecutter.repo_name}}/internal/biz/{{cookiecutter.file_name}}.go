@@ -2,8 +2,12 @@ package biz
 
 import (
 	"context"
+	"encoding/json"
+	"strconv"
+	"time"
 
 	v1 "{{cookiecutter.module_name}}/api/{{cookiecutter.file_name}}/v1"
+	"{{cookiecutter.module_name}}/internal/pkg/pagination"
 	"github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/log"
 )
@@ -15,7 +19,10 @@ var (
 
 // {{cookiecutter.service_name}} is a {{cookiecutter.service_name}} model.
 type {{cookiecutter.service_name}} struct {
+	ID    int64
 	Hello string
+	// DeletedAt is set once the {{cookiecutter.service_name}} is soft-deleted, finds and lists skip it until restored.
+	DeletedAt *time.Time
 }
 
 // {{cookiecutter.service_name}}Repo is a Greater repo.
@@ -25,21 +32,128 @@ type {{cookiecutter.service_name}}Repo interface {
 	FindByID(context.Context, int64) (*{{cookiecutter.service_name}}, error)
 	ListByHello(context.Context, string) ([]*{{cookiecutter.service_name}}, error)
 	ListAll(context.Context) ([]*{{cookiecutter.service_name}}, error)
+	List(context.Context, pagination.Request) ([]*{{cookiecutter.service_name}}, *pagination.Result, error)
+	// Delete soft-deletes by id, it returns ErrUserNotFound if the id is missing or already deleted.
+	Delete(context.Context, int64) error
+	// Restore undoes Delete, it returns ErrUserNotFound if the id is missing or not deleted.
+	Restore(context.Context, int64) (*{{cookiecutter.service_name}}, error)
+	// ListDeleted lists the soft-deleted {{cookiecutter.service_name}}s that the other queries skip.
+	ListDeleted(context.Context) ([]*{{cookiecutter.service_name}}, error)
 }
 
 // {{cookiecutter.service_name}}Usecase is a {{cookiecutter.service_name}} usecase.
 type {{cookiecutter.service_name}}Usecase struct {
-	repo {{cookiecutter.service_name}}Repo
-	log  *log.Helper
+	repo   {{cookiecutter.service_name}}Repo
+	tm     Transaction
+	events EventRepo
+	outbox OutboxRepo
+	log    *log.Helper
 }
 
 // New{{cookiecutter.service_name}}Usecase new a {{cookiecutter.service_name}} usecase.
-func New{{cookiecutter.service_name}}Usecase(repo {{cookiecutter.service_name}}Repo, logger log.Logger) *{{cookiecutter.service_name}}Usecase {
-	return &{{cookiecutter.service_name}}Usecase{repo: repo, log: log.NewHelper(logger)}
+func New{{cookiecutter.service_name}}Usecase(repo {{cookiecutter.service_name}}Repo, tm Transaction, events EventRepo, outbox OutboxRepo, logger log.Logger) *{{cookiecutter.service_name}}Usecase {
+	return &{{cookiecutter.service_name}}Usecase{repo: repo, tm: tm, events: events, outbox: outbox, log: log.NewHelper(logger)}
 }
 
 // Create{{cookiecutter.service_name}} creates a {{cookiecutter.service_name}}, and returns the new {{cookiecutter.service_name}}.
+// The created message is written to the outbox in the same transaction, so it is published only if the {{cookiecutter.service_name}} is saved.
 func (uc *{{cookiecutter.service_name}}Usecase) Create{{cookiecutter.service_name}}(ctx context.Context, g *{{cookiecutter.service_name}}) (*{{cookiecutter.service_name}}, error) {
 	uc.log.WithContext(ctx).Infof("Create{{cookiecutter.service_name}}: %v", g.Hello)
-	return uc.repo.Save(ctx, g)
-}
\ No newline at end of file
+	var created *{{cookiecutter.service_name}}
+	err := uc.tm.InTx(ctx, func(ctx context.Context) error {
+		c, err := uc.repo.Save(ctx, g)
+		if err != nil {
+			return err
+		}
+		created = c
+		return uc.outbox.Add(ctx, createdMessage(c))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// Create{{cookiecutter.service_name}}s creates {{cookiecutter.service_name}}s in one transaction, none is created if any fails.
+func (uc *{{cookiecutter.service_name}}Usecase) Create{{cookiecutter.service_name}}s(ctx context.Context, gs []*{{cookiecutter.service_name}}) ([]*{{cookiecutter.service_name}}, error) {
+	created := make([]*{{cookiecutter.service_name}}, 0, len(gs))
+	err := uc.tm.InTx(ctx, func(ctx context.Context) error {
+		for _, g := range gs {
+			c, err := uc.repo.Save(ctx, g)
+			if err != nil {
+				return err
+			}
+			created = append(created, c)
+		}
+		msgs := make([]*OutboxMessage, 0, len(created))
+		for _, c := range created {
+			msgs = append(msgs, createdMessage(c))
+		}
+		return uc.outbox.Add(ctx, msgs...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.track(ctx, created)
+	return created, nil
+}
+
+// Ensure{{cookiecutter.service_name}} creates g unless a {{cookiecutter.service_name}} with the same Hello exists, it reports whether g was created.
+func (uc *{{cookiecutter.service_name}}Usecase) Ensure{{cookiecutter.service_name}}(ctx context.Context, g *{{cookiecutter.service_name}}) (bool, error) {
+	existing, err := uc.repo.ListByHello(ctx, g.Hello)
+	if err != nil {
+		return false, err
+	}
+	if len(existing) > 0 {
+		return false, nil
+	}
+	if _, err := uc.Create{{cookiecutter.service_name}}(ctx, g); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// List{{cookiecutter.service_name}}s returns a page of {{cookiecutter.service_name}}s by page/page_size or by the cursor of the previous page.
+func (uc *{{cookiecutter.service_name}}Usecase) List{{cookiecutter.service_name}}s(ctx context.Context, req pagination.Request) ([]*{{cookiecutter.service_name}}, *pagination.Result, error) {
+	return uc.repo.List(ctx, req)
+}
+
+// Delete{{cookiecutter.service_name}} soft-deletes a {{cookiecutter.service_name}}, the row is kept and can be restored.
+func (uc *{{cookiecutter.service_name}}Usecase) Delete{{cookiecutter.service_name}}(ctx context.Context, id int64) error {
+	uc.log.WithContext(ctx).Infof("Delete{{cookiecutter.service_name}}: %d", id)
+	return uc.repo.Delete(ctx, id)
+}
+
+// Restore{{cookiecutter.service_name}} brings back a soft-deleted {{cookiecutter.service_name}}.
+func (uc *{{cookiecutter.service_name}}Usecase) Restore{{cookiecutter.service_name}}(ctx context.Context, id int64) (*{{cookiecutter.service_name}}, error) {
+	uc.log.WithContext(ctx).Infof("Restore{{cookiecutter.service_name}}: %d", id)
+	return uc.repo.Restore(ctx, id)
+}
+
+// ListDeleted{{cookiecutter.service_name}}s returns the soft-deleted {{cookiecutter.service_name}}s, e.g. for a recycle bin.
+func (uc *{{cookiecutter.service_name}}Usecase) ListDeleted{{cookiecutter.service_name}}s(ctx context.Context) ([]*{{cookiecutter.service_name}}, error) {
+	return uc.repo.ListDeleted(ctx)
+}
+
+// createdMessage is the outbox message announcing a created {{cookiecutter.service_name}}.
+func createdMessage(g *{{cookiecutter.service_name}}) *OutboxMessage {
+	payload, _ := json.Marshal(map[string]string{"hello": g.Hello})
+	return &OutboxMessage{Topic: "{{cookiecutter.file_name}}.created", Key: g.Hello, Payload: payload}
+}
+
+// track 记录创建事件，分析库写入失败不影响业务结果
+func (uc *{{cookiecutter.service_name}}Usecase) track(ctx context.Context, gs []*{{cookiecutter.service_name}}) {
+	now := time.Now()
+	events := make([]*Event, 0, len(gs))
+	for i, g := range gs {
+		events = append(events, &Event{
+			Name:    "{{cookiecutter.file_name}}.created",
+			Subject: g.Hello,
+			Attrs:   map[string]string{"batch_index": strconv.Itoa(i)},
+			Time:    now,
+		})
+	}
+	if err := uc.events.BatchInsert(ctx, events); err != nil {
+		uc.log.WithContext(ctx).Warnf("track events: %v", err)
+	}
+}
@@ -0,0 +1,4 @@
+package conf
+
+// 各配置段的 wire 提供者，conf.proto 的 Bootstrap 变更后执行 go generate ./... 重新生成
+//go:generate go run {{cookiecutter.module_name}}/cmd/confprovider -out provider_gen.go
@@ -1,19 +1,19 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
 // 	protoc-gen-go v1.36.6
-// 	protoc        v6.30.2
+// 	protoc        (unknown)
 // source: conf/conf.proto
 
 package conf
 
 import (
-	reflect "reflect"
-	sync "sync"
-	unsafe "unsafe"
-
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
 )
 
 const (
@@ -28,6 +28,7 @@ type Bootstrap struct {
 	Server        *Server                `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
 	Data          *Data                  `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
 	Log           *Log                   `protobuf:"bytes,3,opt,name=log,proto3" json:"log,omitempty"`
+	Remote        *Remote                `protobuf:"bytes,4,opt,name=remote,proto3" json:"remote,omitempty"` // 远程配置中心，只从本地配置文件读取
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -83,29 +84,3477 @@ func (x *Bootstrap) GetLog() *Log {
 	return nil
 }
 
+func (x *Bootstrap) GetRemote() *Remote {
+	if x != nil {
+		return x.Remote
+	}
+	return nil
+}
+
+// Remote 远程配置中心和密钥管理，配置中心的配置与本地文件合并，同名配置项以远程为准
+type Remote struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Nacos         *Remote_Nacos          `protobuf:"bytes,1,opt,name=nacos,proto3" json:"nacos,omitempty"`
+	Apollo        *Remote_Apollo         `protobuf:"bytes,2,opt,name=apollo,proto3" json:"apollo,omitempty"` // Apollo 不可用时使用本地文件启动，恢复后通过监听自动加载
+	Etcd          *Remote_Etcd           `protobuf:"bytes,3,opt,name=etcd,proto3" json:"etcd,omitempty"`
+	Vault         *Remote_Vault          `protobuf:"bytes,4,opt,name=vault,proto3" json:"vault,omitempty"`           // 配置值 vault:secret/data/app#db_password 在加载时替换为 Vault 中的密钥
+	Kubernetes    *Remote_Kubernetes     `protobuf:"bytes,5,opt,name=kubernetes,proto3" json:"kubernetes,omitempty"` // 集群内部署时读取挂载的 ConfigMap / Secret，文件变更后自动热更新
+	Http          *Remote_HTTP           `protobuf:"bytes,6,opt,name=http,proto3" json:"http,omitempty"`             // 没有配置中心时从 URL 轮询配置文件，带 If-None-Match，变更后自动热更新
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Remote) Reset() {
+	*x = Remote{}
+	mi := &file_conf_conf_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Remote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Remote) ProtoMessage() {}
+
+func (x *Remote) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Remote.ProtoReflect.Descriptor instead.
+func (*Remote) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Remote) GetNacos() *Remote_Nacos {
+	if x != nil {
+		return x.Nacos
+	}
+	return nil
+}
+
+func (x *Remote) GetApollo() *Remote_Apollo {
+	if x != nil {
+		return x.Apollo
+	}
+	return nil
+}
+
+func (x *Remote) GetEtcd() *Remote_Etcd {
+	if x != nil {
+		return x.Etcd
+	}
+	return nil
+}
+
+func (x *Remote) GetVault() *Remote_Vault {
+	if x != nil {
+		return x.Vault
+	}
+	return nil
+}
+
+func (x *Remote) GetKubernetes() *Remote_Kubernetes {
+	if x != nil {
+		return x.Kubernetes
+	}
+	return nil
+}
+
+func (x *Remote) GetHttp() *Remote_HTTP {
+	if x != nil {
+		return x.Http
+	}
+	return nil
+}
+
 type Server struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Http          *Server_HTTP           `protobuf:"bytes,1,opt,name=http,proto3" json:"http,omitempty"`
 	Grpc          *Server_GRPC           `protobuf:"bytes,2,opt,name=grpc,proto3" json:"grpc,omitempty"`
+	Debug         *Server_Debug          `protobuf:"bytes,3,opt,name=debug,proto3" json:"debug,omitempty"`                                       // /debug/* 管理接口
+	ApiVersion    *Server_APIVersion     `protobuf:"bytes,4,opt,name=api_version,json=apiVersion,proto3" json:"api_version,omitempty"`           // API 版本协商
+	HotRestart    bool                   `protobuf:"varint,5,opt,name=hot_restart,json=hotRestart,proto3" json:"hot_restart,omitempty"`          // 收到 USR2 信号时启动新进程并移交监听，用于虚拟机部署的平滑重启
+	Docs          *Server_Docs           `protobuf:"bytes,6,opt,name=docs,proto3" json:"docs,omitempty"`                                         // /docs/* 接口文档
+	Operation     *Server_Operation      `protobuf:"bytes,7,opt,name=operation,proto3" json:"operation,omitempty"`                               // /v1/operations 长时间运行操作
+	Duplicate     *Server_Duplicate      `protobuf:"bytes,8,opt,name=duplicate,proto3" json:"duplicate,omitempty"`                               // 客户端重试检测
+	Admin         *Server_Admin          `protobuf:"bytes,9,opt,name=admin,proto3" json:"admin,omitempty"`                                       // 管理端口，/debug/config 输出脱敏后的生效配置，鉴权与 debug.token 相同
+	Metrics       *Server_Metrics        `protobuf:"bytes,10,opt,name=metrics,proto3" json:"metrics,omitempty"`                                  // Prometheus 指标端口
+	Diagnostics   *Server_Diagnostics    `protobuf:"bytes,11,opt,name=diagnostics,proto3" json:"diagnostics,omitempty"`                          // 请求级内存分配和 CPU 采样诊断，报告挂载在管理端口 /debug/alloc
+	Modules       *Server_Modules        `protobuf:"bytes,12,opt,name=modules,proto3" json:"modules,omitempty"`                                  // 后台模块暂停和恢复，管理接口挂载在管理端口 /modules
+	Features      *Server_Features       `protobuf:"bytes,13,opt,name=features,proto3" json:"features,omitempty"`                                // 功能开关，当前值挂载在管理端口 /features
+	License       *Server_License        `protobuf:"bytes,14,opt,name=license,proto3" json:"license,omitempty"`                                  // 授权检查，状态输出在管理端口 /healthz?detail=1
+	ConfigHistory *Server_ConfigHistory  `protobuf:"bytes,15,opt,name=config_history,json=configHistory,proto3" json:"config_history,omitempty"` // 配置快照，历史和回滚接口挂载在管理端口 /debug/config/history
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server) Reset() {
+	*x = Server{}
+	mi := &file_conf_conf_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server) ProtoMessage() {}
+
+func (x *Server) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server.ProtoReflect.Descriptor instead.
+func (*Server) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Server) GetHttp() *Server_HTTP {
+	if x != nil {
+		return x.Http
+	}
+	return nil
+}
+
+func (x *Server) GetGrpc() *Server_GRPC {
+	if x != nil {
+		return x.Grpc
+	}
+	return nil
+}
+
+func (x *Server) GetDebug() *Server_Debug {
+	if x != nil {
+		return x.Debug
+	}
+	return nil
+}
+
+func (x *Server) GetApiVersion() *Server_APIVersion {
+	if x != nil {
+		return x.ApiVersion
+	}
+	return nil
+}
+
+func (x *Server) GetHotRestart() bool {
+	if x != nil {
+		return x.HotRestart
+	}
+	return false
+}
+
+func (x *Server) GetDocs() *Server_Docs {
+	if x != nil {
+		return x.Docs
+	}
+	return nil
+}
+
+func (x *Server) GetOperation() *Server_Operation {
+	if x != nil {
+		return x.Operation
+	}
+	return nil
+}
+
+func (x *Server) GetDuplicate() *Server_Duplicate {
+	if x != nil {
+		return x.Duplicate
+	}
+	return nil
+}
+
+func (x *Server) GetAdmin() *Server_Admin {
+	if x != nil {
+		return x.Admin
+	}
+	return nil
+}
+
+func (x *Server) GetMetrics() *Server_Metrics {
+	if x != nil {
+		return x.Metrics
+	}
+	return nil
+}
+
+func (x *Server) GetDiagnostics() *Server_Diagnostics {
+	if x != nil {
+		return x.Diagnostics
+	}
+	return nil
+}
+
+func (x *Server) GetModules() *Server_Modules {
+	if x != nil {
+		return x.Modules
+	}
+	return nil
+}
+
+func (x *Server) GetFeatures() *Server_Features {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *Server) GetLicense() *Server_License {
+	if x != nil {
+		return x.License
+	}
+	return nil
+}
+
+func (x *Server) GetConfigHistory() *Server_ConfigHistory {
+	if x != nil {
+		return x.ConfigHistory
+	}
+	return nil
+}
+
+type Data struct {
+	state          protoimpl.MessageState    `protogen:"open.v1"`
+	Database       *Data_Database            `protobuf:"bytes,1,opt,name=database,proto3" json:"database,omitempty"` // 单实例写法，等同于 databases.default，不能与之同时配置
+	Redis          *Data_Redis               `protobuf:"bytes,2,opt,name=redis,proto3" json:"redis,omitempty"`       // 单实例写法，等同于 redis_instances.default，不能与之同时配置
+	Embedded       *Data_Embedded            `protobuf:"bytes,3,opt,name=embedded,proto3" json:"embedded,omitempty"`
+	Databases      map[string]*Data_Database `protobuf:"bytes,4,rep,name=databases,proto3" json:"databases,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`                                 // 按名称配置多个数据库，如 default 主库、report 报表库
+	RedisInstances map[string]*Data_Redis    `protobuf:"bytes,5,rep,name=redis_instances,json=redisInstances,proto3" json:"redis_instances,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 按名称配置多个 Redis，如 default、session
+	Mongo          *Data_Mongo               `protobuf:"bytes,6,opt,name=mongo,proto3" json:"mongo,omitempty"`
+	Clickhouse     *Data_ClickHouse          `protobuf:"bytes,7,opt,name=clickhouse,proto3" json:"clickhouse,omitempty"`
+	Outbox         *Data_Outbox              `protobuf:"bytes,8,opt,name=outbox,proto3" json:"outbox,omitempty"`
+	Tenancy        *Data_Tenancy             `protobuf:"bytes,9,opt,name=tenancy,proto3" json:"tenancy,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Data) Reset() {
+	*x = Data{}
+	mi := &file_conf_conf_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data) ProtoMessage() {}
+
+func (x *Data) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data.ProtoReflect.Descriptor instead.
+func (*Data) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Data) GetDatabase() *Data_Database {
+	if x != nil {
+		return x.Database
+	}
+	return nil
+}
+
+func (x *Data) GetRedis() *Data_Redis {
+	if x != nil {
+		return x.Redis
+	}
+	return nil
+}
+
+func (x *Data) GetEmbedded() *Data_Embedded {
+	if x != nil {
+		return x.Embedded
+	}
+	return nil
+}
+
+func (x *Data) GetDatabases() map[string]*Data_Database {
+	if x != nil {
+		return x.Databases
+	}
+	return nil
+}
+
+func (x *Data) GetRedisInstances() map[string]*Data_Redis {
+	if x != nil {
+		return x.RedisInstances
+	}
+	return nil
+}
+
+func (x *Data) GetMongo() *Data_Mongo {
+	if x != nil {
+		return x.Mongo
+	}
+	return nil
+}
+
+func (x *Data) GetClickhouse() *Data_ClickHouse {
+	if x != nil {
+		return x.Clickhouse
+	}
+	return nil
+}
+
+func (x *Data) GetOutbox() *Data_Outbox {
+	if x != nil {
+		return x.Outbox
+	}
+	return nil
+}
+
+func (x *Data) GetTenancy() *Data_Tenancy {
+	if x != nil {
+		return x.Tenancy
+	}
+	return nil
+}
+
+type Log struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Level          string                 `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	Filename       string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	MaxSize        int32                  `protobuf:"varint,3,opt,name=max_size,json=maxSize,proto3" json:"max_size,omitempty"` // 单个日志文件大小上限，如 100MB
+	MaxAge         int32                  `protobuf:"varint,4,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+	MaxBackups     int32                  `protobuf:"varint,5,opt,name=max_backups,json=maxBackups,proto3" json:"max_backups,omitempty"`
+	Compress       bool                   `protobuf:"varint,6,opt,name=compress,proto3" json:"compress,omitempty"`
+	Console        bool                   `protobuf:"varint,7,opt,name=console,proto3" json:"console,omitempty"`
+	Format         string                 `protobuf:"bytes,8,opt,name=format,proto3" json:"format,omitempty"`                                           // json or text
+	DedupWindow    *durationpb.Duration   `protobuf:"bytes,9,opt,name=dedup_window,json=dedupWindow,proto3" json:"dedup_window,omitempty"`              // 相同日志的去重窗口，不配置则不去重
+	DedupLevel     string                 `protobuf:"bytes,10,opt,name=dedup_level,json=dedupLevel,proto3" json:"dedup_level,omitempty"`                // 参与去重的最低日志级别，默认 error
+	Archive        *Log_Archive           `protobuf:"bytes,11,opt,name=archive,proto3" json:"archive,omitempty"`                                        // 轮转后的日志归档到对象存储，开启后备份文件上传成功才按 max_backups、max_age 清理
+	Otlp           *Log_OTLP              `protobuf:"bytes,12,opt,name=otlp,proto3" json:"otlp,omitempty"`                                              // 日志导出到 OpenTelemetry Collector
+	Access         *Log_Access            `protobuf:"bytes,13,opt,name=access,proto3" json:"access,omitempty"`                                          // HTTP/gRPC 访问日志
+	MultiProcess   string                 `protobuf:"bytes,14,opt,name=multi_process,json=multiProcess,proto3" json:"multi_process,omitempty"`          // 多进程写同一日志路径: pid 文件名追加进程号，lock 写入时加文件锁，默认不处理
+	Slow           *Log_Slow              `protobuf:"bytes,15,opt,name=slow,proto3" json:"slow,omitempty"`                                              // 慢请求和慢 SQL 日志
+	Audit          *Log_Audit             `protobuf:"bytes,16,opt,name=audit,proto3" json:"audit,omitempty"`                                            // 审计日志
+	Stacktrace     bool                   `protobuf:"varint,17,opt,name=stacktrace,proto3" json:"stacktrace,omitempty"`                                 // error、fatal 级别日志附加调用堆栈
+	TimeFormat     string                 `protobuf:"bytes,18,opt,name=time_format,json=timeFormat,proto3" json:"time_format,omitempty"`                // 时间格式，Go 时间布局或 RFC3339、RFC3339Nano、ISO8601，默认 2006-01-02 15:04:05.000000
+	TimeZone       string                 `protobuf:"bytes,19,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`                      // 时区，如 UTC、Asia/Shanghai，默认本地时区
+	ErrorRateLimit int32                  `protobuf:"varint,20,opt,name=error_rate_limit,json=errorRateLimit,proto3" json:"error_rate_limit,omitempty"` // 同一调用位置每秒最多输出的错误日志条数，0 不限流
+	ErrorBurst     int32                  `protobuf:"varint,21,opt,name=error_burst,json=errorBurst,proto3" json:"error_burst,omitempty"`               // 错误日志限流的突发容量，默认等于 error_rate_limit
+	Fluent         *Log_Fluent            `protobuf:"bytes,22,opt,name=fluent,proto3" json:"fluent,omitempty"`                                          // 日志通过 forward 协议发送到 Fluentd
+	FileMode       string                 `protobuf:"bytes,23,opt,name=file_mode,json=fileMode,proto3" json:"file_mode,omitempty"`                      // 日志文件权限，八进制，如 0640，默认 0644
+	DirMode        string                 `protobuf:"bytes,24,opt,name=dir_mode,json=dirMode,proto3" json:"dir_mode,omitempty"`                         // 日志目录权限，八进制，如 0750，默认 0755
+	Uid            int32                  `protobuf:"varint,25,opt,name=uid,proto3" json:"uid,omitempty"`                                               // 日志文件所有者，仅类 Unix 系统生效，0 表示不修改
+	Gid            int32                  `protobuf:"varint,26,opt,name=gid,proto3" json:"gid,omitempty"`                                               // 日志文件所属组，仅类 Unix 系统生效，0 表示不修改
+	Alert          *Log_Alert             `protobuf:"bytes,27,opt,name=alert,proto3" json:"alert,omitempty"`                                            // 错误日志告警
+	Sentry         *Log_Sentry            `protobuf:"bytes,28,opt,name=sentry,proto3" json:"sentry,omitempty"`                                          // error 及以上级别的日志和 panic 上报到 Sentry
+	FailoverRetry  *durationpb.Duration   `protobuf:"bytes,29,opt,name=failover_retry,json=failoverRetry,proto3" json:"failover_retry,omitempty"`       // 日志文件写入失败时降级写入标准输出，按该间隔重试文件，默认 30s
+	Environment    string                 `protobuf:"bytes,30,opt,name=environment,proto3" json:"environment,omitempty"`                                // 部署环境，如 production、staging，作为 deployment.environment 字段写入每条日志
+	Buffer         *Log_Buffer            `protobuf:"bytes,31,opt,name=buffer,proto3" json:"buffer,omitempty"`                                          // 请求级日志缓冲，debug/info 日志只在请求失败或超时时输出
+	Journald       *Log_Journald          `protobuf:"bytes,32,opt,name=journald,proto3" json:"journald,omitempty"`                                      // 写入 systemd-journald，适用于以 systemd 单元运行的服务，仅支持 Linux
+	Spool          *Log_Spool             `protobuf:"bytes,33,opt,name=spool,proto3" json:"spool,omitempty"`                                            // Fluentd、OTLP 等远端输出先写入本地磁盘缓冲，采集端故障恢复后重放，保证至少一次投递
+	DailyDir       bool                   `protobuf:"varint,34,opt,name=daily_dir,json=dailyDir,proto3" json:"daily_dir,omitempty"`                     // 备份文件按日期写入子目录，如 log/2024-05-01/app-1.log，便于按目录清理和归档
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Log) Reset() {
+	*x = Log{}
+	mi := &file_conf_conf_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Log) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Log) ProtoMessage() {}
+
+func (x *Log) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Log.ProtoReflect.Descriptor instead.
+func (*Log) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Log) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *Log) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *Log) GetMaxSize() int32 {
+	if x != nil {
+		return x.MaxSize
+	}
+	return 0
+}
+
+func (x *Log) GetMaxAge() int32 {
+	if x != nil {
+		return x.MaxAge
+	}
+	return 0
+}
+
+func (x *Log) GetMaxBackups() int32 {
+	if x != nil {
+		return x.MaxBackups
+	}
+	return 0
+}
+
+func (x *Log) GetCompress() bool {
+	if x != nil {
+		return x.Compress
+	}
+	return false
+}
+
+func (x *Log) GetConsole() bool {
+	if x != nil {
+		return x.Console
+	}
+	return false
+}
+
+func (x *Log) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *Log) GetDedupWindow() *durationpb.Duration {
+	if x != nil {
+		return x.DedupWindow
+	}
+	return nil
+}
+
+func (x *Log) GetDedupLevel() string {
+	if x != nil {
+		return x.DedupLevel
+	}
+	return ""
+}
+
+func (x *Log) GetArchive() *Log_Archive {
+	if x != nil {
+		return x.Archive
+	}
+	return nil
+}
+
+func (x *Log) GetOtlp() *Log_OTLP {
+	if x != nil {
+		return x.Otlp
+	}
+	return nil
+}
+
+func (x *Log) GetAccess() *Log_Access {
+	if x != nil {
+		return x.Access
+	}
+	return nil
+}
+
+func (x *Log) GetMultiProcess() string {
+	if x != nil {
+		return x.MultiProcess
+	}
+	return ""
+}
+
+func (x *Log) GetSlow() *Log_Slow {
+	if x != nil {
+		return x.Slow
+	}
+	return nil
+}
+
+func (x *Log) GetAudit() *Log_Audit {
+	if x != nil {
+		return x.Audit
+	}
+	return nil
+}
+
+func (x *Log) GetStacktrace() bool {
+	if x != nil {
+		return x.Stacktrace
+	}
+	return false
+}
+
+func (x *Log) GetTimeFormat() string {
+	if x != nil {
+		return x.TimeFormat
+	}
+	return ""
+}
+
+func (x *Log) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *Log) GetErrorRateLimit() int32 {
+	if x != nil {
+		return x.ErrorRateLimit
+	}
+	return 0
+}
+
+func (x *Log) GetErrorBurst() int32 {
+	if x != nil {
+		return x.ErrorBurst
+	}
+	return 0
+}
+
+func (x *Log) GetFluent() *Log_Fluent {
+	if x != nil {
+		return x.Fluent
+	}
+	return nil
+}
+
+func (x *Log) GetFileMode() string {
+	if x != nil {
+		return x.FileMode
+	}
+	return ""
+}
+
+func (x *Log) GetDirMode() string {
+	if x != nil {
+		return x.DirMode
+	}
+	return ""
+}
+
+func (x *Log) GetUid() int32 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *Log) GetGid() int32 {
+	if x != nil {
+		return x.Gid
+	}
+	return 0
+}
+
+func (x *Log) GetAlert() *Log_Alert {
+	if x != nil {
+		return x.Alert
+	}
+	return nil
+}
+
+func (x *Log) GetSentry() *Log_Sentry {
+	if x != nil {
+		return x.Sentry
+	}
+	return nil
+}
+
+func (x *Log) GetFailoverRetry() *durationpb.Duration {
+	if x != nil {
+		return x.FailoverRetry
+	}
+	return nil
+}
+
+func (x *Log) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+func (x *Log) GetBuffer() *Log_Buffer {
+	if x != nil {
+		return x.Buffer
+	}
+	return nil
+}
+
+func (x *Log) GetJournald() *Log_Journald {
+	if x != nil {
+		return x.Journald
+	}
+	return nil
+}
+
+func (x *Log) GetSpool() *Log_Spool {
+	if x != nil {
+		return x.Spool
+	}
+	return nil
+}
+
+func (x *Log) GetDailyDir() bool {
+	if x != nil {
+		return x.DailyDir
+	}
+	return false
+}
+
+type Remote_Nacos struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enable        bool                   `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`
+	Addrs         []string               `protobuf:"bytes,2,rep,name=addrs,proto3" json:"addrs,omitempty"`                 // 服务地址，如 http://127.0.0.1:8848，多个地址失败时依次切换
+	Namespace     string                 `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`         // 命名空间 ID，默认 public
+	Group         string                 `protobuf:"bytes,4,opt,name=group,proto3" json:"group,omitempty"`                 // 默认 DEFAULT_GROUP
+	DataId        string                 `protobuf:"bytes,5,opt,name=data_id,json=dataId,proto3" json:"data_id,omitempty"` // 配置 ID，扩展名决定配置格式，如 {{cookiecutter.repo_name}}.yaml
+	Username      string                 `protobuf:"bytes,6,opt,name=username,proto3" json:"username,omitempty"`           // 开启鉴权时的用户名
+	Password      string                 `protobuf:"bytes,7,opt,name=password,proto3" json:"password,omitempty"`
+	Timeout       *durationpb.Duration   `protobuf:"bytes,8,opt,name=timeout,proto3" json:"timeout,omitempty"` // 请求超时时间，默认 3s
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Remote_Nacos) Reset() {
+	*x = Remote_Nacos{}
+	mi := &file_conf_conf_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Remote_Nacos) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Remote_Nacos) ProtoMessage() {}
+
+func (x *Remote_Nacos) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Remote_Nacos.ProtoReflect.Descriptor instead.
+func (*Remote_Nacos) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (x *Remote_Nacos) GetEnable() bool {
+	if x != nil {
+		return x.Enable
+	}
+	return false
+}
+
+func (x *Remote_Nacos) GetAddrs() []string {
+	if x != nil {
+		return x.Addrs
+	}
+	return nil
+}
+
+func (x *Remote_Nacos) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *Remote_Nacos) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *Remote_Nacos) GetDataId() string {
+	if x != nil {
+		return x.DataId
+	}
+	return ""
+}
+
+func (x *Remote_Nacos) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Remote_Nacos) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *Remote_Nacos) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+type Remote_Apollo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enable        bool                   `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`
+	Addr          string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"` // Config Service 地址，如 http://127.0.0.1:8080
+	AppId         string                 `protobuf:"bytes,3,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Cluster       string                 `protobuf:"bytes,4,opt,name=cluster,proto3" json:"cluster,omitempty"`       // 默认 default
+	Namespaces    []string               `protobuf:"bytes,5,rep,name=namespaces,proto3" json:"namespaces,omitempty"` // 默认 application，后面的覆盖前面的，非 properties 格式需带扩展名，如 app.yaml
+	Secret        string                 `protobuf:"bytes,6,opt,name=secret,proto3" json:"secret,omitempty"`         // 开启访问密钥时的 secret
+	Timeout       *durationpb.Duration   `protobuf:"bytes,7,opt,name=timeout,proto3" json:"timeout,omitempty"`       // 请求超时时间，默认 3s
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Remote_Apollo) Reset() {
+	*x = Remote_Apollo{}
+	mi := &file_conf_conf_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Remote_Apollo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Remote_Apollo) ProtoMessage() {}
+
+func (x *Remote_Apollo) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Remote_Apollo.ProtoReflect.Descriptor instead.
+func (*Remote_Apollo) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{1, 1}
+}
+
+func (x *Remote_Apollo) GetEnable() bool {
+	if x != nil {
+		return x.Enable
+	}
+	return false
+}
+
+func (x *Remote_Apollo) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *Remote_Apollo) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *Remote_Apollo) GetCluster() string {
+	if x != nil {
+		return x.Cluster
+	}
+	return ""
+}
+
+func (x *Remote_Apollo) GetNamespaces() []string {
+	if x != nil {
+		return x.Namespaces
+	}
+	return nil
+}
+
+func (x *Remote_Apollo) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *Remote_Apollo) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+type Remote_Etcd struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enable        bool                   `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`
+	Endpoints     []string               `protobuf:"bytes,2,rep,name=endpoints,proto3" json:"endpoints,omitempty"` // 如 127.0.0.1:2379
+	Prefix        string                 `protobuf:"bytes,3,opt,name=prefix,proto3" json:"prefix,omitempty"`       // 读取该前缀下的全部 key，每个 key 为一个配置文件，扩展名决定格式，如 /config/{{cookiecutter.repo_name}}/config.yaml
+	Username      string                 `protobuf:"bytes,4,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string                 `protobuf:"bytes,5,opt,name=password,proto3" json:"password,omitempty"`
+	Timeout       *durationpb.Duration   `protobuf:"bytes,6,opt,name=timeout,proto3" json:"timeout,omitempty"`                   // 连接和请求超时时间，默认 3s
+	CaFile        string                 `protobuf:"bytes,7,opt,name=ca_file,json=caFile,proto3" json:"ca_file,omitempty"`       // 开启 TLS 时的 CA 证书
+	CertFile      string                 `protobuf:"bytes,8,opt,name=cert_file,json=certFile,proto3" json:"cert_file,omitempty"` // 双向 TLS 的客户端证书
+	KeyFile       string                 `protobuf:"bytes,9,opt,name=key_file,json=keyFile,proto3" json:"key_file,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Remote_Etcd) Reset() {
+	*x = Remote_Etcd{}
+	mi := &file_conf_conf_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Remote_Etcd) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Remote_Etcd) ProtoMessage() {}
+
+func (x *Remote_Etcd) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Remote_Etcd.ProtoReflect.Descriptor instead.
+func (*Remote_Etcd) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{1, 2}
+}
+
+func (x *Remote_Etcd) GetEnable() bool {
+	if x != nil {
+		return x.Enable
+	}
+	return false
+}
+
+func (x *Remote_Etcd) GetEndpoints() []string {
+	if x != nil {
+		return x.Endpoints
+	}
+	return nil
+}
+
+func (x *Remote_Etcd) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *Remote_Etcd) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Remote_Etcd) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *Remote_Etcd) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *Remote_Etcd) GetCaFile() string {
+	if x != nil {
+		return x.CaFile
+	}
+	return ""
+}
+
+func (x *Remote_Etcd) GetCertFile() string {
+	if x != nil {
+		return x.CertFile
+	}
+	return ""
+}
+
+func (x *Remote_Etcd) GetKeyFile() string {
+	if x != nil {
+		return x.KeyFile
+	}
+	return ""
+}
+
+type Remote_Vault struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enable        bool                   `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`
+	Addr          string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`                   // 如 https://127.0.0.1:8200
+	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`                 // 访问令牌，与 AppRole 二选一
+	RoleId        string                 `protobuf:"bytes,4,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"` // AppRole 登录
+	SecretId      string                 `protobuf:"bytes,5,opt,name=secret_id,json=secretId,proto3" json:"secret_id,omitempty"`
+	Namespace     string                 `protobuf:"bytes,6,opt,name=namespace,proto3" json:"namespace,omitempty"`                     // Vault 企业版命名空间
+	Timeout       *durationpb.Duration   `protobuf:"bytes,7,opt,name=timeout,proto3" json:"timeout,omitempty"`                         // 请求超时时间，默认 3s
+	TransitKey    string                 `protobuf:"bytes,8,opt,name=transit_key,json=transitKey,proto3" json:"transit_key,omitempty"` // Transit 密钥名，CONFIG_KEY 为 vault:v1:... 时用于解密数据密钥
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Remote_Vault) Reset() {
+	*x = Remote_Vault{}
+	mi := &file_conf_conf_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Remote_Vault) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Remote_Vault) ProtoMessage() {}
+
+func (x *Remote_Vault) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Remote_Vault.ProtoReflect.Descriptor instead.
+func (*Remote_Vault) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{1, 3}
+}
+
+func (x *Remote_Vault) GetEnable() bool {
+	if x != nil {
+		return x.Enable
+	}
+	return false
+}
+
+func (x *Remote_Vault) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *Remote_Vault) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *Remote_Vault) GetRoleId() string {
+	if x != nil {
+		return x.RoleId
+	}
+	return ""
+}
+
+func (x *Remote_Vault) GetSecretId() string {
+	if x != nil {
+		return x.SecretId
+	}
+	return ""
+}
+
+func (x *Remote_Vault) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *Remote_Vault) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *Remote_Vault) GetTransitKey() string {
+	if x != nil {
+		return x.TransitKey
+	}
+	return ""
+}
+
+type Remote_HTTP struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enable        bool                   `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`                                                                                   // 配置文件地址，如 https://config.example.com/{{cookiecutter.repo_name}}/prod.yaml
+	Format        string                 `protobuf:"bytes,3,opt,name=format,proto3" json:"format,omitempty"`                                                                             // yaml 或 json，默认按 URL 扩展名和 Content-Type 判断
+	Token         string                 `protobuf:"bytes,4,opt,name=token,proto3" json:"token,omitempty"`                                                                               // 以 Authorization: Bearer 发送
+	Headers       map[string]string      `protobuf:"bytes,5,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 额外的请求头
+	PublicKey     string                 `protobuf:"bytes,6,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`                                                      // base64 编码的 ed25519 公钥，配置后要求响应头 X-Config-Signature 带有响应体的签名
+	Interval      *durationpb.Duration   `protobuf:"bytes,7,opt,name=interval,proto3" json:"interval,omitempty"`                                                                         // 轮询间隔，默认 30s
+	Timeout       *durationpb.Duration   `protobuf:"bytes,8,opt,name=timeout,proto3" json:"timeout,omitempty"`                                                                           // 请求超时时间，默认 3s
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Remote_HTTP) Reset() {
+	*x = Remote_HTTP{}
+	mi := &file_conf_conf_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Remote_HTTP) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Remote_HTTP) ProtoMessage() {}
+
+func (x *Remote_HTTP) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Remote_HTTP.ProtoReflect.Descriptor instead.
+func (*Remote_HTTP) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{1, 4}
+}
+
+func (x *Remote_HTTP) GetEnable() bool {
+	if x != nil {
+		return x.Enable
+	}
+	return false
+}
+
+func (x *Remote_HTTP) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Remote_HTTP) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *Remote_HTTP) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *Remote_HTTP) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *Remote_HTTP) GetPublicKey() string {
+	if x != nil {
+		return x.PublicKey
+	}
+	return ""
+}
+
+func (x *Remote_HTTP) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *Remote_HTTP) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+type Remote_Kubernetes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enable        bool                   `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`
+	Dirs          []string               `protobuf:"bytes,2,rep,name=dirs,proto3" json:"dirs,omitempty"` // ConfigMap / Secret 挂载目录，如 /etc/{{cookiecutter.repo_name}}/config，后面的覆盖前面的
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Remote_Kubernetes) Reset() {
+	*x = Remote_Kubernetes{}
+	mi := &file_conf_conf_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Remote_Kubernetes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Remote_Kubernetes) ProtoMessage() {}
+
+func (x *Remote_Kubernetes) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Remote_Kubernetes.ProtoReflect.Descriptor instead.
+func (*Remote_Kubernetes) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{1, 5}
+}
+
+func (x *Remote_Kubernetes) GetEnable() bool {
+	if x != nil {
+		return x.Enable
+	}
+	return false
+}
+
+func (x *Remote_Kubernetes) GetDirs() []string {
+	if x != nil {
+		return x.Dirs
+	}
+	return nil
+}
+
+type Server_HTTP struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Network       string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	Addr          string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	Timeout       *durationpb.Duration   `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_HTTP) Reset() {
+	*x = Server_HTTP{}
+	mi := &file_conf_conf_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_HTTP) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_HTTP) ProtoMessage() {}
+
+func (x *Server_HTTP) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_HTTP.ProtoReflect.Descriptor instead.
+func (*Server_HTTP) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{2, 0}
+}
+
+func (x *Server_HTTP) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *Server_HTTP) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *Server_HTTP) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+type Server_GRPC struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Network       string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	Addr          string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	Timeout       *durationpb.Duration   `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_GRPC) Reset() {
+	*x = Server_GRPC{}
+	mi := &file_conf_conf_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_GRPC) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_GRPC) ProtoMessage() {}
+
+func (x *Server_GRPC) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_GRPC.ProtoReflect.Descriptor instead.
+func (*Server_GRPC) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{2, 1}
+}
+
+func (x *Server_GRPC) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *Server_GRPC) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *Server_GRPC) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+type Server_Debug struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enable        bool                   `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"` // 管理接口令牌，通过 X-Admin-Token 请求头传递，为空时只允许本机访问
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_Debug) Reset() {
+	*x = Server_Debug{}
+	mi := &file_conf_conf_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_Debug) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_Debug) ProtoMessage() {}
+
+func (x *Server_Debug) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_Debug.ProtoReflect.Descriptor instead.
+func (*Server_Debug) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{2, 2}
+}
+
+func (x *Server_Debug) GetEnable() bool {
+	if x != nil {
+		return x.Enable
+	}
+	return false
+}
+
+func (x *Server_Debug) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type Server_APIVersion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Header        string                 `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`      // 客户端版本请求头，默认 X-API-Version
+	Min           string                 `protobuf:"bytes,2,opt,name=min,proto3" json:"min,omitempty"`            // 支持的最低版本
+	Max           string                 `protobuf:"bytes,3,opt,name=max,proto3" json:"max,omitempty"`            // 支持的最高版本
+	Required      bool                   `protobuf:"varint,4,opt,name=required,proto3" json:"required,omitempty"` // 客户端必须携带版本请求头
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_APIVersion) Reset() {
+	*x = Server_APIVersion{}
+	mi := &file_conf_conf_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_APIVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_APIVersion) ProtoMessage() {}
+
+func (x *Server_APIVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_APIVersion.ProtoReflect.Descriptor instead.
+func (*Server_APIVersion) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{2, 3}
+}
+
+func (x *Server_APIVersion) GetHeader() string {
+	if x != nil {
+		return x.Header
+	}
+	return ""
+}
+
+func (x *Server_APIVersion) GetMin() string {
+	if x != nil {
+		return x.Min
+	}
+	return ""
+}
+
+func (x *Server_APIVersion) GetMax() string {
+	if x != nil {
+		return x.Max
+	}
+	return ""
+}
+
+func (x *Server_APIVersion) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+type Server_Docs struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enable        bool                   `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`                 // 开启接口文档，包含 Swagger UI、注入示例后的 OpenAPI 文档和 curl 命令
+	Openapi       string                 `protobuf:"bytes,2,opt,name=openapi,proto3" json:"openapi,omitempty"`                // make api 生成的 openapi.yaml 路径，默认 ./openapi.yaml
+	BaseUrl       string                 `protobuf:"bytes,3,opt,name=base_url,json=baseUrl,proto3" json:"base_url,omitempty"` // curl 命令中的服务地址，如 http://127.0.0.1:8000
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_Docs) Reset() {
+	*x = Server_Docs{}
+	mi := &file_conf_conf_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_Docs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_Docs) ProtoMessage() {}
+
+func (x *Server_Docs) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_Docs.ProtoReflect.Descriptor instead.
+func (*Server_Docs) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{2, 4}
+}
+
+func (x *Server_Docs) GetEnable() bool {
+	if x != nil {
+		return x.Enable
+	}
+	return false
+}
+
+func (x *Server_Docs) GetOpenapi() string {
+	if x != nil {
+		return x.Openapi
+	}
+	return ""
+}
+
+func (x *Server_Docs) GetBaseUrl() string {
+	if x != nil {
+		return x.BaseUrl
+	}
+	return ""
+}
+
+type Server_Operation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Workers       int32                  `protobuf:"varint,1,opt,name=workers,proto3" json:"workers,omitempty"`                      // 并发执行的操作数，默认 4
+	QueueSize     int32                  `protobuf:"varint,2,opt,name=queue_size,json=queueSize,proto3" json:"queue_size,omitempty"` // 排队上限，超出时提交返回 503，默认 1024
+	Ttl           *durationpb.Duration   `protobuf:"bytes,3,opt,name=ttl,proto3" json:"ttl,omitempty"`                               // 已完成操作的保留时间，默认 24h
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_Operation) Reset() {
+	*x = Server_Operation{}
+	mi := &file_conf_conf_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_Operation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_Operation) ProtoMessage() {}
+
+func (x *Server_Operation) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_Operation.ProtoReflect.Descriptor instead.
+func (*Server_Operation) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{2, 5}
+}
+
+func (x *Server_Operation) GetWorkers() int32 {
+	if x != nil {
+		return x.Workers
+	}
+	return 0
+}
+
+func (x *Server_Operation) GetQueueSize() int32 {
+	if x != nil {
+		return x.QueueSize
+	}
+	return 0
+}
+
+func (x *Server_Operation) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+type Server_Duplicate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enable        bool                   `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`
+	Window        *durationpb.Duration   `protobuf:"bytes,2,opt,name=window,proto3" json:"window,omitempty"` // 相同 Idempotency-Key 或 X-Request-ID 视为重试的时间窗口，默认 1m
+	Dedup         bool                   `protobuf:"varint,3,opt,name=dedup,proto3" json:"dedup,omitempty"`  // 重复请求直接返回首次请求的结果，不再执行业务逻辑
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_Duplicate) Reset() {
+	*x = Server_Duplicate{}
+	mi := &file_conf_conf_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_Duplicate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_Duplicate) ProtoMessage() {}
+
+func (x *Server_Duplicate) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_Duplicate.ProtoReflect.Descriptor instead.
+func (*Server_Duplicate) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{2, 6}
+}
+
+func (x *Server_Duplicate) GetEnable() bool {
+	if x != nil {
+		return x.Enable
+	}
+	return false
+}
+
+func (x *Server_Duplicate) GetWindow() *durationpb.Duration {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+func (x *Server_Duplicate) GetDedup() bool {
+	if x != nil {
+		return x.Dedup
+	}
+	return false
+}
+
+type Server_Admin struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Addr          string                 `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"` // 管理端口，提供 /healthz、/readyz 探针，为空时不启动
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_Admin) Reset() {
+	*x = Server_Admin{}
+	mi := &file_conf_conf_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_Admin) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_Admin) ProtoMessage() {}
+
+func (x *Server_Admin) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_Admin.ProtoReflect.Descriptor instead.
+func (*Server_Admin) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{2, 7}
+}
+
+func (x *Server_Admin) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+type Server_Metrics struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Addr          string                 `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"` // 指标端口，为空时不启动
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"` // 指标路径，默认 /metrics
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_Metrics) Reset() {
+	*x = Server_Metrics{}
+	mi := &file_conf_conf_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_Metrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_Metrics) ProtoMessage() {}
+
+func (x *Server_Metrics) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_Metrics.ProtoReflect.Descriptor instead.
+func (*Server_Metrics) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{2, 8}
+}
+
+func (x *Server_Metrics) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *Server_Metrics) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type Server_Diagnostics struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enable        bool                   `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`
+	SampleRate    float64                `protobuf:"fixed64,2,opt,name=sample_rate,json=sampleRate,proto3" json:"sample_rate,omitempty"` // 采样比例，默认 0.01
+	Top           int32                  `protobuf:"varint,3,opt,name=top,proto3" json:"top,omitempty"`                                  // 报告分配最多的接口数，默认 10
+	Interval      *durationpb.Duration   `protobuf:"bytes,4,opt,name=interval,proto3" json:"interval,omitempty"`                         // 报告间隔，默认 5m
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_Diagnostics) Reset() {
+	*x = Server_Diagnostics{}
+	mi := &file_conf_conf_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_Diagnostics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_Diagnostics) ProtoMessage() {}
+
+func (x *Server_Diagnostics) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_Diagnostics.ProtoReflect.Descriptor instead.
+func (*Server_Diagnostics) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{2, 9}
+}
+
+func (x *Server_Diagnostics) GetEnable() bool {
+	if x != nil {
+		return x.Enable
+	}
+	return false
+}
+
+func (x *Server_Diagnostics) GetSampleRate() float64 {
+	if x != nil {
+		return x.SampleRate
+	}
+	return 0
+}
+
+func (x *Server_Diagnostics) GetTop() int32 {
+	if x != nil {
+		return x.Top
+	}
+	return 0
+}
+
+func (x *Server_Diagnostics) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+type Server_Modules struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Paused        []string               `protobuf:"bytes,1,rep,name=paused,proto3" json:"paused,omitempty"` // 暂停的模块，如 cron、consumer，配置变更时热更新
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_Modules) Reset() {
+	*x = Server_Modules{}
+	mi := &file_conf_conf_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_Modules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_Modules) ProtoMessage() {}
+
+func (x *Server_Modules) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_Modules.ProtoReflect.Descriptor instead.
+func (*Server_Modules) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{2, 10}
+}
+
+func (x *Server_Modules) GetPaused() []string {
+	if x != nil {
+		return x.Paused
+	}
+	return nil
+}
+
+type Server_Features struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Flags          *structpb.Struct       `protobuf:"bytes,1,opt,name=flags,proto3" json:"flags,omitempty"`                                         // 功能开关，如 new_checkout: true、search_limit: 50，配置变更时热更新
+	RemoteUrl      string                 `protobuf:"bytes,2,opt,name=remote_url,json=remoteUrl,proto3" json:"remote_url,omitempty"`                // 远程开关服务地址，GET 返回 {"name": value} 形式的 JSON，优先于本地配置
+	RemoteInterval *durationpb.Duration   `protobuf:"bytes,3,opt,name=remote_interval,json=remoteInterval,proto3" json:"remote_interval,omitempty"` // 远程拉取间隔，默认 30s
+	AllowOverride  bool                   `protobuf:"varint,4,opt,name=allow_override,json=allowOverride,proto3" json:"allow_override,omitempty"`   // 允许通过 X-Feature-Override 请求头覆盖开关，如 new_checkout=true，只用于测试环境
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Server_Features) Reset() {
+	*x = Server_Features{}
+	mi := &file_conf_conf_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_Features) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_Features) ProtoMessage() {}
+
+func (x *Server_Features) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_Features.ProtoReflect.Descriptor instead.
+func (*Server_Features) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{2, 11}
+}
+
+func (x *Server_Features) GetFlags() *structpb.Struct {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+func (x *Server_Features) GetRemoteUrl() string {
+	if x != nil {
+		return x.RemoteUrl
+	}
+	return ""
+}
+
+func (x *Server_Features) GetRemoteInterval() *durationpb.Duration {
+	if x != nil {
+		return x.RemoteInterval
+	}
+	return nil
+}
+
+func (x *Server_Features) GetAllowOverride() bool {
+	if x != nil {
+		return x.AllowOverride
+	}
+	return false
+}
+
+type Server_License struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enable        bool                   `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`
+	File          string                 `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`                                                                                       // 离线 license 文件，签名公钥在构建时通过 -ldflags 注入
+	ServerUrl     string                 `protobuf:"bytes,3,opt,name=server_url,json=serverUrl,proto3" json:"server_url,omitempty"`                                                            // 在线 license 服务，与 file 二选一，GET 返回授权信息 JSON
+	Token         string                 `protobuf:"bytes,4,opt,name=token,proto3" json:"token,omitempty"`                                                                                     // 在线 license 服务令牌
+	Interval      *durationpb.Duration   `protobuf:"bytes,5,opt,name=interval,proto3" json:"interval,omitempty"`                                                                               // 复查间隔，默认 1h
+	Grace         *durationpb.Duration   `protobuf:"bytes,6,opt,name=grace,proto3" json:"grace,omitempty"`                                                                                     // 复查失败时沿用上次结果的宽限期，默认 72h
+	Required      bool                   `protobuf:"varint,7,opt,name=required,proto3" json:"required,omitempty"`                                                                              // 启动时检查失败则退出，否则以未授权状态启动
+	Operations    map[string]string      `protobuf:"bytes,8,rep,name=operations,proto3" json:"operations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 按接口前缀限制授权模块，如 "/helloworld.v1.Greeter/": greeter
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_License) Reset() {
+	*x = Server_License{}
+	mi := &file_conf_conf_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_License) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_License) ProtoMessage() {}
+
+func (x *Server_License) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_License.ProtoReflect.Descriptor instead.
+func (*Server_License) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{2, 12}
+}
+
+func (x *Server_License) GetEnable() bool {
+	if x != nil {
+		return x.Enable
+	}
+	return false
+}
+
+func (x *Server_License) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *Server_License) GetServerUrl() string {
+	if x != nil {
+		return x.ServerUrl
+	}
+	return ""
+}
+
+func (x *Server_License) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *Server_License) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *Server_License) GetGrace() *durationpb.Duration {
+	if x != nil {
+		return x.Grace
+	}
+	return nil
+}
+
+func (x *Server_License) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *Server_License) GetOperations() map[string]string {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+type Server_ConfigHistory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Size          int32                  `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"` // 保留的快照个数，默认 10
+	Dir           string                 `protobuf:"bytes,2,opt,name=dir,proto3" json:"dir,omitempty"`    // 快照目录，为空时只保存在内存，快照中的密钥已脱敏
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_ConfigHistory) Reset() {
+	*x = Server_ConfigHistory{}
+	mi := &file_conf_conf_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_ConfigHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_ConfigHistory) ProtoMessage() {}
+
+func (x *Server_ConfigHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_ConfigHistory.ProtoReflect.Descriptor instead.
+func (*Server_ConfigHistory) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{2, 13}
+}
+
+func (x *Server_ConfigHistory) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Server_ConfigHistory) GetDir() string {
+	if x != nil {
+		return x.Dir
+	}
+	return ""
+}
+
+type Data_Database struct {
+	state              protoimpl.MessageState     `protogen:"open.v1"`
+	Driver             string                     `protobuf:"bytes,1,opt,name=driver,proto3" json:"driver,omitempty"`
+	Source             string                     `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	AutoMigrate        bool                       `protobuf:"varint,3,opt,name=auto_migrate,json=autoMigrate,proto3" json:"auto_migrate,omitempty"`                       // 启动时自动迁移，多副本通过数据库 advisory lock 保证只有一个副本执行
+	MigrateLockTimeout *durationpb.Duration       `protobuf:"bytes,4,opt,name=migrate_lock_timeout,json=migrateLockTimeout,proto3" json:"migrate_lock_timeout,omitempty"` // 等待迁移锁的超时时间，默认 5m
+	SchemaCheck        *Data_Database_SchemaCheck `protobuf:"bytes,5,opt,name=schema_check,json=schemaCheck,proto3" json:"schema_check,omitempty"`
+	MaxOpenConns       int32                      `protobuf:"varint,6,opt,name=max_open_conns,json=maxOpenConns,proto3" json:"max_open_conns,omitempty"`           // 最大打开连接数，默认不限制
+	MaxIdleConns       int32                      `protobuf:"varint,7,opt,name=max_idle_conns,json=maxIdleConns,proto3" json:"max_idle_conns,omitempty"`           // 最大空闲连接数，默认 2
+	ConnMaxLifetime    *durationpb.Duration       `protobuf:"bytes,8,opt,name=conn_max_lifetime,json=connMaxLifetime,proto3" json:"conn_max_lifetime,omitempty"`   // 连接最长复用时间，应小于数据库的 wait_timeout
+	ConnMaxIdleTime    *durationpb.Duration       `protobuf:"bytes,9,opt,name=conn_max_idle_time,json=connMaxIdleTime,proto3" json:"conn_max_idle_time,omitempty"` // 连接最长空闲时间
+	Replicas           []string                   `protobuf:"bytes,10,rep,name=replicas,proto3" json:"replicas,omitempty"`                                         // 只读副本的 source，仅默认数据库生效，读请求随机分配到副本，写请求和事务使用主库
+	SlowThreshold      *durationpb.Duration       `protobuf:"bytes,11,opt,name=slow_threshold,json=slowThreshold,proto3" json:"slow_threshold,omitempty"`          // 执行时间超过该值的 SQL 按 warn 级别输出，默认 200ms，为 0 时关闭
+	RunMigrations      bool                       `protobuf:"varint,12,opt,name=run_migrations,json=runMigrations,proto3" json:"run_migrations,omitempty"`         // 启动时持有迁移锁执行 internal/data/migrations 中未执行的版本化迁移，仅默认数据库生效
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Data_Database) Reset() {
+	*x = Data_Database{}
+	mi := &file_conf_conf_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Database) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Database) ProtoMessage() {}
+
+func (x *Data_Database) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Database.ProtoReflect.Descriptor instead.
+func (*Data_Database) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 0}
+}
+
+func (x *Data_Database) GetDriver() string {
+	if x != nil {
+		return x.Driver
+	}
+	return ""
+}
+
+func (x *Data_Database) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Data_Database) GetAutoMigrate() bool {
+	if x != nil {
+		return x.AutoMigrate
+	}
+	return false
+}
+
+func (x *Data_Database) GetMigrateLockTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.MigrateLockTimeout
+	}
+	return nil
+}
+
+func (x *Data_Database) GetSchemaCheck() *Data_Database_SchemaCheck {
+	if x != nil {
+		return x.SchemaCheck
+	}
+	return nil
+}
+
+func (x *Data_Database) GetMaxOpenConns() int32 {
+	if x != nil {
+		return x.MaxOpenConns
+	}
+	return 0
+}
+
+func (x *Data_Database) GetMaxIdleConns() int32 {
+	if x != nil {
+		return x.MaxIdleConns
+	}
+	return 0
+}
+
+func (x *Data_Database) GetConnMaxLifetime() *durationpb.Duration {
+	if x != nil {
+		return x.ConnMaxLifetime
+	}
+	return nil
+}
+
+func (x *Data_Database) GetConnMaxIdleTime() *durationpb.Duration {
+	if x != nil {
+		return x.ConnMaxIdleTime
+	}
+	return nil
+}
+
+func (x *Data_Database) GetReplicas() []string {
+	if x != nil {
+		return x.Replicas
+	}
+	return nil
+}
+
+func (x *Data_Database) GetSlowThreshold() *durationpb.Duration {
+	if x != nil {
+		return x.SlowThreshold
+	}
+	return nil
+}
+
+func (x *Data_Database) GetRunMigrations() bool {
+	if x != nil {
+		return x.RunMigrations
+	}
+	return false
+}
+
+type Data_Redis struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Network         string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	Addr            string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	ReadTimeout     *durationpb.Duration   `protobuf:"bytes,3,opt,name=read_timeout,json=readTimeout,proto3" json:"read_timeout,omitempty"`
+	WriteTimeout    *durationpb.Duration   `protobuf:"bytes,4,opt,name=write_timeout,json=writeTimeout,proto3" json:"write_timeout,omitempty"`
+	Addrs           []string               `protobuf:"bytes,5,rep,name=addrs,proto3" json:"addrs,omitempty"` // 集群节点地址，配置后使用集群客户端，忽略 addr
+	Password        string                 `protobuf:"bytes,6,opt,name=password,proto3" json:"password,omitempty"`
+	Db              int32                  `protobuf:"varint,7,opt,name=db,proto3" json:"db,omitempty"`                                                      // 集群模式不支持
+	Username        string                 `protobuf:"bytes,8,opt,name=username,proto3" json:"username,omitempty"`                                           // Redis 6 ACL 用户名
+	DialTimeout     *durationpb.Duration   `protobuf:"bytes,9,opt,name=dial_timeout,json=dialTimeout,proto3" json:"dial_timeout,omitempty"`                  // 建立连接的超时时间，默认 5s
+	PoolSize        int32                  `protobuf:"varint,10,opt,name=pool_size,json=poolSize,proto3" json:"pool_size,omitempty"`                         // 连接池大小，默认每个 CPU 10 个连接
+	MinIdleConns    int32                  `protobuf:"varint,11,opt,name=min_idle_conns,json=minIdleConns,proto3" json:"min_idle_conns,omitempty"`           // 最少空闲连接数
+	PoolTimeout     *durationpb.Duration   `protobuf:"bytes,12,opt,name=pool_timeout,json=poolTimeout,proto3" json:"pool_timeout,omitempty"`                 // 连接池耗尽时等待连接的时间，默认 read_timeout + 1s
+	ConnMaxIdleTime *durationpb.Duration   `protobuf:"bytes,13,opt,name=conn_max_idle_time,json=connMaxIdleTime,proto3" json:"conn_max_idle_time,omitempty"` // 连接最长空闲时间，默认 30m
+	Tls             *Data_Redis_TLS        `protobuf:"bytes,14,opt,name=tls,proto3" json:"tls,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Data_Redis) Reset() {
+	*x = Data_Redis{}
+	mi := &file_conf_conf_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Redis) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Redis) ProtoMessage() {}
+
+func (x *Data_Redis) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Redis.ProtoReflect.Descriptor instead.
+func (*Data_Redis) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 1}
+}
+
+func (x *Data_Redis) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *Data_Redis) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *Data_Redis) GetReadTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.ReadTimeout
+	}
+	return nil
+}
+
+func (x *Data_Redis) GetWriteTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.WriteTimeout
+	}
+	return nil
+}
+
+func (x *Data_Redis) GetAddrs() []string {
+	if x != nil {
+		return x.Addrs
+	}
+	return nil
+}
+
+func (x *Data_Redis) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *Data_Redis) GetDb() int32 {
+	if x != nil {
+		return x.Db
+	}
+	return 0
+}
+
+func (x *Data_Redis) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Data_Redis) GetDialTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.DialTimeout
+	}
+	return nil
+}
+
+func (x *Data_Redis) GetPoolSize() int32 {
+	if x != nil {
+		return x.PoolSize
+	}
+	return 0
+}
+
+func (x *Data_Redis) GetMinIdleConns() int32 {
+	if x != nil {
+		return x.MinIdleConns
+	}
+	return 0
+}
+
+func (x *Data_Redis) GetPoolTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.PoolTimeout
+	}
+	return nil
+}
+
+func (x *Data_Redis) GetConnMaxIdleTime() *durationpb.Duration {
+	if x != nil {
+		return x.ConnMaxIdleTime
+	}
+	return nil
+}
+
+func (x *Data_Redis) GetTls() *Data_Redis_TLS {
+	if x != nil {
+		return x.Tls
+	}
+	return nil
+}
+
+type Data_Embedded struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enable        bool                   `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"` // 使用内嵌 bbolt 存储代替外部数据库，适用于单机边缘部署
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`      // 数据文件路径
+	Backup        *Log_Archive           `protobuf:"bytes,3,opt,name=backup,proto3" json:"backup,omitempty"`  // 定期备份到对象存储，配置项与日志归档相同
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_Embedded) Reset() {
+	*x = Data_Embedded{}
+	mi := &file_conf_conf_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Embedded) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Embedded) ProtoMessage() {}
+
+func (x *Data_Embedded) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Embedded.ProtoReflect.Descriptor instead.
+func (*Data_Embedded) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 2}
+}
+
+func (x *Data_Embedded) GetEnable() bool {
+	if x != nil {
+		return x.Enable
+	}
+	return false
+}
+
+func (x *Data_Embedded) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Data_Embedded) GetBackup() *Log_Archive {
+	if x != nil {
+		return x.Backup
+	}
+	return nil
+}
+
+type Data_Mongo struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Enable         bool                   `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"` // 示例 repo 使用 MongoDB 代替关系数据库
+	Uri            string                 `protobuf:"bytes,2,opt,name=uri,proto3" json:"uri,omitempty"`        // 如 mongodb://127.0.0.1:27017
+	Database       string                 `protobuf:"bytes,3,opt,name=database,proto3" json:"database,omitempty"`
+	Timeout        *durationpb.Duration   `protobuf:"bytes,4,opt,name=timeout,proto3" json:"timeout,omitempty"`                                     // 单次操作的超时时间，默认 5s
+	ConnectTimeout *durationpb.Duration   `protobuf:"bytes,5,opt,name=connect_timeout,json=connectTimeout,proto3" json:"connect_timeout,omitempty"` // 建立连接的超时时间，默认 10s
+	MaxPoolSize    uint64                 `protobuf:"varint,6,opt,name=max_pool_size,json=maxPoolSize,proto3" json:"max_pool_size,omitempty"`       // 连接池大小，默认 100
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Data_Mongo) Reset() {
+	*x = Data_Mongo{}
+	mi := &file_conf_conf_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Mongo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Mongo) ProtoMessage() {}
+
+func (x *Data_Mongo) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Mongo.ProtoReflect.Descriptor instead.
+func (*Data_Mongo) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 5}
+}
+
+func (x *Data_Mongo) GetEnable() bool {
+	if x != nil {
+		return x.Enable
+	}
+	return false
+}
+
+func (x *Data_Mongo) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+func (x *Data_Mongo) GetDatabase() string {
+	if x != nil {
+		return x.Database
+	}
+	return ""
+}
+
+func (x *Data_Mongo) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *Data_Mongo) GetConnectTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.ConnectTimeout
+	}
+	return nil
+}
+
+func (x *Data_Mongo) GetMaxPoolSize() uint64 {
+	if x != nil {
+		return x.MaxPoolSize
+	}
+	return 0
+}
+
+type Data_ClickHouse struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	Enable                 bool                   `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"` // 开启后事件写入 ClickHouse，未开启时丢弃
+	Addrs                  []string               `protobuf:"bytes,2,rep,name=addrs,proto3" json:"addrs,omitempty"`    // native 协议地址，如 127.0.0.1:9000
+	Database               string                 `protobuf:"bytes,3,opt,name=database,proto3" json:"database,omitempty"`
+	Username               string                 `protobuf:"bytes,4,opt,name=username,proto3" json:"username,omitempty"`
+	Password               string                 `protobuf:"bytes,5,opt,name=password,proto3" json:"password,omitempty"`
+	DialTimeout            *durationpb.Duration   `protobuf:"bytes,6,opt,name=dial_timeout,json=dialTimeout,proto3" json:"dial_timeout,omitempty"`
+	ReadTimeout            *durationpb.Duration   `protobuf:"bytes,7,opt,name=read_timeout,json=readTimeout,proto3" json:"read_timeout,omitempty"`
+	MaxOpenConns           int32                  `protobuf:"varint,8,opt,name=max_open_conns,json=maxOpenConns,proto3" json:"max_open_conns,omitempty"`
+	MaxIdleConns           int32                  `protobuf:"varint,9,opt,name=max_idle_conns,json=maxIdleConns,proto3" json:"max_idle_conns,omitempty"`
+	ConnMaxLifetime        *durationpb.Duration   `protobuf:"bytes,10,opt,name=conn_max_lifetime,json=connMaxLifetime,proto3" json:"conn_max_lifetime,omitempty"`
+	AsyncInsert            bool                   `protobuf:"varint,11,opt,name=async_insert,json=asyncInsert,proto3" json:"async_insert,omitempty"`                                     // 服务端异步写入，合并小批量插入
+	WaitForAsyncInsert     bool                   `protobuf:"varint,12,opt,name=wait_for_async_insert,json=waitForAsyncInsert,proto3" json:"wait_for_async_insert,omitempty"`            // 等待异步写入落盘后返回
+	AsyncInsertBusyTimeout *durationpb.Duration   `protobuf:"bytes,13,opt,name=async_insert_busy_timeout,json=asyncInsertBusyTimeout,proto3" json:"async_insert_busy_timeout,omitempty"` // 异步写入缓冲的最长时间
+	AutoMigrate            bool                   `protobuf:"varint,14,opt,name=auto_migrate,json=autoMigrate,proto3" json:"auto_migrate,omitempty"`                                     // 启动时创建示例事件表
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *Data_ClickHouse) Reset() {
+	*x = Data_ClickHouse{}
+	mi := &file_conf_conf_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_ClickHouse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_ClickHouse) ProtoMessage() {}
+
+func (x *Data_ClickHouse) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_ClickHouse.ProtoReflect.Descriptor instead.
+func (*Data_ClickHouse) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 6}
+}
+
+func (x *Data_ClickHouse) GetEnable() bool {
+	if x != nil {
+		return x.Enable
+	}
+	return false
+}
+
+func (x *Data_ClickHouse) GetAddrs() []string {
+	if x != nil {
+		return x.Addrs
+	}
+	return nil
+}
+
+func (x *Data_ClickHouse) GetDatabase() string {
+	if x != nil {
+		return x.Database
+	}
+	return ""
+}
+
+func (x *Data_ClickHouse) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Data_ClickHouse) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *Data_ClickHouse) GetDialTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.DialTimeout
+	}
+	return nil
+}
+
+func (x *Data_ClickHouse) GetReadTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.ReadTimeout
+	}
+	return nil
+}
+
+func (x *Data_ClickHouse) GetMaxOpenConns() int32 {
+	if x != nil {
+		return x.MaxOpenConns
+	}
+	return 0
+}
+
+func (x *Data_ClickHouse) GetMaxIdleConns() int32 {
+	if x != nil {
+		return x.MaxIdleConns
+	}
+	return 0
+}
+
+func (x *Data_ClickHouse) GetConnMaxLifetime() *durationpb.Duration {
+	if x != nil {
+		return x.ConnMaxLifetime
+	}
+	return nil
+}
+
+func (x *Data_ClickHouse) GetAsyncInsert() bool {
+	if x != nil {
+		return x.AsyncInsert
+	}
+	return false
+}
+
+func (x *Data_ClickHouse) GetWaitForAsyncInsert() bool {
+	if x != nil {
+		return x.WaitForAsyncInsert
+	}
+	return false
+}
+
+func (x *Data_ClickHouse) GetAsyncInsertBusyTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.AsyncInsertBusyTimeout
+	}
+	return nil
+}
+
+func (x *Data_ClickHouse) GetAutoMigrate() bool {
+	if x != nil {
+		return x.AutoMigrate
+	}
+	return false
+}
+
+type Data_Outbox struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enable        bool                   `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`                              // 业务事务中写入 outbox 表，后台 relay 发布到默认 Redis 的事件总线，需要关系数据库和 Redis
+	BatchSize     int32                  `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`       // 每次领取的消息数，默认 100
+	Interval      *durationpb.Duration   `protobuf:"bytes,3,opt,name=interval,proto3" json:"interval,omitempty"`                           // 没有待发送消息时的轮询间隔，默认 1s
+	MaxAttempts   int32                  `protobuf:"varint,4,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"` // 最大发布次数，超出后标记为 dead 不再重试，默认 10
+	Lease         *durationpb.Duration   `protobuf:"bytes,5,opt,name=lease,proto3" json:"lease,omitempty"`                                 // 领取后其他副本不会重复领取的时长，应大于一批消息的发布时间，默认 30s
+	Retention     *durationpb.Duration   `protobuf:"bytes,6,opt,name=retention,proto3" json:"retention,omitempty"`                         // 已发送消息的保留时间，默认 168h，为 0 时不清理
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_Outbox) Reset() {
+	*x = Data_Outbox{}
+	mi := &file_conf_conf_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Outbox) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Outbox) ProtoMessage() {}
+
+func (x *Data_Outbox) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Outbox.ProtoReflect.Descriptor instead.
+func (*Data_Outbox) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 7}
+}
+
+func (x *Data_Outbox) GetEnable() bool {
+	if x != nil {
+		return x.Enable
+	}
+	return false
+}
+
+func (x *Data_Outbox) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *Data_Outbox) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *Data_Outbox) GetMaxAttempts() int32 {
+	if x != nil {
+		return x.MaxAttempts
+	}
+	return 0
+}
+
+func (x *Data_Outbox) GetLease() *durationpb.Duration {
+	if x != nil {
+		return x.Lease
+	}
+	return nil
+}
+
+func (x *Data_Outbox) GetRetention() *durationpb.Duration {
+	if x != nil {
+		return x.Retention
+	}
+	return nil
+}
+
+type Data_Tenancy struct {
+	state         protoimpl.MessageState          `protogen:"open.v1"`
+	Enable        bool                            `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`                                                                            // 按请求的租户选择数据库，默认数据库的 GORM 查询和事务路由到租户连接
+	Header        string                          `protobuf:"bytes,2,opt,name=header,proto3" json:"header,omitempty"`                                                                             // 租户 id 请求头，默认 X-Tenant-ID
+	Required      bool                            `protobuf:"varint,3,opt,name=required,proto3" json:"required,omitempty"`                                                                        // 请求必须带租户 id，否则返回 400
+	Source        string                          `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`                                                                             // 租户连接串模板，{tenant} 替换为租户 id，如 /app_{tenant}?parseTime=true，为空时使用默认数据库的 source
+	Schema        string                          `protobuf:"bytes,5,opt,name=schema,proto3" json:"schema,omitempty"`                                                                             // PostgreSQL schema 模板，如 tenant_{tenant}，设置后连接的 search_path 指向该 schema，不存在时自动创建
+	Tenants       map[string]*Data_Tenancy_Tenant `protobuf:"bytes,6,rep,name=tenants,proto3" json:"tenants,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 显式配置的租户，覆盖模板，设置 strict 时只允许这些租户
+	Strict        bool                            `protobuf:"varint,7,opt,name=strict,proto3" json:"strict,omitempty"`                                                                            // 只允许 tenants 中的租户，未知租户返回 404；关闭时未知租户只接受令牌 claim 中的，只来自请求头的仍返回 404
+	MaxTenants    int32                           `protobuf:"varint,8,opt,name=max_tenants,json=maxTenants,proto3" json:"max_tenants,omitempty"`                                                  // 最多缓存的租户连接池数，超出时关闭最久未使用的，默认 64
+	IdleTimeout   *durationpb.Duration            `protobuf:"bytes,9,opt,name=idle_timeout,json=idleTimeout,proto3" json:"idle_timeout,omitempty"`                                                // 空闲超过该时长的租户连接池被关闭，默认 10m，为 0 时不关闭
+	RunMigrations bool                            `protobuf:"varint,10,opt,name=run_migrations,json=runMigrations,proto3" json:"run_migrations,omitempty"`                                        // 启动时迁移 tenants 中的全部租户，按模板动态使用的租户通过 migrate tenant <id> 迁移
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_Tenancy) Reset() {
+	*x = Data_Tenancy{}
+	mi := &file_conf_conf_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Tenancy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Tenancy) ProtoMessage() {}
+
+func (x *Data_Tenancy) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Tenancy.ProtoReflect.Descriptor instead.
+func (*Data_Tenancy) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 8}
+}
+
+func (x *Data_Tenancy) GetEnable() bool {
+	if x != nil {
+		return x.Enable
+	}
+	return false
+}
+
+func (x *Data_Tenancy) GetHeader() string {
+	if x != nil {
+		return x.Header
+	}
+	return ""
+}
+
+func (x *Data_Tenancy) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *Data_Tenancy) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Data_Tenancy) GetSchema() string {
+	if x != nil {
+		return x.Schema
+	}
+	return ""
+}
+
+func (x *Data_Tenancy) GetTenants() map[string]*Data_Tenancy_Tenant {
+	if x != nil {
+		return x.Tenants
+	}
+	return nil
+}
+
+func (x *Data_Tenancy) GetStrict() bool {
+	if x != nil {
+		return x.Strict
+	}
+	return false
+}
+
+func (x *Data_Tenancy) GetMaxTenants() int32 {
+	if x != nil {
+		return x.MaxTenants
+	}
+	return 0
+}
+
+func (x *Data_Tenancy) GetIdleTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.IdleTimeout
+	}
+	return nil
+}
+
+func (x *Data_Tenancy) GetRunMigrations() bool {
+	if x != nil {
+		return x.RunMigrations
+	}
+	return false
+}
+
+type Data_Database_SchemaCheck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enable        bool                   `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"` // 启动时比较模型定义与数据库表结构，在自动迁移之后执行
+	Fail          bool                   `protobuf:"varint,2,opt,name=fail,proto3" json:"fail,omitempty"`     // 缺失表或列时启动失败，否则只输出警告
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_Database_SchemaCheck) Reset() {
+	*x = Data_Database_SchemaCheck{}
+	mi := &file_conf_conf_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Database_SchemaCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Database_SchemaCheck) ProtoMessage() {}
+
+func (x *Data_Database_SchemaCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Database_SchemaCheck.ProtoReflect.Descriptor instead.
+func (*Data_Database_SchemaCheck) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 0, 0}
+}
+
+func (x *Data_Database_SchemaCheck) GetEnable() bool {
+	if x != nil {
+		return x.Enable
+	}
+	return false
+}
+
+func (x *Data_Database_SchemaCheck) GetFail() bool {
+	if x != nil {
+		return x.Fail
+	}
+	return false
+}
+
+type Data_Redis_TLS struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Enable             bool                   `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`
+	CaFile             string                 `protobuf:"bytes,2,opt,name=ca_file,json=caFile,proto3" json:"ca_file,omitempty"`       // 为空时使用系统 CA
+	CertFile           string                 `protobuf:"bytes,3,opt,name=cert_file,json=certFile,proto3" json:"cert_file,omitempty"` // 双向 TLS 的客户端证书
+	KeyFile            string                 `protobuf:"bytes,4,opt,name=key_file,json=keyFile,proto3" json:"key_file,omitempty"`
+	ServerName         string                 `protobuf:"bytes,5,opt,name=server_name,json=serverName,proto3" json:"server_name,omitempty"`                            // 证书校验的服务端名称，默认取连接地址
+	InsecureSkipVerify bool                   `protobuf:"varint,6,opt,name=insecure_skip_verify,json=insecureSkipVerify,proto3" json:"insecure_skip_verify,omitempty"` // 跳过证书校验，仅用于测试环境
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Data_Redis_TLS) Reset() {
+	*x = Data_Redis_TLS{}
+	mi := &file_conf_conf_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Redis_TLS) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Redis_TLS) ProtoMessage() {}
+
+func (x *Data_Redis_TLS) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Redis_TLS.ProtoReflect.Descriptor instead.
+func (*Data_Redis_TLS) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 1, 0}
+}
+
+func (x *Data_Redis_TLS) GetEnable() bool {
+	if x != nil {
+		return x.Enable
+	}
+	return false
+}
+
+func (x *Data_Redis_TLS) GetCaFile() string {
+	if x != nil {
+		return x.CaFile
+	}
+	return ""
+}
+
+func (x *Data_Redis_TLS) GetCertFile() string {
+	if x != nil {
+		return x.CertFile
+	}
+	return ""
+}
+
+func (x *Data_Redis_TLS) GetKeyFile() string {
+	if x != nil {
+		return x.KeyFile
+	}
+	return ""
+}
+
+func (x *Data_Redis_TLS) GetServerName() string {
+	if x != nil {
+		return x.ServerName
+	}
+	return ""
+}
+
+func (x *Data_Redis_TLS) GetInsecureSkipVerify() bool {
+	if x != nil {
+		return x.InsecureSkipVerify
+	}
+	return false
+}
+
+type Data_Tenancy_Tenant struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"` // 租户独立的数据库连接串，为空时使用 source 模板
+	Schema        string                 `protobuf:"bytes,2,opt,name=schema,proto3" json:"schema,omitempty"` // 租户的 PostgreSQL schema，为空时使用 schema 模板
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_Tenancy_Tenant) Reset() {
+	*x = Data_Tenancy_Tenant{}
+	mi := &file_conf_conf_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Tenancy_Tenant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Tenancy_Tenant) ProtoMessage() {}
+
+func (x *Data_Tenancy_Tenant) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Tenancy_Tenant.ProtoReflect.Descriptor instead.
+func (*Data_Tenancy_Tenant) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 8, 0}
+}
+
+func (x *Data_Tenancy_Tenant) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Data_Tenancy_Tenant) GetSchema() string {
+	if x != nil {
+		return x.Schema
+	}
+	return ""
+}
+
+type Log_Archive struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enable        bool                   `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`
+	Endpoint      string                 `protobuf:"bytes,2,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Bucket        string                 `protobuf:"bytes,3,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	AccessKey     string                 `protobuf:"bytes,4,opt,name=access_key,json=accessKey,proto3" json:"access_key,omitempty"`
+	SecretKey     string                 `protobuf:"bytes,5,opt,name=secret_key,json=secretKey,proto3" json:"secret_key,omitempty"`
+	Region        string                 `protobuf:"bytes,6,opt,name=region,proto3" json:"region,omitempty"`
+	Secure        bool                   `protobuf:"varint,7,opt,name=secure,proto3" json:"secure,omitempty"`
+	Prefix        string                 `protobuf:"bytes,8,opt,name=prefix,proto3" json:"prefix,omitempty"`     // 对象 key 前缀
+	Interval      *durationpb.Duration   `protobuf:"bytes,9,opt,name=interval,proto3" json:"interval,omitempty"` // 扫描间隔，默认 1m
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Log_Archive) Reset() {
+	*x = Log_Archive{}
+	mi := &file_conf_conf_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Log_Archive) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Log_Archive) ProtoMessage() {}
+
+func (x *Log_Archive) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Log_Archive.ProtoReflect.Descriptor instead.
+func (*Log_Archive) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{4, 0}
+}
+
+func (x *Log_Archive) GetEnable() bool {
+	if x != nil {
+		return x.Enable
+	}
+	return false
+}
+
+func (x *Log_Archive) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *Log_Archive) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *Log_Archive) GetAccessKey() string {
+	if x != nil {
+		return x.AccessKey
+	}
+	return ""
+}
+
+func (x *Log_Archive) GetSecretKey() string {
+	if x != nil {
+		return x.SecretKey
+	}
+	return ""
+}
+
+func (x *Log_Archive) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *Log_Archive) GetSecure() bool {
+	if x != nil {
+		return x.Secure
+	}
+	return false
+}
+
+func (x *Log_Archive) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *Log_Archive) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+type Log_OTLP struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enable        bool                   `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`
+	Endpoint      string                 `protobuf:"bytes,2,opt,name=endpoint,proto3" json:"endpoint,omitempty"` // collector 地址，如 127.0.0.1:4317
+	Insecure      bool                   `protobuf:"varint,3,opt,name=insecure,proto3" json:"insecure,omitempty"`
+	Timeout       *durationpb.Duration   `protobuf:"bytes,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Headers       map[string]string      `protobuf:"bytes,5,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Attributes    map[string]string      `protobuf:"bytes,6,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 额外的资源属性
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Log_OTLP) Reset() {
+	*x = Log_OTLP{}
+	mi := &file_conf_conf_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Log_OTLP) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Log_OTLP) ProtoMessage() {}
+
+func (x *Log_OTLP) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Log_OTLP.ProtoReflect.Descriptor instead.
+func (*Log_OTLP) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{4, 1}
+}
+
+func (x *Log_OTLP) GetEnable() bool {
+	if x != nil {
+		return x.Enable
+	}
+	return false
+}
+
+func (x *Log_OTLP) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *Log_OTLP) GetInsecure() bool {
+	if x != nil {
+		return x.Insecure
+	}
+	return false
+}
+
+func (x *Log_OTLP) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *Log_OTLP) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *Log_OTLP) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+type Log_Access struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Enable         bool                   `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`
+	Filename       string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`                                   // 访问日志文件，轮转参数与应用日志一致
+	CaptureBody    bool                   `protobuf:"varint,3,opt,name=capture_body,json=captureBody,proto3" json:"capture_body,omitempty"`         // 记录请求和响应体
+	MaxBodySize    int32                  `protobuf:"varint,4,opt,name=max_body_size,json=maxBodySize,proto3" json:"max_body_size,omitempty"`       // 请求和响应体的最大记录长度，默认 1KB
+	ContentTypes   []string               `protobuf:"bytes,5,rep,name=content_types,json=contentTypes,proto3" json:"content_types,omitempty"`       // 只记录指定 Content-Type 的请求体
+	TrustedProxies []string               `protobuf:"bytes,6,rep,name=trusted_proxies,json=trustedProxies,proto3" json:"trusted_proxies,omitempty"` // 可信代理的 IP 或 CIDR，如 10.0.0.0/8，直连地址为可信代理时才从 X-Forwarded-For、X-Real-IP 取客户端 IP
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Log_Access) Reset() {
+	*x = Log_Access{}
+	mi := &file_conf_conf_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Log_Access) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Log_Access) ProtoMessage() {}
+
+func (x *Log_Access) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Log_Access.ProtoReflect.Descriptor instead.
+func (*Log_Access) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{4, 2}
+}
+
+func (x *Log_Access) GetEnable() bool {
+	if x != nil {
+		return x.Enable
+	}
+	return false
+}
+
+func (x *Log_Access) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *Log_Access) GetCaptureBody() bool {
+	if x != nil {
+		return x.CaptureBody
+	}
+	return false
+}
+
+func (x *Log_Access) GetMaxBodySize() int32 {
+	if x != nil {
+		return x.MaxBodySize
+	}
+	return 0
+}
+
+func (x *Log_Access) GetContentTypes() []string {
+	if x != nil {
+		return x.ContentTypes
+	}
+	return nil
+}
+
+func (x *Log_Access) GetTrustedProxies() []string {
+	if x != nil {
+		return x.TrustedProxies
+	}
+	return nil
+}
+
+type Log_Slow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enable        bool                   `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`
+	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`                             // 慢日志文件，轮转参数与应用日志一致
+	Threshold     *durationpb.Duration   `protobuf:"bytes,3,opt,name=threshold,proto3" json:"threshold,omitempty"`                           // 慢请求阈值，默认 1s
+	SqlThreshold  *durationpb.Duration   `protobuf:"bytes,4,opt,name=sql_threshold,json=sqlThreshold,proto3" json:"sql_threshold,omitempty"` // 慢 SQL 阈值，默认 200ms
+	Explain       *Log_Slow_Explain      `protobuf:"bytes,5,opt,name=explain,proto3" json:"explain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Log_Slow) Reset() {
+	*x = Log_Slow{}
+	mi := &file_conf_conf_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Log_Slow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Log_Slow) ProtoMessage() {}
+
+func (x *Log_Slow) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Log_Slow.ProtoReflect.Descriptor instead.
+func (*Log_Slow) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{4, 3}
+}
+
+func (x *Log_Slow) GetEnable() bool {
+	if x != nil {
+		return x.Enable
+	}
+	return false
+}
+
+func (x *Log_Slow) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *Log_Slow) GetThreshold() *durationpb.Duration {
+	if x != nil {
+		return x.Threshold
+	}
+	return nil
+}
+
+func (x *Log_Slow) GetSqlThreshold() *durationpb.Duration {
+	if x != nil {
+		return x.SqlThreshold
+	}
+	return nil
+}
+
+func (x *Log_Slow) GetExplain() *Log_Slow_Explain {
+	if x != nil {
+		return x.Explain
+	}
+	return nil
+}
+
+type Log_Audit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enable        bool                   `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`
+	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`                                // 审计日志文件，轮转参数与应用日志一致
+	RemoteUrl     string                 `protobuf:"bytes,3,opt,name=remote_url,json=remoteUrl,proto3" json:"remote_url,omitempty"`             // 远程输出地址，审计记录以 JSON 格式 POST
+	RemoteTimeout *durationpb.Duration   `protobuf:"bytes,4,opt,name=remote_timeout,json=remoteTimeout,proto3" json:"remote_timeout,omitempty"` // 远程输出超时，默认 3s
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Log_Audit) Reset() {
+	*x = Log_Audit{}
+	mi := &file_conf_conf_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Log_Audit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Log_Audit) ProtoMessage() {}
+
+func (x *Log_Audit) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Log_Audit.ProtoReflect.Descriptor instead.
+func (*Log_Audit) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{4, 4}
+}
+
+func (x *Log_Audit) GetEnable() bool {
+	if x != nil {
+		return x.Enable
+	}
+	return false
+}
+
+func (x *Log_Audit) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *Log_Audit) GetRemoteUrl() string {
+	if x != nil {
+		return x.RemoteUrl
+	}
+	return ""
+}
+
+func (x *Log_Audit) GetRemoteTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.RemoteTimeout
+	}
+	return nil
+}
+
+type Log_Alert struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enable        bool                   `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`
+	WebhookUrl    string                 `protobuf:"bytes,2,opt,name=webhook_url,json=webhookUrl,proto3" json:"webhook_url,omitempty"` // 告警机器人地址
+	Format        string                 `protobuf:"bytes,3,opt,name=format,proto3" json:"format,omitempty"`                           // 请求体格式: json、dingtalk、slack，默认 json
+	Level         string                 `protobuf:"bytes,4,opt,name=level,proto3" json:"level,omitempty"`                             // 触发告警的最低级别，默认 error
+	RateLimit     int32                  `protobuf:"varint,5,opt,name=rate_limit,json=rateLimit,proto3" json:"rate_limit,omitempty"`   // 每分钟最多发送的告警数，默认 10
+	Timeout       *durationpb.Duration   `protobuf:"bytes,6,opt,name=timeout,proto3" json:"timeout,omitempty"`                         // 请求超时，默认 3s
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Server) Reset() {
-	*x = Server{}
-	mi := &file_conf_conf_proto_msgTypes[1]
+func (x *Log_Alert) Reset() {
+	*x = Log_Alert{}
+	mi := &file_conf_conf_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Server) String() string {
+func (x *Log_Alert) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Server) ProtoMessage() {}
+func (*Log_Alert) ProtoMessage() {}
 
-func (x *Server) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[1]
+func (x *Log_Alert) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -116,48 +3565,80 @@ func (x *Server) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Server.ProtoReflect.Descriptor instead.
-func (*Server) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use Log_Alert.ProtoReflect.Descriptor instead.
+func (*Log_Alert) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{4, 5}
 }
 
-func (x *Server) GetHttp() *Server_HTTP {
+func (x *Log_Alert) GetEnable() bool {
 	if x != nil {
-		return x.Http
+		return x.Enable
 	}
-	return nil
+	return false
 }
 
-func (x *Server) GetGrpc() *Server_GRPC {
+func (x *Log_Alert) GetWebhookUrl() string {
 	if x != nil {
-		return x.Grpc
+		return x.WebhookUrl
+	}
+	return ""
+}
+
+func (x *Log_Alert) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *Log_Alert) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *Log_Alert) GetRateLimit() int32 {
+	if x != nil {
+		return x.RateLimit
+	}
+	return 0
+}
+
+func (x *Log_Alert) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
 	}
 	return nil
 }
 
-type Data struct {
+type Log_Sentry struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Database      *Data_Database         `protobuf:"bytes,1,opt,name=database,proto3" json:"database,omitempty"`
-	Redis         *Data_Redis            `protobuf:"bytes,2,opt,name=redis,proto3" json:"redis,omitempty"`
+	Enable        bool                   `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`
+	Dsn           string                 `protobuf:"bytes,2,opt,name=dsn,proto3" json:"dsn,omitempty"`
+	Environment   string                 `protobuf:"bytes,3,opt,name=environment,proto3" json:"environment,omitempty"`                   // 环境，如 production、staging
+	Release       string                 `protobuf:"bytes,4,opt,name=release,proto3" json:"release,omitempty"`                           // 版本，为空时使用服务版本
+	SampleRate    float64                `protobuf:"fixed64,5,opt,name=sample_rate,json=sampleRate,proto3" json:"sample_rate,omitempty"` // 采样率，默认 1
+	Level         string                 `protobuf:"bytes,6,opt,name=level,proto3" json:"level,omitempty"`                               // 上报的最低级别，默认 error
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Data) Reset() {
-	*x = Data{}
-	mi := &file_conf_conf_proto_msgTypes[2]
+func (x *Log_Sentry) Reset() {
+	*x = Log_Sentry{}
+	mi := &file_conf_conf_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Data) String() string {
+func (x *Log_Sentry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Data) ProtoMessage() {}
+func (*Log_Sentry) ProtoMessage() {}
 
-func (x *Data) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[2]
+func (x *Log_Sentry) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -168,54 +3649,80 @@ func (x *Data) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Data.ProtoReflect.Descriptor instead.
-func (*Data) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use Log_Sentry.ProtoReflect.Descriptor instead.
+func (*Log_Sentry) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{4, 6}
 }
 
-func (x *Data) GetDatabase() *Data_Database {
+func (x *Log_Sentry) GetEnable() bool {
 	if x != nil {
-		return x.Database
+		return x.Enable
 	}
-	return nil
+	return false
 }
 
-func (x *Data) GetRedis() *Data_Redis {
+func (x *Log_Sentry) GetDsn() string {
 	if x != nil {
-		return x.Redis
+		return x.Dsn
 	}
-	return nil
+	return ""
 }
 
-type Log struct {
+func (x *Log_Sentry) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+func (x *Log_Sentry) GetRelease() string {
+	if x != nil {
+		return x.Release
+	}
+	return ""
+}
+
+func (x *Log_Sentry) GetSampleRate() float64 {
+	if x != nil {
+		return x.SampleRate
+	}
+	return 0
+}
+
+func (x *Log_Sentry) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+type Log_Fluent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Level         string                 `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
-	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
-	MaxSize       int32                  `protobuf:"varint,3,opt,name=max_size,json=maxSize,proto3" json:"max_size,omitempty"`
-	MaxAge        int32                  `protobuf:"varint,4,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
-	MaxBackups    int32                  `protobuf:"varint,5,opt,name=max_backups,json=maxBackups,proto3" json:"max_backups,omitempty"`
-	Compress      bool                   `protobuf:"varint,6,opt,name=compress,proto3" json:"compress,omitempty"`
-	Console       bool                   `protobuf:"varint,7,opt,name=console,proto3" json:"console,omitempty"`
-	Format        string                 `protobuf:"bytes,8,opt,name=format,proto3" json:"format,omitempty"` // json or text
+	Enable        bool                   `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"` // fluentd 地址，如 127.0.0.1:24224
+	Tag           string                 `protobuf:"bytes,3,opt,name=tag,proto3" json:"tag,omitempty"`
+	RequireAck    bool                   `protobuf:"varint,4,opt,name=require_ack,json=requireAck,proto3" json:"require_ack,omitempty"` // 等待服务端 ack 确认，保证至少一次送达
+	Timeout       *durationpb.Duration   `protobuf:"bytes,5,opt,name=timeout,proto3" json:"timeout,omitempty"`                          // 连接和写入超时，默认 3s
+	BufferSize    int32                  `protobuf:"varint,6,opt,name=buffer_size,json=bufferSize,proto3" json:"buffer_size,omitempty"` // 内存队列长度，默认 8192，队列满时丢弃
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Log) Reset() {
-	*x = Log{}
-	mi := &file_conf_conf_proto_msgTypes[3]
+func (x *Log_Fluent) Reset() {
+	*x = Log_Fluent{}
+	mi := &file_conf_conf_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Log) String() string {
+func (x *Log_Fluent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Log) ProtoMessage() {}
+func (*Log_Fluent) ProtoMessage() {}
 
-func (x *Log) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[3]
+func (x *Log_Fluent) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -226,91 +3733,78 @@ func (x *Log) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Log.ProtoReflect.Descriptor instead.
-func (*Log) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use Log_Fluent.ProtoReflect.Descriptor instead.
+func (*Log_Fluent) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{4, 7}
 }
 
-func (x *Log) GetLevel() string {
+func (x *Log_Fluent) GetEnable() bool {
 	if x != nil {
-		return x.Level
+		return x.Enable
 	}
-	return ""
+	return false
 }
 
-func (x *Log) GetFilename() string {
+func (x *Log_Fluent) GetAddress() string {
 	if x != nil {
-		return x.Filename
+		return x.Address
 	}
 	return ""
 }
 
-func (x *Log) GetMaxSize() int32 {
-	if x != nil {
-		return x.MaxSize
-	}
-	return 0
-}
-
-func (x *Log) GetMaxAge() int32 {
-	if x != nil {
-		return x.MaxAge
-	}
-	return 0
-}
-
-func (x *Log) GetMaxBackups() int32 {
+func (x *Log_Fluent) GetTag() string {
 	if x != nil {
-		return x.MaxBackups
+		return x.Tag
 	}
-	return 0
+	return ""
 }
 
-func (x *Log) GetCompress() bool {
+func (x *Log_Fluent) GetRequireAck() bool {
 	if x != nil {
-		return x.Compress
+		return x.RequireAck
 	}
 	return false
 }
 
-func (x *Log) GetConsole() bool {
+func (x *Log_Fluent) GetTimeout() *durationpb.Duration {
 	if x != nil {
-		return x.Console
+		return x.Timeout
 	}
-	return false
+	return nil
 }
 
-func (x *Log) GetFormat() string {
+func (x *Log_Fluent) GetBufferSize() int32 {
 	if x != nil {
-		return x.Format
+		return x.BufferSize
 	}
-	return ""
+	return 0
 }
 
-type Server_HTTP struct {
+type Log_Buffer struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Network       string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
-	Addr          string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
-	Timeout       *durationpb.Duration   `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Enable        bool                   `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`
+	Level         string                 `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`                                      // 低于该级别的日志按请求缓冲，默认 warn
+	LatencyBudget *durationpb.Duration   `protobuf:"bytes,3,opt,name=latency_budget,json=latencyBudget,proto3" json:"latency_budget,omitempty"` // 耗时超过该值的请求输出缓冲的日志，为空时只在失败时输出
+	MaxLines      int32                  `protobuf:"varint,4,opt,name=max_lines,json=maxLines,proto3" json:"max_lines,omitempty"`               // 单个请求最多缓冲的日志条数，默认 256
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Server_HTTP) Reset() {
-	*x = Server_HTTP{}
-	mi := &file_conf_conf_proto_msgTypes[4]
+func (x *Log_Buffer) Reset() {
+	*x = Log_Buffer{}
+	mi := &file_conf_conf_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Server_HTTP) String() string {
+func (x *Log_Buffer) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Server_HTTP) ProtoMessage() {}
+func (*Log_Buffer) ProtoMessage() {}
 
-func (x *Server_HTTP) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[4]
+func (x *Log_Buffer) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -321,56 +3815,63 @@ func (x *Server_HTTP) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Server_HTTP.ProtoReflect.Descriptor instead.
-func (*Server_HTTP) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{1, 0}
+// Deprecated: Use Log_Buffer.ProtoReflect.Descriptor instead.
+func (*Log_Buffer) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{4, 8}
 }
 
-func (x *Server_HTTP) GetNetwork() string {
+func (x *Log_Buffer) GetEnable() bool {
 	if x != nil {
-		return x.Network
+		return x.Enable
 	}
-	return ""
+	return false
 }
 
-func (x *Server_HTTP) GetAddr() string {
+func (x *Log_Buffer) GetLevel() string {
 	if x != nil {
-		return x.Addr
+		return x.Level
 	}
 	return ""
 }
 
-func (x *Server_HTTP) GetTimeout() *durationpb.Duration {
+func (x *Log_Buffer) GetLatencyBudget() *durationpb.Duration {
 	if x != nil {
-		return x.Timeout
+		return x.LatencyBudget
 	}
 	return nil
 }
 
-type Server_GRPC struct {
+func (x *Log_Buffer) GetMaxLines() int32 {
+	if x != nil {
+		return x.MaxLines
+	}
+	return 0
+}
+
+type Log_Journald struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Network       string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
-	Addr          string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
-	Timeout       *durationpb.Duration   `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Enable        bool                   `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`
+	Identifier    string                 `protobuf:"bytes,2,opt,name=identifier,proto3" json:"identifier,omitempty"` // SYSLOG_IDENTIFIER，默认服务名
+	Level         string                 `protobuf:"bytes,3,opt,name=level,proto3" json:"level,omitempty"`           // 写入的最低级别，默认 debug，最终仍受 level 限制
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Server_GRPC) Reset() {
-	*x = Server_GRPC{}
-	mi := &file_conf_conf_proto_msgTypes[5]
+func (x *Log_Journald) Reset() {
+	*x = Log_Journald{}
+	mi := &file_conf_conf_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Server_GRPC) String() string {
+func (x *Log_Journald) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Server_GRPC) ProtoMessage() {}
+func (*Log_Journald) ProtoMessage() {}
 
-func (x *Server_GRPC) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[5]
+func (x *Log_Journald) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -381,55 +3882,57 @@ func (x *Server_GRPC) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Server_GRPC.ProtoReflect.Descriptor instead.
-func (*Server_GRPC) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{1, 1}
+// Deprecated: Use Log_Journald.ProtoReflect.Descriptor instead.
+func (*Log_Journald) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{4, 9}
 }
 
-func (x *Server_GRPC) GetNetwork() string {
+func (x *Log_Journald) GetEnable() bool {
 	if x != nil {
-		return x.Network
+		return x.Enable
 	}
-	return ""
+	return false
 }
 
-func (x *Server_GRPC) GetAddr() string {
+func (x *Log_Journald) GetIdentifier() string {
 	if x != nil {
-		return x.Addr
+		return x.Identifier
 	}
 	return ""
 }
 
-func (x *Server_GRPC) GetTimeout() *durationpb.Duration {
+func (x *Log_Journald) GetLevel() string {
 	if x != nil {
-		return x.Timeout
+		return x.Level
 	}
-	return nil
+	return ""
 }
 
-type Data_Database struct {
+type Log_Spool struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Driver        string                 `protobuf:"bytes,1,opt,name=driver,proto3" json:"driver,omitempty"`
-	Source        string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	Enable        bool                   `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`
+	Dir           string                 `protobuf:"bytes,2,opt,name=dir,proto3" json:"dir,omitempty"`                                     // 缓冲目录，每个远端输出使用独立的子目录，默认 ./log/spool
+	MaxSize       int32                  `protobuf:"varint,3,opt,name=max_size,json=maxSize,proto3" json:"max_size,omitempty"`             // 缓冲总大小上限，超出时丢弃最早的日志，默认 1GB
+	SegmentSize   int32                  `protobuf:"varint,4,opt,name=segment_size,json=segmentSize,proto3" json:"segment_size,omitempty"` // 分段文件大小，默认 16MB
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Data_Database) Reset() {
-	*x = Data_Database{}
-	mi := &file_conf_conf_proto_msgTypes[6]
+func (x *Log_Spool) Reset() {
+	*x = Log_Spool{}
+	mi := &file_conf_conf_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Data_Database) String() string {
+func (x *Log_Spool) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Data_Database) ProtoMessage() {}
+func (*Log_Spool) ProtoMessage() {}
 
-func (x *Data_Database) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[6]
+func (x *Log_Spool) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -440,50 +3943,64 @@ func (x *Data_Database) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Data_Database.ProtoReflect.Descriptor instead.
-func (*Data_Database) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{2, 0}
+// Deprecated: Use Log_Spool.ProtoReflect.Descriptor instead.
+func (*Log_Spool) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{4, 10}
 }
 
-func (x *Data_Database) GetDriver() string {
+func (x *Log_Spool) GetEnable() bool {
 	if x != nil {
-		return x.Driver
+		return x.Enable
 	}
-	return ""
+	return false
 }
 
-func (x *Data_Database) GetSource() string {
+func (x *Log_Spool) GetDir() string {
 	if x != nil {
-		return x.Source
+		return x.Dir
 	}
 	return ""
 }
 
-type Data_Redis struct {
+func (x *Log_Spool) GetMaxSize() int32 {
+	if x != nil {
+		return x.MaxSize
+	}
+	return 0
+}
+
+func (x *Log_Spool) GetSegmentSize() int32 {
+	if x != nil {
+		return x.SegmentSize
+	}
+	return 0
+}
+
+type Log_Slow_Explain struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Network       string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
-	Addr          string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
-	ReadTimeout   *durationpb.Duration   `protobuf:"bytes,3,opt,name=read_timeout,json=readTimeout,proto3" json:"read_timeout,omitempty"`
-	WriteTimeout  *durationpb.Duration   `protobuf:"bytes,4,opt,name=write_timeout,json=writeTimeout,proto3" json:"write_timeout,omitempty"`
+	Enable        bool                   `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`                        // 慢 SQL 执行 EXPLAIN 并将执行计划写入慢日志和链路，只对 SELECT 生效
+	RateLimit     int32                  `protobuf:"varint,2,opt,name=rate_limit,json=rateLimit,proto3" json:"rate_limit,omitempty"` // 每分钟最多执行的 EXPLAIN 次数，默认 6
+	Timeout       *durationpb.Duration   `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`                       // 单次 EXPLAIN 超时时间，默认 1s
+	MaxSize       int32                  `protobuf:"varint,4,opt,name=max_size,json=maxSize,proto3" json:"max_size,omitempty"`       // 执行计划最大记录长度，超出部分截断，默认 4KB
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Data_Redis) Reset() {
-	*x = Data_Redis{}
-	mi := &file_conf_conf_proto_msgTypes[7]
+func (x *Log_Slow_Explain) Reset() {
+	*x = Log_Slow_Explain{}
+	mi := &file_conf_conf_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Data_Redis) String() string {
+func (x *Log_Slow_Explain) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Data_Redis) ProtoMessage() {}
+func (*Log_Slow_Explain) ProtoMessage() {}
 
-func (x *Data_Redis) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[7]
+func (x *Log_Slow_Explain) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -494,37 +4011,37 @@ func (x *Data_Redis) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Data_Redis.ProtoReflect.Descriptor instead.
-func (*Data_Redis) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{2, 1}
+// Deprecated: Use Log_Slow_Explain.ProtoReflect.Descriptor instead.
+func (*Log_Slow_Explain) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{4, 3, 0}
 }
 
-func (x *Data_Redis) GetNetwork() string {
+func (x *Log_Slow_Explain) GetEnable() bool {
 	if x != nil {
-		return x.Network
+		return x.Enable
 	}
-	return ""
+	return false
 }
 
-func (x *Data_Redis) GetAddr() string {
+func (x *Log_Slow_Explain) GetRateLimit() int32 {
 	if x != nil {
-		return x.Addr
+		return x.RateLimit
 	}
-	return ""
+	return 0
 }
 
-func (x *Data_Redis) GetReadTimeout() *durationpb.Duration {
+func (x *Log_Slow_Explain) GetTimeout() *durationpb.Duration {
 	if x != nil {
-		return x.ReadTimeout
+		return x.Timeout
 	}
 	return nil
 }
 
-func (x *Data_Redis) GetWriteTimeout() *durationpb.Duration {
+func (x *Log_Slow_Explain) GetMaxSize() int32 {
 	if x != nil {
-		return x.WriteTimeout
+		return x.MaxSize
 	}
-	return nil
+	return 0
 }
 
 var File_conf_conf_proto protoreflect.FileDescriptor
@@ -532,14 +4049,96 @@ var File_conf_conf_proto protoreflect.FileDescriptor
 const file_conf_conf_proto_rawDesc = "" +
 	"\n" +
 	"\x0fconf/conf.proto\x12\n" +
-	"kratos.api\x1a\x1egoogle/protobuf/duration.proto\"\x80\x01\n" +
+	"kratos.api\x1a\x1egoogle/protobuf/duration.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x10conf/units.proto\"\xac\x01\n" +
 	"\tBootstrap\x12*\n" +
 	"\x06server\x18\x01 \x01(\v2\x12.kratos.api.ServerR\x06server\x12$\n" +
 	"\x04data\x18\x02 \x01(\v2\x10.kratos.api.DataR\x04data\x12!\n" +
-	"\x03log\x18\x03 \x01(\v2\x0f.kratos.api.LogR\x03log\"\xb8\x02\n" +
+	"\x03log\x18\x03 \x01(\v2\x0f.kratos.api.LogR\x03log\x12*\n" +
+	"\x06remote\x18\x04 \x01(\v2\x12.kratos.api.RemoteR\x06remote\"\xa8\r\n" +
+	"\x06Remote\x12.\n" +
+	"\x05nacos\x18\x01 \x01(\v2\x18.kratos.api.Remote.NacosR\x05nacos\x121\n" +
+	"\x06apollo\x18\x02 \x01(\v2\x19.kratos.api.Remote.ApolloR\x06apollo\x12+\n" +
+	"\x04etcd\x18\x03 \x01(\v2\x17.kratos.api.Remote.EtcdR\x04etcd\x12.\n" +
+	"\x05vault\x18\x04 \x01(\v2\x18.kratos.api.Remote.VaultR\x05vault\x12=\n" +
+	"\n" +
+	"kubernetes\x18\x05 \x01(\v2\x1d.kratos.api.Remote.KubernetesR\n" +
+	"kubernetes\x12+\n" +
+	"\x04http\x18\x06 \x01(\v2\x17.kratos.api.Remote.HTTPR\x04http\x1a\xef\x01\n" +
+	"\x05Nacos\x12\x16\n" +
+	"\x06enable\x18\x01 \x01(\bR\x06enable\x12\x14\n" +
+	"\x05addrs\x18\x02 \x03(\tR\x05addrs\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\x12\x14\n" +
+	"\x05group\x18\x04 \x01(\tR\x05group\x12\x17\n" +
+	"\adata_id\x18\x05 \x01(\tR\x06dataId\x12\x1a\n" +
+	"\busername\x18\x06 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\a \x01(\tR\bpassword\x123\n" +
+	"\atimeout\x18\b \x01(\v2\x19.google.protobuf.DurationR\atimeout\x1a\xd2\x01\n" +
+	"\x06Apollo\x12\x16\n" +
+	"\x06enable\x18\x01 \x01(\bR\x06enable\x12\x12\n" +
+	"\x04addr\x18\x02 \x01(\tR\x04addr\x12\x15\n" +
+	"\x06app_id\x18\x03 \x01(\tR\x05appId\x12\x18\n" +
+	"\acluster\x18\x04 \x01(\tR\acluster\x12\x1e\n" +
+	"\n" +
+	"namespaces\x18\x05 \x03(\tR\n" +
+	"namespaces\x12\x16\n" +
+	"\x06secret\x18\x06 \x01(\tR\x06secret\x123\n" +
+	"\atimeout\x18\a \x01(\v2\x19.google.protobuf.DurationR\atimeout\x1a\x92\x02\n" +
+	"\x04Etcd\x12\x16\n" +
+	"\x06enable\x18\x01 \x01(\bR\x06enable\x12\x1c\n" +
+	"\tendpoints\x18\x02 \x03(\tR\tendpoints\x12\x16\n" +
+	"\x06prefix\x18\x03 \x01(\tR\x06prefix\x12\x1a\n" +
+	"\busername\x18\x04 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x05 \x01(\tR\bpassword\x123\n" +
+	"\atimeout\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x12\x17\n" +
+	"\aca_file\x18\a \x01(\tR\x06caFile\x12\x1b\n" +
+	"\tcert_file\x18\b \x01(\tR\bcertFile\x12\x19\n" +
+	"\bkey_file\x18\t \x01(\tR\akeyFile\x1a\xf3\x01\n" +
+	"\x05Vault\x12\x16\n" +
+	"\x06enable\x18\x01 \x01(\bR\x06enable\x12\x12\n" +
+	"\x04addr\x18\x02 \x01(\tR\x04addr\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\x12\x17\n" +
+	"\arole_id\x18\x04 \x01(\tR\x06roleId\x12\x1b\n" +
+	"\tsecret_id\x18\x05 \x01(\tR\bsecretId\x12\x1c\n" +
+	"\tnamespace\x18\x06 \x01(\tR\tnamespace\x123\n" +
+	"\atimeout\x18\a \x01(\v2\x19.google.protobuf.DurationR\atimeout\x12\x1f\n" +
+	"\vtransit_key\x18\b \x01(\tR\n" +
+	"transitKey\x1a\xe5\x02\n" +
+	"\x04HTTP\x12\x16\n" +
+	"\x06enable\x18\x01 \x01(\bR\x06enable\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x16\n" +
+	"\x06format\x18\x03 \x01(\tR\x06format\x12\x14\n" +
+	"\x05token\x18\x04 \x01(\tR\x05token\x12>\n" +
+	"\aheaders\x18\x05 \x03(\v2$.kratos.api.Remote.HTTP.HeadersEntryR\aheaders\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x06 \x01(\tR\tpublicKey\x125\n" +
+	"\binterval\x18\a \x01(\v2\x19.google.protobuf.DurationR\binterval\x123\n" +
+	"\atimeout\x18\b \x01(\v2\x19.google.protobuf.DurationR\atimeout\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a8\n" +
+	"\n" +
+	"Kubernetes\x12\x16\n" +
+	"\x06enable\x18\x01 \x01(\bR\x06enable\x12\x12\n" +
+	"\x04dirs\x18\x02 \x03(\tR\x04dirs\"\xd5\x12\n" +
 	"\x06Server\x12+\n" +
 	"\x04http\x18\x01 \x01(\v2\x17.kratos.api.Server.HTTPR\x04http\x12+\n" +
-	"\x04grpc\x18\x02 \x01(\v2\x17.kratos.api.Server.GRPCR\x04grpc\x1ai\n" +
+	"\x04grpc\x18\x02 \x01(\v2\x17.kratos.api.Server.GRPCR\x04grpc\x12.\n" +
+	"\x05debug\x18\x03 \x01(\v2\x18.kratos.api.Server.DebugR\x05debug\x12>\n" +
+	"\vapi_version\x18\x04 \x01(\v2\x1d.kratos.api.Server.APIVersionR\n" +
+	"apiVersion\x12\x1f\n" +
+	"\vhot_restart\x18\x05 \x01(\bR\n" +
+	"hotRestart\x12+\n" +
+	"\x04docs\x18\x06 \x01(\v2\x17.kratos.api.Server.DocsR\x04docs\x12:\n" +
+	"\toperation\x18\a \x01(\v2\x1c.kratos.api.Server.OperationR\toperation\x12:\n" +
+	"\tduplicate\x18\b \x01(\v2\x1c.kratos.api.Server.DuplicateR\tduplicate\x12.\n" +
+	"\x05admin\x18\t \x01(\v2\x18.kratos.api.Server.AdminR\x05admin\x124\n" +
+	"\ametrics\x18\n" +
+	" \x01(\v2\x1a.kratos.api.Server.MetricsR\ametrics\x12@\n" +
+	"\vdiagnostics\x18\v \x01(\v2\x1e.kratos.api.Server.DiagnosticsR\vdiagnostics\x124\n" +
+	"\amodules\x18\f \x01(\v2\x1a.kratos.api.Server.ModulesR\amodules\x127\n" +
+	"\bfeatures\x18\r \x01(\v2\x1b.kratos.api.Server.FeaturesR\bfeatures\x124\n" +
+	"\alicense\x18\x0e \x01(\v2\x1a.kratos.api.Server.LicenseR\alicense\x12G\n" +
+	"\x0econfig_history\x18\x0f \x01(\v2 .kratos.api.Server.ConfigHistoryR\rconfigHistory\x1ai\n" +
 	"\x04HTTP\x12\x18\n" +
 	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
 	"\x04addr\x18\x02 \x01(\tR\x04addr\x123\n" +
@@ -547,28 +4146,316 @@ const file_conf_conf_proto_rawDesc = "" +
 	"\x04GRPC\x12\x18\n" +
 	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
 	"\x04addr\x18\x02 \x01(\tR\x04addr\x123\n" +
-	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\"\xdd\x02\n" +
+	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x1a5\n" +
+	"\x05Debug\x12\x16\n" +
+	"\x06enable\x18\x01 \x01(\bR\x06enable\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\x1ad\n" +
+	"\n" +
+	"APIVersion\x12\x16\n" +
+	"\x06header\x18\x01 \x01(\tR\x06header\x12\x10\n" +
+	"\x03min\x18\x02 \x01(\tR\x03min\x12\x10\n" +
+	"\x03max\x18\x03 \x01(\tR\x03max\x12\x1a\n" +
+	"\brequired\x18\x04 \x01(\bR\brequired\x1aS\n" +
+	"\x04Docs\x12\x16\n" +
+	"\x06enable\x18\x01 \x01(\bR\x06enable\x12\x18\n" +
+	"\aopenapi\x18\x02 \x01(\tR\aopenapi\x12\x19\n" +
+	"\bbase_url\x18\x03 \x01(\tR\abaseUrl\x1aq\n" +
+	"\tOperation\x12\x18\n" +
+	"\aworkers\x18\x01 \x01(\x05R\aworkers\x12\x1d\n" +
+	"\n" +
+	"queue_size\x18\x02 \x01(\x05R\tqueueSize\x12+\n" +
+	"\x03ttl\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\x1al\n" +
+	"\tDuplicate\x12\x16\n" +
+	"\x06enable\x18\x01 \x01(\bR\x06enable\x121\n" +
+	"\x06window\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x06window\x12\x14\n" +
+	"\x05dedup\x18\x03 \x01(\bR\x05dedup\x1a\x1b\n" +
+	"\x05Admin\x12\x12\n" +
+	"\x04addr\x18\x01 \x01(\tR\x04addr\x1a1\n" +
+	"\aMetrics\x12\x12\n" +
+	"\x04addr\x18\x01 \x01(\tR\x04addr\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x1a\x8f\x01\n" +
+	"\vDiagnostics\x12\x16\n" +
+	"\x06enable\x18\x01 \x01(\bR\x06enable\x12\x1f\n" +
+	"\vsample_rate\x18\x02 \x01(\x01R\n" +
+	"sampleRate\x12\x10\n" +
+	"\x03top\x18\x03 \x01(\x05R\x03top\x125\n" +
+	"\binterval\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\binterval\x1a!\n" +
+	"\aModules\x12\x16\n" +
+	"\x06paused\x18\x01 \x03(\tR\x06paused\x1a\xc3\x01\n" +
+	"\bFeatures\x12-\n" +
+	"\x05flags\x18\x01 \x01(\v2\x17.google.protobuf.StructR\x05flags\x12\x1d\n" +
+	"\n" +
+	"remote_url\x18\x02 \x01(\tR\tremoteUrl\x12B\n" +
+	"\x0fremote_interval\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x0eremoteInterval\x12%\n" +
+	"\x0eallow_override\x18\x04 \x01(\bR\rallowOverride\x1a\xf9\x02\n" +
+	"\aLicense\x12\x16\n" +
+	"\x06enable\x18\x01 \x01(\bR\x06enable\x12\x12\n" +
+	"\x04file\x18\x02 \x01(\tR\x04file\x12\x1d\n" +
+	"\n" +
+	"server_url\x18\x03 \x01(\tR\tserverUrl\x12\x14\n" +
+	"\x05token\x18\x04 \x01(\tR\x05token\x125\n" +
+	"\binterval\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\binterval\x12/\n" +
+	"\x05grace\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\x05grace\x12\x1a\n" +
+	"\brequired\x18\a \x01(\bR\brequired\x12J\n" +
+	"\n" +
+	"operations\x18\b \x03(\v2*.kratos.api.Server.License.OperationsEntryR\n" +
+	"operations\x1a=\n" +
+	"\x0fOperationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a5\n" +
+	"\rConfigHistory\x12\x12\n" +
+	"\x04size\x18\x01 \x01(\x05R\x04size\x12\x10\n" +
+	"\x03dir\x18\x02 \x01(\tR\x03dir\"\x9c\x1e\n" +
 	"\x04Data\x125\n" +
 	"\bdatabase\x18\x01 \x01(\v2\x19.kratos.api.Data.DatabaseR\bdatabase\x12,\n" +
-	"\x05redis\x18\x02 \x01(\v2\x16.kratos.api.Data.RedisR\x05redis\x1a:\n" +
+	"\x05redis\x18\x02 \x01(\v2\x16.kratos.api.Data.RedisR\x05redis\x125\n" +
+	"\bembedded\x18\x03 \x01(\v2\x19.kratos.api.Data.EmbeddedR\bembedded\x12=\n" +
+	"\tdatabases\x18\x04 \x03(\v2\x1f.kratos.api.Data.DatabasesEntryR\tdatabases\x12M\n" +
+	"\x0fredis_instances\x18\x05 \x03(\v2$.kratos.api.Data.RedisInstancesEntryR\x0eredisInstances\x12,\n" +
+	"\x05mongo\x18\x06 \x01(\v2\x16.kratos.api.Data.MongoR\x05mongo\x12;\n" +
+	"\n" +
+	"clickhouse\x18\a \x01(\v2\x1b.kratos.api.Data.ClickHouseR\n" +
+	"clickhouse\x12/\n" +
+	"\x06outbox\x18\b \x01(\v2\x17.kratos.api.Data.OutboxR\x06outbox\x122\n" +
+	"\atenancy\x18\t \x01(\v2\x18.kratos.api.Data.TenancyR\atenancy\x1a\x8f\x05\n" +
 	"\bDatabase\x12\x16\n" +
 	"\x06driver\x18\x01 \x01(\tR\x06driver\x12\x16\n" +
-	"\x06source\x18\x02 \x01(\tR\x06source\x1a\xb3\x01\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12!\n" +
+	"\fauto_migrate\x18\x03 \x01(\bR\vautoMigrate\x12K\n" +
+	"\x14migrate_lock_timeout\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\x12migrateLockTimeout\x12H\n" +
+	"\fschema_check\x18\x05 \x01(\v2%.kratos.api.Data.Database.SchemaCheckR\vschemaCheck\x12$\n" +
+	"\x0emax_open_conns\x18\x06 \x01(\x05R\fmaxOpenConns\x12$\n" +
+	"\x0emax_idle_conns\x18\a \x01(\x05R\fmaxIdleConns\x12E\n" +
+	"\x11conn_max_lifetime\x18\b \x01(\v2\x19.google.protobuf.DurationR\x0fconnMaxLifetime\x12F\n" +
+	"\x12conn_max_idle_time\x18\t \x01(\v2\x19.google.protobuf.DurationR\x0fconnMaxIdleTime\x12\x1a\n" +
+	"\breplicas\x18\n" +
+	" \x03(\tR\breplicas\x12@\n" +
+	"\x0eslow_threshold\x18\v \x01(\v2\x19.google.protobuf.DurationR\rslowThreshold\x12%\n" +
+	"\x0erun_migrations\x18\f \x01(\bR\rrunMigrations\x1a9\n" +
+	"\vSchemaCheck\x12\x16\n" +
+	"\x06enable\x18\x01 \x01(\bR\x06enable\x12\x12\n" +
+	"\x04fail\x18\x02 \x01(\bR\x04fail\x1a\x8a\x06\n" +
 	"\x05Redis\x12\x18\n" +
 	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
 	"\x04addr\x18\x02 \x01(\tR\x04addr\x12<\n" +
 	"\fread_timeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\vreadTimeout\x12>\n" +
-	"\rwrite_timeout\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\fwriteTimeout\"\xda\x01\n" +
+	"\rwrite_timeout\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\fwriteTimeout\x12\x14\n" +
+	"\x05addrs\x18\x05 \x03(\tR\x05addrs\x12\x1a\n" +
+	"\bpassword\x18\x06 \x01(\tR\bpassword\x12\x0e\n" +
+	"\x02db\x18\a \x01(\x05R\x02db\x12\x1a\n" +
+	"\busername\x18\b \x01(\tR\busername\x12<\n" +
+	"\fdial_timeout\x18\t \x01(\v2\x19.google.protobuf.DurationR\vdialTimeout\x12\x1b\n" +
+	"\tpool_size\x18\n" +
+	" \x01(\x05R\bpoolSize\x12$\n" +
+	"\x0emin_idle_conns\x18\v \x01(\x05R\fminIdleConns\x12<\n" +
+	"\fpool_timeout\x18\f \x01(\v2\x19.google.protobuf.DurationR\vpoolTimeout\x12F\n" +
+	"\x12conn_max_idle_time\x18\r \x01(\v2\x19.google.protobuf.DurationR\x0fconnMaxIdleTime\x12,\n" +
+	"\x03tls\x18\x0e \x01(\v2\x1a.kratos.api.Data.Redis.TLSR\x03tls\x1a\xc1\x01\n" +
+	"\x03TLS\x12\x16\n" +
+	"\x06enable\x18\x01 \x01(\bR\x06enable\x12\x17\n" +
+	"\aca_file\x18\x02 \x01(\tR\x06caFile\x12\x1b\n" +
+	"\tcert_file\x18\x03 \x01(\tR\bcertFile\x12\x19\n" +
+	"\bkey_file\x18\x04 \x01(\tR\akeyFile\x12\x1f\n" +
+	"\vserver_name\x18\x05 \x01(\tR\n" +
+	"serverName\x120\n" +
+	"\x14insecure_skip_verify\x18\x06 \x01(\bR\x12insecureSkipVerify\x1ag\n" +
+	"\bEmbedded\x12\x16\n" +
+	"\x06enable\x18\x01 \x01(\bR\x06enable\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12/\n" +
+	"\x06backup\x18\x03 \x01(\v2\x17.kratos.api.Log.ArchiveR\x06backup\x1aW\n" +
+	"\x0eDatabasesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12/\n" +
+	"\x05value\x18\x02 \x01(\v2\x19.kratos.api.Data.DatabaseR\x05value:\x028\x01\x1aY\n" +
+	"\x13RedisInstancesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12,\n" +
+	"\x05value\x18\x02 \x01(\v2\x16.kratos.api.Data.RedisR\x05value:\x028\x01\x1a\xea\x01\n" +
+	"\x05Mongo\x12\x16\n" +
+	"\x06enable\x18\x01 \x01(\bR\x06enable\x12\x10\n" +
+	"\x03uri\x18\x02 \x01(\tR\x03uri\x12\x1a\n" +
+	"\bdatabase\x18\x03 \x01(\tR\bdatabase\x123\n" +
+	"\atimeout\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x12B\n" +
+	"\x0fconnect_timeout\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\x0econnectTimeout\x12\"\n" +
+	"\rmax_pool_size\x18\x06 \x01(\x04R\vmaxPoolSize\x1a\xec\x04\n" +
+	"\n" +
+	"ClickHouse\x12\x16\n" +
+	"\x06enable\x18\x01 \x01(\bR\x06enable\x12\x14\n" +
+	"\x05addrs\x18\x02 \x03(\tR\x05addrs\x12\x1a\n" +
+	"\bdatabase\x18\x03 \x01(\tR\bdatabase\x12\x1a\n" +
+	"\busername\x18\x04 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x05 \x01(\tR\bpassword\x12<\n" +
+	"\fdial_timeout\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\vdialTimeout\x12<\n" +
+	"\fread_timeout\x18\a \x01(\v2\x19.google.protobuf.DurationR\vreadTimeout\x12$\n" +
+	"\x0emax_open_conns\x18\b \x01(\x05R\fmaxOpenConns\x12$\n" +
+	"\x0emax_idle_conns\x18\t \x01(\x05R\fmaxIdleConns\x12E\n" +
+	"\x11conn_max_lifetime\x18\n" +
+	" \x01(\v2\x19.google.protobuf.DurationR\x0fconnMaxLifetime\x12!\n" +
+	"\fasync_insert\x18\v \x01(\bR\vasyncInsert\x121\n" +
+	"\x15wait_for_async_insert\x18\f \x01(\bR\x12waitForAsyncInsert\x12T\n" +
+	"\x19async_insert_busy_timeout\x18\r \x01(\v2\x19.google.protobuf.DurationR\x16asyncInsertBusyTimeout\x12!\n" +
+	"\fauto_migrate\x18\x0e \x01(\bR\vautoMigrate\x1a\x83\x02\n" +
+	"\x06Outbox\x12\x16\n" +
+	"\x06enable\x18\x01 \x01(\bR\x06enable\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x02 \x01(\x05R\tbatchSize\x125\n" +
+	"\binterval\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\binterval\x12!\n" +
+	"\fmax_attempts\x18\x04 \x01(\x05R\vmaxAttempts\x12/\n" +
+	"\x05lease\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\x05lease\x127\n" +
+	"\tretention\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\tretention\x1a\xfb\x03\n" +
+	"\aTenancy\x12\x16\n" +
+	"\x06enable\x18\x01 \x01(\bR\x06enable\x12\x16\n" +
+	"\x06header\x18\x02 \x01(\tR\x06header\x12\x1a\n" +
+	"\brequired\x18\x03 \x01(\bR\brequired\x12\x16\n" +
+	"\x06source\x18\x04 \x01(\tR\x06source\x12\x16\n" +
+	"\x06schema\x18\x05 \x01(\tR\x06schema\x12?\n" +
+	"\atenants\x18\x06 \x03(\v2%.kratos.api.Data.Tenancy.TenantsEntryR\atenants\x12\x16\n" +
+	"\x06strict\x18\a \x01(\bR\x06strict\x12\x1f\n" +
+	"\vmax_tenants\x18\b \x01(\x05R\n" +
+	"maxTenants\x12<\n" +
+	"\fidle_timeout\x18\t \x01(\v2\x19.google.protobuf.DurationR\vidleTimeout\x12%\n" +
+	"\x0erun_migrations\x18\n" +
+	" \x01(\bR\rrunMigrations\x1a8\n" +
+	"\x06Tenant\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\x16\n" +
+	"\x06schema\x18\x02 \x01(\tR\x06schema\x1a[\n" +
+	"\fTenantsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x125\n" +
+	"\x05value\x18\x02 \x01(\v2\x1f.kratos.api.Data.Tenancy.TenantR\x05value:\x028\x01\"\xb1\x1c\n" +
 	"\x03Log\x12\x14\n" +
 	"\x05level\x18\x01 \x01(\tR\x05level\x12\x1a\n" +
-	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x19\n" +
-	"\bmax_size\x18\x03 \x01(\x05R\amaxSize\x12\x17\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x1f\n" +
+	"\bmax_size\x18\x03 \x01(\x05B\x04\xa0\xbb\x18\x03R\amaxSize\x12\x17\n" +
 	"\amax_age\x18\x04 \x01(\x05R\x06maxAge\x12\x1f\n" +
 	"\vmax_backups\x18\x05 \x01(\x05R\n" +
 	"maxBackups\x12\x1a\n" +
 	"\bcompress\x18\x06 \x01(\bR\bcompress\x12\x18\n" +
 	"\aconsole\x18\a \x01(\bR\aconsole\x12\x16\n" +
-	"\x06format\x18\b \x01(\tR\x06formatB\x1fZ\x1d{{cookiecutter.module_name}}/internal/conf;confb\x06proto3"
+	"\x06format\x18\b \x01(\tR\x06format\x12<\n" +
+	"\fdedup_window\x18\t \x01(\v2\x19.google.protobuf.DurationR\vdedupWindow\x12\x1f\n" +
+	"\vdedup_level\x18\n" +
+	" \x01(\tR\n" +
+	"dedupLevel\x121\n" +
+	"\aarchive\x18\v \x01(\v2\x17.kratos.api.Log.ArchiveR\aarchive\x12(\n" +
+	"\x04otlp\x18\f \x01(\v2\x14.kratos.api.Log.OTLPR\x04otlp\x12.\n" +
+	"\x06access\x18\r \x01(\v2\x16.kratos.api.Log.AccessR\x06access\x12#\n" +
+	"\rmulti_process\x18\x0e \x01(\tR\fmultiProcess\x12(\n" +
+	"\x04slow\x18\x0f \x01(\v2\x14.kratos.api.Log.SlowR\x04slow\x12+\n" +
+	"\x05audit\x18\x10 \x01(\v2\x15.kratos.api.Log.AuditR\x05audit\x12\x1e\n" +
+	"\n" +
+	"stacktrace\x18\x11 \x01(\bR\n" +
+	"stacktrace\x12\x1f\n" +
+	"\vtime_format\x18\x12 \x01(\tR\n" +
+	"timeFormat\x12\x1b\n" +
+	"\ttime_zone\x18\x13 \x01(\tR\btimeZone\x12(\n" +
+	"\x10error_rate_limit\x18\x14 \x01(\x05R\x0eerrorRateLimit\x12\x1f\n" +
+	"\verror_burst\x18\x15 \x01(\x05R\n" +
+	"errorBurst\x12.\n" +
+	"\x06fluent\x18\x16 \x01(\v2\x16.kratos.api.Log.FluentR\x06fluent\x12\x1b\n" +
+	"\tfile_mode\x18\x17 \x01(\tR\bfileMode\x12\x19\n" +
+	"\bdir_mode\x18\x18 \x01(\tR\adirMode\x12\x10\n" +
+	"\x03uid\x18\x19 \x01(\x05R\x03uid\x12\x10\n" +
+	"\x03gid\x18\x1a \x01(\x05R\x03gid\x12+\n" +
+	"\x05alert\x18\x1b \x01(\v2\x15.kratos.api.Log.AlertR\x05alert\x12.\n" +
+	"\x06sentry\x18\x1c \x01(\v2\x16.kratos.api.Log.SentryR\x06sentry\x12@\n" +
+	"\x0efailover_retry\x18\x1d \x01(\v2\x19.google.protobuf.DurationR\rfailoverRetry\x12 \n" +
+	"\venvironment\x18\x1e \x01(\tR\venvironment\x12.\n" +
+	"\x06buffer\x18\x1f \x01(\v2\x16.kratos.api.Log.BufferR\x06buffer\x124\n" +
+	"\bjournald\x18  \x01(\v2\x18.kratos.api.Log.JournaldR\bjournald\x12+\n" +
+	"\x05spool\x18! \x01(\v2\x15.kratos.api.Log.SpoolR\x05spool\x12\x1b\n" +
+	"\tdaily_dir\x18\" \x01(\bR\bdailyDir\x1a\x92\x02\n" +
+	"\aArchive\x12\x16\n" +
+	"\x06enable\x18\x01 \x01(\bR\x06enable\x12\x1a\n" +
+	"\bendpoint\x18\x02 \x01(\tR\bendpoint\x12\x16\n" +
+	"\x06bucket\x18\x03 \x01(\tR\x06bucket\x12\x1d\n" +
+	"\n" +
+	"access_key\x18\x04 \x01(\tR\taccessKey\x12\x1d\n" +
+	"\n" +
+	"secret_key\x18\x05 \x01(\tR\tsecretKey\x12\x16\n" +
+	"\x06region\x18\x06 \x01(\tR\x06region\x12\x16\n" +
+	"\x06secure\x18\a \x01(\bR\x06secure\x12\x16\n" +
+	"\x06prefix\x18\b \x01(\tR\x06prefix\x125\n" +
+	"\binterval\x18\t \x01(\v2\x19.google.protobuf.DurationR\binterval\x1a\x89\x03\n" +
+	"\x04OTLP\x12\x16\n" +
+	"\x06enable\x18\x01 \x01(\bR\x06enable\x12\x1a\n" +
+	"\bendpoint\x18\x02 \x01(\tR\bendpoint\x12\x1a\n" +
+	"\binsecure\x18\x03 \x01(\bR\binsecure\x123\n" +
+	"\atimeout\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x12;\n" +
+	"\aheaders\x18\x05 \x03(\v2!.kratos.api.Log.OTLP.HeadersEntryR\aheaders\x12D\n" +
+	"\n" +
+	"attributes\x18\x06 \x03(\v2$.kratos.api.Log.OTLP.AttributesEntryR\n" +
+	"attributes\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a\xd7\x01\n" +
+	"\x06Access\x12\x16\n" +
+	"\x06enable\x18\x01 \x01(\bR\x06enable\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12!\n" +
+	"\fcapture_body\x18\x03 \x01(\bR\vcaptureBody\x12(\n" +
+	"\rmax_body_size\x18\x04 \x01(\x05B\x04\xa0\xbb\x18\x01R\vmaxBodySize\x12#\n" +
+	"\rcontent_types\x18\x05 \x03(\tR\fcontentTypes\x12'\n" +
+	"\x0ftrusted_proxies\x18\x06 \x03(\tR\x0etrustedProxies\x1a\x84\x03\n" +
+	"\x04Slow\x12\x16\n" +
+	"\x06enable\x18\x01 \x01(\bR\x06enable\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x127\n" +
+	"\tthreshold\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\tthreshold\x12>\n" +
+	"\rsql_threshold\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\fsqlThreshold\x126\n" +
+	"\aexplain\x18\x05 \x01(\v2\x1c.kratos.api.Log.Slow.ExplainR\aexplain\x1a\x96\x01\n" +
+	"\aExplain\x12\x16\n" +
+	"\x06enable\x18\x01 \x01(\bR\x06enable\x12\x1d\n" +
+	"\n" +
+	"rate_limit\x18\x02 \x01(\x05R\trateLimit\x123\n" +
+	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x12\x1f\n" +
+	"\bmax_size\x18\x04 \x01(\x05B\x04\xa0\xbb\x18\x01R\amaxSize\x1a\x9c\x01\n" +
+	"\x05Audit\x12\x16\n" +
+	"\x06enable\x18\x01 \x01(\bR\x06enable\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x1d\n" +
+	"\n" +
+	"remote_url\x18\x03 \x01(\tR\tremoteUrl\x12@\n" +
+	"\x0eremote_timeout\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\rremoteTimeout\x1a\xc2\x01\n" +
+	"\x05Alert\x12\x16\n" +
+	"\x06enable\x18\x01 \x01(\bR\x06enable\x12\x1f\n" +
+	"\vwebhook_url\x18\x02 \x01(\tR\n" +
+	"webhookUrl\x12\x16\n" +
+	"\x06format\x18\x03 \x01(\tR\x06format\x12\x14\n" +
+	"\x05level\x18\x04 \x01(\tR\x05level\x12\x1d\n" +
+	"\n" +
+	"rate_limit\x18\x05 \x01(\x05R\trateLimit\x123\n" +
+	"\atimeout\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x1a\xa5\x01\n" +
+	"\x06Sentry\x12\x16\n" +
+	"\x06enable\x18\x01 \x01(\bR\x06enable\x12\x10\n" +
+	"\x03dsn\x18\x02 \x01(\tR\x03dsn\x12 \n" +
+	"\venvironment\x18\x03 \x01(\tR\venvironment\x12\x18\n" +
+	"\arelease\x18\x04 \x01(\tR\arelease\x12\x1f\n" +
+	"\vsample_rate\x18\x05 \x01(\x01R\n" +
+	"sampleRate\x12\x14\n" +
+	"\x05level\x18\x06 \x01(\tR\x05level\x1a\xc3\x01\n" +
+	"\x06Fluent\x12\x16\n" +
+	"\x06enable\x18\x01 \x01(\bR\x06enable\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x10\n" +
+	"\x03tag\x18\x03 \x01(\tR\x03tag\x12\x1f\n" +
+	"\vrequire_ack\x18\x04 \x01(\bR\n" +
+	"requireAck\x123\n" +
+	"\atimeout\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x12\x1f\n" +
+	"\vbuffer_size\x18\x06 \x01(\x05R\n" +
+	"bufferSize\x1a\x95\x01\n" +
+	"\x06Buffer\x12\x16\n" +
+	"\x06enable\x18\x01 \x01(\bR\x06enable\x12\x14\n" +
+	"\x05level\x18\x02 \x01(\tR\x05level\x12@\n" +
+	"\x0elatency_budget\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\rlatencyBudget\x12\x1b\n" +
+	"\tmax_lines\x18\x04 \x01(\x05R\bmaxLines\x1aX\n" +
+	"\bJournald\x12\x16\n" +
+	"\x06enable\x18\x01 \x01(\bR\x06enable\x12\x1e\n" +
+	"\n" +
+	"identifier\x18\x02 \x01(\tR\n" +
+	"identifier\x12\x14\n" +
+	"\x05level\x18\x03 \x01(\tR\x05level\x1a{\n" +
+	"\x05Spool\x12\x16\n" +
+	"\x06enable\x18\x01 \x01(\bR\x06enable\x12\x10\n" +
+	"\x03dir\x18\x02 \x01(\tR\x03dir\x12\x1f\n" +
+	"\bmax_size\x18\x03 \x01(\x05B\x04\xa0\xbb\x18\x03R\amaxSize\x12'\n" +
+	"\fsegment_size\x18\x04 \x01(\x05B\x04\xa0\xbb\x18\x03R\vsegmentSizeb\x06proto3"
 
 var (
 	file_conf_conf_proto_rawDescOnce sync.Once
@@ -582,35 +4469,172 @@ func file_conf_conf_proto_rawDescGZIP() []byte {
 	return file_conf_conf_proto_rawDescData
 }
 
-var file_conf_conf_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_conf_conf_proto_msgTypes = make([]protoimpl.MessageInfo, 54)
 var file_conf_conf_proto_goTypes = []any{
-	(*Bootstrap)(nil),           // 0: kratos.api.Bootstrap
-	(*Server)(nil),              // 1: kratos.api.Server
-	(*Data)(nil),                // 2: kratos.api.Data
-	(*Log)(nil),                 // 3: kratos.api.Log
-	(*Server_HTTP)(nil),         // 4: kratos.api.Server.HTTP
-	(*Server_GRPC)(nil),         // 5: kratos.api.Server.GRPC
-	(*Data_Database)(nil),       // 6: kratos.api.Data.Database
-	(*Data_Redis)(nil),          // 7: kratos.api.Data.Redis
-	(*durationpb.Duration)(nil), // 8: google.protobuf.Duration
+	(*Bootstrap)(nil),                 // 0: kratos.api.Bootstrap
+	(*Remote)(nil),                    // 1: kratos.api.Remote
+	(*Server)(nil),                    // 2: kratos.api.Server
+	(*Data)(nil),                      // 3: kratos.api.Data
+	(*Log)(nil),                       // 4: kratos.api.Log
+	(*Remote_Nacos)(nil),              // 5: kratos.api.Remote.Nacos
+	(*Remote_Apollo)(nil),             // 6: kratos.api.Remote.Apollo
+	(*Remote_Etcd)(nil),               // 7: kratos.api.Remote.Etcd
+	(*Remote_Vault)(nil),              // 8: kratos.api.Remote.Vault
+	(*Remote_HTTP)(nil),               // 9: kratos.api.Remote.HTTP
+	(*Remote_Kubernetes)(nil),         // 10: kratos.api.Remote.Kubernetes
+	nil,                               // 11: kratos.api.Remote.HTTP.HeadersEntry
+	(*Server_HTTP)(nil),               // 12: kratos.api.Server.HTTP
+	(*Server_GRPC)(nil),               // 13: kratos.api.Server.GRPC
+	(*Server_Debug)(nil),              // 14: kratos.api.Server.Debug
+	(*Server_APIVersion)(nil),         // 15: kratos.api.Server.APIVersion
+	(*Server_Docs)(nil),               // 16: kratos.api.Server.Docs
+	(*Server_Operation)(nil),          // 17: kratos.api.Server.Operation
+	(*Server_Duplicate)(nil),          // 18: kratos.api.Server.Duplicate
+	(*Server_Admin)(nil),              // 19: kratos.api.Server.Admin
+	(*Server_Metrics)(nil),            // 20: kratos.api.Server.Metrics
+	(*Server_Diagnostics)(nil),        // 21: kratos.api.Server.Diagnostics
+	(*Server_Modules)(nil),            // 22: kratos.api.Server.Modules
+	(*Server_Features)(nil),           // 23: kratos.api.Server.Features
+	(*Server_License)(nil),            // 24: kratos.api.Server.License
+	(*Server_ConfigHistory)(nil),      // 25: kratos.api.Server.ConfigHistory
+	nil,                               // 26: kratos.api.Server.License.OperationsEntry
+	(*Data_Database)(nil),             // 27: kratos.api.Data.Database
+	(*Data_Redis)(nil),                // 28: kratos.api.Data.Redis
+	(*Data_Embedded)(nil),             // 29: kratos.api.Data.Embedded
+	nil,                               // 30: kratos.api.Data.DatabasesEntry
+	nil,                               // 31: kratos.api.Data.RedisInstancesEntry
+	(*Data_Mongo)(nil),                // 32: kratos.api.Data.Mongo
+	(*Data_ClickHouse)(nil),           // 33: kratos.api.Data.ClickHouse
+	(*Data_Outbox)(nil),               // 34: kratos.api.Data.Outbox
+	(*Data_Tenancy)(nil),              // 35: kratos.api.Data.Tenancy
+	(*Data_Database_SchemaCheck)(nil), // 36: kratos.api.Data.Database.SchemaCheck
+	(*Data_Redis_TLS)(nil),            // 37: kratos.api.Data.Redis.TLS
+	(*Data_Tenancy_Tenant)(nil),       // 38: kratos.api.Data.Tenancy.Tenant
+	nil,                               // 39: kratos.api.Data.Tenancy.TenantsEntry
+	(*Log_Archive)(nil),               // 40: kratos.api.Log.Archive
+	(*Log_OTLP)(nil),                  // 41: kratos.api.Log.OTLP
+	(*Log_Access)(nil),                // 42: kratos.api.Log.Access
+	(*Log_Slow)(nil),                  // 43: kratos.api.Log.Slow
+	(*Log_Audit)(nil),                 // 44: kratos.api.Log.Audit
+	(*Log_Alert)(nil),                 // 45: kratos.api.Log.Alert
+	(*Log_Sentry)(nil),                // 46: kratos.api.Log.Sentry
+	(*Log_Fluent)(nil),                // 47: kratos.api.Log.Fluent
+	(*Log_Buffer)(nil),                // 48: kratos.api.Log.Buffer
+	(*Log_Journald)(nil),              // 49: kratos.api.Log.Journald
+	(*Log_Spool)(nil),                 // 50: kratos.api.Log.Spool
+	nil,                               // 51: kratos.api.Log.OTLP.HeadersEntry
+	nil,                               // 52: kratos.api.Log.OTLP.AttributesEntry
+	(*Log_Slow_Explain)(nil),          // 53: kratos.api.Log.Slow.Explain
+	(*durationpb.Duration)(nil),       // 54: google.protobuf.Duration
+	(*structpb.Struct)(nil),           // 55: google.protobuf.Struct
 }
 var file_conf_conf_proto_depIdxs = []int32{
-	1,  // 0: kratos.api.Bootstrap.server:type_name -> kratos.api.Server
-	2,  // 1: kratos.api.Bootstrap.data:type_name -> kratos.api.Data
-	3,  // 2: kratos.api.Bootstrap.log:type_name -> kratos.api.Log
-	4,  // 3: kratos.api.Server.http:type_name -> kratos.api.Server.HTTP
-	5,  // 4: kratos.api.Server.grpc:type_name -> kratos.api.Server.GRPC
-	6,  // 5: kratos.api.Data.database:type_name -> kratos.api.Data.Database
-	7,  // 6: kratos.api.Data.redis:type_name -> kratos.api.Data.Redis
-	8,  // 7: kratos.api.Server.HTTP.timeout:type_name -> google.protobuf.Duration
-	8,  // 8: kratos.api.Server.GRPC.timeout:type_name -> google.protobuf.Duration
-	8,  // 9: kratos.api.Data.Redis.read_timeout:type_name -> google.protobuf.Duration
-	8,  // 10: kratos.api.Data.Redis.write_timeout:type_name -> google.protobuf.Duration
-	11, // [11:11] is the sub-list for method output_type
-	11, // [11:11] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	2,   // 0: kratos.api.Bootstrap.server:type_name -> kratos.api.Server
+	3,   // 1: kratos.api.Bootstrap.data:type_name -> kratos.api.Data
+	4,   // 2: kratos.api.Bootstrap.log:type_name -> kratos.api.Log
+	1,   // 3: kratos.api.Bootstrap.remote:type_name -> kratos.api.Remote
+	5,   // 4: kratos.api.Remote.nacos:type_name -> kratos.api.Remote.Nacos
+	6,   // 5: kratos.api.Remote.apollo:type_name -> kratos.api.Remote.Apollo
+	7,   // 6: kratos.api.Remote.etcd:type_name -> kratos.api.Remote.Etcd
+	8,   // 7: kratos.api.Remote.vault:type_name -> kratos.api.Remote.Vault
+	10,  // 8: kratos.api.Remote.kubernetes:type_name -> kratos.api.Remote.Kubernetes
+	9,   // 9: kratos.api.Remote.http:type_name -> kratos.api.Remote.HTTP
+	12,  // 10: kratos.api.Server.http:type_name -> kratos.api.Server.HTTP
+	13,  // 11: kratos.api.Server.grpc:type_name -> kratos.api.Server.GRPC
+	14,  // 12: kratos.api.Server.debug:type_name -> kratos.api.Server.Debug
+	15,  // 13: kratos.api.Server.api_version:type_name -> kratos.api.Server.APIVersion
+	16,  // 14: kratos.api.Server.docs:type_name -> kratos.api.Server.Docs
+	17,  // 15: kratos.api.Server.operation:type_name -> kratos.api.Server.Operation
+	18,  // 16: kratos.api.Server.duplicate:type_name -> kratos.api.Server.Duplicate
+	19,  // 17: kratos.api.Server.admin:type_name -> kratos.api.Server.Admin
+	20,  // 18: kratos.api.Server.metrics:type_name -> kratos.api.Server.Metrics
+	21,  // 19: kratos.api.Server.diagnostics:type_name -> kratos.api.Server.Diagnostics
+	22,  // 20: kratos.api.Server.modules:type_name -> kratos.api.Server.Modules
+	23,  // 21: kratos.api.Server.features:type_name -> kratos.api.Server.Features
+	24,  // 22: kratos.api.Server.license:type_name -> kratos.api.Server.License
+	25,  // 23: kratos.api.Server.config_history:type_name -> kratos.api.Server.ConfigHistory
+	27,  // 24: kratos.api.Data.database:type_name -> kratos.api.Data.Database
+	28,  // 25: kratos.api.Data.redis:type_name -> kratos.api.Data.Redis
+	29,  // 26: kratos.api.Data.embedded:type_name -> kratos.api.Data.Embedded
+	30,  // 27: kratos.api.Data.databases:type_name -> kratos.api.Data.DatabasesEntry
+	31,  // 28: kratos.api.Data.redis_instances:type_name -> kratos.api.Data.RedisInstancesEntry
+	32,  // 29: kratos.api.Data.mongo:type_name -> kratos.api.Data.Mongo
+	33,  // 30: kratos.api.Data.clickhouse:type_name -> kratos.api.Data.ClickHouse
+	34,  // 31: kratos.api.Data.outbox:type_name -> kratos.api.Data.Outbox
+	35,  // 32: kratos.api.Data.tenancy:type_name -> kratos.api.Data.Tenancy
+	54,  // 33: kratos.api.Log.dedup_window:type_name -> google.protobuf.Duration
+	40,  // 34: kratos.api.Log.archive:type_name -> kratos.api.Log.Archive
+	41,  // 35: kratos.api.Log.otlp:type_name -> kratos.api.Log.OTLP
+	42,  // 36: kratos.api.Log.access:type_name -> kratos.api.Log.Access
+	43,  // 37: kratos.api.Log.slow:type_name -> kratos.api.Log.Slow
+	44,  // 38: kratos.api.Log.audit:type_name -> kratos.api.Log.Audit
+	47,  // 39: kratos.api.Log.fluent:type_name -> kratos.api.Log.Fluent
+	45,  // 40: kratos.api.Log.alert:type_name -> kratos.api.Log.Alert
+	46,  // 41: kratos.api.Log.sentry:type_name -> kratos.api.Log.Sentry
+	54,  // 42: kratos.api.Log.failover_retry:type_name -> google.protobuf.Duration
+	48,  // 43: kratos.api.Log.buffer:type_name -> kratos.api.Log.Buffer
+	49,  // 44: kratos.api.Log.journald:type_name -> kratos.api.Log.Journald
+	50,  // 45: kratos.api.Log.spool:type_name -> kratos.api.Log.Spool
+	54,  // 46: kratos.api.Remote.Nacos.timeout:type_name -> google.protobuf.Duration
+	54,  // 47: kratos.api.Remote.Apollo.timeout:type_name -> google.protobuf.Duration
+	54,  // 48: kratos.api.Remote.Etcd.timeout:type_name -> google.protobuf.Duration
+	54,  // 49: kratos.api.Remote.Vault.timeout:type_name -> google.protobuf.Duration
+	11,  // 50: kratos.api.Remote.HTTP.headers:type_name -> kratos.api.Remote.HTTP.HeadersEntry
+	54,  // 51: kratos.api.Remote.HTTP.interval:type_name -> google.protobuf.Duration
+	54,  // 52: kratos.api.Remote.HTTP.timeout:type_name -> google.protobuf.Duration
+	54,  // 53: kratos.api.Server.HTTP.timeout:type_name -> google.protobuf.Duration
+	54,  // 54: kratos.api.Server.GRPC.timeout:type_name -> google.protobuf.Duration
+	54,  // 55: kratos.api.Server.Operation.ttl:type_name -> google.protobuf.Duration
+	54,  // 56: kratos.api.Server.Duplicate.window:type_name -> google.protobuf.Duration
+	54,  // 57: kratos.api.Server.Diagnostics.interval:type_name -> google.protobuf.Duration
+	55,  // 58: kratos.api.Server.Features.flags:type_name -> google.protobuf.Struct
+	54,  // 59: kratos.api.Server.Features.remote_interval:type_name -> google.protobuf.Duration
+	54,  // 60: kratos.api.Server.License.interval:type_name -> google.protobuf.Duration
+	54,  // 61: kratos.api.Server.License.grace:type_name -> google.protobuf.Duration
+	26,  // 62: kratos.api.Server.License.operations:type_name -> kratos.api.Server.License.OperationsEntry
+	54,  // 63: kratos.api.Data.Database.migrate_lock_timeout:type_name -> google.protobuf.Duration
+	36,  // 64: kratos.api.Data.Database.schema_check:type_name -> kratos.api.Data.Database.SchemaCheck
+	54,  // 65: kratos.api.Data.Database.conn_max_lifetime:type_name -> google.protobuf.Duration
+	54,  // 66: kratos.api.Data.Database.conn_max_idle_time:type_name -> google.protobuf.Duration
+	54,  // 67: kratos.api.Data.Database.slow_threshold:type_name -> google.protobuf.Duration
+	54,  // 68: kratos.api.Data.Redis.read_timeout:type_name -> google.protobuf.Duration
+	54,  // 69: kratos.api.Data.Redis.write_timeout:type_name -> google.protobuf.Duration
+	54,  // 70: kratos.api.Data.Redis.dial_timeout:type_name -> google.protobuf.Duration
+	54,  // 71: kratos.api.Data.Redis.pool_timeout:type_name -> google.protobuf.Duration
+	54,  // 72: kratos.api.Data.Redis.conn_max_idle_time:type_name -> google.protobuf.Duration
+	37,  // 73: kratos.api.Data.Redis.tls:type_name -> kratos.api.Data.Redis.TLS
+	40,  // 74: kratos.api.Data.Embedded.backup:type_name -> kratos.api.Log.Archive
+	27,  // 75: kratos.api.Data.DatabasesEntry.value:type_name -> kratos.api.Data.Database
+	28,  // 76: kratos.api.Data.RedisInstancesEntry.value:type_name -> kratos.api.Data.Redis
+	54,  // 77: kratos.api.Data.Mongo.timeout:type_name -> google.protobuf.Duration
+	54,  // 78: kratos.api.Data.Mongo.connect_timeout:type_name -> google.protobuf.Duration
+	54,  // 79: kratos.api.Data.ClickHouse.dial_timeout:type_name -> google.protobuf.Duration
+	54,  // 80: kratos.api.Data.ClickHouse.read_timeout:type_name -> google.protobuf.Duration
+	54,  // 81: kratos.api.Data.ClickHouse.conn_max_lifetime:type_name -> google.protobuf.Duration
+	54,  // 82: kratos.api.Data.ClickHouse.async_insert_busy_timeout:type_name -> google.protobuf.Duration
+	54,  // 83: kratos.api.Data.Outbox.interval:type_name -> google.protobuf.Duration
+	54,  // 84: kratos.api.Data.Outbox.lease:type_name -> google.protobuf.Duration
+	54,  // 85: kratos.api.Data.Outbox.retention:type_name -> google.protobuf.Duration
+	39,  // 86: kratos.api.Data.Tenancy.tenants:type_name -> kratos.api.Data.Tenancy.TenantsEntry
+	54,  // 87: kratos.api.Data.Tenancy.idle_timeout:type_name -> google.protobuf.Duration
+	38,  // 88: kratos.api.Data.Tenancy.TenantsEntry.value:type_name -> kratos.api.Data.Tenancy.Tenant
+	54,  // 89: kratos.api.Log.Archive.interval:type_name -> google.protobuf.Duration
+	54,  // 90: kratos.api.Log.OTLP.timeout:type_name -> google.protobuf.Duration
+	51,  // 91: kratos.api.Log.OTLP.headers:type_name -> kratos.api.Log.OTLP.HeadersEntry
+	52,  // 92: kratos.api.Log.OTLP.attributes:type_name -> kratos.api.Log.OTLP.AttributesEntry
+	54,  // 93: kratos.api.Log.Slow.threshold:type_name -> google.protobuf.Duration
+	54,  // 94: kratos.api.Log.Slow.sql_threshold:type_name -> google.protobuf.Duration
+	53,  // 95: kratos.api.Log.Slow.explain:type_name -> kratos.api.Log.Slow.Explain
+	54,  // 96: kratos.api.Log.Audit.remote_timeout:type_name -> google.protobuf.Duration
+	54,  // 97: kratos.api.Log.Alert.timeout:type_name -> google.protobuf.Duration
+	54,  // 98: kratos.api.Log.Fluent.timeout:type_name -> google.protobuf.Duration
+	54,  // 99: kratos.api.Log.Buffer.latency_budget:type_name -> google.protobuf.Duration
+	54,  // 100: kratos.api.Log.Slow.Explain.timeout:type_name -> google.protobuf.Duration
+	101, // [101:101] is the sub-list for method output_type
+	101, // [101:101] is the sub-list for method input_type
+	101, // [101:101] is the sub-list for extension type_name
+	101, // [101:101] is the sub-list for extension extendee
+	0,   // [0:101] is the sub-list for field type_name
 }
 
 func init() { file_conf_conf_proto_init() }
@@ -618,13 +4642,14 @@ func file_conf_conf_proto_init() {
 	if File_conf_conf_proto != nil {
 		return
 	}
+	file_conf_units_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_conf_conf_proto_rawDesc), len(file_conf_conf_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   54,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
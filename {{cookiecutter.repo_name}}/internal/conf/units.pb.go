@@ -0,0 +1,162 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: conf/units.proto
+
+package conf
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SizeUnit 大小字段的单位，配置中可以写 512KB、100MB 等带单位的字符串，加载时换算为该单位的整数
+type SizeUnit int32
+
+const (
+	SizeUnit_SIZE_UNIT_UNSPECIFIED SizeUnit = 0
+	SizeUnit_BYTES                 SizeUnit = 1
+	SizeUnit_KB                    SizeUnit = 2
+	SizeUnit_MB                    SizeUnit = 3
+	SizeUnit_GB                    SizeUnit = 4
+)
+
+// Enum value maps for SizeUnit.
+var (
+	SizeUnit_name = map[int32]string{
+		0: "SIZE_UNIT_UNSPECIFIED",
+		1: "BYTES",
+		2: "KB",
+		3: "MB",
+		4: "GB",
+	}
+	SizeUnit_value = map[string]int32{
+		"SIZE_UNIT_UNSPECIFIED": 0,
+		"BYTES":                 1,
+		"KB":                    2,
+		"MB":                    3,
+		"GB":                    4,
+	}
+)
+
+func (x SizeUnit) Enum() *SizeUnit {
+	p := new(SizeUnit)
+	*p = x
+	return p
+}
+
+func (x SizeUnit) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SizeUnit) Descriptor() protoreflect.EnumDescriptor {
+	return file_conf_units_proto_enumTypes[0].Descriptor()
+}
+
+func (SizeUnit) Type() protoreflect.EnumType {
+	return &file_conf_units_proto_enumTypes[0]
+}
+
+func (x SizeUnit) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SizeUnit.Descriptor instead.
+func (SizeUnit) EnumDescriptor() ([]byte, []int) {
+	return file_conf_units_proto_rawDescGZIP(), []int{0}
+}
+
+var file_conf_units_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*SizeUnit)(nil),
+		Field:         50100,
+		Name:          "kratos.api.size",
+		Tag:           "varint,50100,opt,name=size,enum=kratos.api.SizeUnit",
+		Filename:      "conf/units.proto",
+	},
+}
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// optional kratos.api.SizeUnit size = 50100;
+	E_Size = &file_conf_units_proto_extTypes[0] // 整数字段的大小单位，如 int32 max_size = 3 [(size) = MB]
+)
+
+var File_conf_units_proto protoreflect.FileDescriptor
+
+const file_conf_units_proto_rawDesc = "" +
+	"\n" +
+	"\x10conf/units.proto\x12\n" +
+	"kratos.api\x1a google/protobuf/descriptor.proto*H\n" +
+	"\bSizeUnit\x12\x19\n" +
+	"\x15SIZE_UNIT_UNSPECIFIED\x10\x00\x12\t\n" +
+	"\x05BYTES\x10\x01\x12\x06\n" +
+	"\x02KB\x10\x02\x12\x06\n" +
+	"\x02MB\x10\x03\x12\x06\n" +
+	"\x02GB\x10\x04:I\n" +
+	"\x04size\x12\x1d.google.protobuf.FieldOptions\x18\xb4\x87\x03 \x01(\x0e2\x14.kratos.api.SizeUnitR\x04sizeb\x06proto3"
+
+var (
+	file_conf_units_proto_rawDescOnce sync.Once
+	file_conf_units_proto_rawDescData []byte
+)
+
+func file_conf_units_proto_rawDescGZIP() []byte {
+	file_conf_units_proto_rawDescOnce.Do(func() {
+		file_conf_units_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_conf_units_proto_rawDesc), len(file_conf_units_proto_rawDesc)))
+	})
+	return file_conf_units_proto_rawDescData
+}
+
+var file_conf_units_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_conf_units_proto_goTypes = []any{
+	(SizeUnit)(0),                     // 0: kratos.api.SizeUnit
+	(*descriptorpb.FieldOptions)(nil), // 1: google.protobuf.FieldOptions
+}
+var file_conf_units_proto_depIdxs = []int32{
+	1, // 0: kratos.api.size:extendee -> google.protobuf.FieldOptions
+	0, // 1: kratos.api.size:type_name -> kratos.api.SizeUnit
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	1, // [1:2] is the sub-list for extension type_name
+	0, // [0:1] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_conf_units_proto_init() }
+func file_conf_units_proto_init() {
+	if File_conf_units_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_conf_units_proto_rawDesc), len(file_conf_units_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   0,
+			NumExtensions: 1,
+			NumServices:   0,
+		},
+		GoTypes:           file_conf_units_proto_goTypes,
+		DependencyIndexes: file_conf_units_proto_depIdxs,
+		EnumInfos:         file_conf_units_proto_enumTypes,
+		ExtensionInfos:    file_conf_units_proto_extTypes,
+	}.Build()
+	File_conf_units_proto = out.File
+	file_conf_units_proto_goTypes = nil
+	file_conf_units_proto_depIdxs = nil
+}
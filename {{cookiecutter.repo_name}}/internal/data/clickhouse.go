@@ -0,0 +1,92 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"{{cookiecutter.module_name}}/internal/conf"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// clickhouseTables auto_migrate 时创建的表，已存在时跳过
+var clickhouseTables = []string{
+	`CREATE TABLE IF NOT EXISTS {{cookiecutter.file_name}}_events (
+	name LowCardinality(String),
+	subject String,
+	attrs Map(String, String),
+	time DateTime64(3)
+) ENGINE = MergeTree
+PARTITION BY toYYYYMM(time)
+ORDER BY (name, time)`,
+}
+
+// ClickHouse native 协议连接，用于分析、事件等写多读少的表
+type ClickHouse struct {
+	driver.Conn
+}
+
+// NewClickHouse 连接 ClickHouse，未开启时返回 nil
+func NewClickHouse(c *conf.Data, logger log.Logger) (*ClickHouse, func(), error) {
+	cc := c.GetClickhouse()
+	if !cc.GetEnable() {
+		return nil, func() {}, nil
+	}
+	opts := &clickhouse.Options{
+		Addr: cc.Addrs,
+		Auth: clickhouse.Auth{
+			Database: cc.Database,
+			Username: cc.Username,
+			Password: cc.Password,
+		},
+		Settings:        clickhouseSettings(cc),
+		Compression:     &clickhouse.Compression{Method: clickhouse.CompressionLZ4},
+		MaxOpenConns:    int(cc.MaxOpenConns),
+		MaxIdleConns:    int(cc.MaxIdleConns),
+		DialTimeout:     cc.DialTimeout.AsDuration(),
+		ReadTimeout:     cc.ReadTimeout.AsDuration(),
+		ConnMaxLifetime: cc.ConnMaxLifetime.AsDuration(),
+	}
+	conn, err := clickhouse.Open(opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("data: open clickhouse: %w", err)
+	}
+	cleanup := func() {
+		if err := conn.Close(); err != nil {
+			log.NewHelper(logger).Errorf("close clickhouse: %v", err)
+		}
+	}
+	ch := &ClickHouse{Conn: conn}
+	if cc.AutoMigrate {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		for _, ddl := range clickhouseTables {
+			if err := ch.Exec(ctx, ddl); err != nil {
+				cleanup()
+				return nil, nil, fmt.Errorf("data: create clickhouse table: %w", err)
+			}
+		}
+	}
+	return ch, cleanup, nil
+}
+
+// clickhouseSettings 连接级别的查询设置，开启 async_insert 时服务端缓冲小批量插入后合并写入
+func clickhouseSettings(cc *conf.Data_ClickHouse) clickhouse.Settings {
+	if !cc.AsyncInsert {
+		return nil
+	}
+	s := clickhouse.Settings{
+		"async_insert":          1,
+		"wait_for_async_insert": 0,
+	}
+	if cc.WaitForAsyncInsert {
+		s["wait_for_async_insert"] = 1
+	}
+	if cc.AsyncInsertBusyTimeout != nil {
+		s["async_insert_busy_timeout_ms"] = cc.AsyncInsertBusyTimeout.AsDuration().Milliseconds()
+	}
+	return s
+}
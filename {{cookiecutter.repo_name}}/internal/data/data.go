@@ -1,23 +1,186 @@
 package data
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
+
 	"{{cookiecutter.module_name}}/internal/conf"
+	"{{cookiecutter.module_name}}/internal/pkg/migrate"
+	"{{cookiecutter.module_name}}/internal/pkg/rwsplit"
+	"{{cookiecutter.module_name}}/internal/pkg/tenant"
+
 	"github.com/go-kratos/kratos/v2/log"
+	_ "github.com/go-sql-driver/mysql"
 	"github.com/google/wire"
+	bolt "go.etcd.io/bbolt"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
 )
 
 // ProviderSet is data providers.
-var ProviderSet = wire.NewSet(NewData, New{{cookiecutter.service_name}}Repo)
+var ProviderSet = wire.NewSet(NewDatabases, NewRedisClients, NewGormDB, NewMongo, NewClickHouse, NewData, NewTransaction, New{{cookiecutter.service_name}}Repo, NewEventRepo, NewOutboxRepo, NewOutboxRelay, NewIdempotencyStore, NewMailRenderer)
+
+// models GORM 模型，用于启动时的表结构检查
+var models = []interface{}{
+	&{{cookiecutter.file_name}}Model{},
+	&idempotencyKeyModel{},
+	&outboxModel{},
+}
 
 // Data .
 type Data struct {
-	// TODO wrapped database client
+	// db 默认数据库的 GORM，开启内嵌存储时为 nil
+	db *gorm.DB
+	// mongo 开启 data.mongo 时的 MongoDB
+	mongo *Mongo
+	// dbs 命名数据库，默认实例为 dbs.Default()
+	dbs Databases
+	// rdbs 命名 Redis 客户端，默认实例为 rdbs.Default()
+	rdbs RedisClients
+	// kv 内嵌存储，开启 conf.Data.Embedded 时使用，此时不连接外部数据库
+	kv *bolt.DB
+	// tenants 开启 data.tenancy 时按 ctx 中的租户选择数据库
+	tenants *TenantRouter
 }
 
 // NewData .
-func NewData(c *conf.Data, logger log.Logger) (*Data, func(), error) {
+func NewData(c *conf.Data, dbs Databases, rdbs RedisClients, db *gorm.DB, mdb *Mongo, logger log.Logger) (*Data, func(), error) {
+	if c.Embedded.GetEnable() {
+		return newEmbeddedData(c.Embedded, logger)
+	}
+	if db == nil && mdb == nil {
+		return nil, nil, fmt.Errorf("data: database %q is not configured", DefaultInstance)
+	}
+	configs := databaseConfigs(c)
+	for _, name := range sortedNames(dbs) {
+		dc := configs[name]
+		if dc.RunMigrations && name == DefaultInstance {
+			m, err := NewMigrator(dbs, c, logger)
+			if err != nil {
+				return nil, nil, err
+			}
+			if err := m.Up(context.Background()); err != nil {
+				return nil, nil, err
+			}
+		}
+		if dc.AutoMigrate {
+			if err := runMigrate(name, dbs[name], db, dc, logger); err != nil {
+				return nil, nil, err
+			}
+		}
+		if dc.SchemaCheck.GetEnable() {
+			if err := checkSchema(dbs[name], dc, logger); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	tenants, closeTenants, err := NewTenantRouter(c, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	// 租户迁移只在启动时执行，不在请求路径上
+	if tenants != nil && c.Tenancy.RunMigrations {
+		if err := tenants.Migrate(context.Background()); err != nil {
+			closeTenants()
+			return nil, nil, err
+		}
+	}
+	unregister, err := registerPoolMetrics(dbs, rdbs)
+	if err != nil {
+		closeTenants()
+		return nil, nil, err
+	}
 	cleanup := func() {
 		log.NewHelper(logger).Info("closing the data resources")
+		closeTenants()
+		unregister()
+	}
+	return &Data{db: db, mongo: mdb, dbs: dbs, rdbs: rdbs, tenants: tenants}, cleanup, nil
+}
+
+// DB 返回绑定 ctx 的 GORM，ctx 在 InTx 事务中时返回事务连接，
+// ctx 带租户时返回租户的连接，ctx 经 rwsplit.ForcePrimary 标记时读请求也使用主库
+func (d *Data) DB(ctx context.Context) *gorm.DB {
+	if tx := txFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	if id, ok := tenant.FromContext(ctx); ok && d.tenants != nil {
+		tdb, err := d.tenants.DB(ctx, id)
+		if err != nil {
+			// 错误随返回的 GORM 在执行时返回，调用方按查询错误处理
+			db := d.db.WithContext(ctx)
+			_ = db.AddError(err)
+			return db
+		}
+		return tdb.WithContext(ctx)
+	}
+	db := d.db.WithContext(ctx)
+	if rwsplit.IsPrimary(ctx) {
+		db = db.Clauses(dbresolver.Write)
+	}
+	return db
+}
+
+// newEmbeddedData 使用内嵌存储创建 Data
+func newEmbeddedData(c *conf.Data_Embedded, logger log.Logger) (*Data, func(), error) {
+	db, err := openEmbedded(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	var backup *embeddedBackup
+	if c.Backup.GetEnable() {
+		if backup, err = newEmbeddedBackup(db, c, logger); err != nil {
+			db.Close()
+			return nil, nil, err
+		}
+	}
+	cleanup := func() {
+		log.NewHelper(logger).Info("closing the data resources")
+		if backup != nil {
+			backup.Stop()
+		}
+		db.Close()
+	}
+	return &Data{kv: db}, cleanup, nil
+}
+
+// runMigrate 持有迁移锁执行自动迁移，避免多副本并发迁移，每个命名数据库使用独立的锁
+// models 只迁移到默认数据库，其他命名数据库按需补充
+func runMigrate(name string, db *sql.DB, gdb *gorm.DB, c *conf.Data_Database, logger log.Logger) error {
+	return migrate.WithLock(context.Background(), db, c.Driver, migrateLock(name), c.MigrateLockTimeout.AsDuration(), logger, func(ctx context.Context) error {
+		if name == DefaultInstance {
+			return gdb.WithContext(ctx).AutoMigrate(models...)
+		}
+		return nil
+	})
+}
+
+// migrateLock 命名数据库的迁移锁名称
+func migrateLock(name string) string {
+	lock := "{{cookiecutter.repo_name}}:migrate"
+	if name != DefaultInstance {
+		lock += ":" + name
+	}
+	return lock
+}
+
+// checkSchema 检查模型定义与数据库表结构的差异，避免迁移未完成时运行期才出现列不存在的错误
+func checkSchema(db *sql.DB, c *conf.Data_Database, logger log.Logger) error {
+	tables, err := migrate.TablesFromGORM(models...)
+	if err != nil {
+		return err
+	}
+	drifts, err := migrate.CheckDrift(context.Background(), db, c.Driver, tables)
+	if err != nil {
+		return err
+	}
+	helper := log.NewHelper(logger)
+	for _, d := range drifts {
+		helper.Warnf("schema check: %s", d)
+	}
+	if len(drifts) > 0 && c.SchemaCheck.Fail {
+		return fmt.Errorf("%w: %d difference(s)", migrate.ErrSchemaDrift, len(drifts))
 	}
-	return &Data{}, cleanup, nil
+	return nil
 }
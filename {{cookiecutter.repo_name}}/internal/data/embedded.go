@@ -0,0 +1,114 @@
+package data
+
+import (
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"{{cookiecutter.module_name}}/internal/conf"
+	pkglog "{{cookiecutter.module_name}}/internal/pkg/log"
+
+	"github.com/go-kratos/kratos/v2/log"
+	bolt "go.etcd.io/bbolt"
+)
+
+// openEmbedded 打开内嵌 bbolt 存储
+func openEmbedded(c *conf.Data_Embedded) (*bolt.DB, error) {
+	if err := os.MkdirAll(filepath.Dir(c.Path), 0o755); err != nil {
+		return nil, err
+	}
+	return bolt.Open(c.Path, 0o600, &bolt.Options{Timeout: time.Second})
+}
+
+// embeddedBackup 内嵌存储备份器，定期将一致性快照上传到对象存储
+type embeddedBackup struct {
+	db       *bolt.DB
+	storage  pkglog.ObjectStorage
+	path     string
+	prefix   string
+	host     string
+	interval time.Duration
+	log      *log.Helper
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// newEmbeddedBackup 创建并启动内嵌存储备份
+func newEmbeddedBackup(db *bolt.DB, c *conf.Data_Embedded, logger log.Logger) (*embeddedBackup, error) {
+	storage, err := pkglog.NewS3Storage(c.Backup)
+	if err != nil {
+		return nil, err
+	}
+	interval := time.Hour
+	if c.Backup.Interval != nil && c.Backup.Interval.AsDuration() > 0 {
+		interval = c.Backup.Interval.AsDuration()
+	}
+	host, _ := os.Hostname()
+	b := &embeddedBackup{
+		db:       db,
+		storage:  storage,
+		path:     c.Path,
+		prefix:   strings.Trim(c.Backup.Prefix, "/"),
+		host:     host,
+		interval: interval,
+		log:      log.NewHelper(logger),
+		done:     make(chan struct{}),
+	}
+	b.wg.Add(1)
+	go b.run()
+	return b, nil
+}
+
+// Stop 停止备份，退出前再做一次备份
+func (b *embeddedBackup) Stop() {
+	close(b.done)
+	b.wg.Wait()
+	if err := b.backup(context.Background()); err != nil {
+		b.log.Errorf("embedded: backup failed: %v", err)
+	}
+}
+
+// run 定期备份
+func (b *embeddedBackup) run() {
+	defer b.wg.Done()
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := b.backup(context.Background()); err != nil {
+				b.log.Errorf("embedded: backup failed: %v", err)
+			}
+		case <-b.done:
+			return
+		}
+	}
+}
+
+// backup 在只读事务中导出快照到临时文件后上传，导出期间不阻塞写入
+func (b *embeddedBackup) backup(ctx context.Context) error {
+	now := time.Now()
+	tmp := b.path + ".backup"
+	defer os.Remove(tmp)
+
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.CopyFile(tmp, 0o600)
+	})
+	if err != nil {
+		return err
+	}
+
+	base := filepath.Base(b.path)
+	name := strings.TrimSuffix(base, filepath.Ext(base)) + "-" + now.Format("20060102T150405") + filepath.Ext(base)
+	key := path.Join(b.prefix, now.Format("2006/01/02"), b.host, name)
+	if err := b.storage.Upload(ctx, key, tmp); err != nil {
+		return err
+	}
+	b.log.Infof("embedded: backup uploaded to %s", key)
+	return nil
+}
@@ -0,0 +1,21 @@
+//go:build ignore
+
+package main
+
+import (
+	"log"
+
+	"entgo.io/ent/entc"
+	"entgo.io/ent/entc/gen"
+)
+
+// 生成 ent 客户端，schema 和生成的代码都带 entrepo 构建标签
+// 不使用 ent 标签：atlas 的开源实现带 !ent 约束，使用 ent 标签时 atlas 无法编译
+func main() {
+	err := entc.Generate("./schema", &gen.Config{
+		Header: "//go:build entrepo\n\n// Code generated by ent, DO NOT EDIT.",
+	}, entc.BuildTags("entrepo"))
+	if err != nil {
+		log.Fatalf("running ent codegen: %v", err)
+	}
+}
@@ -0,0 +1,9 @@
+//go:build entrepo
+
+// Package ent 由 schema 生成的 ent 客户端，生成的代码带 ent 构建标签，默认构建不包含
+//
+//	make ent
+//	go build -tags entrepo ./...
+package ent
+
+//go:generate go run -mod=mod entc.go
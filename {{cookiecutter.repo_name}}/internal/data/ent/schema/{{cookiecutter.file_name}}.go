@@ -0,0 +1,44 @@
+//go:build entrepo
+
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// {{cookiecutter.service_name}} 与 GORM 版本的 {{cookiecutter.file_name}}Model 使用同一张表和相同的列
+type {{cookiecutter.service_name}} struct {
+	ent.Schema
+}
+
+// Annotations 表名与 GORM 模型一致
+func ({{cookiecutter.service_name}}) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "{{cookiecutter.file_name}}"},
+	}
+}
+
+// Fields 字段
+func ({{cookiecutter.service_name}}) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("hello").MaxLen(255),
+		field.Time("created_at").Default(time.Now).Immutable(),
+		field.Time("updated_at").Default(time.Now).UpdateDefault(time.Now),
+		// 软删除，为空表示未删除
+		field.Time("deleted_at").Optional().Nillable(),
+	}
+}
+
+// Indexes 索引
+func ({{cookiecutter.service_name}}) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("hello"),
+		index.Fields("deleted_at"),
+	}
+}
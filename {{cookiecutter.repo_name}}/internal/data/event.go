@@ -0,0 +1,51 @@
+package data
+
+import (
+	"context"
+	"fmt"
+
+	"{{cookiecutter.module_name}}/internal/biz"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// eventRepo 批量写入 ClickHouse 事件表
+type eventRepo struct {
+	ch  *ClickHouse
+	log *log.Helper
+}
+
+// NewEventRepo 创建事件仓储，未开启 ClickHouse 时丢弃事件
+func NewEventRepo(ch *ClickHouse, logger log.Logger) biz.EventRepo {
+	if ch == nil {
+		return discardEventRepo{}
+	}
+	return &eventRepo{ch: ch, log: log.NewHelper(logger)}
+}
+
+// BatchInsert 一批事件作为一次 INSERT 发送，ClickHouse 适合少量大批次而不是逐条写入
+func (r *eventRepo) BatchInsert(ctx context.Context, events []*biz.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	batch, err := r.ch.PrepareBatch(ctx, "INSERT INTO {{cookiecutter.file_name}}_events (name, subject, attrs, time)")
+	if err != nil {
+		return fmt.Errorf("prepare batch: %w", err)
+	}
+	for _, e := range events {
+		attrs := e.Attrs
+		if attrs == nil {
+			attrs = map[string]string{}
+		}
+		if err := batch.Append(e.Name, e.Subject, attrs, e.Time); err != nil {
+			_ = batch.Abort()
+			return fmt.Errorf("append event: %w", err)
+		}
+	}
+	return batch.Send()
+}
+
+// discardEventRepo 未开启 ClickHouse 时使用
+type discardEventRepo struct{}
+
+func (discardEventRepo) BatchInsert(context.Context, []*biz.Event) error { return nil }
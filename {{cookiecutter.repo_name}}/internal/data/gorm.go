@@ -0,0 +1,176 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"{{cookiecutter.module_name}}/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+	"gorm.io/plugin/dbresolver"
+)
+
+// NewGormDB 在默认数据库的连接池上创建 GORM，开启内嵌存储或未配置默认数据库时返回 nil
+// 配置了只读副本时注册 dbresolver，读请求分配到副本，写请求、事务和 rwsplit.ForcePrimary 的请求使用主库
+func NewGormDB(c *conf.Data, dbs Databases, logger log.Logger) (*gorm.DB, func(), error) {
+	db := dbs.Default()
+	if db == nil {
+		return nil, func() {}, nil
+	}
+	dc := databaseConfigs(c)[DefaultInstance]
+	gdb, err := openGorm(dc, db, logger)
+	if err != nil {
+		return nil, nil, fmt.Errorf("data: open gorm: %w", err)
+	}
+	if len(dc.Replicas) == 0 {
+		return gdb, func() {}, nil
+	}
+
+	replicas := make([]*sql.DB, 0, len(dc.Replicas))
+	cleanup := func() {
+		for i, r := range replicas {
+			if err := r.Close(); err != nil {
+				log.NewHelper(logger).Errorf("close database replica %d: %v", i, err)
+			}
+		}
+	}
+	dialectors := make([]gorm.Dialector, 0, len(dc.Replicas))
+	for i, source := range dc.Replicas {
+		r, err := sql.Open(sqlDriver(dc.Driver), source)
+		if err != nil {
+			cleanup()
+			return nil, nil, fmt.Errorf("data: open database replica %d: %w", i, err)
+		}
+		setPool(r, dc)
+		replicas = append(replicas, r)
+		d, err := dialector(dc.Driver, r)
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		dialectors = append(dialectors, d)
+	}
+	if err := gdb.Use(dbresolver.Register(dbresolver.Config{Replicas: dialectors})); err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("data: register replicas: %w", err)
+	}
+	return gdb, cleanup, nil
+}
+
+// openGorm 复用已打开的连接池创建 GORM，与 database/sql 一样在首次使用时才建立连接
+func openGorm(dc *conf.Data_Database, db *sql.DB, logger log.Logger) (*gorm.DB, error) {
+	d, err := dialector(dc.Driver, db)
+	if err != nil {
+		return nil, err
+	}
+	slow := defaultSlowThreshold
+	if dc.SlowThreshold != nil {
+		slow = dc.SlowThreshold.AsDuration()
+	}
+	return gorm.Open(d, &gorm.Config{
+		Logger:               newGormLogger(logger, slow),
+		DisableAutomaticPing: true,
+	})
+}
+
+// dialector 驱动对应的 GORM 方言
+func dialector(driver string, db *sql.DB) (gorm.Dialector, error) {
+	switch driver {
+	case "mysql":
+		return mysql.New(mysql.Config{Conn: db, SkipInitializeWithVersion: true}), nil
+	case "postgres", "pgx":
+		return postgres.New(postgres.Config{Conn: db}), nil
+	case "sqlite", "sqlite3":
+		return sqlite.Dialector{Conn: db}, nil
+	default:
+		return nil, fmt.Errorf("unsupported driver %q", driver)
+	}
+}
+
+// sqlDriver 配置中的驱动名对应 database/sql 注册的驱动名
+func sqlDriver(driver string) string {
+	switch driver {
+	case "postgres":
+		return "pgx"
+	case "sqlite":
+		return "sqlite3"
+	default:
+		return driver
+	}
+}
+
+// defaultSlowThreshold 慢查询阈值，与 GORM 默认值一致
+const defaultSlowThreshold = 200 * time.Millisecond
+
+// gormLogger 将 GORM 日志输出到 kratos 日志，SQL 按 debug 级别输出，慢查询按 warn 级别输出，执行错误按 error 级别输出，
+// 日志通过 WithContext 带上请求的 trace.id，便于从慢查询找到对应的请求链路
+type gormLogger struct {
+	log   *log.Helper
+	level gormlogger.LogLevel
+	// slow 慢查询阈值，为 0 时不区分慢查询
+	slow time.Duration
+}
+
+func newGormLogger(logger log.Logger, slow time.Duration) *gormLogger {
+	return &gormLogger{
+		log:   log.NewHelper(log.With(logger, "module", "gorm")),
+		level: gormlogger.Info,
+		slow:  slow,
+	}
+}
+
+// LogMode 实现 gormlogger.Interface，db.Debug() 等调用会切换级别
+func (l *gormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	n := *l
+	n.level = level
+	return &n
+}
+
+// Info 实现 gormlogger.Interface
+func (l *gormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Info {
+		l.log.WithContext(ctx).Infof(msg, args...)
+	}
+}
+
+// Warn 实现 gormlogger.Interface
+func (l *gormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Warn {
+		l.log.WithContext(ctx).Warnf(msg, args...)
+	}
+}
+
+// Error 实现 gormlogger.Interface
+func (l *gormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Error {
+		l.log.WithContext(ctx).Errorf(msg, args...)
+	}
+}
+
+// Trace 实现 gormlogger.Interface，记录不存在不视为错误
+func (l *gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+	elapsed := time.Since(begin)
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && l.level >= gormlogger.Error:
+		query, rows := fc()
+		l.log.WithContext(ctx).Errorw("msg", "sql error", "sql", query, "rows", rows, "elapsed", elapsed.String(), "error", err.Error())
+	case l.slow > 0 && elapsed > l.slow && l.level >= gormlogger.Warn:
+		query, rows := fc()
+		l.log.WithContext(ctx).Warnw("msg", "slow sql", "sql", query, "rows", rows, "elapsed", elapsed.String(), "threshold", l.slow.String())
+	case l.level >= gormlogger.Info:
+		query, rows := fc()
+		l.log.WithContext(ctx).Debugw("msg", "sql", "sql", query, "rows", rows, "elapsed", elapsed.String())
+	}
+}
@@ -0,0 +1,160 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"{{cookiecutter.module_name}}/internal/pkg/idempotency"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/plugin/dbresolver"
+)
+
+// idempotencyKeyPrefix 幂等键在 Redis 中的前缀
+const idempotencyKeyPrefix = "{{cookiecutter.repo_name}}:idempotency:"
+
+const (
+	// idempotencyPending 处理中的占位值
+	idempotencyPending byte = 'p'
+	// idempotencyDone 已完成，后接保存的响应
+	idempotencyDone byte = 'v'
+)
+
+// idempotencyReleaseScript 只删除处理中的 key，不删除已保存的结果
+var idempotencyReleaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+// NewIdempotencyStore 幂等键存储，配置了默认 Redis 时使用 Redis，否则使用默认数据库的 idempotency_keys 表；
+// 两者都没有时（内嵌存储或 MongoDB）返回 nil，idempotency.Do 直接执行业务，不做去重
+func NewIdempotencyStore(data *Data, logger log.Logger) idempotency.Store {
+	if rdb := data.rdbs.Default(); rdb != nil {
+		return &redisIdempotencyStore{rdb: rdb}
+	}
+	if data.db != nil {
+		return &gormIdempotencyStore{data: data}
+	}
+	log.NewHelper(logger).Warn("idempotency: neither redis nor database is configured, idempotency keys are ignored")
+	return nil
+}
+
+// redisIdempotencyStore 基于 Redis 的幂等键存储，SET NX 占用 key，过期由 Redis 清理
+type redisIdempotencyStore struct {
+	rdb redis.UniversalClient
+}
+
+// Reserve 实现 idempotency.Store
+func (s *redisIdempotencyStore) Reserve(ctx context.Context, key string, ttl time.Duration) ([]byte, bool, error) {
+	key = idempotencyKeyPrefix + key
+	// 读取时 key 恰好过期则重新占用一次
+	for i := 0; i < 2; i++ {
+		ok, err := s.rdb.SetNX(ctx, key, []byte{idempotencyPending}, ttl).Result()
+		if err != nil {
+			return nil, false, err
+		}
+		if ok {
+			return nil, true, nil
+		}
+		b, err := s.rdb.Get(ctx, key).Bytes()
+		switch {
+		case errors.Is(err, redis.Nil):
+			continue
+		case err != nil:
+			return nil, false, err
+		case len(b) > 0 && b[0] == idempotencyDone:
+			return b[1:], false, nil
+		default:
+			return nil, false, idempotency.ErrInProgress
+		}
+	}
+	return nil, false, idempotency.ErrInProgress
+}
+
+// Save 实现 idempotency.Store
+func (s *redisIdempotencyStore) Save(ctx context.Context, key string, payload []byte, ttl time.Duration) error {
+	return s.rdb.Set(ctx, idempotencyKeyPrefix+key, append([]byte{idempotencyDone}, payload...), ttl).Err()
+}
+
+// Release 实现 idempotency.Store
+func (s *redisIdempotencyStore) Release(ctx context.Context, key string) error {
+	return idempotencyReleaseScript.Run(ctx, s.rdb, []string{idempotencyKeyPrefix + key}, []byte{idempotencyPending}).Err()
+}
+
+// idempotencyKeyModel 幂等键记录，过期的记录在下次使用同一个 key 时复用，
+// 也可以定期执行 DELETE FROM idempotency_keys WHERE expires_at < now 清理
+type idempotencyKeyModel struct {
+	ID        string `gorm:"primaryKey;size:191"`
+	Done      bool   `gorm:"not null;default:false"`
+	Payload   []byte
+	ExpiresAt time.Time `gorm:"index"`
+	CreatedAt time.Time
+}
+
+// TableName 表名
+func (idempotencyKeyModel) TableName() string {
+	return "idempotency_keys"
+}
+
+// gormIdempotencyStore 基于数据库的幂等键存储，主键冲突表示 key 已被占用
+type gormIdempotencyStore struct {
+	data *Data
+}
+
+// db 幂等记录不参与业务事务，读写都使用主库，避免副本延迟读到旧状态
+func (s *gormIdempotencyStore) db(ctx context.Context) *gorm.DB {
+	return s.data.db.WithContext(ctx).Clauses(dbresolver.Write)
+}
+
+// Reserve 实现 idempotency.Store
+func (s *gormIdempotencyStore) Reserve(ctx context.Context, key string, ttl time.Duration) ([]byte, bool, error) {
+	now := time.Now()
+	res := s.db(ctx).Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&idempotencyKeyModel{ID: key, ExpiresAt: now.Add(ttl)})
+	if res.Error != nil {
+		return nil, false, res.Error
+	}
+	if res.RowsAffected == 1 {
+		return nil, true, nil
+	}
+	// 已过期的记录视为不存在，重新占用
+	res = s.db(ctx).Model(&idempotencyKeyModel{}).
+		Where("id = ? AND expires_at <= ?", key, now).
+		Updates(map[string]interface{}{"done": false, "payload": nil, "expires_at": now.Add(ttl)})
+	if res.Error != nil {
+		return nil, false, res.Error
+	}
+	if res.RowsAffected == 1 {
+		return nil, true, nil
+	}
+	var m idempotencyKeyModel
+	if err := s.db(ctx).Where("id = ?", key).Take(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, idempotency.ErrInProgress
+		}
+		return nil, false, err
+	}
+	if !m.Done {
+		return nil, false, idempotency.ErrInProgress
+	}
+	return m.Payload, false, nil
+}
+
+// Save 实现 idempotency.Store
+func (s *gormIdempotencyStore) Save(ctx context.Context, key string, payload []byte, ttl time.Duration) error {
+	return s.db(ctx).Model(&idempotencyKeyModel{}).
+		Where("id = ?", key).
+		Updates(map[string]interface{}{"done": true, "payload": payload, "expires_at": time.Now().Add(ttl)}).Error
+}
+
+// Release 实现 idempotency.Store
+func (s *gormIdempotencyStore) Release(ctx context.Context, key string) error {
+	return s.db(ctx).
+		Where("id = ? AND done = ?", key, false).
+		Delete(&idempotencyKeyModel{}).Error
+}
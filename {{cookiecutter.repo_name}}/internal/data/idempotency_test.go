@@ -0,0 +1,143 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"{{cookiecutter.module_name}}/internal/conf"
+	"{{cookiecutter.module_name}}/internal/pkg/idempotency"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// newTestData 使用 sqlite 文件作为默认数据库的 Data，表结构由 GORM 模型创建
+func newTestData(t *testing.T) *Data {
+	t.Helper()
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	gdb, err := openGorm(&conf.Data_Database{Driver: "sqlite"}, db, log.NewFilter(log.DefaultLogger, log.FilterLevel(log.LevelWarn)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gdb.AutoMigrate(models...); err != nil {
+		t.Fatal(err)
+	}
+	return &Data{db: gdb, dbs: Databases{DefaultInstance: db}}
+}
+
+func TestIdempotencyStore(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+
+	stores := map[string]struct {
+		store  idempotency.Store
+		expire func(key string)
+	}{
+		"redis": {
+			store:  &redisIdempotencyStore{rdb: rdb},
+			expire: func(key string) { mr.Del(idempotencyKeyPrefix + key) },
+		},
+	}
+	data := newTestData(t)
+	stores["gorm"] = struct {
+		store  idempotency.Store
+		expire func(key string)
+	}{
+		store: &gormIdempotencyStore{data: data},
+		expire: func(key string) {
+			data.db.Model(&idempotencyKeyModel{}).Where("id = ?", key).Update("expires_at", time.Now().Add(-time.Second))
+		},
+	}
+
+	for name, s := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			store := s.store
+
+			if _, ok, err := store.Reserve(ctx, "k1", time.Hour); err != nil || !ok {
+				t.Fatalf("first reserve: ok = %v, err = %v", ok, err)
+			}
+			if _, _, err := store.Reserve(ctx, "k1", time.Hour); !errors.Is(err, idempotency.ErrInProgress) {
+				t.Fatalf("reserve in progress: err = %v, want ErrInProgress", err)
+			}
+			if err := store.Save(ctx, "k1", []byte("reply"), time.Hour); err != nil {
+				t.Fatal(err)
+			}
+			payload, ok, err := store.Reserve(ctx, "k1", time.Hour)
+			if err != nil || ok || string(payload) != "reply" {
+				t.Fatalf("reserve done: payload = %q, ok = %v, err = %v", payload, ok, err)
+			}
+			// 已完成的 key 不会被 Release 删除
+			if err := store.Release(ctx, "k1"); err != nil {
+				t.Fatal(err)
+			}
+			if payload, _, _ := store.Reserve(ctx, "k1", time.Hour); string(payload) != "reply" {
+				t.Fatalf("release removed a saved result")
+			}
+			// 过期后重新占用
+			s.expire("k1")
+			if _, ok, err := store.Reserve(ctx, "k1", time.Hour); err != nil || !ok {
+				t.Fatalf("reserve after expiry: ok = %v, err = %v", ok, err)
+			}
+
+			// 处理失败释放后允许重试
+			if _, ok, _ := store.Reserve(ctx, "k2", time.Hour); !ok {
+				t.Fatal("reserve k2 failed")
+			}
+			if err := store.Release(ctx, "k2"); err != nil {
+				t.Fatal(err)
+			}
+			if _, ok, err := store.Reserve(ctx, "k2", time.Hour); err != nil || !ok {
+				t.Fatalf("reserve after release: ok = %v, err = %v", ok, err)
+			}
+		})
+	}
+}
+
+func TestIdempotencyDo(t *testing.T) {
+	store := &gormIdempotencyStore{data: newTestData(t)}
+	ctx := context.Background()
+
+	calls := 0
+	fn := func(context.Context) (*wrapperspb.StringValue, error) {
+		calls++
+		if calls == 1 {
+			return nil, errors.New("downstream failed")
+		}
+		return wrapperspb.String("hello"), nil
+	}
+	// 失败的结果不保存，同一个 key 可以重试
+	if _, err := idempotency.Do(ctx, store, "SayHello:abc", time.Hour, fn); err == nil {
+		t.Fatal("want the first call to fail")
+	}
+	for i := 0; i < 3; i++ {
+		reply, err := idempotency.Do(ctx, store, "SayHello:abc", time.Hour, fn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reply.GetValue() != "hello" {
+			t.Fatalf("reply = %v, want hello", reply)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("fn called %d times, want 2", calls)
+	}
+	// 没有幂等键时每次都执行
+	if _, err := idempotency.Do(ctx, store, "", time.Hour, fn); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 3 {
+		t.Errorf("fn called %d times without a key, want 3", calls)
+	}
+}
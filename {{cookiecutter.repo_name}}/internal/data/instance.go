@@ -0,0 +1,245 @@
+package data
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
+	"fmt"
+	"os"
+	"sort"
+
+	"{{cookiecutter.module_name}}/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/extra/redisotel/v9"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// DefaultInstance 默认实例名，单实例写法 data.database、data.redis 对应该名称
+const DefaultInstance = "default"
+
+// Databases 按名称索引的数据库连接，连接在首次使用时建立
+type Databases map[string]*sql.DB
+
+// Get 获取命名数据库，未配置时返回错误
+func (d Databases) Get(name string) (*sql.DB, error) {
+	if db, ok := d[name]; ok {
+		return db, nil
+	}
+	return nil, fmt.Errorf("data: database %q is not configured", name)
+}
+
+// Default 默认数据库，未配置时返回 nil
+func (d Databases) Default() *sql.DB {
+	return d[DefaultInstance]
+}
+
+// RedisClients 按名称索引的 Redis 客户端
+type RedisClients map[string]redis.UniversalClient
+
+// Get 获取命名 Redis 客户端，未配置时返回错误
+func (r RedisClients) Get(name string) (redis.UniversalClient, error) {
+	if c, ok := r[name]; ok {
+		return c, nil
+	}
+	return nil, fmt.Errorf("data: redis %q is not configured", name)
+}
+
+// Default 默认 Redis 客户端，未配置时返回 nil
+func (r RedisClients) Default() redis.UniversalClient {
+	return r[DefaultInstance]
+}
+
+// NewDatabases 打开全部命名数据库，未配置 source 的实例和开启内嵌存储时跳过
+func NewDatabases(c *conf.Data, logger log.Logger) (Databases, func(), error) {
+	dbs := make(Databases)
+	if c.Embedded.GetEnable() {
+		return dbs, func() {}, nil
+	}
+	configs := databaseConfigs(c)
+	for _, name := range sortedNames(configs) {
+		dc := configs[name]
+		if dc.Source == "" {
+			continue
+		}
+		db, err := sql.Open(sqlDriver(dc.Driver), dc.Source)
+		if err != nil {
+			closeDatabases(dbs, logger)
+			return nil, nil, fmt.Errorf("data: open database %s: %w", name, err)
+		}
+		setPool(db, dc)
+		dbs[name] = db
+	}
+	return dbs, func() { closeDatabases(dbs, logger) }, nil
+}
+
+// setPool 按配置设置连接池，未配置的项保持 database/sql 的默认值
+func setPool(db *sql.DB, c *conf.Data_Database) {
+	if c.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(int(c.MaxOpenConns))
+	}
+	if c.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(int(c.MaxIdleConns))
+	}
+	if c.ConnMaxLifetime != nil {
+		db.SetConnMaxLifetime(c.ConnMaxLifetime.AsDuration())
+	}
+	if c.ConnMaxIdleTime != nil {
+		db.SetConnMaxIdleTime(c.ConnMaxIdleTime.AsDuration())
+	}
+}
+
+// NewRedisClients 创建全部命名 Redis 客户端，配置了 addrs 时使用集群客户端
+// 客户端接入 OpenTelemetry 链路追踪和连接池指标，指标随 metrics 端口暴露
+func NewRedisClients(c *conf.Data, logger log.Logger) (RedisClients, func(), error) {
+	clients := make(RedisClients)
+	cleanup := func() {
+		for name, client := range clients {
+			if err := client.Close(); err != nil {
+				log.NewHelper(logger).Errorf("close redis %s: %v", name, err)
+			}
+		}
+	}
+	if c.Embedded.GetEnable() {
+		return clients, func() {}, nil
+	}
+	configs := redisConfigs(c)
+	for _, name := range sortedNames(configs) {
+		rc := configs[name]
+		if rc.Addr == "" && len(rc.Addrs) == 0 {
+			continue
+		}
+		client, err := newRedisClient(name, rc)
+		if err != nil {
+			cleanup()
+			return nil, nil, fmt.Errorf("data: redis %s: %w", name, err)
+		}
+		clients[name] = client
+	}
+	return clients, cleanup, nil
+}
+
+// newRedisClient 创建单个 Redis 客户端
+func newRedisClient(name string, c *conf.Data_Redis) (redis.UniversalClient, error) {
+	tlsConfig, err := newRedisTLSConfig(c.Tls)
+	if err != nil {
+		return nil, err
+	}
+	var client redis.UniversalClient
+	if len(c.Addrs) > 0 {
+		client = redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:           c.Addrs,
+			Username:        c.Username,
+			Password:        c.Password,
+			DialTimeout:     c.DialTimeout.AsDuration(),
+			ReadTimeout:     c.ReadTimeout.AsDuration(),
+			WriteTimeout:    c.WriteTimeout.AsDuration(),
+			PoolSize:        int(c.PoolSize),
+			MinIdleConns:    int(c.MinIdleConns),
+			PoolTimeout:     c.PoolTimeout.AsDuration(),
+			ConnMaxIdleTime: c.ConnMaxIdleTime.AsDuration(),
+			TLSConfig:       tlsConfig,
+		})
+	} else {
+		client = redis.NewClient(&redis.Options{
+			Network:         c.Network,
+			Addr:            c.Addr,
+			Username:        c.Username,
+			Password:        c.Password,
+			DB:              int(c.Db),
+			DialTimeout:     c.DialTimeout.AsDuration(),
+			ReadTimeout:     c.ReadTimeout.AsDuration(),
+			WriteTimeout:    c.WriteTimeout.AsDuration(),
+			PoolSize:        int(c.PoolSize),
+			MinIdleConns:    int(c.MinIdleConns),
+			PoolTimeout:     c.PoolTimeout.AsDuration(),
+			ConnMaxIdleTime: c.ConnMaxIdleTime.AsDuration(),
+			TLSConfig:       tlsConfig,
+		})
+	}
+	attrs := redisotel.WithAttributes(attribute.String("redis.instance", name))
+	if err := redisotel.InstrumentTracing(client, attrs); err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+	if err := redisotel.InstrumentMetrics(client, attrs); err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+	return client, nil
+}
+
+// newRedisTLSConfig 根据证书配置创建 TLS 配置，未开启时返回 nil
+func newRedisTLSConfig(c *conf.Data_Redis_TLS) (*tls.Config, error) {
+	if !c.GetEnable() {
+		return nil, nil
+	}
+	cfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         c.ServerName,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+	if c.CaFile != "" {
+		ca, err := os.ReadFile(c.CaFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("invalid ca file %s", c.CaFile)
+		}
+		cfg.RootCAs = pool
+	}
+	if c.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
+// closeDatabases 关闭全部数据库连接
+func closeDatabases(dbs Databases, logger log.Logger) {
+	for name, db := range dbs {
+		if err := db.Close(); err != nil {
+			log.NewHelper(logger).Errorf("close database %s: %v", name, err)
+		}
+	}
+}
+
+// databaseConfigs 合并单实例写法和命名实例的数据库配置
+func databaseConfigs(c *conf.Data) map[string]*conf.Data_Database {
+	out := make(map[string]*conf.Data_Database, len(c.GetDatabases())+1)
+	if c.GetDatabase() != nil {
+		out[DefaultInstance] = c.Database
+	}
+	for name, dc := range c.GetDatabases() {
+		out[name] = dc
+	}
+	return out
+}
+
+// redisConfigs 合并单实例写法和命名实例的 Redis 配置
+func redisConfigs(c *conf.Data) map[string]*conf.Data_Redis {
+	out := make(map[string]*conf.Data_Redis, len(c.GetRedisInstances())+1)
+	if c.GetRedis() != nil {
+		out[DefaultInstance] = c.Redis
+	}
+	for name, rc := range c.GetRedisInstances() {
+		out[name] = rc
+	}
+	return out
+}
+
+// sortedNames 按名称排序，保证迁移等操作的执行顺序稳定
+func sortedNames[T any](m map[string]T) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,21 @@
+package data
+
+import (
+	"os/exec"
+
+	"{{cookiecutter.module_name}}/internal/pkg/notify"
+)
+
+// NewMailRenderer 邮件模板渲染器，使用 internal/pkg/notify/templates 中内置的模板
+// 安装了 mjml 命令行时支持 .mjml 模板，模板存放在数据库时改用 notify.NewSQLStore
+func NewMailRenderer() (*notify.Renderer, error) {
+	store, err := notify.NewFSStore(notify.Templates, "templates")
+	if err != nil {
+		return nil, err
+	}
+	var opts []notify.Option
+	if bin, err := exec.LookPath("mjml"); err == nil {
+		opts = append(opts, notify.WithMJML(notify.MJMLCommand(bin)))
+	}
+	return notify.NewRenderer(store, opts...), nil
+}
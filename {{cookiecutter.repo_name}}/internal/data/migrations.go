@@ -0,0 +1,49 @@
+package data
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+
+	"{{cookiecutter.module_name}}/internal/conf"
+	"{{cookiecutter.module_name}}/internal/pkg/migrate"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// migrationsFS 版本化 SQL 迁移，按驱动分目录，新增迁移时每个目录各加一个同版本号的文件
+//
+//go:embed migrations
+var migrationsFS embed.FS
+
+// NewMigrator 创建默认数据库的迁移执行器，使用与 auto_migrate 相同的迁移锁
+func NewMigrator(dbs Databases, c *conf.Data, logger log.Logger) (*migrate.Runner, error) {
+	db, err := dbs.Get(DefaultInstance)
+	if err != nil {
+		return nil, err
+	}
+	dc := databaseConfigs(c)[DefaultInstance]
+	dir, err := migrationDir(dc.Driver)
+	if err != nil {
+		return nil, err
+	}
+	fsys, err := fs.Sub(migrationsFS, dir)
+	if err != nil {
+		return nil, err
+	}
+	return migrate.NewRunner(db, dc.Driver, fsys, migrateLock(DefaultInstance), dc.MigrateLockTimeout.AsDuration(), logger)
+}
+
+// migrationDir 驱动对应的迁移目录
+func migrationDir(driver string) (string, error) {
+	switch driver {
+	case "mysql":
+		return "migrations/mysql", nil
+	case "postgres", "pgx":
+		return "migrations/postgres", nil
+	case "sqlite", "sqlite3":
+		return "migrations/sqlite", nil
+	default:
+		return "", fmt.Errorf("data: no migrations for driver %q", driver)
+	}
+}
@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS `{{cookiecutter.file_name}}` (
+  `id` BIGINT NOT NULL AUTO_INCREMENT,
+  `hello` VARCHAR(255) NOT NULL DEFAULT '',
+  `created_at` DATETIME(3) NULL,
+  `updated_at` DATETIME(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_{{cookiecutter.file_name}}_hello` (`hello`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- +goose Down
+DROP TABLE IF EXISTS `{{cookiecutter.file_name}}`;
@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS `idempotency_keys` (
+  `id` VARCHAR(191) NOT NULL,
+  `done` BOOLEAN NOT NULL DEFAULT FALSE,
+  `payload` LONGBLOB NULL,
+  `expires_at` DATETIME(3) NULL,
+  `created_at` DATETIME(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_idempotency_keys_expires_at` (`expires_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- +goose Down
+DROP TABLE IF EXISTS `idempotency_keys`;
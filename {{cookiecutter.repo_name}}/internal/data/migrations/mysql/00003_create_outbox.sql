@@ -0,0 +1,23 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS `outbox` (
+  `id` BIGINT NOT NULL AUTO_INCREMENT,
+  `topic` VARCHAR(255) NOT NULL,
+  `msg_key` VARCHAR(255) NOT NULL DEFAULT '',
+  `dedup_key` VARCHAR(191) NOT NULL,
+  `payload` LONGBLOB NULL,
+  `headers` BLOB NULL,
+  `status` VARCHAR(16) NOT NULL,
+  `next_attempt_at` DATETIME(3) NULL,
+  `attempts` INT NOT NULL DEFAULT 0,
+  `last_error` VARCHAR(1024) NOT NULL DEFAULT '',
+  `claim_token` VARCHAR(64) NOT NULL DEFAULT '',
+  `sent_at` DATETIME(3) NULL,
+  `created_at` DATETIME(3) NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_outbox_dedup_key` (`dedup_key`),
+  INDEX `idx_outbox_status_next` (`status`, `next_attempt_at`),
+  INDEX `idx_outbox_claim_token` (`claim_token`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- +goose Down
+DROP TABLE IF EXISTS `outbox`;
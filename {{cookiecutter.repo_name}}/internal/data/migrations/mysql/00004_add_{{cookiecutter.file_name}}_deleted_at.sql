@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE `{{cookiecutter.file_name}}`
+  ADD COLUMN `deleted_at` DATETIME(3) NULL,
+  ADD INDEX `idx_{{cookiecutter.file_name}}_deleted_at` (`deleted_at`);
+
+-- +goose Down
+ALTER TABLE `{{cookiecutter.file_name}}`
+  DROP INDEX `idx_{{cookiecutter.file_name}}_deleted_at`,
+  DROP COLUMN `deleted_at`;
@@ -0,0 +1,11 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS "{{cookiecutter.file_name}}" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "hello" VARCHAR(255) NOT NULL DEFAULT '',
+  "created_at" TIMESTAMPTZ NULL,
+  "updated_at" TIMESTAMPTZ NULL
+);
+CREATE INDEX IF NOT EXISTS "idx_{{cookiecutter.file_name}}_hello" ON "{{cookiecutter.file_name}}" ("hello");
+
+-- +goose Down
+DROP TABLE IF EXISTS "{{cookiecutter.file_name}}";
@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS "idempotency_keys" (
+  "id" VARCHAR(191) PRIMARY KEY,
+  "done" BOOLEAN NOT NULL DEFAULT FALSE,
+  "payload" BYTEA NULL,
+  "expires_at" TIMESTAMPTZ NULL,
+  "created_at" TIMESTAMPTZ NULL
+);
+CREATE INDEX IF NOT EXISTS "idx_idempotency_keys_expires_at" ON "idempotency_keys" ("expires_at");
+
+-- +goose Down
+DROP TABLE IF EXISTS "idempotency_keys";
@@ -0,0 +1,22 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS "outbox" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "topic" VARCHAR(255) NOT NULL,
+  "msg_key" VARCHAR(255) NOT NULL DEFAULT '',
+  "dedup_key" VARCHAR(191) NOT NULL,
+  "payload" BYTEA NULL,
+  "headers" BYTEA NULL,
+  "status" VARCHAR(16) NOT NULL,
+  "next_attempt_at" TIMESTAMPTZ NULL,
+  "attempts" INTEGER NOT NULL DEFAULT 0,
+  "last_error" VARCHAR(1024) NOT NULL DEFAULT '',
+  "claim_token" VARCHAR(64) NOT NULL DEFAULT '',
+  "sent_at" TIMESTAMPTZ NULL,
+  "created_at" TIMESTAMPTZ NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_outbox_dedup_key" ON "outbox" ("dedup_key");
+CREATE INDEX IF NOT EXISTS "idx_outbox_status_next" ON "outbox" ("status", "next_attempt_at");
+CREATE INDEX IF NOT EXISTS "idx_outbox_claim_token" ON "outbox" ("claim_token");
+
+-- +goose Down
+DROP TABLE IF EXISTS "outbox";
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE "{{cookiecutter.file_name}}" ADD COLUMN IF NOT EXISTS "deleted_at" TIMESTAMPTZ NULL;
+CREATE INDEX IF NOT EXISTS "idx_{{cookiecutter.file_name}}_deleted_at" ON "{{cookiecutter.file_name}}" ("deleted_at");
+
+-- +goose Down
+DROP INDEX IF EXISTS "idx_{{cookiecutter.file_name}}_deleted_at";
+ALTER TABLE "{{cookiecutter.file_name}}" DROP COLUMN IF EXISTS "deleted_at";
@@ -0,0 +1,11 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS `{{cookiecutter.file_name}}` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `hello` TEXT NOT NULL DEFAULT '',
+  `created_at` DATETIME NULL,
+  `updated_at` DATETIME NULL
+);
+CREATE INDEX IF NOT EXISTS `idx_{{cookiecutter.file_name}}_hello` ON `{{cookiecutter.file_name}}` (`hello`);
+
+-- +goose Down
+DROP TABLE IF EXISTS `{{cookiecutter.file_name}}`;
@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS `idempotency_keys` (
+  `id` TEXT PRIMARY KEY,
+  `done` NUMERIC NOT NULL DEFAULT false,
+  `payload` BLOB NULL,
+  `expires_at` DATETIME NULL,
+  `created_at` DATETIME NULL
+);
+CREATE INDEX IF NOT EXISTS `idx_idempotency_keys_expires_at` ON `idempotency_keys` (`expires_at`);
+
+-- +goose Down
+DROP TABLE IF EXISTS `idempotency_keys`;
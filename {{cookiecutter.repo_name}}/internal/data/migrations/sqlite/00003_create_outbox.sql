@@ -0,0 +1,22 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS `outbox` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `topic` TEXT NOT NULL,
+  `msg_key` TEXT NOT NULL DEFAULT '',
+  `dedup_key` TEXT NOT NULL,
+  `payload` BLOB NULL,
+  `headers` BLOB NULL,
+  `status` TEXT NOT NULL,
+  `next_attempt_at` DATETIME NULL,
+  `attempts` INTEGER NOT NULL DEFAULT 0,
+  `last_error` TEXT NOT NULL DEFAULT '',
+  `claim_token` TEXT NOT NULL DEFAULT '',
+  `sent_at` DATETIME NULL,
+  `created_at` DATETIME NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_outbox_dedup_key` ON `outbox` (`dedup_key`);
+CREATE INDEX IF NOT EXISTS `idx_outbox_status_next` ON `outbox` (`status`, `next_attempt_at`);
+CREATE INDEX IF NOT EXISTS `idx_outbox_claim_token` ON `outbox` (`claim_token`);
+
+-- +goose Down
+DROP TABLE IF EXISTS `outbox`;
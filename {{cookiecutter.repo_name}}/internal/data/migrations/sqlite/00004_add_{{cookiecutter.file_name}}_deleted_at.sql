@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE `{{cookiecutter.file_name}}` ADD COLUMN `deleted_at` DATETIME NULL;
+CREATE INDEX IF NOT EXISTS `idx_{{cookiecutter.file_name}}_deleted_at` ON `{{cookiecutter.file_name}}` (`deleted_at`);
+
+-- +goose Down
+DROP INDEX IF EXISTS `idx_{{cookiecutter.file_name}}_deleted_at`;
+ALTER TABLE `{{cookiecutter.file_name}}` DROP COLUMN `deleted_at`;
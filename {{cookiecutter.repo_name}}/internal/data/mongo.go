@@ -0,0 +1,99 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"{{cookiecutter.module_name}}/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// defaultMongoTimeout 单次操作的默认超时时间
+const defaultMongoTimeout = 5 * time.Second
+
+// mongoIndexes 各集合的索引，启动时创建，已存在的同名索引不会重复创建
+var mongoIndexes = map[string][]mongo.IndexModel{
+	{{cookiecutter.file_name}}Collection: {
+		mongo.IndexModel{Keys: bson.D{bson.E{Key: "hello", Value: 1}}},
+		mongo.IndexModel{Keys: bson.D{bson.E{Key: "deleted_at", Value: 1}}},
+	},
+}
+
+// Mongo MongoDB 数据库，开启时示例 repo 使用 MongoDB 代替关系数据库
+type Mongo struct {
+	*mongo.Database
+	timeout time.Duration
+}
+
+// NewMongo 连接 MongoDB 并创建索引，未开启时返回 nil
+func NewMongo(c *conf.Data, logger log.Logger) (*Mongo, func(), error) {
+	mc := c.GetMongo()
+	if !mc.GetEnable() {
+		return nil, func() {}, nil
+	}
+	opts := options.Client().ApplyURI(mc.Uri).SetConnectTimeout(10 * time.Second)
+	if mc.ConnectTimeout != nil {
+		opts.SetConnectTimeout(mc.ConnectTimeout.AsDuration())
+	}
+	if mc.MaxPoolSize > 0 {
+		opts.SetMaxPoolSize(mc.MaxPoolSize)
+	}
+	client, err := mongo.Connect(context.Background(), opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("data: connect mongo: %w", err)
+	}
+	cleanup := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultMongoTimeout)
+		defer cancel()
+		if err := client.Disconnect(ctx); err != nil {
+			log.NewHelper(logger).Errorf("close mongo: %v", err)
+		}
+	}
+	m := &Mongo{Database: client.Database(mc.Database), timeout: defaultMongoTimeout}
+	if mc.Timeout != nil {
+		m.timeout = mc.Timeout.AsDuration()
+	}
+	if err := m.EnsureIndexes(context.Background(), mongoIndexes); err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	return m, cleanup, nil
+}
+
+// WithTimeout 为单次操作设置超时，ctx 已有更早的截止时间时保持不变
+func (m *Mongo) WithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, m.timeout)
+}
+
+// EnsureIndexes 按集合创建索引，索引定义相同时为幂等操作，定义冲突时返回错误
+func (m *Mongo) EnsureIndexes(ctx context.Context, indexes map[string][]mongo.IndexModel) error {
+	for _, name := range sortedNames(indexes) {
+		ctx, cancel := m.WithTimeout(ctx)
+		_, err := m.Collection(name).Indexes().CreateMany(ctx, indexes[name])
+		cancel()
+		if err != nil {
+			return fmt.Errorf("data: create indexes on %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// NextID 按计数器集合生成自增 id，供需要整数 id 的集合使用
+func (m *Mongo) NextID(ctx context.Context, name string) (int64, error) {
+	ctx, cancel := m.WithTimeout(ctx)
+	defer cancel()
+	var doc struct {
+		Seq int64 `bson:"seq"`
+	}
+	err := m.Collection("counters").FindOneAndUpdate(ctx,
+		bson.M{"_id": name},
+		bson.M{"$inc": bson.M{"seq": 1}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&doc)
+	return doc.Seq, err
+}
@@ -0,0 +1,222 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"{{cookiecutter.module_name}}/internal/biz"
+	"{{cookiecutter.module_name}}/internal/conf"
+	"{{cookiecutter.module_name}}/internal/pkg/eventbus"
+	"{{cookiecutter.module_name}}/internal/pkg/outbox"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// outboxEventPrefix outbox 发布到事件总线的 stream 前缀
+const outboxEventPrefix = "{{cookiecutter.repo_name}}:events:"
+
+const (
+	outboxPending = "pending"
+	outboxSent    = "sent"
+	outboxDead    = "dead"
+)
+
+// outboxModel outbox 消息，dedup_key 唯一，重复写入同一条消息时事务失败
+type outboxModel struct {
+	ID            int64  `gorm:"primaryKey"`
+	Topic         string `gorm:"size:255;not null"`
+	MsgKey        string `gorm:"size:255"`
+	DedupKey      string `gorm:"size:191;not null;uniqueIndex"`
+	Payload       []byte
+	Headers       []byte
+	Status        string    `gorm:"size:16;not null;index:idx_outbox_status_next,priority:1"`
+	NextAttemptAt time.Time `gorm:"index:idx_outbox_status_next,priority:2"`
+	Attempts      int32     `gorm:"not null;default:0"`
+	LastError     string    `gorm:"size:1024"`
+	ClaimToken    string    `gorm:"size:64;index"`
+	SentAt        *time.Time
+	CreatedAt     time.Time
+}
+
+// TableName 表名
+func (outboxModel) TableName() string {
+	return "outbox"
+}
+
+// toMessage 转换为 relay 发布的消息
+func (m *outboxModel) toMessage() *outbox.Message {
+	msg := &outbox.Message{
+		ID:       m.ID,
+		Topic:    m.Topic,
+		Key:      m.MsgKey,
+		DedupKey: m.DedupKey,
+		Payload:  m.Payload,
+		Attempts: m.Attempts,
+	}
+	if len(m.Headers) > 0 {
+		_ = json.Unmarshal(m.Headers, &msg.Headers)
+	}
+	return msg
+}
+
+// outboxRepo 将消息写入 outbox 表，ctx 在 InTx 事务中时随业务数据一起提交
+type outboxRepo struct {
+	data *Data
+}
+
+// NewOutboxRepo 创建 outbox 仓储，未开启 data.outbox 时丢弃消息
+func NewOutboxRepo(c *conf.Data, data *Data) biz.OutboxRepo {
+	if !c.Outbox.GetEnable() || data.db == nil {
+		return discardOutboxRepo{}
+	}
+	return &outboxRepo{data: data}
+}
+
+// Add 实现 biz.OutboxRepo
+func (r *outboxRepo) Add(ctx context.Context, msgs ...*biz.OutboxMessage) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	now := time.Now()
+	rows := make([]*outboxModel, 0, len(msgs))
+	for _, m := range msgs {
+		row := &outboxModel{
+			Topic:         m.Topic,
+			MsgKey:        m.Key,
+			DedupKey:      m.DedupKey,
+			Payload:       m.Payload,
+			Status:        outboxPending,
+			NextAttemptAt: now,
+		}
+		if row.DedupKey == "" {
+			row.DedupKey = uuid.NewString()
+		}
+		if len(m.Headers) > 0 {
+			b, err := json.Marshal(m.Headers)
+			if err != nil {
+				return err
+			}
+			row.Headers = b
+		}
+		rows = append(rows, row)
+	}
+	return r.data.DB(ctx).Create(&rows).Error
+}
+
+// discardOutboxRepo 未开启 outbox 时使用
+type discardOutboxRepo struct{}
+
+func (discardOutboxRepo) Add(context.Context, ...*biz.OutboxMessage) error { return nil }
+
+// outboxStore 基于 GORM 的 outbox.Store，先选出到期的消息，再用随机 token 条件更新领取，
+// 不依赖 SKIP LOCKED，MySQL、PostgreSQL 和 SQLite 行为一致
+type outboxStore struct {
+	data *Data
+}
+
+// db relay 不参与业务事务，读写都使用主库
+func (s *outboxStore) db(ctx context.Context) *gorm.DB {
+	return s.data.db.WithContext(ctx).Clauses(dbresolver.Write)
+}
+
+// Claim 实现 outbox.Store
+func (s *outboxStore) Claim(ctx context.Context, limit int, lease time.Duration) ([]*outbox.Message, error) {
+	now := time.Now()
+	var ids []int64
+	err := s.db(ctx).Model(&outboxModel{}).
+		Where("status = ? AND next_attempt_at <= ?", outboxPending, now).
+		Order("id").Limit(limit).Pluck("id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+	token := uuid.NewString()
+	err = s.db(ctx).Model(&outboxModel{}).
+		Where("id IN ? AND status = ? AND next_attempt_at <= ?", ids, outboxPending, now).
+		Updates(map[string]interface{}{"claim_token": token, "next_attempt_at": now.Add(lease)}).Error
+	if err != nil {
+		return nil, err
+	}
+	var rows []*outboxModel
+	if err := s.db(ctx).Where("claim_token = ?", token).Order("id").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	msgs := make([]*outbox.Message, 0, len(rows))
+	for _, row := range rows {
+		msgs = append(msgs, row.toMessage())
+	}
+	return msgs, nil
+}
+
+// MarkSent 实现 outbox.Store
+func (s *outboxStore) MarkSent(ctx context.Context, ids []int64) error {
+	return s.db(ctx).Model(&outboxModel{}).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{"status": outboxSent, "sent_at": time.Now(), "claim_token": ""}).Error
+}
+
+// MarkFailed 实现 outbox.Store
+func (s *outboxStore) MarkFailed(ctx context.Context, m *outbox.Message, cause error, next time.Time, dead bool) error {
+	status := outboxPending
+	if dead {
+		status = outboxDead
+	}
+	msg := cause.Error()
+	if len(msg) > 1024 {
+		msg = msg[:1024]
+	}
+	return s.db(ctx).Model(&outboxModel{}).
+		Where("id = ?", m.ID).
+		Updates(map[string]interface{}{
+			"status":          status,
+			"attempts":        m.Attempts + 1,
+			"last_error":      msg,
+			"next_attempt_at": next,
+			"claim_token":     "",
+		}).Error
+}
+
+// Purge 实现 outbox.Store
+func (s *outboxStore) Purge(ctx context.Context, before time.Time) (int64, error) {
+	res := s.db(ctx).Where("status = ? AND sent_at < ?", outboxSent, before).Delete(&outboxModel{})
+	return res.RowsAffected, res.Error
+}
+
+// OutboxRelay 开启 data.outbox 时的后台 relay，作为 kratos 的 Server 随应用启停，未开启时 Relay 为 nil
+type OutboxRelay struct {
+	*outbox.Relay
+}
+
+// NewOutboxRelay 创建 relay，消息发布到默认 Redis 的事件总线
+func NewOutboxRelay(c *conf.Data, data *Data, logger log.Logger) (OutboxRelay, error) {
+	oc := c.GetOutbox()
+	if !oc.GetEnable() {
+		return OutboxRelay{}, nil
+	}
+	rdb := data.rdbs.Default()
+	if data.db == nil || rdb == nil {
+		return OutboxRelay{}, fmt.Errorf("data: outbox requires database %q and redis %q", DefaultInstance, DefaultInstance)
+	}
+	var opts []outbox.Option
+	if oc.BatchSize > 0 {
+		opts = append(opts, outbox.WithBatch(int(oc.BatchSize)))
+	}
+	if d := oc.Interval.AsDuration(); d > 0 {
+		opts = append(opts, outbox.WithInterval(d))
+	}
+	if oc.MaxAttempts > 0 {
+		opts = append(opts, outbox.WithMaxAttempts(oc.MaxAttempts))
+	}
+	if d := oc.Lease.AsDuration(); d > 0 {
+		opts = append(opts, outbox.WithLease(d))
+	}
+	if oc.Retention != nil {
+		opts = append(opts, outbox.WithRetention(oc.Retention.AsDuration()))
+	}
+	bus := eventbus.NewRedisBus(rdb, logger, eventbus.WithPrefix(outboxEventPrefix))
+	return OutboxRelay{outbox.NewRelay(&outboxStore{data: data}, bus, logger, opts...)}, nil
+}
@@ -0,0 +1,112 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"{{cookiecutter.module_name}}/internal/biz"
+)
+
+func TestOutboxAddInTx(t *testing.T) {
+	data := newTestData(t)
+	repo := &outboxRepo{data: data}
+	ctx := context.Background()
+
+	// 事务回滚时消息一起回滚
+	rollback := errors.New("rollback")
+	err := data.InTx(ctx, func(ctx context.Context) error {
+		if err := repo.Add(ctx, &biz.OutboxMessage{Topic: "greeter.created"}); err != nil {
+			return err
+		}
+		return rollback
+	})
+	if !errors.Is(err, rollback) {
+		t.Fatal(err)
+	}
+	var n int64
+	data.db.Model(&outboxModel{}).Count(&n)
+	if n != 0 {
+		t.Fatalf("%d messages after rollback, want 0", n)
+	}
+
+	err = data.InTx(ctx, func(ctx context.Context) error {
+		return repo.Add(ctx,
+			&biz.OutboxMessage{Topic: "greeter.created", DedupKey: "d1", Headers: map[string]string{"trace": "t1"}},
+			&biz.OutboxMessage{Topic: "greeter.created"},
+		)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rows []*outboxModel
+	data.db.Order("id").Find(&rows)
+	if len(rows) != 2 || rows[0].DedupKey != "d1" || rows[1].DedupKey == "" || rows[0].Status != outboxPending {
+		t.Fatalf("rows = %+v, want two pending messages with dedup keys", rows)
+	}
+	// 同一个去重键重复写入时失败
+	if err := repo.Add(ctx, &biz.OutboxMessage{Topic: "greeter.created", DedupKey: "d1"}); err == nil {
+		t.Error("duplicate dedup key: want error")
+	}
+}
+
+func TestOutboxStore(t *testing.T) {
+	data := newTestData(t)
+	repo := &outboxRepo{data: data}
+	store := &outboxStore{data: data}
+	ctx := context.Background()
+
+	for _, key := range []string{"d1", "d2", "d3"} {
+		if err := repo.Add(ctx, &biz.OutboxMessage{Topic: "greeter.created", DedupKey: key, Headers: map[string]string{"k": key}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	msgs, err := store.Claim(ctx, 2, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 2 || msgs[0].DedupKey != "d1" || msgs[0].Headers["k"] != "d1" {
+		t.Fatalf("claimed %+v, want d1 and d2", msgs)
+	}
+	// 租期内不会被再次领取
+	again, err := store.Claim(ctx, 10, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again) != 1 || again[0].DedupKey != "d3" {
+		t.Fatalf("claimed %+v during the lease, want only d3", again)
+	}
+
+	if err := store.MarkSent(ctx, []int64{msgs[0].ID}); err != nil {
+		t.Fatal(err)
+	}
+	// 失败后按 next 重试，dead 后不再领取
+	if err := store.MarkFailed(ctx, msgs[1], errors.New("broker down"), time.Now().Add(-time.Second), false); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.MarkFailed(ctx, again[0], errors.New("broker down"), time.Now(), true); err != nil {
+		t.Fatal(err)
+	}
+	retry, err := store.Claim(ctx, 10, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(retry) != 1 || retry[0].DedupKey != "d2" || retry[0].Attempts != 1 {
+		t.Fatalf("claimed %+v after failures, want d2 with 1 attempt", retry)
+	}
+	var dead outboxModel
+	data.db.Where("dedup_key = ?", "d3").Take(&dead)
+	if dead.Status != outboxDead || dead.LastError != "broker down" {
+		t.Errorf("d3 = %s (%q), want dead with the last error", dead.Status, dead.LastError)
+	}
+
+	// 只清理早于 before 的已发送消息
+	if n, err := store.Purge(ctx, time.Now().Add(-time.Hour)); err != nil || n != 0 {
+		t.Fatalf("purge old: n = %d, err = %v", n, err)
+	}
+	if n, err := store.Purge(ctx, time.Now().Add(time.Second)); err != nil || n != 1 {
+		t.Fatalf("purge: n = %d, err = %v, want 1", n, err)
+	}
+}
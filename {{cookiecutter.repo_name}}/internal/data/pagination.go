@@ -0,0 +1,50 @@
+package data
+
+import (
+	"{{cookiecutter.module_name}}/internal/pkg/pagination"
+
+	"gorm.io/gorm"
+)
+
+// paginate 分页 scope，按 id 升序，提供游标时查询游标之后的记录，否则按偏移查询，多取一条用于判断是否还有下一页
+func paginate(req pagination.Request) (func(*gorm.DB) *gorm.DB, error) {
+	after, err := req.AfterID()
+	if err != nil {
+		return nil, err
+	}
+	return func(db *gorm.DB) *gorm.DB {
+		if req.Cursor != "" {
+			db = db.Where("id > ?", after)
+		} else {
+			db = db.Offset(req.Offset())
+		}
+		return db.Order("id").Limit(req.Limit() + 1)
+	}, nil
+}
+
+// findPage 按 req 查询一页记录，WithTotal 时先统计同样条件下不分页的总数
+//
+//	rows, res, err := findPage(r.data.DB(ctx).Where("hello = ?", hello), req, func(m *Model) int64 { return m.ID })
+func findPage[T any](db *gorm.DB, req pagination.Request, id func(*T) int64) ([]*T, *pagination.Result, error) {
+	scope, err := paginate(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	// 统计和查询分别使用独立的 Statement，避免条件互相影响
+	db = db.Session(&gorm.Session{})
+	res := &pagination.Result{Total: -1}
+	if req.WithTotal {
+		if err := db.Model(new(T)).Count(&res.Total).Error; err != nil {
+			return nil, nil, err
+		}
+	}
+	var rows []*T
+	if err := db.Scopes(scope).Find(&rows).Error; err != nil {
+		return nil, nil, err
+	}
+	rows, res.NextCursor, err = pagination.Trim(rows, req, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rows, res, nil
+}
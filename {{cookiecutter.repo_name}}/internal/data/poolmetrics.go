@@ -0,0 +1,79 @@
+package data
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// registerPoolMetrics 注册数据库和 Redis 连接池指标，每次抓取 /metrics 时读取连接池的当前状态，
+// 按 pool.type（database、redis）和 pool.name 区分，返回的函数注销指标回调
+//
+// Redis 连接池没有等待时长统计，wait_count 为取连接时没有空闲连接的次数，另外记录等待超时次数
+func registerPoolMetrics(dbs Databases, rdbs RedisClients) (func(), error) {
+	meter := otel.Meter("data")
+	inUse, err := meter.Int64ObservableGauge(
+		"pool.connections.in_use",
+		metric.WithDescription("Number of connections currently in use"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	idle, err := meter.Int64ObservableGauge(
+		"pool.connections.idle",
+		metric.WithDescription("Number of idle connections in the pool"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	waitCount, err := meter.Int64ObservableGauge(
+		"pool.wait_count",
+		metric.WithDescription("Total number of times a caller had to wait for a connection"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	waitDuration, err := meter.Float64ObservableGauge(
+		"pool.wait_duration",
+		metric.WithDescription("Total time blocked waiting for a connection"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	timeouts, err := meter.Int64ObservableGauge(
+		"pool.timeouts",
+		metric.WithDescription("Total number of times waiting for a Redis connection timed out"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	dbNames, rdbNames := sortedNames(dbs), sortedNames(rdbs)
+	reg, err := meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		for _, name := range dbNames {
+			s := dbs[name].Stats()
+			attrs := metric.WithAttributes(attribute.String("pool.type", "database"), attribute.String("pool.name", name))
+			o.ObserveInt64(inUse, int64(s.InUse), attrs)
+			o.ObserveInt64(idle, int64(s.Idle), attrs)
+			o.ObserveInt64(waitCount, s.WaitCount, attrs)
+			o.ObserveFloat64(waitDuration, s.WaitDuration.Seconds(), attrs)
+		}
+		for _, name := range rdbNames {
+			s := rdbs[name].PoolStats()
+			attrs := metric.WithAttributes(attribute.String("pool.type", "redis"), attribute.String("pool.name", name))
+			o.ObserveInt64(inUse, int64(s.TotalConns)-int64(s.IdleConns), attrs)
+			o.ObserveInt64(idle, int64(s.IdleConns), attrs)
+			o.ObserveInt64(waitCount, int64(s.Misses), attrs)
+			o.ObserveInt64(timeouts, int64(s.Timeouts), attrs)
+		}
+		return nil
+	}, inUse, idle, waitCount, waitDuration, timeouts)
+	if err != nil {
+		return nil, fmt.Errorf("data: register pool metrics: %w", err)
+	}
+	return func() { _ = reg.Unregister() }, nil
+}
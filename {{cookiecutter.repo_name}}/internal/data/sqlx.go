@@ -0,0 +1,71 @@
+//go:build sqlx
+
+package data
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// newSqlxDB 在默认数据库的连接池上创建 sqlx，占位符按驱动自动转换
+func newSqlxDB(data *Data) *sqlx.DB {
+	return sqlx.NewDb(data.dbs.Default(), sqlDriver(data.db.Dialector.Name()))
+}
+
+// namedGet 执行命名参数查询并扫描单行到 dest，无结果时返回 sql.ErrNoRows
+//
+//	namedGet(ctx, db, &m, "SELECT * FROM t WHERE id = :id", map[string]interface{}{"id": id})
+func namedGet(ctx context.Context, db *sqlx.DB, dest interface{}, query string, arg interface{}) error {
+	q, args, err := bindNamed(db, query, arg)
+	if err != nil {
+		return err
+	}
+	return db.GetContext(ctx, dest, q, args...)
+}
+
+// namedSelect 执行命名参数查询并扫描全部行到切片 dest
+func namedSelect(ctx context.Context, db *sqlx.DB, dest interface{}, query string, arg interface{}) error {
+	q, args, err := bindNamed(db, query, arg)
+	if err != nil {
+		return err
+	}
+	return db.SelectContext(ctx, dest, q, args...)
+}
+
+// namedExec 执行命名参数语句，返回影响的行数
+func namedExec(ctx context.Context, db *sqlx.DB, query string, arg interface{}) (int64, error) {
+	res, err := db.NamedExecContext(ctx, query, arg)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// namedInsert 执行命名参数的 INSERT 语句，返回自增 id；
+// pgx 不支持 LastInsertId，改为追加 RETURNING id 查询
+func namedInsert(ctx context.Context, db *sqlx.DB, query string, arg interface{}) (int64, error) {
+	if db.DriverName() == "pgx" {
+		var id int64
+		err := namedGet(ctx, db, &id, query+" RETURNING id", arg)
+		return id, err
+	}
+	res, err := db.NamedExecContext(ctx, query, arg)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+// bindNamed 将命名参数转换为驱动的占位符，切片参数展开为 IN 列表
+func bindNamed(db *sqlx.DB, query string, arg interface{}) (string, []interface{}, error) {
+	q, args, err := sqlx.Named(query, arg)
+	if err != nil {
+		return "", nil, err
+	}
+	q, args, err = sqlx.In(q, args...)
+	if err != nil {
+		return "", nil, err
+	}
+	return db.Rebind(q), args, nil
+}
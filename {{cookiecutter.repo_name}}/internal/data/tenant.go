@@ -0,0 +1,374 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"strings"
+	"sync"
+	"time"
+
+	"{{cookiecutter.module_name}}/internal/conf"
+	"{{cookiecutter.module_name}}/internal/pkg/migrate"
+	"{{cookiecutter.module_name}}/internal/pkg/tenant"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"golang.org/x/sync/singleflight"
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultMaxTenants 默认最多缓存的租户连接池数
+	defaultMaxTenants = 64
+	// defaultTenantIdleTimeout 租户连接池默认空闲关闭时间
+	defaultTenantIdleTimeout = 10 * time.Minute
+	// tenantPlaceholder 连接串和 schema 模板中的租户 id 占位符
+	tenantPlaceholder = "{tenant}"
+	// tenantCloseDelay 移出缓存的连接池延迟关闭，已取到连接池的请求在此期间仍可执行查询
+	tenantCloseDelay = time.Minute
+)
+
+// ErrTenantNotFound 租户不在 tenants 配置中，且开启了 strict 或租户 id 只来自未经认证的请求头
+var ErrTenantNotFound = errors.NotFound("TENANT_NOT_FOUND", "tenant not found")
+
+// TenantRouter 按租户选择数据库，租户连接池在首次使用时打开并缓存，
+// 超出 max_tenants 时移出最久未使用的，空闲超过 idle_timeout 的定期移出，移出的连接池延迟关闭
+type TenantRouter struct {
+	c      *conf.Data_Tenancy
+	dc     *conf.Data_Database
+	logger log.Logger
+	log    *log.Helper
+	delay  time.Duration
+
+	group    singleflight.Group
+	mu       sync.Mutex
+	conns    map[string]*tenantConn
+	retiring map[string]*tenantConn
+	closing  sync.WaitGroup
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// tenantConn 租户连接池
+type tenantConn struct {
+	db    *sql.DB
+	gorm  *gorm.DB
+	used  time.Time
+	timer *time.Timer // 移出缓存后的延迟关闭
+}
+
+// NewTenantRouter 创建租户路由，租户连接沿用默认数据库的驱动和连接池配置，未开启 data.tenancy 时返回 nil
+func NewTenantRouter(c *conf.Data, logger log.Logger) (*TenantRouter, func(), error) {
+	if !c.GetTenancy().GetEnable() {
+		return nil, func() {}, nil
+	}
+	dc := databaseConfigs(c)[DefaultInstance]
+	if dc == nil {
+		return nil, nil, fmt.Errorf("data: tenancy needs database %q", DefaultInstance)
+	}
+	r := &TenantRouter{
+		c:        c.Tenancy,
+		dc:       dc,
+		logger:   logger,
+		log:      log.NewHelper(log.With(logger, "module", "data/tenant")),
+		delay:    tenantCloseDelay,
+		conns:    make(map[string]*tenantConn),
+		retiring: make(map[string]*tenantConn),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	idle := defaultTenantIdleTimeout
+	if r.c.IdleTimeout != nil {
+		idle = r.c.IdleTimeout.AsDuration()
+	}
+	if idle > 0 {
+		go r.janitor(idle)
+	} else {
+		close(r.done)
+	}
+	return r, r.Close, nil
+}
+
+// DB 返回租户的 GORM，连接池未打开时打开，不在请求路径上执行迁移
+// 未开启 strict 时，不在 tenants 中的租户只能来自令牌 claim 或代码设置的 ctx，只来自请求头时返回 404
+func (r *TenantRouter) DB(ctx context.Context, id string) (*gorm.DB, error) {
+	r.mu.Lock()
+	if tc, ok := r.acquireLocked(id); ok {
+		r.mu.Unlock()
+		return tc.gorm, nil
+	}
+	r.mu.Unlock()
+
+	if _, ok := r.c.Tenants[id]; !ok && (r.c.Strict || tenant.FromHeader(ctx)) {
+		return nil, ErrTenantNotFound.WithMetadata(map[string]string{"tenant": id})
+	}
+	// 同一租户的并发请求只打开一次，打开过程不随单个请求取消
+	v, err, _ := r.group.Do(id, func() (interface{}, error) {
+		r.mu.Lock()
+		if tc, ok := r.acquireLocked(id); ok {
+			r.mu.Unlock()
+			return tc, nil
+		}
+		r.mu.Unlock()
+		tc, err := r.open(context.WithoutCancel(ctx), id)
+		if err != nil {
+			return nil, err
+		}
+		r.mu.Lock()
+		r.evictLocked()
+		r.conns[id] = tc
+		r.mu.Unlock()
+		return tc, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*tenantConn).gorm, nil
+}
+
+// Tenants tenants 中显式配置的租户，按名称排序
+func (r *TenantRouter) Tenants() []string {
+	return sortedNames(r.c.Tenants)
+}
+
+// Migrate 对 tenants 中的全部租户执行版本化迁移，开启 run_migrations 时在服务启动时调用
+// 按模板动态使用的租户需在发布前通过 migrate tenant <id> 迁移
+func (r *TenantRouter) Migrate(ctx context.Context) error {
+	for _, id := range r.Tenants() {
+		m, cleanup, err := r.Migrator(ctx, id)
+		if err != nil {
+			return err
+		}
+		err = m.Up(ctx)
+		cleanup()
+		if err != nil {
+			return fmt.Errorf("data: migrate tenant %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// Migrator 创建租户的迁移执行器，使用独立的连接池，调用方用完后调用 cleanup
+// 每个租户使用各自的迁移锁，不同租户可以并行迁移
+func (r *TenantRouter) Migrator(ctx context.Context, id string) (*migrate.Runner, func(), error) {
+	db, err := r.connect(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	m, err := r.migrator(db, id)
+	if err != nil {
+		db.Close()
+		return nil, nil, err
+	}
+	return m, func() { db.Close() }, nil
+}
+
+// Close 关闭全部租户连接池，包括等待延迟关闭的，服务停止时请求已处理完
+func (r *TenantRouter) Close() {
+	select {
+	case <-r.stop:
+	default:
+		close(r.stop)
+	}
+	<-r.done
+	r.mu.Lock()
+	conns, retiring := r.conns, make(map[string]*tenantConn)
+	r.conns = make(map[string]*tenantConn)
+	for id, tc := range r.retiring {
+		// 计时器已触发的由回调关闭，closing 等待它们完成
+		if tc.timer.Stop() {
+			retiring[id] = tc
+			r.closing.Done()
+		}
+	}
+	r.retiring = make(map[string]*tenantConn)
+	r.mu.Unlock()
+	r.close(conns)
+	r.close(retiring)
+	r.closing.Wait()
+}
+
+// open 打开租户连接池
+func (r *TenantRouter) open(ctx context.Context, id string) (*tenantConn, error) {
+	db, err := r.connect(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	gdb, err := openGorm(r.dc, db, r.logger)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("data: open gorm for tenant %s: %w", id, err)
+	}
+	r.log.Infof("opened database for tenant %s", id)
+	return &tenantConn{db: db, gorm: gdb, used: time.Now()}, nil
+}
+
+// connect 打开租户的连接池，配置了 schema 时先创建 schema 并将 search_path 指向它
+func (r *TenantRouter) connect(ctx context.Context, id string) (*sql.DB, error) {
+	source, schema, err := r.target(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	driver := sqlDriver(r.dc.Driver)
+	if schema != "" {
+		if err := createSchema(ctx, driver, source, schema); err != nil {
+			return nil, fmt.Errorf("data: create schema for tenant %s: %w", id, err)
+		}
+		source = withSearchPath(source, schema)
+	}
+	db, err := sql.Open(driver, source)
+	if err != nil {
+		return nil, fmt.Errorf("data: open database for tenant %s: %w", id, err)
+	}
+	setPool(db, r.dc)
+	return db, nil
+}
+
+// target 租户的连接串和 schema，tenants 中的配置优先，其次按模板替换租户 id
+func (r *TenantRouter) target(ctx context.Context, id string) (source, schema string, err error) {
+	t, ok := r.c.Tenants[id]
+	if !ok && (r.c.Strict || tenant.FromHeader(ctx)) {
+		return "", "", ErrTenantNotFound.WithMetadata(map[string]string{"tenant": id})
+	}
+	source = t.GetSource()
+	if source == "" {
+		source = r.dc.Source
+		if r.c.Source != "" {
+			source = strings.ReplaceAll(r.c.Source, tenantPlaceholder, id)
+		}
+	}
+	schema = t.GetSchema()
+	if schema == "" && r.c.Schema != "" {
+		schema = strings.ReplaceAll(r.c.Schema, tenantPlaceholder, id)
+	}
+	return source, schema, nil
+}
+
+// migrator 租户连接池上的迁移执行器
+func (r *TenantRouter) migrator(db *sql.DB, id string) (*migrate.Runner, error) {
+	dir, err := migrationDir(r.dc.Driver)
+	if err != nil {
+		return nil, err
+	}
+	fsys, err := fs.Sub(migrationsFS, dir)
+	if err != nil {
+		return nil, err
+	}
+	return migrate.NewRunner(db, r.dc.Driver, fsys, migrateLock("tenant:"+id), r.dc.MigrateLockTimeout.AsDuration(), r.logger)
+}
+
+// acquireLocked 取出缓存的连接池并更新使用时间，等待关闭的连接池重新放回缓存，需要持有 r.mu
+func (r *TenantRouter) acquireLocked(id string) (*tenantConn, bool) {
+	tc, ok := r.conns[id]
+	if !ok {
+		tc, ok = r.retiring[id]
+		// 计时器已触发时连接池正在关闭，重新打开
+		if !ok || !tc.timer.Stop() {
+			return nil, false
+		}
+		r.closing.Done()
+		delete(r.retiring, id)
+		r.evictLocked()
+		r.conns[id] = tc
+	}
+	tc.used = time.Now()
+	return tc, true
+}
+
+// evictLocked 连接池数达到 max_tenants 时移出最久未使用的，需要持有 r.mu
+func (r *TenantRouter) evictLocked() {
+	limit := int(r.c.MaxTenants)
+	if limit <= 0 {
+		limit = defaultMaxTenants
+	}
+	for len(r.conns) >= limit {
+		var oldest string
+		for id, tc := range r.conns {
+			if oldest == "" || tc.used.Before(r.conns[oldest].used) {
+				oldest = id
+			}
+		}
+		r.retireLocked(oldest)
+	}
+}
+
+// retireLocked 将连接池移出缓存，delay 后在后台关闭，需要持有 r.mu
+// 请求取到 *gorm.DB 后可能稍后才执行查询，立即关闭会使这些查询返回 sql: database is closed
+func (r *TenantRouter) retireLocked(id string) {
+	tc := r.conns[id]
+	delete(r.conns, id)
+	// 同一租户等待关闭的旧连接池计时器已触发，由它自己的回调关闭
+	r.retiring[id] = tc
+	r.closing.Add(1)
+	tc.timer = time.AfterFunc(r.delay, func() {
+		defer r.closing.Done()
+		r.mu.Lock()
+		if r.retiring[id] == tc {
+			delete(r.retiring, id)
+		}
+		r.mu.Unlock()
+		r.close(map[string]*tenantConn{id: tc})
+	})
+}
+
+// janitor 定期关闭空闲的租户连接池
+func (r *TenantRouter) janitor(idle time.Duration) {
+	defer close(r.done)
+	ticker := time.NewTicker(max(idle/2, time.Second))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.stop:
+			return
+		case now := <-ticker.C:
+			r.mu.Lock()
+			for id, tc := range r.conns {
+				if now.Sub(tc.used) > idle {
+					r.retireLocked(id)
+				}
+			}
+			r.mu.Unlock()
+		}
+	}
+}
+
+// close 关闭连接池，sql.DB.Close 只等待已开始的查询结束，之后发起的查询返回 sql: database is closed
+func (r *TenantRouter) close(conns map[string]*tenantConn) {
+	for id, tc := range conns {
+		if err := tc.db.Close(); err != nil {
+			r.log.Errorf("close database for tenant %s: %v", id, err)
+			continue
+		}
+		r.log.Infof("closed database for tenant %s", id)
+	}
+}
+
+// createSchema 在 PostgreSQL 中创建租户 schema，已存在时忽略
+func createSchema(ctx context.Context, driver, source, schema string) error {
+	db, err := sql.Open(driver, source)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	_, err = db.ExecContext(ctx, "CREATE SCHEMA IF NOT EXISTS "+quoteIdent(schema))
+	return err
+}
+
+// withSearchPath 在 PostgreSQL 连接串上设置 search_path，支持 URL 和 key=value 两种格式
+func withSearchPath(source, schema string) string {
+	if !strings.Contains(source, "://") {
+		return source + " search_path=" + schema
+	}
+	sep := "?"
+	if strings.Contains(source, "?") {
+		sep = "&"
+	}
+	return source + sep + "search_path=" + schema
+}
+
+// quoteIdent 按 SQL 标准用双引号引用标识符
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
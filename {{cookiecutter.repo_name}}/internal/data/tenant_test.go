@@ -0,0 +1,191 @@
+package data
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"{{cookiecutter.module_name}}/internal/conf"
+	"{{cookiecutter.module_name}}/internal/pkg/tenant"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// newTestRouter 每个租户一个 sqlite 文件的租户路由，不启动空闲回收
+func newTestRouter(t *testing.T, tc *conf.Data_Tenancy) *TenantRouter {
+	t.Helper()
+	dir := t.TempDir()
+	tc.Enable = true
+	tc.Source = "file:" + filepath.Join(dir, "{tenant}.db")
+	tc.IdleTimeout = durationpb.New(0)
+	c := &conf.Data{
+		Database: &conf.Data_Database{Driver: "sqlite", Source: "file:" + filepath.Join(dir, "default.db")},
+		Tenancy:  tc,
+	}
+	r, cleanup, err := NewTenantRouter(c, log.DefaultLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(cleanup)
+	return r
+}
+
+// headerTransport 只带请求头的 transport，租户 id 来自 X-Tenant-ID
+type headerTransport struct {
+	header transportHeader
+}
+
+func (t headerTransport) Kind() transport.Kind            { return transport.KindHTTP }
+func (t headerTransport) Endpoint() string                { return "" }
+func (t headerTransport) Operation() string               { return "/test" }
+func (t headerTransport) RequestHeader() transport.Header { return t.header }
+func (t headerTransport) ReplyHeader() transport.Header   { return transportHeader{} }
+
+// transportHeader 测试用请求头
+type transportHeader http.Header
+
+func (h transportHeader) Get(key string) string      { return http.Header(h).Get(key) }
+func (h transportHeader) Set(key, value string)      { http.Header(h).Set(key, value) }
+func (h transportHeader) Add(key, value string)      { http.Header(h).Add(key, value) }
+func (h transportHeader) Keys() []string             { return nil }
+func (h transportHeader) Values(key string) []string { return http.Header(h).Values(key) }
+
+// headerContext 经过租户中间件的 ctx，租户 id 只来自请求头
+func headerContext(t *testing.T, id string) context.Context {
+	t.Helper()
+	h := transportHeader{}
+	h.Set(tenant.DefaultHeader, id)
+	var ctx context.Context
+	_, err := tenant.Server()(func(c context.Context, _ interface{}) (interface{}, error) {
+		ctx = c
+		return nil, nil
+	})(transport.NewServerContext(context.Background(), headerTransport{header: h}), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ctx
+}
+
+func TestTenantRouterStrict(t *testing.T) {
+	r := newTestRouter(t, &conf.Data_Tenancy{
+		Strict:  true,
+		Tenants: map[string]*conf.Data_Tenancy_Tenant{"acme": {}},
+	})
+	if _, err := r.DB(context.Background(), "acme"); err != nil {
+		t.Fatalf("listed tenant: %v", err)
+	}
+	if _, err := r.DB(context.Background(), "other"); !errors.Is(err, ErrTenantNotFound) {
+		t.Fatalf("unlisted tenant: err = %v, want ErrTenantNotFound", err)
+	}
+}
+
+func TestTenantRouterNonStrict(t *testing.T) {
+	r := newTestRouter(t, &conf.Data_Tenancy{
+		Tenants: map[string]*conf.Data_Tenancy_Tenant{"acme": {}},
+	})
+	// 请求头未经认证，不为其中的未知租户按模板创建连接
+	if _, err := r.DB(headerContext(t, "other"), "other"); !errors.Is(err, ErrTenantNotFound) {
+		t.Fatalf("unlisted tenant from header: err = %v, want ErrTenantNotFound", err)
+	}
+	if _, err := r.DB(headerContext(t, "acme"), "acme"); err != nil {
+		t.Fatalf("listed tenant from header: %v", err)
+	}
+	// 令牌 claim 或后台任务设置的租户按模板创建连接
+	if _, err := r.DB(tenant.NewContext(context.Background(), "other"), "other"); err != nil {
+		t.Fatalf("unlisted tenant from claim: %v", err)
+	}
+}
+
+func TestTenantRouterMigrate(t *testing.T) {
+	r := newTestRouter(t, &conf.Data_Tenancy{
+		Strict:  true,
+		Tenants: map[string]*conf.Data_Tenancy_Tenant{"acme": {}, "globex": {}},
+	})
+	if err := r.Migrate(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range r.Tenants() {
+		db, err := r.DB(context.Background(), id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !db.Migrator().HasTable("{{cookiecutter.file_name}}") {
+			t.Errorf("tenant %s: table not migrated", id)
+		}
+	}
+}
+
+func TestTenantRouterEvictDelaysClose(t *testing.T) {
+	r := newTestRouter(t, &conf.Data_Tenancy{MaxTenants: 1})
+	r.delay = 50 * time.Millisecond
+	ctx := context.Background()
+
+	a, err := r.DB(ctx, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.DB(ctx, "b"); err != nil {
+		t.Fatal(err)
+	}
+	// a 已被移出缓存，但取到它的请求仍可以执行查询
+	if err := a.Exec("SELECT 1").Error; err != nil {
+		t.Fatalf("query on evicted pool within the grace period: %v", err)
+	}
+	// 再次使用时取回等待关闭的连接池，不重新打开
+	again, err := r.DB(ctx, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again != a {
+		t.Error("retiring pool was not reused")
+	}
+
+	// b 被移出，超过延迟后关闭
+	r.mu.Lock()
+	b := r.retiring["b"]
+	r.mu.Unlock()
+	if b == nil {
+		t.Fatal("b is not retiring")
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for b.db.Ping() == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("evicted pool was not closed after the delay")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestTenantRouterCloseRetiring(t *testing.T) {
+	r := newTestRouter(t, &conf.Data_Tenancy{MaxTenants: 1})
+	r.delay = time.Hour
+	ctx := context.Background()
+	if _, err := r.DB(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.DB(ctx, "b"); err != nil {
+		t.Fatal(err)
+	}
+	r.mu.Lock()
+	a := r.retiring["a"]
+	r.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		r.Close()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Close waits for the retire delay")
+	}
+	if a.db.Ping() == nil {
+		t.Error("retiring pool left open after Close")
+	}
+}
@@ -0,0 +1,34 @@
+package data
+
+import (
+	"context"
+
+	"{{cookiecutter.module_name}}/internal/biz"
+
+	"gorm.io/gorm"
+)
+
+// txKey 当前事务的 context key
+type txKey struct{}
+
+// NewTransaction 基于默认数据库的事务管理
+func NewTransaction(d *Data) biz.Transaction {
+	return d
+}
+
+// InTx 实现 biz.Transaction，事务保存在 ctx 中，repo 通过 d.DB(ctx) 取得事务连接
+// 已在事务中时直接执行 fn；ctx 带租户时在租户的数据库上开启事务；内嵌存储不支持跨 repo 事务，直接执行 fn
+func (d *Data) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if d.db == nil || txFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+	return d.DB(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// txFromContext 取出 ctx 中的事务，不在事务中时返回 nil
+func txFromContext(ctx context.Context) *gorm.DB {
+	tx, _ := ctx.Value(txKey{}).(*gorm.DB)
+	return tx
+}
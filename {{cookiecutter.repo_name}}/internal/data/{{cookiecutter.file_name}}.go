@@ -2,41 +2,214 @@ package data
 
 import (
 	"context"
+	"errors"
+	"strconv"
+	"time"
 
 	"{{cookiecutter.module_name}}/internal/biz"
+	"{{cookiecutter.module_name}}/internal/pkg/cache"
+	"{{cookiecutter.module_name}}/internal/pkg/pagination"
+	"{{cookiecutter.module_name}}/internal/pkg/rwsplit"
+	"{{cookiecutter.module_name}}/internal/pkg/tenant"
 	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
 )
 
+const (
+	// {{cookiecutter.file_name}}CacheTTL FindByID 结果在 Redis 中的缓存时间
+	{{cookiecutter.file_name}}CacheTTL = 5 * time.Minute
+	// {{cookiecutter.file_name}}NegativeTTL 不存在的 id 的缓存时间
+	{{cookiecutter.file_name}}NegativeTTL = 30 * time.Second
+)
+
+// {{cookiecutter.file_name}}Model {{cookiecutter.service_name}} 的 GORM 模型，db 标签供 sqlx 版本扫描
+// DeletedAt 开启 GORM 软删除：Delete 只设置 deleted_at，查询自动排除已删除的记录，Unscoped 时包含
+type {{cookiecutter.file_name}}Model struct {
+	ID        int64          `gorm:"primaryKey" db:"id"`
+	Hello     string         `gorm:"size:255;index" db:"hello"`
+	CreatedAt time.Time      `db:"created_at"`
+	UpdatedAt time.Time      `db:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" db:"deleted_at"`
+}
+
+// TableName 表名
+func ({{cookiecutter.file_name}}Model) TableName() string {
+	return "{{cookiecutter.file_name}}"
+}
+
+// toBiz 转换为 biz 实体
+func (m *{{cookiecutter.file_name}}Model) toBiz() *biz.{{cookiecutter.service_name}} {
+	g := &biz.{{cookiecutter.service_name}}{ID: m.ID, Hello: m.Hello}
+	if m.DeletedAt.Valid {
+		g.DeletedAt = &m.DeletedAt.Time
+	}
+	return g
+}
+
+// {{cookiecutter.file_name}}Repo 基于 GORM 的 repo 实现，FindByID 经默认 Redis 缓存
 type {{cookiecutter.file_name}}Repo struct {
-	data *Data
-	log  *log.Helper
+	data  *Data
+	cache *cache.Cache
+	log   *log.Helper
+}
+
+// repoVariant 使用 -tags entrepo 或 -tags sqlx 构建时设置，以对应的实现代替 GORM
+var repoVariant struct {
+	name string
+	new  func(*Data, log.Logger) biz.{{cookiecutter.service_name}}Repo
+}
+
+// setRepoVariant 注册 repo 实现，同时使用多个构建标签时报错
+func setRepoVariant(name string, fn func(*Data, log.Logger) biz.{{cookiecutter.service_name}}Repo) {
+	if repoVariant.new != nil {
+		panic("data: build tags " + repoVariant.name + " and " + name + " are mutually exclusive")
+	}
+	repoVariant.name, repoVariant.new = name, fn
 }
 
 // New{{cookiecutter.service_name}}Repo .
 func New{{cookiecutter.service_name}}Repo(data *Data, logger log.Logger) biz.{{cookiecutter.service_name}}Repo {
+	if data.kv != nil {
+		return new{{cookiecutter.service_name}}KVRepo(data, logger)
+	}
+	if data.mongo != nil {
+		return new{{cookiecutter.service_name}}MongoRepo(data, logger)
+	}
+	if repoVariant.new != nil {
+		return repoVariant.new(data, logger)
+	}
 	return &{{cookiecutter.file_name}}Repo{
 		data: data,
-		log:  log.NewHelper(logger),
+		cache: cache.New(data.rdbs.Default(),
+			cache.WithName("{{cookiecutter.file_name}}"),
+			cache.WithPrefix("{{cookiecutter.repo_name}}:{{cookiecutter.file_name}}:"),
+			cache.WithNegative(biz.ErrUserNotFound, {{cookiecutter.file_name}}NegativeTTL),
+		),
+		log: log.NewHelper(logger),
 	}
 }
 
 func (r *{{cookiecutter.file_name}}Repo) Save(ctx context.Context, g *biz.{{cookiecutter.service_name}}) (*biz.{{cookiecutter.service_name}}, error) {
-	r.log.WithContext(ctx).Infof("Save: %v", g.Hello)
-	return g, nil
+	m := &{{cookiecutter.file_name}}Model{Hello: g.Hello}
+	if err := r.data.DB(ctx).Create(m).Error; err != nil {
+		return nil, err
+	}
+	// 清除该 id 此前可能缓存的不存在标记
+	r.invalidate(ctx, m.ID)
+	return m.toBiz(), nil
 }
 
 func (r *{{cookiecutter.file_name}}Repo) Update(ctx context.Context, g *biz.{{cookiecutter.service_name}}) (*biz.{{cookiecutter.service_name}}, error) {
-	return g, nil
+	var m {{cookiecutter.file_name}}Model
+	err := r.data.DB(ctx).Where("hello = ?", g.Hello).Order("id").First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, biz.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	m.Hello = g.Hello
+	if err := r.data.DB(ctx).Save(&m).Error; err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx, m.ID)
+	return m.toBiz(), nil
 }
 
-func (r *{{cookiecutter.file_name}}Repo) FindByID(context.Context, int64) (*biz.{{cookiecutter.service_name}}, error) {
-	return nil, nil
+func (r *{{cookiecutter.file_name}}Repo) FindByID(ctx context.Context, id int64) (*biz.{{cookiecutter.service_name}}, error) {
+	return cache.Get(ctx, r.cache, cacheKey(ctx, id), {{cookiecutter.file_name}}CacheTTL, func(ctx context.Context) (*biz.{{cookiecutter.service_name}}, error) {
+		var m {{cookiecutter.file_name}}Model
+		err := r.data.DB(ctx).First(&m, id).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, biz.ErrUserNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		return m.toBiz(), nil
+	})
 }
 
-func (r *{{cookiecutter.file_name}}Repo) ListByHello(context.Context, string) ([]*biz.{{cookiecutter.service_name}}, error) {
-	return nil, nil
+func (r *{{cookiecutter.file_name}}Repo) ListByHello(ctx context.Context, hello string) ([]*biz.{{cookiecutter.service_name}}, error) {
+	return r.list(r.data.DB(ctx).Where("hello = ?", hello))
 }
 
-func (r *{{cookiecutter.file_name}}Repo) ListAll(context.Context) ([]*biz.{{cookiecutter.service_name}}, error) {
-	return nil, nil
-}
\ No newline at end of file
+func (r *{{cookiecutter.file_name}}Repo) ListAll(ctx context.Context) ([]*biz.{{cookiecutter.service_name}}, error) {
+	return r.list(r.data.DB(ctx))
+}
+
+func (r *{{cookiecutter.file_name}}Repo) List(ctx context.Context, req pagination.Request) ([]*biz.{{cookiecutter.service_name}}, *pagination.Result, error) {
+	rows, res, err := findPage(r.data.DB(ctx), req, func(m *{{cookiecutter.file_name}}Model) int64 { return m.ID })
+	if err != nil {
+		return nil, nil, err
+	}
+	list := make([]*biz.{{cookiecutter.service_name}}, 0, len(rows))
+	for _, m := range rows {
+		list = append(list, m.toBiz())
+	}
+	return list, res, nil
+}
+
+func (r *{{cookiecutter.file_name}}Repo) Delete(ctx context.Context, id int64) error {
+	res := r.data.DB(ctx).Delete(&{{cookiecutter.file_name}}Model{}, id)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return biz.ErrUserNotFound
+	}
+	r.invalidate(ctx, id)
+	return nil
+}
+
+func (r *{{cookiecutter.file_name}}Repo) Restore(ctx context.Context, id int64) (*biz.{{cookiecutter.service_name}}, error) {
+	res := r.data.DB(ctx).Unscoped().Model(&{{cookiecutter.file_name}}Model{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, biz.ErrUserNotFound
+	}
+	// 删除后缓存的不存在标记
+	r.invalidate(ctx, id)
+	var m {{cookiecutter.file_name}}Model
+	if err := r.data.DB(rwsplit.ForcePrimary(ctx)).First(&m, id).Error; err != nil {
+		return nil, err
+	}
+	return m.toBiz(), nil
+}
+
+func (r *{{cookiecutter.file_name}}Repo) ListDeleted(ctx context.Context) ([]*biz.{{cookiecutter.service_name}}, error) {
+	return r.list(r.data.DB(ctx).Unscoped().Where("deleted_at IS NOT NULL"))
+}
+
+// list 按 id 顺序查询并转换为 biz 实体
+func (r *{{cookiecutter.file_name}}Repo) list(db *gorm.DB) ([]*biz.{{cookiecutter.service_name}}, error) {
+	var rows []*{{cookiecutter.file_name}}Model
+	if err := db.Order("id").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	list := make([]*biz.{{cookiecutter.service_name}}, 0, len(rows))
+	for _, m := range rows {
+		list = append(list, m.toBiz())
+	}
+	return list, nil
+}
+
+// invalidate 数据变更后删除缓存，失败时只记录日志，缓存会在过期后自然失效
+func (r *{{cookiecutter.file_name}}Repo) invalidate(ctx context.Context, id int64) {
+	if err := r.cache.Delete(ctx, cacheKey(ctx, id)); err != nil {
+		r.log.WithContext(ctx).Warnf("cache delete %d: %v", id, err)
+	}
+}
+
+// cacheKey 缓存 key，带租户时加上租户前缀，不同租户的相同 id 互不影响
+func cacheKey(ctx context.Context, id int64) string {
+	key := strconv.FormatInt(id, 10)
+	if t, ok := tenant.FromContext(ctx); ok {
+		key = t + ":" + key
+	}
+	return key
+}
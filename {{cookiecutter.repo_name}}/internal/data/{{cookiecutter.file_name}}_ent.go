@@ -0,0 +1,195 @@
+//go:build entrepo
+
+package data
+
+import (
+	"context"
+	"time"
+
+	"{{cookiecutter.module_name}}/internal/biz"
+	"{{cookiecutter.module_name}}/internal/data/ent"
+	"{{cookiecutter.module_name}}/internal/data/ent/predicate"
+	"{{cookiecutter.module_name}}/internal/pkg/pagination"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+func init() {
+	setRepoVariant("ent", new{{cookiecutter.service_name}}EntRepo)
+}
+
+// {{cookiecutter.file_name}}EntRepo 基于 ent 的 repo 实现，使用 -tags entrepo 构建时代替 GORM 版本
+type {{cookiecutter.file_name}}EntRepo struct {
+	client *ent.Client
+	log    *log.Helper
+}
+
+// new{{cookiecutter.service_name}}EntRepo 在默认数据库的连接池上创建 ent 客户端，连接池由 Databases 负责关闭
+func new{{cookiecutter.service_name}}EntRepo(data *Data, logger log.Logger) biz.{{cookiecutter.service_name}}Repo {
+	drv := entsql.OpenDB(entDialect(data.db.Dialector.Name()), data.dbs.Default())
+	return &{{cookiecutter.file_name}}EntRepo{
+		client: ent.NewClient(ent.Driver(drv)),
+		log:    log.NewHelper(logger),
+	}
+}
+
+// entDialect GORM 方言名对应的 ent 方言
+func entDialect(name string) string {
+	switch name {
+	case "postgres":
+		return dialect.Postgres
+	case "sqlite":
+		return dialect.SQLite
+	default:
+		return dialect.MySQL
+	}
+}
+
+// helloEQ 按 hello 过滤
+func helloEQ(hello string) predicate.{{cookiecutter.service_name}} {
+	return func(s *entsql.Selector) {
+		s.Where(entsql.EQ(s.C("hello"), hello))
+	}
+}
+
+// idGT 查询 id 之后的记录，用于游标翻页
+func idGT(id int64) predicate.{{cookiecutter.service_name}} {
+	return func(s *entsql.Selector) {
+		s.Where(entsql.GT(s.C("id"), id))
+	}
+}
+
+// idEQ 按 id 过滤
+func idEQ(id int64) predicate.{{cookiecutter.service_name}} {
+	return func(s *entsql.Selector) {
+		s.Where(entsql.EQ(s.C("id"), id))
+	}
+}
+
+// notDeleted 排除软删除的记录，每条查询都需要带上
+func notDeleted() predicate.{{cookiecutter.service_name}} {
+	return func(s *entsql.Selector) {
+		s.Where(entsql.IsNull(s.C("deleted_at")))
+	}
+}
+
+// deleted 只查询软删除的记录
+func deleted() predicate.{{cookiecutter.service_name}} {
+	return func(s *entsql.Selector) {
+		s.Where(entsql.NotNull(s.C("deleted_at")))
+	}
+}
+
+// entToBiz 转换为 biz 实体
+func entToBiz(e *ent.{{cookiecutter.service_name}}) *biz.{{cookiecutter.service_name}} {
+	return &biz.{{cookiecutter.service_name}}{ID: int64(e.ID), Hello: e.Hello, DeletedAt: e.DeletedAt}
+}
+
+func (r *{{cookiecutter.file_name}}EntRepo) Save(ctx context.Context, g *biz.{{cookiecutter.service_name}}) (*biz.{{cookiecutter.service_name}}, error) {
+	e, err := r.client.{{cookiecutter.service_name}}.Create().SetHello(g.Hello).Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return entToBiz(e), nil
+}
+
+func (r *{{cookiecutter.file_name}}EntRepo) Update(ctx context.Context, g *biz.{{cookiecutter.service_name}}) (*biz.{{cookiecutter.service_name}}, error) {
+	n, err := r.client.{{cookiecutter.service_name}}.Update().Where(helloEQ(g.Hello), notDeleted()).SetHello(g.Hello).Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, biz.ErrUserNotFound
+	}
+	return g, nil
+}
+
+func (r *{{cookiecutter.file_name}}EntRepo) FindByID(ctx context.Context, id int64) (*biz.{{cookiecutter.service_name}}, error) {
+	e, err := r.client.{{cookiecutter.service_name}}.Query().Where(idEQ(id), notDeleted()).Only(ctx)
+	if ent.IsNotFound(err) {
+		return nil, biz.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return entToBiz(e), nil
+}
+
+func (r *{{cookiecutter.file_name}}EntRepo) ListByHello(ctx context.Context, hello string) ([]*biz.{{cookiecutter.service_name}}, error) {
+	return r.list(r.client.{{cookiecutter.service_name}}.Query().Where(helloEQ(hello), notDeleted()).Order(ent.Asc("id")).All(ctx))
+}
+
+func (r *{{cookiecutter.file_name}}EntRepo) ListAll(ctx context.Context) ([]*biz.{{cookiecutter.service_name}}, error) {
+	return r.list(r.client.{{cookiecutter.service_name}}.Query().Where(notDeleted()).Order(ent.Asc("id")).All(ctx))
+}
+
+func (r *{{cookiecutter.file_name}}EntRepo) List(ctx context.Context, req pagination.Request) ([]*biz.{{cookiecutter.service_name}}, *pagination.Result, error) {
+	after, err := req.AfterID()
+	if err != nil {
+		return nil, nil, err
+	}
+	res := &pagination.Result{Total: -1}
+	if req.WithTotal {
+		n, err := r.client.{{cookiecutter.service_name}}.Query().Where(notDeleted()).Count(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		res.Total = int64(n)
+	}
+	q := r.client.{{cookiecutter.service_name}}.Query().Where(notDeleted()).Order(ent.Asc("id")).Limit(req.Limit() + 1)
+	if req.Cursor != "" {
+		q = q.Where(idGT(after))
+	} else {
+		q = q.Offset(req.Offset())
+	}
+	rows, err := q.All(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	rows, res.NextCursor, err = pagination.Trim(rows, req, func(e *ent.{{cookiecutter.service_name}}) int64 { return int64(e.ID) })
+	if err != nil {
+		return nil, nil, err
+	}
+	list, err := r.list(rows, nil)
+	return list, res, err
+}
+
+func (r *{{cookiecutter.file_name}}EntRepo) Delete(ctx context.Context, id int64) error {
+	n, err := r.client.{{cookiecutter.service_name}}.Update().Where(idEQ(id), notDeleted()).SetDeletedAt(time.Now()).Save(ctx)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return biz.ErrUserNotFound
+	}
+	return nil
+}
+
+func (r *{{cookiecutter.file_name}}EntRepo) Restore(ctx context.Context, id int64) (*biz.{{cookiecutter.service_name}}, error) {
+	n, err := r.client.{{cookiecutter.service_name}}.Update().Where(idEQ(id), deleted()).ClearDeletedAt().Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, biz.ErrUserNotFound
+	}
+	return r.FindByID(ctx, id)
+}
+
+func (r *{{cookiecutter.file_name}}EntRepo) ListDeleted(ctx context.Context) ([]*biz.{{cookiecutter.service_name}}, error) {
+	return r.list(r.client.{{cookiecutter.service_name}}.Query().Where(deleted()).Order(ent.Asc("id")).All(ctx))
+}
+
+// list 转换查询结果为 biz 实体
+func (r *{{cookiecutter.file_name}}EntRepo) list(rows []*ent.{{cookiecutter.service_name}}, err error) ([]*biz.{{cookiecutter.service_name}}, error) {
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*biz.{{cookiecutter.service_name}}, 0, len(rows))
+	for _, e := range rows {
+		list = append(list, entToBiz(e))
+	}
+	return list, nil
+}
@@ -0,0 +1,279 @@
+package data
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"time"
+
+	"{{cookiecutter.module_name}}/internal/biz"
+	"{{cookiecutter.module_name}}/internal/pkg/pagination"
+	"github.com/go-kratos/kratos/v2/log"
+	bolt "go.etcd.io/bbolt"
+)
+
+// {{cookiecutter.file_name}}Bucket 内嵌存储的 bucket 名称
+var {{cookiecutter.file_name}}Bucket = []byte("{{cookiecutter.file_name}}")
+
+// {{cookiecutter.file_name}}KVRepo 基于内嵌 bbolt 存储的 repo 实现，key 为自增 id，
+// 软删除的记录保留在 bucket 中，DeletedAt 不为空，查询时跳过
+type {{cookiecutter.file_name}}KVRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+func new{{cookiecutter.service_name}}KVRepo(data *Data, logger log.Logger) biz.{{cookiecutter.service_name}}Repo {
+	return &{{cookiecutter.file_name}}KVRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+func (r *{{cookiecutter.file_name}}KVRepo) Save(ctx context.Context, g *biz.{{cookiecutter.service_name}}) (*biz.{{cookiecutter.service_name}}, error) {
+	err := r.data.kv.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists({{cookiecutter.file_name}}Bucket)
+		if err != nil {
+			return err
+		}
+		id, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		g = &biz.{{cookiecutter.service_name}}{ID: int64(id), Hello: g.Hello}
+		v, err := json.Marshal(g)
+		if err != nil {
+			return err
+		}
+		return b.Put(kvKey(g.ID), v)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func (r *{{cookiecutter.file_name}}KVRepo) Update(ctx context.Context, g *biz.{{cookiecutter.service_name}}) (*biz.{{cookiecutter.service_name}}, error) {
+	found := false
+	err := r.data.kv.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket({{cookiecutter.file_name}}Bucket)
+		if b == nil {
+			return nil
+		}
+		c := b.Cursor()
+		for k, old := c.First(); k != nil; k, old = c.Next() {
+			var m biz.{{cookiecutter.service_name}}
+			if err := json.Unmarshal(old, &m); err != nil {
+				return err
+			}
+			if m.Hello == g.Hello && m.DeletedAt == nil {
+				found = true
+				g = &biz.{{cookiecutter.service_name}}{ID: m.ID, Hello: g.Hello}
+				v, err := json.Marshal(g)
+				if err != nil {
+					return err
+				}
+				return b.Put(k, v)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, biz.ErrUserNotFound
+	}
+	return g, nil
+}
+
+func (r *{{cookiecutter.file_name}}KVRepo) FindByID(ctx context.Context, id int64) (*biz.{{cookiecutter.service_name}}, error) {
+	var g *biz.{{cookiecutter.service_name}}
+	err := r.data.kv.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket({{cookiecutter.file_name}}Bucket)
+		if b == nil {
+			return nil
+		}
+		v := b.Get(kvKey(id))
+		if v == nil {
+			return nil
+		}
+		g = &biz.{{cookiecutter.service_name}}{ID: id}
+		return json.Unmarshal(v, g)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if g == nil || g.DeletedAt != nil {
+		return nil, biz.ErrUserNotFound
+	}
+	return g, nil
+}
+
+func (r *{{cookiecutter.file_name}}KVRepo) ListByHello(ctx context.Context, hello string) ([]*biz.{{cookiecutter.service_name}}, error) {
+	return r.list(func(g *biz.{{cookiecutter.service_name}}) bool {
+		return g.Hello == hello && g.DeletedAt == nil
+	})
+}
+
+func (r *{{cookiecutter.file_name}}KVRepo) ListAll(context.Context) ([]*biz.{{cookiecutter.service_name}}, error) {
+	return r.list(func(g *biz.{{cookiecutter.service_name}}) bool {
+		return g.DeletedAt == nil
+	})
+}
+
+func (r *{{cookiecutter.file_name}}KVRepo) List(ctx context.Context, req pagination.Request) ([]*biz.{{cookiecutter.service_name}}, *pagination.Result, error) {
+	after, err := req.AfterID()
+	if err != nil {
+		return nil, nil, err
+	}
+	type row struct {
+		id int64
+		g  *biz.{{cookiecutter.service_name}}
+	}
+	var rows []row
+	res := &pagination.Result{Total: -1}
+	err = r.data.kv.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket({{cookiecutter.file_name}}Bucket)
+		if b == nil {
+			if req.WithTotal {
+				res.Total = 0
+			}
+			return nil
+		}
+		// 软删除的记录也在 bucket 中，总数和偏移都需要逐条判断，游标翻页直接定位到游标之后的 key
+		c := b.Cursor()
+		k, v := c.First()
+		if req.Cursor != "" {
+			k, v = c.Seek(kvKey(after + 1))
+		}
+		skip := req.Offset()
+		for ; k != nil && len(rows) <= req.Limit(); k, v = c.Next() {
+			g := &biz.{{cookiecutter.service_name}}{ID: int64(binary.BigEndian.Uint64(k))}
+			if err := json.Unmarshal(v, g); err != nil {
+				return err
+			}
+			if g.DeletedAt != nil {
+				continue
+			}
+			if skip > 0 {
+				skip--
+				continue
+			}
+			rows = append(rows, row{id: int64(binary.BigEndian.Uint64(k)), g: g})
+		}
+		if req.WithTotal {
+			res.Total = 0
+			return b.ForEach(func(_, v []byte) error {
+				var g biz.{{cookiecutter.service_name}}
+				if err := json.Unmarshal(v, &g); err != nil {
+					return err
+				}
+				if g.DeletedAt == nil {
+					res.Total++
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	rows, res.NextCursor, err = pagination.Trim(rows, req, func(r row) int64 { return r.id })
+	if err != nil {
+		return nil, nil, err
+	}
+	list := make([]*biz.{{cookiecutter.service_name}}, 0, len(rows))
+	for _, r := range rows {
+		list = append(list, r.g)
+	}
+	return list, res, nil
+}
+
+func (r *{{cookiecutter.file_name}}KVRepo) Delete(ctx context.Context, id int64) error {
+	now := time.Now()
+	_, err := r.setDeleted(id, func(g *biz.{{cookiecutter.service_name}}) bool {
+		if g.DeletedAt != nil {
+			return false
+		}
+		g.DeletedAt = &now
+		return true
+	})
+	return err
+}
+
+func (r *{{cookiecutter.file_name}}KVRepo) Restore(ctx context.Context, id int64) (*biz.{{cookiecutter.service_name}}, error) {
+	return r.setDeleted(id, func(g *biz.{{cookiecutter.service_name}}) bool {
+		if g.DeletedAt == nil {
+			return false
+		}
+		g.DeletedAt = nil
+		return true
+	})
+}
+
+func (r *{{cookiecutter.file_name}}KVRepo) ListDeleted(context.Context) ([]*biz.{{cookiecutter.service_name}}, error) {
+	return r.list(func(g *biz.{{cookiecutter.service_name}}) bool {
+		return g.DeletedAt != nil
+	})
+}
+
+// setDeleted 读取 id 对应的记录，change 返回 false 时视为不存在，否则写回修改后的记录
+func (r *{{cookiecutter.file_name}}KVRepo) setDeleted(id int64, change func(*biz.{{cookiecutter.service_name}}) bool) (*biz.{{cookiecutter.service_name}}, error) {
+	var g *biz.{{cookiecutter.service_name}}
+	err := r.data.kv.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket({{cookiecutter.file_name}}Bucket)
+		if b == nil {
+			return biz.ErrUserNotFound
+		}
+		v := b.Get(kvKey(id))
+		if v == nil {
+			return biz.ErrUserNotFound
+		}
+		g = &biz.{{cookiecutter.service_name}}{ID: id}
+		if err := json.Unmarshal(v, g); err != nil {
+			return err
+		}
+		if !change(g) {
+			return biz.ErrUserNotFound
+		}
+		v, err := json.Marshal(g)
+		if err != nil {
+			return err
+		}
+		return b.Put(kvKey(id), v)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// list 按 id 顺序遍历并过滤
+func (r *{{cookiecutter.file_name}}KVRepo) list(match func(*biz.{{cookiecutter.service_name}}) bool) ([]*biz.{{cookiecutter.service_name}}, error) {
+	var list []*biz.{{cookiecutter.service_name}}
+	err := r.data.kv.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket({{cookiecutter.file_name}}Bucket)
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) error {
+			g := &biz.{{cookiecutter.service_name}}{ID: int64(binary.BigEndian.Uint64(k))}
+			if err := json.Unmarshal(v, g); err != nil {
+				return err
+			}
+			if match(g) {
+				list = append(list, g)
+			}
+			return nil
+		})
+	})
+	return list, err
+}
+
+// kvKey 使用大端序编码 id，保证遍历顺序与 id 顺序一致
+func kvKey(id int64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(id))
+	return b
+}
@@ -0,0 +1,201 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"{{cookiecutter.module_name}}/internal/biz"
+	"{{cookiecutter.module_name}}/internal/pkg/pagination"
+	"github.com/go-kratos/kratos/v2/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// {{cookiecutter.file_name}}Collection MongoDB 集合名称，与关系数据库的表名一致
+const {{cookiecutter.file_name}}Collection = "{{cookiecutter.file_name}}"
+
+// {{cookiecutter.file_name}}Document MongoDB 文档，_id 使用计数器生成的整数 id，软删除时设置 deleted_at
+type {{cookiecutter.file_name}}Document struct {
+	ID        int64      `bson:"_id"`
+	Hello     string     `bson:"hello"`
+	CreatedAt time.Time  `bson:"created_at"`
+	UpdatedAt time.Time  `bson:"updated_at"`
+	DeletedAt *time.Time `bson:"deleted_at,omitempty"`
+}
+
+// toBiz 转换为 biz 实体
+func (d *{{cookiecutter.file_name}}Document) toBiz() *biz.{{cookiecutter.service_name}} {
+	return &biz.{{cookiecutter.service_name}}{ID: d.ID, Hello: d.Hello, DeletedAt: d.DeletedAt}
+}
+
+// notDeletedFilter 在 filter 上排除软删除的文档，deleted_at 为 null 或不存在时匹配
+func notDeletedFilter(filter bson.M) bson.M {
+	filter["deleted_at"] = nil
+	return filter
+}
+
+// {{cookiecutter.file_name}}MongoRepo 基于 MongoDB 的 repo 实现，开启 data.mongo 时使用
+type {{cookiecutter.file_name}}MongoRepo struct {
+	db  *Mongo
+	log *log.Helper
+}
+
+func new{{cookiecutter.service_name}}MongoRepo(data *Data, logger log.Logger) biz.{{cookiecutter.service_name}}Repo {
+	return &{{cookiecutter.file_name}}MongoRepo{
+		db:  data.mongo,
+		log: log.NewHelper(logger),
+	}
+}
+
+func (r *{{cookiecutter.file_name}}MongoRepo) Save(ctx context.Context, g *biz.{{cookiecutter.service_name}}) (*biz.{{cookiecutter.service_name}}, error) {
+	id, err := r.db.NextID(ctx, {{cookiecutter.file_name}}Collection)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	doc := &{{cookiecutter.file_name}}Document{ID: id, Hello: g.Hello, CreatedAt: now, UpdatedAt: now}
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+	if _, err := r.db.Collection({{cookiecutter.file_name}}Collection).InsertOne(ctx, doc); err != nil {
+		return nil, err
+	}
+	return doc.toBiz(), nil
+}
+
+func (r *{{cookiecutter.file_name}}MongoRepo) Update(ctx context.Context, g *biz.{{cookiecutter.service_name}}) (*biz.{{cookiecutter.service_name}}, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+	res, err := r.db.Collection({{cookiecutter.file_name}}Collection).UpdateOne(ctx,
+		notDeletedFilter(bson.M{"hello": g.Hello}),
+		bson.M{"$set": bson.M{"hello": g.Hello, "updated_at": time.Now()}},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if res.MatchedCount == 0 {
+		return nil, biz.ErrUserNotFound
+	}
+	return g, nil
+}
+
+func (r *{{cookiecutter.file_name}}MongoRepo) FindByID(ctx context.Context, id int64) (*biz.{{cookiecutter.service_name}}, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+	var doc {{cookiecutter.file_name}}Document
+	err := r.db.Collection({{cookiecutter.file_name}}Collection).FindOne(ctx, notDeletedFilter(bson.M{"_id": id})).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, biz.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return doc.toBiz(), nil
+}
+
+func (r *{{cookiecutter.file_name}}MongoRepo) ListByHello(ctx context.Context, hello string) ([]*biz.{{cookiecutter.service_name}}, error) {
+	return r.list(ctx, notDeletedFilter(bson.M{"hello": hello}))
+}
+
+func (r *{{cookiecutter.file_name}}MongoRepo) ListAll(ctx context.Context) ([]*biz.{{cookiecutter.service_name}}, error) {
+	return r.list(ctx, notDeletedFilter(bson.M{}))
+}
+
+func (r *{{cookiecutter.file_name}}MongoRepo) List(ctx context.Context, req pagination.Request) ([]*biz.{{cookiecutter.service_name}}, *pagination.Result, error) {
+	after, err := req.AfterID()
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+	coll := r.db.Collection({{cookiecutter.file_name}}Collection)
+	res := &pagination.Result{Total: -1}
+	if req.WithTotal {
+		if res.Total, err = coll.CountDocuments(ctx, notDeletedFilter(bson.M{})); err != nil {
+			return nil, nil, err
+		}
+	}
+	filter := notDeletedFilter(bson.M{})
+	opts := options.Find().SetSort(bson.D{bson.E{Key: "_id", Value: 1}}).SetLimit(int64(req.Limit() + 1))
+	if req.Cursor != "" {
+		filter = notDeletedFilter(bson.M{"_id": bson.M{"$gt": after}})
+	} else {
+		opts.SetSkip(int64(req.Offset()))
+	}
+	cur, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	var docs []*{{cookiecutter.file_name}}Document
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, nil, err
+	}
+	docs, res.NextCursor, err = pagination.Trim(docs, req, func(d *{{cookiecutter.file_name}}Document) int64 { return d.ID })
+	if err != nil {
+		return nil, nil, err
+	}
+	list := make([]*biz.{{cookiecutter.service_name}}, 0, len(docs))
+	for _, doc := range docs {
+		list = append(list, doc.toBiz())
+	}
+	return list, res, nil
+}
+
+func (r *{{cookiecutter.file_name}}MongoRepo) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+	now := time.Now()
+	res, err := r.db.Collection({{cookiecutter.file_name}}Collection).UpdateOne(ctx,
+		notDeletedFilter(bson.M{"_id": id}),
+		bson.M{"$set": bson.M{"deleted_at": now, "updated_at": now}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return biz.ErrUserNotFound
+	}
+	return nil
+}
+
+func (r *{{cookiecutter.file_name}}MongoRepo) Restore(ctx context.Context, id int64) (*biz.{{cookiecutter.service_name}}, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+	var doc {{cookiecutter.file_name}}Document
+	err := r.db.Collection({{cookiecutter.file_name}}Collection).FindOneAndUpdate(ctx,
+		bson.M{"_id": id, "deleted_at": bson.M{"$ne": nil}},
+		bson.M{"$unset": bson.M{"deleted_at": ""}, "$set": bson.M{"updated_at": time.Now()}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, biz.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return doc.toBiz(), nil
+}
+
+func (r *{{cookiecutter.file_name}}MongoRepo) ListDeleted(ctx context.Context) ([]*biz.{{cookiecutter.service_name}}, error) {
+	return r.list(ctx, bson.M{"deleted_at": bson.M{"$ne": nil}})
+}
+
+// list 按 id 顺序查询并转换为 biz 实体
+func (r *{{cookiecutter.file_name}}MongoRepo) list(ctx context.Context, filter bson.M) ([]*biz.{{cookiecutter.service_name}}, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+	cur, err := r.db.Collection({{cookiecutter.file_name}}Collection).Find(ctx, filter, options.Find().SetSort(bson.D{bson.E{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	var docs []*{{cookiecutter.file_name}}Document
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	list := make([]*biz.{{cookiecutter.service_name}}, 0, len(docs))
+	for _, doc := range docs {
+		list = append(list, doc.toBiz())
+	}
+	return list, nil
+}
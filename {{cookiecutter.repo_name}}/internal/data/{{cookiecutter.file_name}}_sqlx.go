@@ -0,0 +1,150 @@
+//go:build sqlx
+
+package data
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"{{cookiecutter.module_name}}/internal/biz"
+	"{{cookiecutter.module_name}}/internal/pkg/pagination"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/jmoiron/sqlx"
+)
+
+func init() {
+	setRepoVariant("sqlx", new{{cookiecutter.service_name}}SqlxRepo)
+}
+
+// {{cookiecutter.file_name}}Columns 查询的列，与 {{cookiecutter.file_name}}Model 的 db 标签对应
+const {{cookiecutter.file_name}}Columns = "id, hello, created_at, updated_at, deleted_at"
+
+// {{cookiecutter.file_name}}SqlxRepo 基于 sqlx 的 repo 实现，使用 -tags sqlx 构建时代替 GORM 版本，
+// 软删除需要在每条查询中显式带上 deleted_at IS NULL
+type {{cookiecutter.file_name}}SqlxRepo struct {
+	db  *sqlx.DB
+	log *log.Helper
+}
+
+func new{{cookiecutter.service_name}}SqlxRepo(data *Data, logger log.Logger) biz.{{cookiecutter.service_name}}Repo {
+	return &{{cookiecutter.file_name}}SqlxRepo{
+		db:  newSqlxDB(data),
+		log: log.NewHelper(logger),
+	}
+}
+
+func (r *{{cookiecutter.file_name}}SqlxRepo) Save(ctx context.Context, g *biz.{{cookiecutter.service_name}}) (*biz.{{cookiecutter.service_name}}, error) {
+	now := time.Now()
+	m := &{{cookiecutter.file_name}}Model{Hello: g.Hello, CreatedAt: now, UpdatedAt: now}
+	id, err := namedInsert(ctx, r.db, `INSERT INTO {{cookiecutter.file_name}} (hello, created_at, updated_at) VALUES (:hello, :created_at, :updated_at)`, m)
+	if err != nil {
+		return nil, err
+	}
+	m.ID = id
+	return m.toBiz(), nil
+}
+
+func (r *{{cookiecutter.file_name}}SqlxRepo) Update(ctx context.Context, g *biz.{{cookiecutter.service_name}}) (*biz.{{cookiecutter.service_name}}, error) {
+	m := &{{cookiecutter.file_name}}Model{Hello: g.Hello, UpdatedAt: time.Now()}
+	n, err := namedExec(ctx, r.db, `UPDATE {{cookiecutter.file_name}} SET hello = :hello, updated_at = :updated_at WHERE hello = :hello AND deleted_at IS NULL`, m)
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, biz.ErrUserNotFound
+	}
+	return m.toBiz(), nil
+}
+
+func (r *{{cookiecutter.file_name}}SqlxRepo) FindByID(ctx context.Context, id int64) (*biz.{{cookiecutter.service_name}}, error) {
+	var m {{cookiecutter.file_name}}Model
+	err := namedGet(ctx, r.db, &m, `SELECT `+{{cookiecutter.file_name}}Columns+` FROM {{cookiecutter.file_name}} WHERE id = :id AND deleted_at IS NULL`, map[string]interface{}{"id": id})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, biz.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return m.toBiz(), nil
+}
+
+func (r *{{cookiecutter.file_name}}SqlxRepo) ListByHello(ctx context.Context, hello string) ([]*biz.{{cookiecutter.service_name}}, error) {
+	return r.list(ctx, `SELECT `+{{cookiecutter.file_name}}Columns+` FROM {{cookiecutter.file_name}} WHERE hello = :hello AND deleted_at IS NULL ORDER BY id`, map[string]interface{}{"hello": hello})
+}
+
+func (r *{{cookiecutter.file_name}}SqlxRepo) ListAll(ctx context.Context) ([]*biz.{{cookiecutter.service_name}}, error) {
+	return r.list(ctx, `SELECT `+{{cookiecutter.file_name}}Columns+` FROM {{cookiecutter.file_name}} WHERE deleted_at IS NULL ORDER BY id`, map[string]interface{}{})
+}
+
+func (r *{{cookiecutter.file_name}}SqlxRepo) List(ctx context.Context, req pagination.Request) ([]*biz.{{cookiecutter.service_name}}, *pagination.Result, error) {
+	after, err := req.AfterID()
+	if err != nil {
+		return nil, nil, err
+	}
+	res := &pagination.Result{Total: -1}
+	if req.WithTotal {
+		if err := r.db.GetContext(ctx, &res.Total, `SELECT COUNT(*) FROM {{cookiecutter.file_name}} WHERE deleted_at IS NULL`); err != nil {
+			return nil, nil, err
+		}
+	}
+	arg := map[string]interface{}{"after": after, "limit": req.Limit() + 1, "offset": req.Offset()}
+	query := `SELECT ` + {{cookiecutter.file_name}}Columns + ` FROM {{cookiecutter.file_name}} WHERE deleted_at IS NULL ORDER BY id LIMIT :limit OFFSET :offset`
+	if req.Cursor != "" {
+		query = `SELECT ` + {{cookiecutter.file_name}}Columns + ` FROM {{cookiecutter.file_name}} WHERE id > :after AND deleted_at IS NULL ORDER BY id LIMIT :limit`
+	}
+	var rows []*{{cookiecutter.file_name}}Model
+	if err := namedSelect(ctx, r.db, &rows, query, arg); err != nil {
+		return nil, nil, err
+	}
+	rows, res.NextCursor, err = pagination.Trim(rows, req, func(m *{{cookiecutter.file_name}}Model) int64 { return m.ID })
+	if err != nil {
+		return nil, nil, err
+	}
+	list := make([]*biz.{{cookiecutter.service_name}}, 0, len(rows))
+	for _, m := range rows {
+		list = append(list, m.toBiz())
+	}
+	return list, res, nil
+}
+
+func (r *{{cookiecutter.file_name}}SqlxRepo) Delete(ctx context.Context, id int64) error {
+	n, err := namedExec(ctx, r.db, `UPDATE {{cookiecutter.file_name}} SET deleted_at = :now WHERE id = :id AND deleted_at IS NULL`, map[string]interface{}{"id": id, "now": time.Now()})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return biz.ErrUserNotFound
+	}
+	return nil
+}
+
+func (r *{{cookiecutter.file_name}}SqlxRepo) Restore(ctx context.Context, id int64) (*biz.{{cookiecutter.service_name}}, error) {
+	n, err := namedExec(ctx, r.db, `UPDATE {{cookiecutter.file_name}} SET deleted_at = NULL, updated_at = :now WHERE id = :id AND deleted_at IS NOT NULL`, map[string]interface{}{"id": id, "now": time.Now()})
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, biz.ErrUserNotFound
+	}
+	return r.FindByID(ctx, id)
+}
+
+func (r *{{cookiecutter.file_name}}SqlxRepo) ListDeleted(ctx context.Context) ([]*biz.{{cookiecutter.service_name}}, error) {
+	return r.list(ctx, `SELECT `+{{cookiecutter.file_name}}Columns+` FROM {{cookiecutter.file_name}} WHERE deleted_at IS NOT NULL ORDER BY id`, map[string]interface{}{})
+}
+
+// list 查询并转换为 biz 实体
+func (r *{{cookiecutter.file_name}}SqlxRepo) list(ctx context.Context, query string, arg interface{}) ([]*biz.{{cookiecutter.service_name}}, error) {
+	var rows []*{{cookiecutter.file_name}}Model
+	if err := namedSelect(ctx, r.db, &rows, query, arg); err != nil {
+		return nil, err
+	}
+	list := make([]*biz.{{cookiecutter.service_name}}, 0, len(rows))
+	for _, m := range rows {
+		list = append(list, m.toBiz())
+	}
+	return list, nil
+}
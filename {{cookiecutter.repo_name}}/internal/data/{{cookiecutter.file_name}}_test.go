@@ -0,0 +1,85 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"{{cookiecutter.module_name}}/internal/biz"
+	"{{cookiecutter.module_name}}/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+func TestSoftDelete(t *testing.T) {
+	repos := map[string]func(t *testing.T) biz.{{cookiecutter.service_name}}Repo{
+		"gorm": func(t *testing.T) biz.{{cookiecutter.service_name}}Repo {
+			return New{{cookiecutter.service_name}}Repo(newTestData(t), log.DefaultLogger)
+		},
+		"kv": func(t *testing.T) biz.{{cookiecutter.service_name}}Repo {
+			data, cleanup, err := newEmbeddedData(&conf.Data_Embedded{Enable: true, Path: filepath.Join(t.TempDir(), "kv.db")}, log.DefaultLogger)
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(cleanup)
+			return New{{cookiecutter.service_name}}Repo(data, log.DefaultLogger)
+		},
+	}
+	for name, newRepo := range repos {
+		t.Run(name, func(t *testing.T) {
+			repo := newRepo(t)
+			ctx := context.Background()
+
+			a, err := repo.Save(ctx, &biz.{{cookiecutter.service_name}}{Hello: "a"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := repo.Save(ctx, &biz.{{cookiecutter.service_name}}{Hello: "b"}); err != nil {
+				t.Fatal(err)
+			}
+			if err := repo.Delete(ctx, a.ID); err != nil {
+				t.Fatal(err)
+			}
+
+			// 已删除的记录不出现在查询中
+			if _, err := repo.FindByID(ctx, a.ID); !errors.Is(err, biz.ErrUserNotFound) {
+				t.Fatalf("find deleted: err = %v, want ErrUserNotFound", err)
+			}
+			if list, err := repo.ListAll(ctx); err != nil || len(list) != 1 || list[0].Hello != "b" {
+				t.Fatalf("list all = %v, %v, want only b", list, err)
+			}
+			if list, err := repo.ListByHello(ctx, "a"); err != nil || len(list) != 0 {
+				t.Fatalf("list by hello = %v, %v, want none", list, err)
+			}
+			deleted, err := repo.ListDeleted(ctx)
+			if err != nil || len(deleted) != 1 || deleted[0].ID != a.ID || deleted[0].DeletedAt == nil {
+				t.Fatalf("list deleted = %v, %v, want a with DeletedAt", deleted, err)
+			}
+			if err := repo.Delete(ctx, a.ID); !errors.Is(err, biz.ErrUserNotFound) {
+				t.Fatalf("delete twice: err = %v, want ErrUserNotFound", err)
+			}
+
+			restored, err := repo.Restore(ctx, a.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if restored.ID != a.ID || restored.Hello != "a" || restored.DeletedAt != nil {
+				t.Fatalf("restored = %+v", restored)
+			}
+			if _, err := repo.FindByID(ctx, a.ID); err != nil {
+				t.Fatalf("find restored: %v", err)
+			}
+			if deleted, _ := repo.ListDeleted(ctx); len(deleted) != 0 {
+				t.Fatalf("list deleted after restore = %v", deleted)
+			}
+			// 未删除或不存在的记录不能恢复
+			if _, err := repo.Restore(ctx, a.ID); !errors.Is(err, biz.ErrUserNotFound) {
+				t.Errorf("restore live: err = %v, want ErrUserNotFound", err)
+			}
+			if _, err := repo.Restore(ctx, 999); !errors.Is(err, biz.ErrUserNotFound) {
+				t.Errorf("restore missing: err = %v, want ErrUserNotFound", err)
+			}
+		})
+	}
+}
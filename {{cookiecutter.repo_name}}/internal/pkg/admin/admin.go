@@ -0,0 +1,112 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+)
+
+var _ transport.Server = (*Server)(nil)
+
+// Server 管理端口服务，与业务端口分离，提供存活和就绪探针，可挂载 /metrics 等内部接口
+//
+//	GET /healthz          存活探针，进程运行即返回 200
+//	GET /healthz?detail=1 同时以 JSON 输出各组件状态，如授权状态
+//	GET /readyz           就绪探针，启动完成后返回 200，停机开始后返回 503 以便负载均衡摘除流量
+type Server struct {
+	addr  string
+	mux   *http.ServeMux
+	srv   *http.Server
+	ready atomic.Bool
+	log   *log.Helper
+
+	mu      sync.Mutex
+	details map[string]func() interface{}
+}
+
+// NewServer 创建管理端口服务
+func NewServer(addr string, logger log.Logger) *Server {
+	s := &Server{
+		addr: addr,
+		mux:  http.NewServeMux(),
+		log:  log.NewHelper(logger),
+	}
+	s.mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("detail") == "" {
+			_, _ = w.Write([]byte("ok"))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"status": "ok", "details": s.detail()})
+	})
+	s.mux.HandleFunc("/readyz", func(w http.ResponseWriter, _ *http.Request) {
+		if !s.ready.Load() {
+			http.Error(w, "not ready", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	})
+	s.srv = &http.Server{Handler: s.mux, ReadHeaderTimeout: 5 * time.Second}
+	return s
+}
+
+// Handle 挂载内部接口
+func (s *Server) Handle(pattern string, h http.Handler) {
+	s.mux.Handle(pattern, h)
+}
+
+// Detail 注册 /healthz?detail=1 中输出的组件状态，只用于展示，不影响探针结果
+func (s *Server) Detail(name string, fn func() interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.details == nil {
+		s.details = make(map[string]func() interface{})
+	}
+	s.details[name] = fn
+}
+
+// detail 收集各组件状态
+func (s *Server) detail() map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make(map[string]interface{}, len(s.details))
+	for name, fn := range s.details {
+		out[name] = fn()
+	}
+	return out
+}
+
+// SetReady 设置就绪状态，停机前置为 false 可以提前摘除流量
+func (s *Server) SetReady(ready bool) {
+	s.ready.Store(ready)
+}
+
+// Start 实现 transport.Server 接口
+func (s *Server) Start(ctx context.Context) error {
+	lis, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	s.log.Infof("[admin] server listening on: %s", lis.Addr().String())
+	s.ready.Store(true)
+	s.srv.BaseContext = func(net.Listener) context.Context { return ctx }
+	if err := s.srv.Serve(lis); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Stop 实现 transport.Server 接口
+func (s *Server) Stop(ctx context.Context) error {
+	s.ready.Store(false)
+	s.log.Info("[admin] server stopping")
+	return s.srv.Shutdown(ctx)
+}
@@ -0,0 +1,281 @@
+package apidoc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"gopkg.in/yaml.v3"
+)
+
+// Operation 接口操作的请求、响应示例和 curl 命令
+type Operation struct {
+	OperationID string      `json:"operationId"`
+	Method      string      `json:"method"`
+	Path        string      `json:"path"`
+	Request     interface{} `json:"request,omitempty"`
+	Response    interface{} `json:"response"`
+	Curl        string      `json:"curl"`
+}
+
+// Docs 接口文档，在 protoc-gen-openapi 生成的 openapi.yaml 中注入示例和 curl 命令
+// 路由:
+//
+//	{prefix}/               Swagger UI
+//	{prefix}/openapi.yaml   注入示例后的 OpenAPI 文档
+//	{prefix}/examples       所有接口的示例，JSON 格式
+type Docs struct {
+	prefix string
+	spec   []byte
+	ops    []Operation
+}
+
+// New 创建接口文档，specFile 为 openapi.yaml 路径，baseURL 用于生成 curl 命令，
+// services 为 proto service 全名，如 helloworld.v1.Greeter
+func New(prefix, specFile, baseURL string, services ...string) (*Docs, error) {
+	var ops []Operation
+	for _, name := range services {
+		d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(name))
+		if err != nil {
+			return nil, fmt.Errorf("apidoc: service %s not found: %w", name, err)
+		}
+		sd, ok := d.(protoreflect.ServiceDescriptor)
+		if !ok {
+			return nil, fmt.Errorf("apidoc: %s is not a service", name)
+		}
+		ops = append(ops, serviceOperations(sd, baseURL)...)
+	}
+
+	b, err := os.ReadFile(specFile)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := injectExamples(b, ops)
+	if err != nil {
+		return nil, err
+	}
+	return &Docs{prefix: strings.TrimSuffix(prefix, "/"), spec: spec, ops: ops}, nil
+}
+
+// Operations 返回所有接口操作的示例
+func (d *Docs) Operations() []Operation {
+	return d.ops
+}
+
+// ServeHTTP 实现 http.Handler 接口
+func (d *Docs) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch strings.TrimPrefix(r.URL.Path, d.prefix) {
+	case "/openapi.yaml":
+		w.Header().Set("Content-Type", "application/yaml")
+		_, _ = w.Write(d.spec)
+	case "/examples":
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(d.ops)
+	case "/", "":
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = fmt.Fprintf(w, swaggerUI, d.prefix+"/openapi.yaml")
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// serviceOperations 生成 service 中带 HTTP 注解的方法的示例
+func serviceOperations(sd protoreflect.ServiceDescriptor, baseURL string) []Operation {
+	var ops []Operation
+	methods := sd.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		md := methods.Get(i)
+		rule, ok := proto.GetExtension(md.Options(), annotations.E_Http).(*annotations.HttpRule)
+		if !ok || rule == nil {
+			continue
+		}
+		for _, r := range append([]*annotations.HttpRule{rule}, rule.GetAdditionalBindings()...) {
+			method, path := httpPattern(r)
+			if method == "" {
+				continue
+			}
+			ops = append(ops, newOperation(sd, md, r, method, path, baseURL))
+		}
+	}
+	return ops
+}
+
+// newOperation 生成单个接口操作的示例
+func newOperation(sd protoreflect.ServiceDescriptor, md protoreflect.MethodDescriptor, rule *annotations.HttpRule, method, path, baseURL string) Operation {
+	req := Example(md.Input())
+	op := Operation{
+		OperationID: fmt.Sprintf("%s_%s", sd.Name(), md.Name()),
+		Method:      method,
+		Path:        path,
+		Response:    Example(md.Output()),
+	}
+
+	// 路径参数替换为示例值，并从请求体中移除
+	fields := md.Input().Fields()
+	realPath := path
+	for _, v := range pathVars(path) {
+		name := strings.SplitN(v, "=", 2)[0]
+		value := name
+		if fd := fields.ByName(protoreflect.Name(name)); fd != nil {
+			value = fmt.Sprint(req[fd.JSONName()])
+			delete(req, fd.JSONName())
+		}
+		realPath = strings.Replace(realPath, "{"+v+"}", url.PathEscape(value), 1)
+	}
+
+	var body interface{}
+	switch rule.GetBody() {
+	case "":
+		// 没有请求体时，其余标量字段作为查询参数
+		query := url.Values{}
+		keys := make([]string, 0, len(req))
+		for k := range req {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			switch v := req[k].(type) {
+			case map[string]interface{}, []interface{}:
+			default:
+				query.Set(k, fmt.Sprint(v))
+			}
+		}
+		if len(query) > 0 {
+			realPath += "?" + query.Encode()
+		}
+	case "*":
+		body = req
+	default:
+		if fd := fields.ByName(protoreflect.Name(rule.GetBody())); fd != nil {
+			body = req[fd.JSONName()]
+		}
+	}
+	op.Request = body
+	op.Curl = curl(method, strings.TrimSuffix(baseURL, "/")+realPath, body)
+	return op
+}
+
+// httpPattern 获取 HTTP 方法和路径
+func httpPattern(r *annotations.HttpRule) (string, string) {
+	switch p := r.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		return http.MethodGet, p.Get
+	case *annotations.HttpRule_Post:
+		return http.MethodPost, p.Post
+	case *annotations.HttpRule_Put:
+		return http.MethodPut, p.Put
+	case *annotations.HttpRule_Delete:
+		return http.MethodDelete, p.Delete
+	case *annotations.HttpRule_Patch:
+		return http.MethodPatch, p.Patch
+	case *annotations.HttpRule_Custom:
+		return strings.ToUpper(p.Custom.GetKind()), p.Custom.GetPath()
+	}
+	return "", ""
+}
+
+// pathVars 获取路径中的变量，如 /v1/{name=users/*} 返回 name=users/*
+func pathVars(path string) []string {
+	var vars []string
+	for {
+		start := strings.Index(path, "{")
+		if start < 0 {
+			return vars
+		}
+		end := strings.Index(path[start:], "}")
+		if end < 0 {
+			return vars
+		}
+		vars = append(vars, path[start+1:start+end])
+		path = path[start+end+1:]
+	}
+}
+
+// curl 生成 curl 命令
+func curl(method, target string, body interface{}) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "curl -X %s '%s'", method, target)
+	if body != nil {
+		data, _ := json.Marshal(body)
+		fmt.Fprintf(&b, " \\\n  -H 'Content-Type: application/json' \\\n  -d '%s'", strings.ReplaceAll(string(data), "'", `'\''`))
+	}
+	return b.String()
+}
+
+// injectExamples 按 operationId 将请求、响应示例和 curl 命令注入 OpenAPI 文档
+func injectExamples(spec []byte, ops []Operation) ([]byte, error) {
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(spec, &doc); err != nil {
+		return nil, err
+	}
+	byID := make(map[string]Operation, len(ops))
+	for _, op := range ops {
+		byID[op.OperationID] = op
+	}
+
+	paths, _ := doc["paths"].(map[string]interface{})
+	for _, item := range paths {
+		methods, _ := item.(map[string]interface{})
+		for _, v := range methods {
+			m, _ := v.(map[string]interface{})
+			id, _ := m["operationId"].(string)
+			op, ok := byID[id]
+			if !ok {
+				continue
+			}
+			if op.Request != nil {
+				setExample(m, op.Request, "requestBody")
+			}
+			setExample(m, op.Response, "responses", "200")
+			m["x-codeSamples"] = []interface{}{
+				map[string]interface{}{"lang": "Shell", "label": "curl", "source": op.Curl},
+			}
+		}
+	}
+	return yaml.Marshal(doc)
+}
+
+// setExample 设置 keys 对应节点下 application/json 内容的示例
+func setExample(m map[string]interface{}, example interface{}, keys ...string) {
+	for _, k := range keys {
+		next, ok := m[k].(map[string]interface{})
+		if !ok {
+			return
+		}
+		m = next
+	}
+	content, _ := m["content"].(map[string]interface{})
+	media, ok := content["application/json"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	media["example"] = example
+}
+
+// swaggerUI Swagger UI 页面
+const swaggerUI = `<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <title>API Docs</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "%s", dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>
+`
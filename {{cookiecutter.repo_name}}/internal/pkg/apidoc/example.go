@@ -0,0 +1,250 @@
+package apidoc
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/envoyproxy/protoc-gen-validate/validate"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// maxDepth 生成示例时嵌套消息的最大深度，避免递归消息无限展开
+const maxDepth = 4
+
+// Example 根据字段类型和 validate 校验规则生成消息的 JSON 示例
+func Example(md protoreflect.MessageDescriptor) map[string]interface{} {
+	return messageExample(md, 0)
+}
+
+// messageExample 生成消息示例
+func messageExample(md protoreflect.MessageDescriptor, depth int) map[string]interface{} {
+	m := make(map[string]interface{})
+	if depth >= maxDepth {
+		return m
+	}
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		// oneof 只展示第一个字段
+		if oneof := fd.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() && oneof.Fields().Get(0) != fd {
+			continue
+		}
+		m[fd.JSONName()] = fieldExample(fd, depth)
+	}
+	return m
+}
+
+// fieldExample 生成字段示例
+func fieldExample(fd protoreflect.FieldDescriptor, depth int) interface{} {
+	rules := fieldRules(fd)
+	switch {
+	case fd.IsMap():
+		return map[string]interface{}{
+			"key": singularExample(fd.MapValue(), nil, depth),
+		}
+	case fd.IsList():
+		var item *validate.FieldRules
+		if r := rules.GetRepeated(); r != nil {
+			item = r.GetItems()
+		}
+		return []interface{}{singularExample(fd, item, depth)}
+	default:
+		return singularExample(fd, rules, depth)
+	}
+}
+
+// fieldRules 获取字段的 validate 校验规则
+func fieldRules(fd protoreflect.FieldDescriptor) *validate.FieldRules {
+	rules, _ := proto.GetExtension(fd.Options(), validate.E_Rules).(*validate.FieldRules)
+	return rules
+}
+
+// singularExample 生成单个值的示例，优先满足校验规则
+func singularExample(fd protoreflect.FieldDescriptor, rules *validate.FieldRules, depth int) interface{} {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return stringExample(fd, rules.GetString_())
+	case protoreflect.BoolKind:
+		if r := rules.GetBool(); r != nil {
+			return r.GetConst()
+		}
+		return true
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return intExample(rules.GetInt32())
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		// int64 在 JSON 中编码为字符串
+		return strconv.FormatInt(intExample(rules.GetInt64()), 10)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return intExample(rules.GetUint32())
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return strconv.FormatUint(intExample(rules.GetUint64()), 10)
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return floatExample(rules)
+	case protoreflect.BytesKind:
+		return "ZXhhbXBsZQ=="
+	case protoreflect.EnumKind:
+		return enumExample(fd.Enum(), rules.GetEnum())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return wellKnownExample(fd.Message(), depth)
+	}
+	return nil
+}
+
+// stringExample 生成字符串示例
+func stringExample(fd protoreflect.FieldDescriptor, r *validate.StringRules) string {
+	if r == nil {
+		return string(fd.Name())
+	}
+	switch {
+	case r.Const != nil:
+		return r.GetConst()
+	case len(r.GetIn()) > 0:
+		return r.GetIn()[0]
+	case r.GetEmail():
+		return "user@example.com"
+	case r.GetUri(), r.GetUriRef():
+		return "https://example.com"
+	case r.GetHostname(), r.GetAddress():
+		return "example.com"
+	case r.GetIp(), r.GetIpv4():
+		return "192.168.1.1"
+	case r.GetIpv6():
+		return "::1"
+	case r.GetUuid():
+		return "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+	}
+
+	s := r.GetPrefix() + string(fd.Name()) + r.GetContains() + r.GetSuffix()
+	minLen := int(r.GetMinLen())
+	if r.Len != nil {
+		minLen = int(r.GetLen())
+	}
+	for len(s) < minLen {
+		s += "x"
+	}
+	maxLen := int(r.GetMaxLen())
+	if r.Len != nil {
+		maxLen = int(r.GetLen())
+	}
+	if maxLen > 0 && len(s) > maxLen {
+		s = s[:maxLen]
+	}
+	return s
+}
+
+// numberRules 数值类型校验规则的公共方法
+type numberRules[T int32 | int64 | uint32 | uint64] interface {
+	GetConst() T
+	GetGt() T
+	GetGte() T
+	GetLt() T
+	GetLte() T
+	GetIn() []T
+}
+
+// intExample 生成整数示例，取满足范围约束的最小值，无约束时为 1
+func intExample[T int32 | int64 | uint32 | uint64, R numberRules[T]](r R) T {
+	if len(r.GetIn()) > 0 {
+		return r.GetIn()[0]
+	}
+	if v := r.GetConst(); v != 0 {
+		return v
+	}
+	switch {
+	case r.GetGte() != 0:
+		return r.GetGte()
+	case r.GetGt() != 0:
+		return r.GetGt() + 1
+	case r.GetLte() != 0 && r.GetLte() < 1:
+		return r.GetLte()
+	case r.GetLt() != 0 && r.GetLt() <= 1:
+		return r.GetLt() - 1
+	}
+	return 1
+}
+
+// floatExample 生成浮点数示例
+func floatExample(rules *validate.FieldRules) float64 {
+	if r := rules.GetDouble(); r != nil {
+		switch {
+		case len(r.GetIn()) > 0:
+			return r.GetIn()[0]
+		case r.Const != nil:
+			return r.GetConst()
+		case r.Gte != nil:
+			return r.GetGte()
+		case r.Gt != nil:
+			return r.GetGt() + 1
+		}
+	}
+	if r := rules.GetFloat(); r != nil {
+		switch {
+		case len(r.GetIn()) > 0:
+			return float64(r.GetIn()[0])
+		case r.Const != nil:
+			return float64(r.GetConst())
+		case r.Gte != nil:
+			return float64(r.GetGte())
+		case r.Gt != nil:
+			return float64(r.GetGt()) + 1
+		}
+	}
+	return 1.5
+}
+
+// enumExample 生成枚举示例，优先使用 in 规则中的值，否则跳过零值
+func enumExample(ed protoreflect.EnumDescriptor, r *validate.EnumRules) string {
+	values := ed.Values()
+	if r != nil {
+		if len(r.GetIn()) > 0 {
+			if v := values.ByNumber(protoreflect.EnumNumber(r.GetIn()[0])); v != nil {
+				return string(v.Name())
+			}
+		}
+		if r.Const != nil {
+			if v := values.ByNumber(protoreflect.EnumNumber(r.GetConst())); v != nil {
+				return string(v.Name())
+			}
+		}
+	}
+	if values.Len() > 1 {
+		return string(values.Get(1).Name())
+	}
+	if values.Len() == 1 {
+		return string(values.Get(0).Name())
+	}
+	return ""
+}
+
+// wellKnownExample 生成消息示例，well-known 类型按 protojson 的编码格式生成
+func wellKnownExample(md protoreflect.MessageDescriptor, depth int) interface{} {
+	switch md.FullName() {
+	case "google.protobuf.Timestamp":
+		return time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC).Format(time.RFC3339)
+	case "google.protobuf.Duration":
+		return "1s"
+	case "google.protobuf.FieldMask":
+		return "field1,field2"
+	case "google.protobuf.Struct":
+		return map[string]interface{}{"key": "value"}
+	case "google.protobuf.Value":
+		return "value"
+	case "google.protobuf.ListValue":
+		return []interface{}{"value"}
+	case "google.protobuf.Empty":
+		return map[string]interface{}{}
+	case "google.protobuf.Any":
+		return map[string]interface{}{"@type": "type.googleapis.com/google.protobuf.Empty"}
+	case "google.protobuf.StringValue", "google.protobuf.BytesValue":
+		return "value"
+	case "google.protobuf.BoolValue":
+		return true
+	case "google.protobuf.Int64Value", "google.protobuf.UInt64Value":
+		return "1"
+	case "google.protobuf.Int32Value", "google.protobuf.UInt32Value",
+		"google.protobuf.DoubleValue", "google.protobuf.FloatValue":
+		return 1
+	}
+	return messageExample(md, depth+1)
+}
@@ -0,0 +1,126 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"{{cookiecutter.module_name}}/internal/pkg/diff"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware/tracing"
+)
+
+// Result 操作结果
+type Result string
+
+const (
+	// ResultSuccess 操作成功
+	ResultSuccess Result = "success"
+	// ResultFailure 操作失败
+	ResultFailure Result = "failure"
+	// ResultDenied 无权限被拒绝
+	ResultDenied Result = "denied"
+)
+
+// Entry 审计记录
+type Entry struct {
+	Time     time.Time `json:"time"`
+	Actor    string    `json:"actor"`
+	Action   string    `json:"action"`
+	Resource string    `json:"resource"`
+	Result   Result    `json:"result"`
+	TraceID  string    `json:"trace_id,omitempty"`
+	// Changes 更新操作的字段变更，由 diff.Compare 生成
+	Changes diff.ChangeSet `json:"changes,omitempty"`
+}
+
+// Sink 审计记录的远程输出
+type Sink interface {
+	Write(ctx context.Context, e *Entry) error
+}
+
+// Auditor 审计日志记录器，与应用日志分开写入独立文件，可同时输出到远程
+type Auditor struct {
+	logger log.Logger
+	sinks  []Sink
+	errLog *log.Helper
+}
+
+// New 创建审计日志记录器，logger 为审计日志文件，errLogger 用于记录远程输出失败
+func New(logger log.Logger, errLogger log.Logger, sinks ...Sink) *Auditor {
+	return &Auditor{
+		logger: logger,
+		sinks:  sinks,
+		errLog: log.NewHelper(errLogger),
+	}
+}
+
+// Record 记录一条审计日志
+func (a *Auditor) Record(ctx context.Context, actor, action, resource string, result Result) {
+	a.RecordChanges(ctx, actor, action, resource, result, nil)
+}
+
+// RecordChanges 记录一条带字段变更的审计日志
+func (a *Auditor) RecordChanges(ctx context.Context, actor, action, resource string, result Result, changes diff.ChangeSet) {
+	e := &Entry{
+		Time:     time.Now(),
+		Actor:    actor,
+		Action:   action,
+		Resource: resource,
+		Result:   result,
+		TraceID:  fmt.Sprint(tracing.TraceID()(ctx)),
+		Changes:  changes,
+	}
+	kvs := []interface{}{
+		"actor", e.Actor,
+		"action", e.Action,
+		"resource", e.Resource,
+		"result", string(e.Result),
+		"trace.id", e.TraceID,
+	}
+	if len(e.Changes) > 0 {
+		kvs = append(kvs, "changes", e.Changes)
+	}
+	_ = a.logger.Log(log.LevelInfo, kvs...)
+	for _, s := range a.sinks {
+		if err := s.Write(ctx, e); err != nil {
+			a.errLog.WithContext(ctx).Errorf("audit: write sink failed: %v", err)
+		}
+	}
+}
+
+var (
+	mu  sync.RWMutex
+	std *Auditor
+)
+
+// SetDefault 设置全局审计日志记录器
+func SetDefault(a *Auditor) {
+	mu.Lock()
+	defer mu.Unlock()
+	std = a
+}
+
+// Record 使用全局审计日志记录器记录一条审计日志，未启用审计时忽略
+func Record(ctx context.Context, actor, action, resource string, result Result) {
+	mu.RLock()
+	a := std
+	mu.RUnlock()
+	if a == nil {
+		return
+	}
+	a.Record(ctx, actor, action, resource, result)
+}
+
+// RecordChanges 使用全局审计日志记录器记录一条带字段变更的审计日志，未启用审计时忽略
+func RecordChanges(ctx context.Context, actor, action, resource string, result Result, changes diff.ChangeSet) {
+	mu.RLock()
+	a := std
+	mu.RUnlock()
+	if a == nil {
+		return
+	}
+	a.RecordChanges(ctx, actor, action, resource, result, changes)
+}
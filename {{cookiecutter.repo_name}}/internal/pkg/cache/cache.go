@@ -0,0 +1,203 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/vmihailenco/msgpack/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"golang.org/x/sync/singleflight"
+)
+
+var requests, _ = otel.Meter("cache").Int64Counter(
+	"cache.redis.requests",
+	metric.WithDescription("Number of Redis cache lookups by result: hit, negative, miss or error"),
+)
+
+const (
+	// tagValue 缓存值前缀，后接编码后的值
+	tagValue byte = 'v'
+	// tagNegative 空值缓存，表示数据不存在
+	tagNegative byte = 'n'
+)
+
+// Codec 缓存值的序列化方式
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(b []byte, v interface{}) error
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error)   { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(b []byte, v interface{}) error { return json.Unmarshal(b, v) }
+
+type msgpackCodec struct{}
+
+func (msgpackCodec) Marshal(v interface{}) ([]byte, error)   { return msgpack.Marshal(v) }
+func (msgpackCodec) Unmarshal(b []byte, v interface{}) error { return msgpack.Unmarshal(b, v) }
+
+var (
+	// JSON 使用 JSON 序列化，便于在 redis-cli 中查看
+	JSON Codec = jsonCodec{}
+	// Msgpack 使用 msgpack 序列化，体积更小，默认值
+	Msgpack Codec = msgpackCodec{}
+)
+
+// Option 缓存配置项
+type Option func(*Cache)
+
+// WithName 指标的 cache 维度，默认 default
+func WithName(name string) Option {
+	return func(c *Cache) {
+		c.name = name
+	}
+}
+
+// WithPrefix key 前缀，如 {{cookiecutter.repo_name}}:user:
+func WithPrefix(prefix string) Option {
+	return func(c *Cache) {
+		c.prefix = prefix
+	}
+}
+
+// WithCodec 序列化方式，默认 Msgpack
+func WithCodec(codec Codec) Option {
+	return func(c *Cache) {
+		c.codec = codec
+	}
+}
+
+// WithJitter 过期时间随机增加 0 到 ttl*ratio，避免同一批 key 同时过期，默认 0.1
+func WithJitter(ratio float64) Option {
+	return func(c *Cache) {
+		c.jitter = ratio
+	}
+}
+
+// WithNegative 缓存 loader 返回的 err（按 errors.Is 判断）ttl 时长，命中时直接返回 err，防止不存在的 key 穿透到数据库
+func WithNegative(err error, ttl time.Duration) Option {
+	return func(c *Cache) {
+		c.notFound = err
+		c.negativeTTL = ttl
+	}
+}
+
+// Cache 基于 Redis 的旁路缓存，未命中时调用 loader 回源并写回缓存，
+// 同一 key 的并发回源只执行一次；Redis 读写失败时直接回源，不影响调用方
+type Cache struct {
+	rdb         redis.UniversalClient
+	name        string
+	prefix      string
+	codec       Codec
+	jitter      float64
+	notFound    error
+	negativeTTL time.Duration
+	group       singleflight.Group
+}
+
+// New 创建缓存，rdb 为 nil 时每次都回源，便于未配置 Redis 时使用同一套代码
+func New(rdb redis.UniversalClient, opts ...Option) *Cache {
+	c := &Cache{
+		rdb:    rdb,
+		name:   "default",
+		codec:  Msgpack,
+		jitter: 0.1,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Get 读取 key，未命中时调用 loader 并按 ttl 写入缓存
+//
+//	g, err := cache.Get(ctx, r.cache, strconv.FormatInt(id, 10), 5*time.Minute, func(ctx context.Context) (*biz.User, error) {
+//		return r.load(ctx, id)
+//	})
+func Get[T any](ctx context.Context, c *Cache, key string, ttl time.Duration, loader func(ctx context.Context) (T, error)) (T, error) {
+	var zero T
+	key = c.prefix + key
+	if c.rdb != nil {
+		b, err := c.rdb.Get(ctx, key).Bytes()
+		switch {
+		case err == nil && len(b) > 0 && b[0] == tagNegative && c.notFound != nil:
+			c.record(ctx, "negative")
+			return zero, c.notFound
+		case err == nil && len(b) > 0 && b[0] == tagValue:
+			var v T
+			if err := c.codec.Unmarshal(b[1:], &v); err == nil {
+				c.record(ctx, "hit")
+				return v, nil
+			}
+			c.record(ctx, "error")
+		case errors.Is(err, redis.Nil):
+			c.record(ctx, "miss")
+		default:
+			c.record(ctx, "error")
+		}
+	}
+
+	// 回源不随单个调用方取消，等待中的其他调用方共享结果
+	ch := c.group.DoChan(key, func() (interface{}, error) {
+		ctx := context.WithoutCancel(ctx)
+		v, err := loader(ctx)
+		if err != nil {
+			if c.notFound != nil && c.negativeTTL > 0 && errors.Is(err, c.notFound) {
+				c.set(ctx, key, []byte{tagNegative}, c.negativeTTL)
+			}
+			return nil, err
+		}
+		if b, err := c.codec.Marshal(v); err == nil {
+			c.set(ctx, key, append([]byte{tagValue}, b...), ttl)
+		}
+		return v, nil
+	})
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return zero, res.Err
+		}
+		v, _ := res.Val.(T)
+		return v, nil
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
+
+// Delete 删除 key，数据变更后调用
+func (c *Cache) Delete(ctx context.Context, keys ...string) error {
+	if c.rdb == nil || len(keys) == 0 {
+		return nil
+	}
+	full := make([]string, len(keys))
+	for i, k := range keys {
+		full[i] = c.prefix + k
+	}
+	return c.rdb.Del(ctx, full...).Err()
+}
+
+// set 写入缓存，过期时间加上随机抖动，写入失败时忽略
+func (c *Cache) set(ctx context.Context, key string, b []byte, ttl time.Duration) {
+	if c.rdb == nil || ttl <= 0 {
+		return
+	}
+	if c.jitter > 0 {
+		ttl += time.Duration(rand.Float64() * c.jitter * float64(ttl))
+	}
+	_ = c.rdb.Set(ctx, key, b, ttl).Err()
+}
+
+// record 记录缓存查询结果
+func (c *Cache) record(ctx context.Context, result string) {
+	requests.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("cache", c.name),
+		attribute.String("result", result),
+	))
+}
@@ -0,0 +1,530 @@
+package confcheck
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"{{cookiecutter.module_name}}/internal/conf"
+	"{{cookiecutter.module_name}}/internal/pkg/middleware/accesslog"
+	"{{cookiecutter.module_name}}/internal/pkg/middleware/version"
+	"{{cookiecutter.module_name}}/internal/pkg/tenant"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// FieldError 单个配置项的校验错误
+type FieldError struct {
+	Field   string // 配置路径，如 server.http.addr
+	Message string
+}
+
+// Errors 全部配置校验错误，启动时一次性输出，避免改一个错一个
+type Errors []FieldError
+
+// Error 实现 error 接口
+func (e Errors) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "invalid config (%d errors):", len(e))
+	for _, fe := range e {
+		fmt.Fprintf(&b, "\n  %s: %s", fe.Field, fe.Message)
+	}
+	return b.String()
+}
+
+// Validate 校验启动配置，包括必填项、端口范围、互斥选项和 URL 格式，无错误时返回 nil
+func Validate(bc *conf.Bootstrap) error {
+	c := &checker{}
+	c.server(bc.Server)
+	c.data(bc.Data)
+	c.log(bc.Log)
+	c.remote(bc.Remote)
+	if len(c.errs) == 0 {
+		return nil
+	}
+	return c.errs
+}
+
+// checker 收集校验错误
+type checker struct {
+	errs Errors
+}
+
+func (c *checker) fail(field, format string, args ...interface{}) {
+	c.errs = append(c.errs, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (c *checker) server(s *conf.Server) {
+	if s == nil {
+		return
+	}
+	listeners := map[string]string{}
+	listen := func(field, network, addr string) {
+		if addr == "" {
+			return
+		}
+		c.oneOf(field+".network", network, "", "tcp", "tcp4", "tcp6", "unix")
+		if network == "unix" {
+			return
+		}
+		if !c.addr(field+".addr", addr) {
+			return
+		}
+		if prev, ok := listeners[addr]; ok {
+			c.fail(field+".addr", "%s is already used by %s", addr, prev)
+			return
+		}
+		listeners[addr] = field + ".addr"
+	}
+	listen("server.http", s.Http.GetNetwork(), s.Http.GetAddr())
+	c.duration("server.http.timeout", s.Http.GetTimeout())
+	listen("server.grpc", s.Grpc.GetNetwork(), s.Grpc.GetAddr())
+	c.duration("server.grpc.timeout", s.Grpc.GetTimeout())
+	listen("server.admin", "", s.Admin.GetAddr())
+	listen("server.metrics", "", s.Metrics.GetAddr())
+	if p := s.Metrics.GetPath(); p != "" && !strings.HasPrefix(p, "/") {
+		c.fail("server.metrics.path", "must start with /")
+	}
+
+	if v := s.ApiVersion; v != nil && v.Min != "" && v.Max != "" && version.Compare(v.Min, v.Max) > 0 {
+		c.fail("server.api_version", "min %s is greater than max %s", v.Min, v.Max)
+	}
+	if s.Docs.GetEnable() {
+		c.url("server.docs.base_url", s.Docs.BaseUrl, false, "http", "https")
+	}
+	if o := s.Operation; o != nil {
+		c.nonNegative("server.operation.workers", int64(o.Workers))
+		c.nonNegative("server.operation.queue_size", int64(o.QueueSize))
+		c.duration("server.operation.ttl", o.Ttl)
+	}
+	if s.Duplicate.GetEnable() {
+		c.duration("server.duplicate.window", s.Duplicate.Window)
+	}
+	if d := s.Diagnostics; d.GetEnable() {
+		if d.SampleRate < 0 || d.SampleRate > 1 {
+			c.fail("server.diagnostics.sample_rate", "must be between 0 and 1, got %v", d.SampleRate)
+		}
+		c.nonNegative("server.diagnostics.top", int64(d.Top))
+		c.duration("server.diagnostics.interval", d.Interval)
+	}
+	if l := s.License; l.GetEnable() {
+		switch {
+		case l.File == "" && l.ServerUrl == "":
+			c.fail("server.license", "file or server_url is required")
+		case l.File != "" && l.ServerUrl != "":
+			c.fail("server.license", "file and server_url are mutually exclusive")
+		case l.ServerUrl != "":
+			c.url("server.license.server_url", l.ServerUrl, true, "http", "https")
+		}
+		c.duration("server.license.interval", l.Interval)
+		c.duration("server.license.grace", l.Grace)
+	}
+	c.nonNegative("server.config_history.size", int64(s.ConfigHistory.GetSize()))
+}
+
+func (c *checker) data(d *conf.Data) {
+	if d == nil {
+		return
+	}
+	if d.Embedded.GetEnable() {
+		if d.Embedded.Path == "" {
+			c.fail("data.embedded.path", "is required when data.embedded.enable is true")
+		}
+		if d.Database.GetAutoMigrate() || d.Database.GetSchemaCheck().GetEnable() {
+			c.fail("data.embedded.enable", "is mutually exclusive with data.database.auto_migrate and data.database.schema_check")
+		}
+		for name, db := range d.Databases {
+			if db.GetAutoMigrate() || db.GetSchemaCheck().GetEnable() {
+				c.fail("data.embedded.enable", "is mutually exclusive with data.databases.%s.auto_migrate and schema_check", name)
+			}
+		}
+		if b := d.Embedded.Backup; b.GetEnable() {
+			c.archive("data.embedded.backup", b)
+		}
+	}
+	if d.Database != nil && d.Databases["default"] != nil {
+		c.fail("data.database", "conflicts with data.databases.default, use one of them")
+	}
+	if d.Redis != nil && d.RedisInstances["default"] != nil {
+		c.fail("data.redis", "conflicts with data.redis_instances.default, use one of them")
+	}
+	if db := d.Database; db != nil {
+		c.database("data.database", db)
+	}
+	for name, db := range d.Databases {
+		c.database("data.databases."+name, db)
+	}
+	if r := d.Redis; r != nil {
+		c.redis("data.redis", r)
+	}
+	for name, r := range d.RedisInstances {
+		c.redis("data.redis_instances."+name, r)
+	}
+	if m := d.Mongo; m.GetEnable() {
+		// 副本集 URI 包含多个主机，不按 url 校验
+		if c.required("data.mongo.uri", m.Uri) && !strings.HasPrefix(m.Uri, "mongodb://") && !strings.HasPrefix(m.Uri, "mongodb+srv://") {
+			c.fail("data.mongo.uri", "unsupported scheme: want mongodb:// or mongodb+srv://")
+		}
+		c.required("data.mongo.database", m.Database)
+		c.duration("data.mongo.timeout", m.Timeout)
+		c.duration("data.mongo.connect_timeout", m.ConnectTimeout)
+	}
+	if ch := d.Clickhouse; ch.GetEnable() {
+		if len(ch.Addrs) == 0 {
+			c.fail("data.clickhouse.addrs", "is required when data.clickhouse.enable is true")
+		}
+		for i, addr := range ch.Addrs {
+			c.addr(fmt.Sprintf("data.clickhouse.addrs[%d]", i), addr)
+		}
+		c.duration("data.clickhouse.dial_timeout", ch.DialTimeout)
+		c.duration("data.clickhouse.read_timeout", ch.ReadTimeout)
+		c.duration("data.clickhouse.conn_max_lifetime", ch.ConnMaxLifetime)
+		c.duration("data.clickhouse.async_insert_busy_timeout", ch.AsyncInsertBusyTimeout)
+		c.nonNegative("data.clickhouse.max_open_conns", int64(ch.MaxOpenConns))
+		c.nonNegative("data.clickhouse.max_idle_conns", int64(ch.MaxIdleConns))
+		if ch.WaitForAsyncInsert && !ch.AsyncInsert {
+			c.fail("data.clickhouse.wait_for_async_insert", "requires async_insert")
+		}
+	}
+	if o := d.Outbox; o.GetEnable() {
+		// outbox 表在关系数据库中与业务数据同事务写入，消息发布到 Redis 事件总线
+		if d.Embedded.GetEnable() || d.Mongo.GetEnable() {
+			c.fail("data.outbox.enable", "requires a relational database, not data.embedded or data.mongo")
+		}
+		if d.Redis == nil && d.RedisInstances["default"] == nil {
+			c.fail("data.outbox.enable", "requires the default redis for publishing")
+		}
+		c.nonNegative("data.outbox.batch_size", int64(o.BatchSize))
+		c.nonNegative("data.outbox.max_attempts", int64(o.MaxAttempts))
+		c.duration("data.outbox.interval", o.Interval)
+		c.duration("data.outbox.lease", o.Lease)
+		c.duration("data.outbox.retention", o.Retention)
+	}
+	if t := d.Tenancy; t.GetEnable() {
+		c.tenancy(d, t)
+	}
+}
+
+// tenancy 校验多租户配置，每个租户必须落到独立的数据库或 schema
+func (c *checker) tenancy(d *conf.Data, t *conf.Data_Tenancy) {
+	db := d.Database
+	if db == nil {
+		db = d.Databases["default"]
+	}
+	if d.Embedded.GetEnable() || d.Mongo.GetEnable() || db == nil {
+		c.fail("data.tenancy.enable", "requires the default relational database, not data.embedded or data.mongo")
+		return
+	}
+	if d.Outbox.GetEnable() {
+		c.fail("data.tenancy.enable", "is mutually exclusive with data.outbox, the relay only reads the default database")
+	}
+	postgres := db.Driver == "postgres" || db.Driver == "pgx"
+	if t.Schema != "" && !postgres {
+		c.fail("data.tenancy.schema", "is only supported with postgres, use a source template with {tenant} instead")
+	}
+	templated := strings.Contains(t.Source, "{tenant}") || strings.Contains(t.Schema, "{tenant}")
+	if !templated && !t.Strict {
+		c.fail("data.tenancy", "source or schema must contain {tenant}, or set strict and configure every tenant, otherwise tenants share one database")
+	}
+	for name, tc := range t.Tenants {
+		field := "data.tenancy.tenants." + name
+		if !tenant.Valid(name) {
+			c.fail(field, "tenant id may only contain letters, digits, _ and -, at most 63 characters")
+		}
+		if tc.Schema != "" && !postgres {
+			c.fail(field+".schema", "is only supported with postgres")
+		}
+		if t.Strict && !templated && tc.Source == "" && tc.Schema == "" {
+			c.fail(field, "source or schema is required when data.tenancy has no template")
+		}
+	}
+	c.nonNegative("data.tenancy.max_tenants", int64(t.MaxTenants))
+	c.duration("data.tenancy.idle_timeout", t.IdleTimeout)
+}
+
+// database 校验单个数据库配置
+func (c *checker) database(field string, db *conf.Data_Database) {
+	if db.Source != "" && db.Driver == "" {
+		c.fail(field+".driver", "is required when source is set")
+	}
+	if (db.AutoMigrate || db.RunMigrations || db.SchemaCheck.GetEnable()) && db.Source == "" {
+		c.fail(field+".source", "is required when auto_migrate, run_migrations or schema_check is enabled")
+	}
+	if db.AutoMigrate && db.RunMigrations {
+		c.fail(field+".run_migrations", "is mutually exclusive with auto_migrate, use versioned migrations or AutoMigrate")
+	}
+	c.duration(field+".migrate_lock_timeout", db.MigrateLockTimeout)
+	c.duration(field+".slow_threshold", db.SlowThreshold)
+	if len(db.Replicas) > 0 && db.Source == "" {
+		c.fail(field+".replicas", "requires source of the primary")
+	}
+}
+
+// redis 校验单个 Redis 配置
+func (c *checker) redis(field string, r *conf.Data_Redis) {
+	if r.Addr == "" && len(r.Addrs) == 0 {
+		return
+	}
+	if len(r.Addrs) > 0 {
+		for i, addr := range r.Addrs {
+			c.addr(fmt.Sprintf("%s.addrs[%d]", field, i), addr)
+		}
+		if r.Db != 0 {
+			c.fail(field+".db", "is not supported in cluster mode")
+		}
+	} else {
+		c.oneOf(field+".network", r.Network, "", "tcp", "tcp4", "tcp6", "unix")
+		if r.Network != "unix" {
+			c.addr(field+".addr", r.Addr)
+		}
+	}
+	c.nonNegative(field+".db", int64(r.Db))
+	c.duration(field+".read_timeout", r.ReadTimeout)
+	c.duration(field+".write_timeout", r.WriteTimeout)
+	c.duration(field+".dial_timeout", r.DialTimeout)
+	c.duration(field+".pool_timeout", r.PoolTimeout)
+	c.duration(field+".conn_max_idle_time", r.ConnMaxIdleTime)
+	c.nonNegative(field+".pool_size", int64(r.PoolSize))
+	c.nonNegative(field+".min_idle_conns", int64(r.MinIdleConns))
+	if t := r.Tls; t.GetEnable() && (t.CertFile == "") != (t.KeyFile == "") {
+		c.fail(field+".tls.cert_file", "cert_file and key_file must be set together")
+	}
+}
+
+func (c *checker) log(l *conf.Log) {
+	if l == nil {
+		return
+	}
+	levels := []string{"", "debug", "info", "warn", "error", "fatal"}
+	c.oneOf("log.level", strings.ToLower(l.Level), levels...)
+	c.oneOf("log.dedup_level", strings.ToLower(l.DedupLevel), levels...)
+	c.oneOf("log.format", l.Format, "", "json", "text")
+	c.oneOf("log.multi_process", strings.ToLower(l.MultiProcess), "", "pid", "lock")
+	c.nonNegative("log.max_size", int64(l.MaxSize))
+	c.nonNegative("log.max_age", int64(l.MaxAge))
+	c.nonNegative("log.max_backups", int64(l.MaxBackups))
+	c.nonNegative("log.error_rate_limit", int64(l.ErrorRateLimit))
+	c.duration("log.dedup_window", l.DedupWindow)
+	c.mode("log.file_mode", l.FileMode)
+	c.mode("log.dir_mode", l.DirMode)
+	if l.TimeZone != "" {
+		if _, err := time.LoadLocation(l.TimeZone); err != nil {
+			c.fail("log.time_zone", "%v", err)
+		}
+	}
+
+	if l.Archive.GetEnable() {
+		if l.Filename == "" {
+			c.fail("log.archive.enable", "requires log.filename")
+		}
+		c.archive("log.archive", l.Archive)
+	}
+	if o := l.Otlp; o.GetEnable() {
+		c.required("log.otlp.endpoint", o.Endpoint)
+		c.duration("log.otlp.timeout", o.Timeout)
+	}
+	if a := l.Access; a.GetEnable() {
+		c.nonNegative("log.access.max_body_size", int64(a.MaxBodySize))
+		for _, p := range a.TrustedProxies {
+			if _, err := accesslog.ParseProxy(p); err != nil {
+				c.fail("log.access.trusted_proxies", "invalid IP or CIDR %q", p)
+			}
+		}
+	}
+	if s := l.Slow; s.GetEnable() {
+		c.duration("log.slow.threshold", s.Threshold)
+		c.duration("log.slow.sql_threshold", s.SqlThreshold)
+	}
+	if a := l.Audit; a.GetEnable() {
+		c.url("log.audit.remote_url", a.RemoteUrl, false, "http", "https")
+	}
+	if a := l.Alert; a.GetEnable() {
+		c.url("log.alert.webhook_url", a.WebhookUrl, true, "http", "https")
+		c.oneOf("log.alert.format", a.Format, "", "json", "dingtalk", "slack")
+		c.oneOf("log.alert.level", strings.ToLower(a.Level), levels...)
+		c.nonNegative("log.alert.rate_limit", int64(a.RateLimit))
+	}
+	if s := l.Sentry; s.GetEnable() {
+		c.url("log.sentry.dsn", s.Dsn, true, "http", "https")
+		if s.SampleRate < 0 || s.SampleRate > 1 {
+			c.fail("log.sentry.sample_rate", "must be between 0 and 1, got %v", s.SampleRate)
+		}
+		c.oneOf("log.sentry.level", strings.ToLower(s.Level), levels...)
+	}
+	if f := l.Fluent; f.GetEnable() {
+		if c.required("log.fluent.address", f.Address) {
+			c.addr("log.fluent.address", f.Address)
+		}
+		c.nonNegative("log.fluent.buffer_size", int64(f.BufferSize))
+	}
+	if b := l.Buffer; b.GetEnable() {
+		c.oneOf("log.buffer.level", strings.ToLower(b.Level), levels...)
+		c.nonNegative("log.buffer.max_lines", int64(b.MaxLines))
+	}
+	if s := l.Spool; s.GetEnable() {
+		c.nonNegative("log.spool.max_size", int64(s.MaxSize))
+		c.nonNegative("log.spool.segment_size", int64(s.SegmentSize))
+		if s.MaxSize > 0 && s.SegmentSize > s.MaxSize {
+			c.fail("log.spool.segment_size", "must not exceed log.spool.max_size")
+		}
+	}
+}
+
+func (c *checker) remote(r *conf.Remote) {
+	if r == nil {
+		return
+	}
+	if n := r.Nacos; n.GetEnable() {
+		if len(n.Addrs) == 0 {
+			c.fail("remote.nacos.addrs", "is required")
+		}
+		for i, addr := range n.Addrs {
+			c.url(fmt.Sprintf("remote.nacos.addrs[%d]", i), addr, true, "http", "https")
+		}
+		c.required("remote.nacos.data_id", n.DataId)
+		if (n.Username == "") != (n.Password == "") {
+			c.fail("remote.nacos.username", "username and password must be set together")
+		}
+	}
+	if a := r.Apollo; a.GetEnable() {
+		c.url("remote.apollo.addr", a.Addr, true, "http", "https")
+		c.required("remote.apollo.app_id", a.AppId)
+	}
+	if e := r.Etcd; e.GetEnable() {
+		if len(e.Endpoints) == 0 {
+			c.fail("remote.etcd.endpoints", "is required")
+		}
+		c.required("remote.etcd.prefix", e.Prefix)
+		if (e.CertFile == "") != (e.KeyFile == "") {
+			c.fail("remote.etcd.cert_file", "cert_file and key_file must be set together")
+		}
+	}
+	if k := r.Kubernetes; k.GetEnable() && len(k.Dirs) == 0 {
+		c.fail("remote.kubernetes.dirs", "is required")
+	}
+	if h := r.Http; h.GetEnable() {
+		c.url("remote.http.url", h.Url, true, "http", "https")
+		c.oneOf("remote.http.format", h.Format, "", "yaml", "yml", "json")
+	}
+	if v := r.Vault; v.GetEnable() {
+		c.url("remote.vault.addr", v.Addr, true, "http", "https")
+		approle := v.RoleId != "" || v.SecretId != ""
+		switch {
+		case v.Token != "" && approle:
+			c.fail("remote.vault.token", "token and AppRole (role_id/secret_id) are mutually exclusive")
+		case v.Token == "" && !approle:
+			c.fail("remote.vault.token", "either token or AppRole (role_id/secret_id) is required")
+		case approle && (v.RoleId == "" || v.SecretId == ""):
+			c.fail("remote.vault.role_id", "role_id and secret_id must be set together")
+		}
+	}
+	c.duration("remote.nacos.timeout", r.Nacos.GetTimeout())
+	c.duration("remote.apollo.timeout", r.Apollo.GetTimeout())
+	c.duration("remote.etcd.timeout", r.Etcd.GetTimeout())
+	c.duration("remote.http.interval", r.Http.GetInterval())
+	c.duration("remote.http.timeout", r.Http.GetTimeout())
+	c.duration("remote.vault.timeout", r.Vault.GetTimeout())
+}
+
+// archive 校验对象存储配置
+func (c *checker) archive(field string, a *conf.Log_Archive) {
+	c.required(field+".endpoint", a.Endpoint)
+	c.required(field+".bucket", a.Bucket)
+	if (a.AccessKey == "") != (a.SecretKey == "") {
+		c.fail(field+".access_key", "access_key and secret_key must be set together")
+	}
+	c.duration(field+".interval", a.Interval)
+}
+
+// required 必填项，为空时记录错误并返回 false
+func (c *checker) required(field, v string) bool {
+	if v == "" {
+		c.fail(field, "is required")
+		return false
+	}
+	return true
+}
+
+// addr 校验 host:port 格式和端口范围，校验失败返回 false
+func (c *checker) addr(field, v string) bool {
+	_, port, err := net.SplitHostPort(v)
+	if err != nil {
+		c.fail(field, "invalid address %q: want host:port", v)
+		return false
+	}
+	n, err := strconv.Atoi(port)
+	if err != nil || n < 0 || n > 65535 {
+		c.fail(field, "invalid port %q: want 0-65535", port)
+		return false
+	}
+	return true
+}
+
+// url 校验 URL 格式和协议，required 为 false 时允许为空
+func (c *checker) url(field, v string, required bool, schemes ...string) {
+	if v == "" {
+		if required {
+			c.fail(field, "is required")
+		}
+		return
+	}
+	u, err := url.Parse(v)
+	if err != nil || u.Host == "" {
+		c.fail(field, "invalid URL %q", v)
+		return
+	}
+	for _, s := range schemes {
+		if u.Scheme == s {
+			return
+		}
+	}
+	c.fail(field, "unsupported scheme %q: want one of %s", u.Scheme, strings.Join(schemes, ", "))
+}
+
+// oneOf 枚举值校验，空字符串需显式列入可选值
+func (c *checker) oneOf(field, v string, values ...string) {
+	for _, s := range values {
+		if v == s {
+			return
+		}
+	}
+	var names []string
+	for _, s := range values {
+		if s != "" {
+			names = append(names, s)
+		}
+	}
+	c.fail(field, "invalid value %q: want one of %s", v, strings.Join(names, ", "))
+}
+
+func (c *checker) nonNegative(field string, v int64) {
+	if v < 0 {
+		c.fail(field, "must not be negative, got %d", v)
+	}
+}
+
+func (c *checker) duration(field string, d *durationpb.Duration) {
+	if d == nil {
+		return
+	}
+	if err := d.CheckValid(); err != nil {
+		c.fail(field, "%v", err)
+		return
+	}
+	if d.AsDuration() < 0 {
+		c.fail(field, "must not be negative, got %s", d.AsDuration())
+	}
+}
+
+// mode 八进制文件权限，如 0640
+func (c *checker) mode(field, v string) {
+	if v == "" {
+		return
+	}
+	if m, err := strconv.ParseUint(v, 8, 32); err != nil || m > 0777 {
+		c.fail(field, "invalid file mode %q: want octal such as 0640", v)
+	}
+}
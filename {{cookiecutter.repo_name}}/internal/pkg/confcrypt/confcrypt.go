@@ -0,0 +1,139 @@
+package confcrypt
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"{{cookiecutter.module_name}}/internal/pkg/confenv"
+)
+
+const (
+	// EnvKey 数据密钥，base64 编码的 16/24/32 字节 AES 密钥，或 KMS 加密后的密文
+	EnvKey = "CONFIG_KEY"
+	// EnvKeyFile 数据密钥文件，适用于以文件挂载的 Kubernetes Secret
+	EnvKeyFile = "CONFIG_KEY_FILE"
+)
+
+var (
+	// ErrNoKey 配置中有加密值但没有配置数据密钥
+	ErrNoKey = errors.New("confcrypt: encrypted value found but " + EnvKey + " is not set")
+	// ErrWrappedKey 数据密钥被 KMS 加密，需要先解密
+	ErrWrappedKey = errors.New("confcrypt: " + EnvKey + " is wrapped by KMS")
+)
+
+// Unwrapper 解密 KMS 加密的数据密钥，如 Vault Transit
+type Unwrapper func(ctx context.Context, wrapped string) ([]byte, error)
+
+// Cipher 配置值加解密，AES-GCM，密文格式为 ENC(base64(nonce|ciphertext))
+type Cipher struct {
+	aead cipher.AEAD
+}
+
+// New 使用 AES 密钥创建加解密器
+func New(key []byte) (*Cipher, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("confcrypt: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Cipher{aead: aead}, nil
+}
+
+// GenerateKey 生成 base64 编码的 256 位随机密钥
+func GenerateKey() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// FromEnv 从环境变量读取数据密钥，未配置时返回 nil
+// 密钥为 KMS 密文（如 vault:v1:...）时使用 unwrap 解密，unwrap 为 nil 时返回 ErrWrappedKey
+func FromEnv(ctx context.Context, unwrap Unwrapper) (*Cipher, error) {
+	raw := os.Getenv(EnvKey)
+	if name := os.Getenv(EnvKeyFile); raw == "" && name != "" {
+		b, err := os.ReadFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("confcrypt: %w", err)
+		}
+		raw = string(b)
+	}
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	if key, err := base64.StdEncoding.DecodeString(raw); err == nil {
+		return New(key)
+	}
+	if unwrap == nil {
+		return nil, ErrWrappedKey
+	}
+	key, err := unwrap(ctx, raw)
+	if err != nil {
+		return nil, fmt.Errorf("confcrypt: unwrap data key: %w", err)
+	}
+	return New(key)
+}
+
+// IsEncrypted 是否为 ENC(...) 形式的加密值
+func IsEncrypted(s string) bool {
+	return strings.HasPrefix(s, "ENC(") && strings.HasSuffix(s, ")")
+}
+
+// Encrypt 加密配置值，返回 ENC(...)
+func (c *Cipher) Encrypt(plain string) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := c.aead.Seal(nonce, nonce, []byte(plain), nil)
+	return "ENC(" + base64.StdEncoding.EncodeToString(sealed) + ")", nil
+}
+
+// Decrypt 解密 ENC(...) 形式的配置值
+func (c *Cipher) Decrypt(s string) (string, error) {
+	if !IsEncrypted(s) {
+		return "", errors.New("confcrypt: value is not in ENC(...) form")
+	}
+	b, err := base64.StdEncoding.DecodeString(s[len("ENC(") : len(s)-1])
+	if err != nil {
+		return "", fmt.Errorf("confcrypt: %w", err)
+	}
+	n := c.aead.NonceSize()
+	if len(b) < n {
+		return "", errors.New("confcrypt: ciphertext too short")
+	}
+	plain, err := c.aead.Open(nil, b[:n], b[n:], nil)
+	if err != nil {
+		// 密钥不匹配或密文被篡改
+		return "", errors.New("confcrypt: decrypt failed, wrong key or corrupted value")
+	}
+	return string(plain), nil
+}
+
+// Resolver 解密配置中的 ENC(...) 值，用于 config.WithResolver
+// c 为 nil 时遇到加密值返回 ErrNoKey，避免把密文当作密码使用
+func Resolver(c *Cipher) func(map[string]interface{}) error {
+	return func(input map[string]interface{}) error {
+		return confenv.Walk(input, func(s string) (interface{}, error) {
+			if !IsEncrypted(s) {
+				return s, nil
+			}
+			if c == nil {
+				return nil, ErrNoKey
+			}
+			return c.Decrypt(s)
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package confdump
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"{{cookiecutter.module_name}}/internal/pkg/audit"
+	"{{cookiecutter.module_name}}/internal/pkg/diff"
+	"{{cookiecutter.module_name}}/internal/pkg/reload"
+
+	"github.com/go-kratos/kratos/v2/config"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// Revision 配置源推送的一次变更
+type Revision struct {
+	Source   string `json:"source"`   // 配置源名称，如 file、nacos
+	Revision string `json:"revision"` // 变更内容的摘要
+}
+
+// String 如 nacos@1a2b3c4d5e6f
+func (r Revision) String() string {
+	if r.Source == "" {
+		return "unknown"
+	}
+	return r.Source + "@" + r.Revision
+}
+
+// Tracker 记录配置源最近一次推送的变更，用于审计日志中标记变更来源
+type Tracker struct {
+	mu   sync.Mutex
+	last Revision
+}
+
+// NewTracker 创建配置变更来源记录器
+func NewTracker() *Tracker {
+	return &Tracker{}
+}
+
+// Wrap 包装配置源，监听到的变更先经过 Tracker 记录再交给配置合并
+func (t *Tracker) Wrap(s Source) config.Source {
+	return &trackedSource{Source: s.Source, name: s.Name, tracker: t}
+}
+
+// Last 最近一次变更
+func (t *Tracker) Last() Revision {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.last
+}
+
+// record 记录变更，摘要为全部配置项内容的 sha256 前 12 位
+func (t *Tracker) record(name string, kvs []*config.KeyValue) {
+	var b []byte
+	for _, kv := range kvs {
+		b = append(b, kv.Key...)
+		b = append(b, 0)
+		b = append(b, kv.Value...)
+		b = append(b, 0)
+	}
+	t.mu.Lock()
+	t.last = Revision{Source: name, Revision: digest(b)}
+	t.mu.Unlock()
+}
+
+// digest 内容摘要，sha256 的前 12 位
+func digest(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// trackedSource 记录变更来源的配置源
+type trackedSource struct {
+	config.Source
+	name    string
+	tracker *Tracker
+}
+
+// Watch 实现 config.Source 接口
+func (s *trackedSource) Watch() (config.Watcher, error) {
+	w, err := s.Source.Watch()
+	if err != nil {
+		return nil, err
+	}
+	return &trackedWatcher{Watcher: w, source: s}, nil
+}
+
+// trackedWatcher 记录变更来源的配置监听
+type trackedWatcher struct {
+	config.Watcher
+	source *trackedSource
+}
+
+// Next 实现 config.Watcher 接口
+func (w *trackedWatcher) Next() ([]*config.KeyValue, error) {
+	kvs, err := w.Watcher.Next()
+	if err == nil {
+		w.source.tracker.record(w.source.name, kvs)
+	}
+	return kvs, err
+}
+
+// Auditor 配置变更审计，热更新生效后对比前后的配置，将脱敏后的变更写入审计日志，并记录配置快照
+// 敏感配置项变更时前后值均为 Mask，只体现发生了变更
+type Auditor struct {
+	dumper  *Dumper
+	tracker *Tracker
+	history *History
+	log     *log.Helper
+
+	mu   sync.Mutex
+	last map[string]interface{}
+}
+
+// NewAuditor 创建配置变更审计，以当前生效的配置作为对比基线，h 不为 nil 时记录启动配置和每次变更后的快照
+func NewAuditor(d *Dumper, t *Tracker, h *History, logger log.Logger) (*Auditor, error) {
+	last, err := d.values()
+	if err != nil {
+		return nil, err
+	}
+	a := &Auditor{dumper: d, tracker: t, history: h, log: log.NewHelper(logger), last: last}
+	if h != nil {
+		raw, err := d.Snapshot()
+		if err != nil {
+			return nil, err
+		}
+		h.Record(Revision{Source: "startup", Revision: digest(raw)}, raw)
+	}
+	return a, nil
+}
+
+// Watch 监听全部顶层配置项的热更新
+func (a *Auditor) Watch(r *reload.Registry) error {
+	fields := a.dumper.conf.ProtoReflect().Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		err := r.OnChange(fields.Get(i).TextName(), func(config.Value) error {
+			return a.check()
+		})
+		// 配置文件中没有的顶层配置项无法监听
+		if err != nil && !errors.Is(err, config.ErrNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+// check 对比上次的配置，有变更时写入审计日志，一次热更新触发多个顶层配置项时只记录一次
+func (a *Auditor) check() error {
+	current, err := a.dumper.values()
+	if err != nil {
+		return err
+	}
+	a.mu.Lock()
+	changes := compare(a.last, current)
+	a.last = current
+	a.mu.Unlock()
+	if len(changes) == 0 {
+		return nil
+	}
+	rev := a.tracker.Last()
+	a.log.Infof("config changed by %s: %v", rev, changes.Fields())
+	audit.RecordChanges(context.Background(), "config", "config.reload", rev.String(), audit.ResultSuccess, changes)
+	if a.history != nil {
+		raw, err := a.dumper.Snapshot()
+		if err != nil {
+			return err
+		}
+		a.history.Record(rev, raw)
+	}
+	return nil
+}
+
+// compare 对比两次导出的配置并脱敏，按配置路径排序，新增或删除的配置项一侧为 nil
+func compare(before, after map[string]interface{}) diff.ChangeSet {
+	keys := make([]string, 0, len(after))
+	for k := range after {
+		keys = append(keys, k)
+	}
+	for k := range before {
+		if _, ok := after[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	var changes diff.ChangeSet
+	for _, k := range keys {
+		b, a := before[k], after[k]
+		if fmt.Sprint(b) == fmt.Sprint(a) {
+			continue
+		}
+		changes = append(changes, diff.Change{Field: k, Old: mask(k, b), New: mask(k, a)})
+	}
+	return changes
+}
+
+// mask 脱敏单个值，nil 表示配置项不存在，保持原样
+func mask(key string, v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	return MaskValue(key, v)
+}
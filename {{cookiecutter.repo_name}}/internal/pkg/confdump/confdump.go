@@ -0,0 +1,264 @@
+package confdump
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/config"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+// Mask 脱敏后的占位值
+const Mask = "******"
+
+var (
+	// secretKeys 字段名包含以下关键字时整体脱敏
+	secretKeys = []string{"password", "passwd", "secret", "token", "access_key", "credential", "private_key", "dsn", "authorization"}
+	// dsnPassword 匹配 DSN 中的密码部分，如 root:root@tcp(...)、redis://:pass@host、host=db password=pass、?password=pass
+	dsnPassword = regexp.MustCompile(`(?i)([a-z][a-z0-9+.-]*://[^:/@]*:|^[^:/@]+:)[^@]*@|(\bpassword=)\S+`)
+	// urlSecret 匹配 URL 中的凭证参数，如告警机器人地址中的 ?access_token=xxx&sign=xxx
+	urlSecret = regexp.MustCompile(`(?i)([?&](?:token|access_token|key|sign|secret)=)[^&#\s]+`)
+)
+
+// Source 带名称的配置源，名称用于标记配置项来源，如 file、env、nacos
+type Source struct {
+	Name   string
+	Source config.Source
+}
+
+// Dumper 配置导出器，输出脱敏后的生效配置及每个配置项的来源
+type Dumper struct {
+	config  config.Config
+	conf    proto.Message
+	sources []Source
+}
+
+// New 创建配置导出器，sources 需与加载配置时的顺序一致，后面的覆盖前面的
+// c 不为 nil 时每次导出都从 c 重新读取，包含热更新后的值，conf 只作为类型模板；否则导出 conf
+func New(c config.Config, conf proto.Message, sources ...Source) *Dumper {
+	return &Dumper{config: c, conf: conf, sources: sources}
+}
+
+// current 当前生效的配置，经过合并和解析器处理
+func (d *Dumper) current() (proto.Message, error) {
+	if d.config == nil {
+		return d.conf, nil
+	}
+	m := d.conf.ProtoReflect().New().Interface()
+	if err := d.config.Scan(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Dump 导出脱敏后的生效配置，key 为点分隔的配置路径
+func (d *Dumper) Dump() (map[string]interface{}, error) {
+	values, err := d.values()
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range values {
+		values[k] = MaskValue(k, v)
+	}
+	return values, nil
+}
+
+// Snapshot 未脱敏的生效配置，JSON 格式，包含零值字段，用于配置快照和回滚
+func (d *Dumper) Snapshot() ([]byte, error) {
+	msg, err := d.current()
+	if err != nil {
+		return nil, err
+	}
+	return protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(msg)
+}
+
+// values 未脱敏的生效配置，key 为点分隔的配置路径
+func (d *Dumper) values() (map[string]interface{}, error) {
+	msg, err := d.current()
+	if err != nil {
+		return nil, err
+	}
+	b, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	values := make(map[string]interface{})
+	flatten("", m, values)
+	return values, nil
+}
+
+// Origins 获取每个配置项的来源，格式为 源名称:key，如 file:config.yaml
+func (d *Dumper) Origins() (map[string]string, error) {
+	origins := make(map[string]string)
+	for _, s := range d.sources {
+		kvs, err := s.Source.Load()
+		if err != nil {
+			return nil, err
+		}
+		for _, kv := range kvs {
+			m, err := decode(kv)
+			if err != nil {
+				// 无法解析的配置按单个值处理，如环境变量
+				origins[strings.ToLower(kv.Key)] = s.Name + ":" + kv.Key
+				continue
+			}
+			values := make(map[string]interface{})
+			flatten("", m, values)
+			for k := range values {
+				origins[k] = s.Name + ":" + kv.Key
+			}
+		}
+	}
+	return origins, nil
+}
+
+// ServeHTTP 输出 JSON 格式的生效配置及来源，?format=yaml 时输出与配置文件结构一致的 YAML，便于与配置仓库对比
+func (d *Dumper) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	values, err := d.Dump()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if r.URL.Query().Get("format") == "yaml" {
+		w.Header().Set("Content-Type", "application/yaml")
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		_ = enc.Encode(unflatten(values))
+		return
+	}
+	origins, err := d.Origins()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	type item struct {
+		Key    string      `json:"key"`
+		Value  interface{} `json:"value"`
+		Source string      `json:"source"`
+	}
+	items := make([]item, 0, len(values))
+	for k, v := range values {
+		source := origins[k]
+		if source == "" {
+			source = "default"
+		}
+		items = append(items, item{Key: k, Value: v, Source: source})
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Key < items[j].Key })
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(items)
+}
+
+// Guard 管理接口鉴权，配置了 token 时以常量时间比较 X-Admin-Token 请求头，否则只允许本机访问
+func Guard(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token != "" {
+			if subtle.ConstantTimeCompare([]byte(r.Header.Get("X-Admin-Token")), []byte(token)) != 1 {
+				http.Error(w, "forbidden", http.StatusForbidden)
+				return
+			}
+		} else if !isLoopback(r.RemoteAddr) {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// MaskValue 对敏感配置项脱敏
+func MaskValue(key string, value interface{}) interface{} {
+	k := strings.ToLower(key)
+	for _, s := range secretKeys {
+		if strings.Contains(k, s) {
+			return Mask
+		}
+	}
+	s, ok := value.(string)
+	if !ok {
+		return value
+	}
+	s = dsnPassword.ReplaceAllStringFunc(s, func(m string) string {
+		sub := dsnPassword.FindStringSubmatch(m)
+		if sub[2] != "" {
+			return sub[2] + Mask
+		}
+		return sub[1] + Mask + "@"
+	})
+	return urlSecret.ReplaceAllString(s, "${1}"+Mask)
+}
+
+// flatten 将嵌套 map 展开为点分隔的 key
+func flatten(prefix string, m map[string]interface{}, out map[string]interface{}) {
+	for k, v := range m {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		if sub, ok := v.(map[string]interface{}); ok && len(sub) > 0 {
+			flatten(key, sub, out)
+			continue
+		}
+		out[key] = v
+	}
+}
+
+// unflatten 将点分隔的 key 还原为嵌套 map
+func unflatten(values map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{})
+	for k, v := range values {
+		parts := strings.Split(k, ".")
+		m := out
+		for _, p := range parts[:len(parts)-1] {
+			child, ok := m[p].(map[string]interface{})
+			if !ok {
+				child = make(map[string]interface{})
+				m[p] = child
+			}
+			m = child
+		}
+		m[parts[len(parts)-1]] = v
+	}
+	return out
+}
+
+// decode 解析配置内容
+func decode(kv *config.KeyValue) (map[string]interface{}, error) {
+	m := make(map[string]interface{})
+	switch kv.Format {
+	case "yaml", "yml":
+		if err := yaml.Unmarshal(kv.Value, &m); err != nil {
+			return nil, err
+		}
+	case "json":
+		if err := json.Unmarshal(kv.Value, &m); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", kv.Format)
+	}
+	return m, nil
+}
+
+// isLoopback 判断请求是否来自本机
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,90 @@
+package confdump
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGuard(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name   string
+		token  string
+		header string
+		remote string
+		want   int
+	}{
+		{"token match", "s3cret", "s3cret", "203.0.113.7:4000", http.StatusNoContent},
+		{"token mismatch", "s3cret", "s3cre", "127.0.0.1:4000", http.StatusForbidden},
+		{"token missing", "s3cret", "", "127.0.0.1:4000", http.StatusForbidden},
+		{"no token loopback", "", "", "127.0.0.1:4000", http.StatusNoContent},
+		{"no token ipv6 loopback", "", "", "[::1]:4000", http.StatusNoContent},
+		{"no token remote", "", "anything", "203.0.113.7:4000", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/debug/config", nil)
+			r.RemoteAddr = tt.remote
+			if tt.header != "" {
+				r.Header.Set("X-Admin-Token", tt.header)
+			}
+			w := httptest.NewRecorder()
+			Guard(tt.token, ok).ServeHTTP(w, r)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaskValueDSN(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"mysql", "root:root@tcp(127.0.0.1:3306)/test?parseTime=True", "root:******@tcp(127.0.0.1:3306)/test?parseTime=True"},
+		{"url", "postgres://app:p%40ss@db:5432/app?sslmode=disable", "postgres://app:******@db:5432/app?sslmode=disable"},
+		{"url without user", "redis://:secret@127.0.0.1:6379/0", "redis://:******@127.0.0.1:6379/0"},
+		{"key value", "host=db port=5432 user=app password=secret dbname=app", "host=db port=5432 user=app password=****** dbname=app"},
+		{"key value upper case", "Server=db;User Id=app;Password=secret", "Server=db;User Id=app;Password=******"},
+		{"query", "clickhouse://127.0.0.1:9000?username=app&password=secret", "clickhouse://127.0.0.1:9000?username=app&password=******"},
+		{"no password", "postgres://db:5432/app?sslmode=disable", "postgres://db:5432/app?sslmode=disable"},
+		{"plain", "127.0.0.1:6379", "127.0.0.1:6379"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MaskValue("data.database.source", tt.in); got != tt.want {
+				t.Errorf("MaskValue(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+	if got := MaskValue("data.redis.password", "secret"); got != Mask {
+		t.Errorf("secret key not masked: %v", got)
+	}
+}
+
+func TestMaskValueURLCredentials(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"dingtalk", "https://oapi.dingtalk.com/robot/send?access_token=abc123&timestamp=1&sign=xyz", "https://oapi.dingtalk.com/robot/send?access_token=******&timestamp=1&sign=******"},
+		{"token", "https://hooks.example.com/alert?token=abc#top", "https://hooks.example.com/alert?token=******#top"},
+		{"key", "https://qyapi.weixin.qq.com/cgi-bin/webhook/send?key=abc", "https://qyapi.weixin.qq.com/cgi-bin/webhook/send?key=******"},
+		{"secret after other params", "https://hooks.example.com/alert?channel=ops&Secret=abc", "https://hooks.example.com/alert?channel=ops&Secret=******"},
+		{"similar names kept", "https://hooks.example.com/alert?monkey=1&signal=2", "https://hooks.example.com/alert?monkey=1&signal=2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MaskValue("log.alert.webhook_url", tt.in); got != tt.want {
+				t.Errorf("MaskValue(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,390 @@
+package confdump
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/config"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// ErrSnapshotNotFound 快照不存在或已被淘汰
+var ErrSnapshotNotFound = errors.New("confdump: snapshot not found")
+
+// Snapshot 一次生效的完整配置
+type Snapshot struct {
+	ID       int64           `json:"id"`
+	Time     time.Time       `json:"time"`
+	Revision Revision        `json:"revision"`
+	Config   json.RawMessage `json:"config"`           // 脱敏后的配置，包含零值字段
+	Masked   []string        `json:"masked,omitempty"` // 被脱敏的配置项，回滚时不覆盖，沿用配置源的当前值
+}
+
+// History 配置快照历史，保留最近 size 个生效的配置，支持运行时回滚
+// 回滚通过最后一个配置源 Source() 推送快照实现，之后任意配置源的新推送仍会覆盖对应配置项
+// 快照中的密码、密钥、DSN 密码已脱敏，回滚时这些配置项由配置源重新加载和解析，不会固定已过期的 vault 凭据
+type History struct {
+	size int
+	dir  string
+	log  *log.Helper
+
+	mu        sync.Mutex
+	snapshots []*Snapshot
+	nextID    int64
+	rollback  []*config.KeyValue
+	ch        chan []*config.KeyValue
+}
+
+// NewHistory 创建配置快照历史，dir 不为空时快照同时写入该目录，重启后继续保留
+// 旧版本写入的未脱敏快照在加载时脱敏并重写
+func NewHistory(size int, dir string, logger log.Logger) (*History, error) {
+	if size <= 0 {
+		size = 10
+	}
+	h := &History{
+		size:   size,
+		dir:    dir,
+		log:    log.NewHelper(logger),
+		nextID: 1,
+		ch:     make(chan []*config.KeyValue, 1),
+	}
+	if dir == "" {
+		return h, nil
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	if err := h.load(); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// Record 记录一次生效的配置，raw 为 Dumper.Snapshot 的结果，脱敏后保存
+func (h *History) Record(rev Revision, raw []byte) {
+	cfg, masked, err := scrub(raw, nil)
+	if err != nil {
+		h.log.Errorf("config history: scrub snapshot: %v", err)
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s := &Snapshot{ID: h.nextID, Time: time.Now(), Revision: rev, Config: cfg, Masked: masked}
+	h.nextID++
+	h.snapshots = append(h.snapshots, s)
+	var evicted []*Snapshot
+	if n := len(h.snapshots) - h.size; n > 0 {
+		evicted = append(evicted, h.snapshots[:n]...)
+		h.snapshots = append([]*Snapshot(nil), h.snapshots[n:]...)
+	}
+	if h.dir == "" {
+		return
+	}
+	if err := h.write(s); err != nil {
+		h.log.Errorf("config history: save snapshot %d: %v", s.ID, err)
+	}
+	for _, e := range evicted {
+		_ = os.Remove(h.path(e.ID))
+	}
+}
+
+// List 快照列表，新的在前
+func (h *History) List() []*Snapshot {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	out := make([]*Snapshot, len(h.snapshots))
+	for i, s := range h.snapshots {
+		out[len(out)-1-i] = s
+	}
+	return out
+}
+
+// Get 获取快照
+func (h *History) Get(id int64) (*Snapshot, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, s := range h.snapshots {
+		if s.ID == id {
+			return s, nil
+		}
+	}
+	return nil, ErrSnapshotNotFound
+}
+
+// Rollback 回滚到快照，配置合并后按热更新流程生效，并作为新的快照记录
+// 脱敏的配置项不在回滚内容中，沿用其他配置源的当前值
+func (h *History) Rollback(id int64) error {
+	s, err := h.Get(id)
+	if err != nil {
+		return err
+	}
+	value, err := s.rollback()
+	if err != nil {
+		return err
+	}
+	kv := &config.KeyValue{Key: "rollback-" + strconv.FormatInt(id, 10), Value: value, Format: "json"}
+	kvs := []*config.KeyValue{kv}
+	h.mu.Lock()
+	h.rollback = kvs
+	h.mu.Unlock()
+	select {
+	case h.ch <- kvs:
+	default:
+		// 上一次回滚尚未被应用，替换为本次
+		select {
+		case <-h.ch:
+		default:
+		}
+		h.ch <- kvs
+	}
+	h.log.Warnf("config rolled back to snapshot %d (%s)", s.ID, s.Revision)
+	return nil
+}
+
+// Source 回滚配置源，需作为最后一个配置源加入，保证回滚的快照覆盖其他配置源
+func (h *History) Source() Source {
+	return Source{Name: "rollback", Source: &rollbackSource{history: h}}
+}
+
+// ServeHTTP GET 输出快照列表，?id=N 输出脱敏后的快照内容；POST ?id=N 回滚到该快照
+func (h *History) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	idParam := r.URL.Query().Get("id")
+	if r.Method == http.MethodPost {
+		id, err := strconv.ParseInt(idParam, 10, 64)
+		if err != nil {
+			http.Error(w, "id is required", http.StatusBadRequest)
+			return
+		}
+		if err := h.Rollback(id); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if idParam == "" {
+		type item struct {
+			ID       int64     `json:"id"`
+			Time     time.Time `json:"time"`
+			Revision string    `json:"revision"`
+		}
+		list := h.List()
+		items := make([]item, 0, len(list))
+		for _, s := range list {
+			items = append(items, item{ID: s.ID, Time: s.Time, Revision: s.Revision.String()})
+		}
+		_ = enc.Encode(items)
+		return
+	}
+	id, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+	s, err := h.Get(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(s.Config, &m); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	values := make(map[string]interface{})
+	flatten("", m, values)
+	for k, v := range values {
+		values[k] = MaskValue(k, v)
+	}
+	_ = enc.Encode(map[string]interface{}{
+		"id":       s.ID,
+		"time":     s.Time,
+		"revision": s.Revision.String(),
+		"config":   values,
+	})
+}
+
+// path 快照文件路径
+func (h *History) path(id int64) string {
+	return filepath.Join(h.dir, fmt.Sprintf("%08d.json", id))
+}
+
+// write 写入快照文件
+func (h *History) write(s *Snapshot) error {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	tmp := h.path(s.ID) + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, h.path(s.ID))
+}
+
+// load 读取目录中的快照，只保留最近 size 个
+func (h *History) load() error {
+	entries, err := os.ReadDir(h.dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		b, err := os.ReadFile(filepath.Join(h.dir, e.Name()))
+		if err != nil {
+			return err
+		}
+		var s Snapshot
+		if err := json.Unmarshal(b, &s); err != nil {
+			h.log.Warnf("config history: skip %s: %v", e.Name(), err)
+			continue
+		}
+		cfg, masked, err := scrub(s.Config, s.Masked)
+		if err != nil {
+			h.log.Warnf("config history: skip %s: %v", e.Name(), err)
+			continue
+		}
+		if len(masked) != len(s.Masked) {
+			s.Config, s.Masked = cfg, masked
+			if err := h.write(&s); err != nil {
+				return err
+			}
+		}
+		h.snapshots = append(h.snapshots, &s)
+	}
+	sort.Slice(h.snapshots, func(i, j int) bool { return h.snapshots[i].ID < h.snapshots[j].ID })
+	if n := len(h.snapshots) - h.size; n > 0 {
+		for _, s := range h.snapshots[:n] {
+			_ = os.Remove(h.path(s.ID))
+		}
+		h.snapshots = h.snapshots[n:]
+	}
+	if n := len(h.snapshots); n > 0 {
+		h.nextID = h.snapshots[n-1].ID + 1
+	}
+	return nil
+}
+
+// rollback 回滚内容，去掉脱敏的配置项
+func (s *Snapshot) rollback() ([]byte, error) {
+	var m map[string]interface{}
+	if err := json.Unmarshal(s.Config, &m); err != nil {
+		return nil, err
+	}
+	masked := make(map[string]bool, len(s.Masked))
+	for _, k := range s.Masked {
+		masked[k] = true
+	}
+	rewrite("", m, func(key string, v interface{}) (interface{}, bool) {
+		return v, !masked[key]
+	})
+	return json.Marshal(m)
+}
+
+// scrub 脱敏快照，返回脱敏后的配置和被脱敏的配置项，masked 为已知被脱敏的配置项
+func scrub(raw []byte, masked []string) (json.RawMessage, []string, error) {
+	var m map[string]interface{}
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, nil, err
+	}
+	seen := make(map[string]bool, len(masked))
+	for _, k := range masked {
+		seen[k] = true
+	}
+	out := append([]string(nil), masked...)
+	rewrite("", m, func(key string, v interface{}) (interface{}, bool) {
+		mv := MaskValue(key, v)
+		if fmt.Sprint(mv) != fmt.Sprint(v) && !seen[key] {
+			seen[key] = true
+			out = append(out, key)
+		}
+		return mv, true
+	})
+	sort.Strings(out)
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, nil, err
+	}
+	return b, out, nil
+}
+
+// rewrite 按 flatten 的规则遍历嵌套 map 的配置项，fn 返回新值，第二个返回值为 false 时删除该配置项
+// 直接修改嵌套 map，不经过 unflatten，key 中含点的 map 字段也能保持原样
+func rewrite(prefix string, m map[string]interface{}, fn func(key string, v interface{}) (interface{}, bool)) {
+	for k, v := range m {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		if sub, ok := v.(map[string]interface{}); ok && len(sub) > 0 {
+			rewrite(key, sub, fn)
+			continue
+		}
+		if nv, keep := fn(key, v); keep {
+			m[k] = nv
+		} else {
+			delete(m, k)
+		}
+	}
+}
+
+var _ config.Source = (*rollbackSource)(nil)
+
+// rollbackSource 推送回滚快照的配置源
+type rollbackSource struct {
+	history *History
+}
+
+// Load 实现 config.Source 接口，未回滚时为空
+func (s *rollbackSource) Load() ([]*config.KeyValue, error) {
+	s.history.mu.Lock()
+	defer s.history.mu.Unlock()
+	return s.history.rollback, nil
+}
+
+// Watch 实现 config.Source 接口
+func (s *rollbackSource) Watch() (config.Watcher, error) {
+	return &rollbackWatcher{ch: s.history.ch, done: make(chan struct{})}, nil
+}
+
+// rollbackWatcher 回滚快照监听
+type rollbackWatcher struct {
+	ch   chan []*config.KeyValue
+	done chan struct{}
+	once sync.Once
+}
+
+// Next 实现 config.Watcher 接口，阻塞直到回滚
+func (w *rollbackWatcher) Next() ([]*config.KeyValue, error) {
+	select {
+	case kvs := <-w.ch:
+		return kvs, nil
+	case <-w.done:
+		return nil, context.Canceled
+	}
+}
+
+// Stop 实现 config.Watcher 接口
+func (w *rollbackWatcher) Stop() error {
+	w.once.Do(func() { close(w.done) })
+	return nil
+}
@@ -0,0 +1,111 @@
+package confdump
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+const snapshotJSON = `{
+	"data": {
+		"database": {"driver": "mysql", "source": "root:s3cret@tcp(127.0.0.1:3306)/test"},
+		"redis": {"addr": "127.0.0.1:6379", "password": "vault-lease-1"}
+	},
+	"server": {"license": {"operations": {"/helloworld.v1.Greeter/": "greeter"}}}
+}`
+
+func TestHistoryRecordMasksSecrets(t *testing.T) {
+	dir := t.TempDir()
+	h, err := NewHistory(5, dir, log.DefaultLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.Record(Revision{Source: "file", Revision: "abc"}, []byte(snapshotJSON))
+
+	s, err := h.Get(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"data.database.source", "data.redis.password"}
+	if !reflect.DeepEqual(s.Masked, want) {
+		t.Errorf("Masked = %v, want %v", s.Masked, want)
+	}
+	b, err := os.ReadFile(filepath.Join(dir, "00000001.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"s3cret", "vault-lease-1"} {
+		if strings.Contains(string(s.Config), secret) || strings.Contains(string(b), secret) {
+			t.Errorf("snapshot contains %q", secret)
+		}
+	}
+}
+
+func TestHistoryRollbackOmitsMasked(t *testing.T) {
+	h, err := NewHistory(5, "", log.DefaultLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.Record(Revision{Source: "file", Revision: "abc"}, []byte(snapshotJSON))
+	if err := h.Rollback(1); err != nil {
+		t.Fatal(err)
+	}
+	kvs, err := h.Source().Source.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(kvs) != 1 || kvs[0].Key != "rollback-1" {
+		t.Fatalf("rollback source = %v", kvs)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(kvs[0].Value, &got); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"data": map[string]interface{}{
+			"database": map[string]interface{}{"driver": "mysql"},
+			"redis":    map[string]interface{}{"addr": "127.0.0.1:6379"},
+		},
+		"server": map[string]interface{}{
+			"license": map[string]interface{}{
+				"operations": map[string]interface{}{"/helloworld.v1.Greeter/": "greeter"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rollback = %v, want %v", got, want)
+	}
+}
+
+func TestHistoryLoadScrubsPlaintext(t *testing.T) {
+	dir := t.TempDir()
+	old := Snapshot{ID: 7, Revision: Revision{Source: "file", Revision: "abc"}, Config: json.RawMessage(snapshotJSON)}
+	b, err := json.Marshal(old)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "00000007.json")
+	if err := os.WriteFile(path, b, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := NewHistory(5, dir, log.DefaultLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := h.Get(7); err != nil {
+		t.Fatal(err)
+	}
+	b, err = os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), "s3cret") || strings.Contains(string(b), "vault-lease-1") {
+		t.Errorf("plaintext snapshot not rewritten: %s", b)
+	}
+}
@@ -0,0 +1,127 @@
+package confdump
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// MaskTag 结构体字段标签，mask:"true" 的字段无论名称如何都脱敏
+const MaskTag = "mask"
+
+// Scrub 返回脱敏后的副本，用于输出到日志，原值不受影响
+// 字段名或 map key 包含 password、secret、token、dsn 等关键字时整体脱敏，其他字符串中 DSN 的密码部分和 URL 中的 token、sign 等凭证参数脱敏；
+// proto 消息按 proto 字段名输出，普通结构体按 json 标签输出，并支持 mask 标签：
+//
+//	APIKey string `json:"api_key" mask:"true"`
+func Scrub(v interface{}) interface{} {
+	if m, ok := v.(proto.Message); ok {
+		return scrubProto(m)
+	}
+	return scrubReflect("", reflect.ValueOf(v))
+}
+
+// scrubProto 按 protojson 格式输出并脱敏
+func scrubProto(m proto.Message) interface{} {
+	b, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(m)
+	if err != nil {
+		return fmt.Sprintf("!scrub: %v", err)
+	}
+	var out interface{}
+	if err := json.Unmarshal(b, &out); err != nil {
+		return fmt.Sprintf("!scrub: %v", err)
+	}
+	return scrubJSON("", out)
+}
+
+// scrubJSON 脱敏 JSON 解码后的值，key 为所在字段名
+func scrubJSON(key string, v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, item := range val {
+			val[k] = scrubJSON(k, item)
+		}
+		return val
+	case []interface{}:
+		for i, item := range val {
+			val[i] = scrubJSON(key, item)
+		}
+		return val
+	case nil:
+		return nil
+	default:
+		return MaskValue(key, val)
+	}
+}
+
+// scrubReflect 通过反射复制并脱敏任意值
+func scrubReflect(key string, v reflect.Value) interface{} {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		if m, ok := v.Interface().(proto.Message); ok {
+			return scrubProto(m)
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Invalid:
+		return nil
+	case reflect.Struct:
+		if _, ok := v.Interface().(fmt.Stringer); ok {
+			// 如 time.Time，按字符串输出
+			return MaskValue(key, fmt.Sprint(v.Interface()))
+		}
+		out := make(map[string]interface{}, v.NumField())
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			name := jsonName(f)
+			if name == "-" {
+				continue
+			}
+			if f.Tag.Get(MaskTag) == "true" {
+				out[name] = Mask
+				continue
+			}
+			out[name] = scrubReflect(name, v.Field(i))
+		}
+		return out
+	case reflect.Map:
+		out := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			k := fmt.Sprint(iter.Key().Interface())
+			out[k] = scrubReflect(k, iter.Value())
+		}
+		return out
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			return MaskValue(key, string(v.Bytes()))
+		}
+		out := make([]interface{}, v.Len())
+		for i := range out {
+			out[i] = scrubReflect(key, v.Index(i))
+		}
+		return out
+	default:
+		return MaskValue(key, v.Interface())
+	}
+}
+
+// jsonName 字段的 json 名称，没有 json 标签时使用字段名
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
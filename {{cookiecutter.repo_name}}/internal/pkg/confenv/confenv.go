@@ -0,0 +1,84 @@
+package confenv
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// placeholder 环境变量占位符: ${NAME} 或 ${NAME:default}
+var placeholder = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?::([^}]*))?\}`)
+
+// Resolver 配置加载时展开环境变量占位符，用于 config.WithResolver
+//
+//	password: ${DB_PASSWORD}
+//	addr: 0.0.0.0:${PORT:8000}
+//
+// 环境变量未设置时使用默认值，没有默认值时报错；
+// 展开结果始终是字符串，密码等字符串字段的值不会被转换为数字，数字和布尔字段由 confunit 按字段类型转换
+func Resolver(input map[string]interface{}) error {
+	return Walk(input, expandValue)
+}
+
+// Expand 展开字符串中的环境变量占位符
+func Expand(s string) (string, error) {
+	var missing []string
+	out := placeholder.ReplaceAllStringFunc(s, func(m string) string {
+		sub := placeholder.FindStringSubmatch(m)
+		if v, ok := os.LookupEnv(sub[1]); ok {
+			return v
+		}
+		if strings.Contains(m, ":") {
+			return sub[2]
+		}
+		missing = append(missing, sub[1])
+		return m
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("environment variable %s is not set", strings.Join(missing, ", "))
+	}
+	return out, nil
+}
+
+// Walk 递归遍历配置中的字符串值，fn 返回替换后的值，错误信息带上配置路径
+// 供其他 Resolver 复用，如 Vault 密钥引用
+func Walk(m map[string]interface{}, fn func(s string) (interface{}, error)) error {
+	for k, v := range m {
+		nv, err := walkValue(v, fn)
+		if err != nil {
+			return fmt.Errorf("%s: %w", k, err)
+		}
+		m[k] = nv
+	}
+	return nil
+}
+
+// walkValue 遍历单个配置值
+func walkValue(v interface{}, fn func(s string) (interface{}, error)) (interface{}, error) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		return val, Walk(val, fn)
+	case []interface{}:
+		for i, item := range val {
+			nv, err := walkValue(item, fn)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			val[i] = nv
+		}
+		return val, nil
+	case string:
+		return fn(val)
+	default:
+		return v, nil
+	}
+}
+
+// expandValue 展开单个字符串值中的占位符
+func expandValue(val string) (interface{}, error) {
+	if !strings.Contains(val, "${") {
+		return val, nil
+	}
+	return Expand(val)
+}
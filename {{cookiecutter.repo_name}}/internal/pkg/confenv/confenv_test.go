@@ -0,0 +1,108 @@
+package confenv
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExpand(t *testing.T) {
+	t.Setenv("CONFENV_HOST", "10.0.0.1")
+	t.Setenv("CONFENV_EMPTY", "")
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"plain", "plain"},
+		{"${CONFENV_HOST}", "10.0.0.1"},
+		{"${CONFENV_HOST}:${CONFENV_PORT:8000}", "10.0.0.1:8000"},
+		{"${CONFENV_PORT:}", ""},
+		{"${CONFENV_EMPTY:fallback}", ""},
+		{"${CONFENV_DSN:user:pass@tcp(db)/x}", "user:pass@tcp(db)/x"},
+	}
+	for _, tt := range tests {
+		got, err := Expand(tt.in)
+		if err != nil {
+			t.Errorf("Expand(%q) error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Expand(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestExpandMissing(t *testing.T) {
+	_, err := Expand("${CONFENV_MISSING_A}/${CONFENV_MISSING_B}")
+	if err == nil {
+		t.Fatal("Expand with unset variables: want error")
+	}
+	for _, name := range []string{"CONFENV_MISSING_A", "CONFENV_MISSING_B"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("error %q does not name %s", err, name)
+		}
+	}
+}
+
+func TestResolverKeepsStrings(t *testing.T) {
+	t.Setenv("CLICKHOUSE_PASSWORD", "123456")
+	t.Setenv("CONFENV_CODE", "0123")
+	t.Setenv("CONFENV_FLAG", "true")
+	t.Setenv("CONFENV_RATIO", "1.5")
+
+	input := map[string]interface{}{
+		"password": "${CLICKHOUSE_PASSWORD:}",
+		"code":     "${CONFENV_CODE}",
+		"enable":   "${CONFENV_FLAG}",
+		"ratio":    "${CONFENV_RATIO}",
+		"port":     8000,
+	}
+	if err := Resolver(input); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"password": "123456",
+		"code":     "0123",
+		"enable":   "true",
+		"ratio":    "1.5",
+		"port":     8000,
+	}
+	if !reflect.DeepEqual(input, want) {
+		t.Errorf("Resolver = %#v, want %#v", input, want)
+	}
+}
+
+func TestResolverNested(t *testing.T) {
+	t.Setenv("CONFENV_ADDR", "127.0.0.1:9000")
+
+	input := map[string]interface{}{
+		"data": map[string]interface{}{
+			"clickhouse": map[string]interface{}{
+				"addrs": []interface{}{"${CONFENV_ADDR}", "static:9000"},
+			},
+		},
+	}
+	if err := Resolver(input); err != nil {
+		t.Fatal(err)
+	}
+	addrs := input["data"].(map[string]interface{})["clickhouse"].(map[string]interface{})["addrs"]
+	if want := []interface{}{"127.0.0.1:9000", "static:9000"}; !reflect.DeepEqual(addrs, want) {
+		t.Errorf("addrs = %v, want %v", addrs, want)
+	}
+}
+
+func TestResolverErrorPath(t *testing.T) {
+	input := map[string]interface{}{
+		"data": map[string]interface{}{
+			"addrs": []interface{}{"ok", "${CONFENV_MISSING}"},
+		},
+	}
+	err := Resolver(input)
+	if err == nil {
+		t.Fatal("Resolver with unset variable: want error")
+	}
+	if !strings.HasPrefix(err.Error(), "data: addrs: [1]: ") {
+		t.Errorf("error %q does not carry the config path", err)
+	}
+}
@@ -0,0 +1,118 @@
+package confenv
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// DotEnvFile 本地开发环境变量文件，位于项目根目录
+const DotEnvFile = ".env"
+
+// LoadDotEnv 读取 .env 文件写入进程环境变量，文件不存在时忽略
+//
+//	# 注释
+//	DB_PASSWORD=secret
+//	export PORT=8000
+//	GREETING="hello\nworld"
+//	TOKEN='a#b c'
+//
+// 已设置的环境变量优先，不会被覆盖，与容器中注入环境变量的行为一致
+func LoadDotEnv(path string) error {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		key, value, ok, err := parseDotEnvLine(scanner.Text())
+		if err != nil {
+			return fmt.Errorf("%s:%d: %w", path, n, err)
+		}
+		if !ok {
+			continue
+		}
+		if _, exists := os.LookupEnv(key); exists {
+			continue
+		}
+		if err := os.Setenv(key, value); err != nil {
+			return fmt.Errorf("%s:%d: %w", path, n, err)
+		}
+	}
+	return scanner.Err()
+}
+
+// parseDotEnvLine 解析单行，空行和注释返回 ok=false
+func parseDotEnvLine(line string) (key, value string, ok bool, err error) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", "", false, nil
+	}
+	line = strings.TrimPrefix(line, "export ")
+	i := strings.IndexByte(line, '=')
+	if i <= 0 {
+		return "", "", false, fmt.Errorf("invalid line %q", line)
+	}
+	key = strings.TrimSpace(line[:i])
+	if !validKey(key) {
+		return "", "", false, fmt.Errorf("invalid key %q", key)
+	}
+	value = strings.TrimSpace(line[i+1:])
+	switch {
+	case strings.HasPrefix(value, `"`):
+		end := closingQuote(value)
+		if end < 0 {
+			return "", "", false, fmt.Errorf("unterminated quote in %s", key)
+		}
+		if value, err = strconv.Unquote(value[:end+1]); err != nil {
+			return "", "", false, fmt.Errorf("%s: %w", key, err)
+		}
+	case strings.HasPrefix(value, "'"):
+		end := strings.IndexByte(value[1:], '\'')
+		if end < 0 {
+			return "", "", false, fmt.Errorf("unterminated quote in %s", key)
+		}
+		value = value[1 : end+1]
+	default:
+		// 未加引号的值，空白后的 # 视为行尾注释
+		if j := strings.Index(value, " #"); j >= 0 {
+			value = strings.TrimSpace(value[:j])
+		}
+	}
+	return key, value, true, nil
+}
+
+// closingQuote 返回双引号字符串的结束引号位置，跳过转义字符
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+// validKey 环境变量名只允许字母、数字和下划线，且不以数字开头
+func validKey(key string) bool {
+	if key == "" || (key[0] >= '0' && key[0] <= '9') {
+		return false
+	}
+	for _, c := range key {
+		if c != '_' && (c < 'A' || c > 'Z') && (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
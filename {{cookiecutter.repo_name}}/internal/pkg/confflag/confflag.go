@@ -0,0 +1,103 @@
+package confflag
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Overrides 命令行配置覆盖，实现 flag.Value，可重复指定
+//
+//	-set server.http.addr=:9000 -set log.level=debug -set 'remote.etcd.endpoints=[10.0.0.1:2379]'
+//
+// 值按 YAML 解析，与配置文件中的写法一致，字符串形式的数字需加引号，如 -set 'server.api_version.min="1.0"'
+type Overrides struct {
+	items []item
+}
+
+// item 单个覆盖项
+type item struct {
+	raw   string
+	path  []string
+	value interface{}
+}
+
+// String 实现 flag.Value 接口
+func (o *Overrides) String() string {
+	if o == nil {
+		return ""
+	}
+	raws := make([]string, 0, len(o.items))
+	for _, it := range o.items {
+		raws = append(raws, it.raw)
+	}
+	return strings.Join(raws, ",")
+}
+
+// Set 实现 flag.Value 接口，格式为 key.path=value
+func (o *Overrides) Set(s string) error {
+	key, raw, ok := strings.Cut(s, "=")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" {
+		return fmt.Errorf("invalid override %q: want key.path=value", s)
+	}
+	path := strings.Split(key, ".")
+	for _, p := range path {
+		if p == "" {
+			return fmt.Errorf("invalid override key %q", key)
+		}
+	}
+	var value interface{} = ""
+	if strings.TrimSpace(raw) != "" {
+		if err := yaml.Unmarshal([]byte(raw), &value); err != nil {
+			return fmt.Errorf("invalid override value for %s: %w", key, err)
+		}
+	}
+	o.items = append(o.items, item{raw: s, path: path, value: value})
+	return nil
+}
+
+// Resolve 将覆盖项写入配置，用于 config.WithResolver
+// 解析器在每次加载和热更新后执行，覆盖项始终优先于本地文件和远程配置中心
+func (o *Overrides) Resolve(input map[string]interface{}) error {
+	for _, it := range o.items {
+		m := input
+		for i, p := range it.path[:len(it.path)-1] {
+			next, ok := m[p]
+			if !ok || next == nil {
+				child := map[string]interface{}{}
+				m[p] = child
+				m = child
+				continue
+			}
+			child, ok := next.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("override %s: %s is not an object", it.raw, strings.Join(it.path[:i+1], "."))
+			}
+			m = child
+		}
+		m[it.path[len(it.path)-1]] = copyValue(it.value)
+	}
+	return nil
+}
+
+// copyValue 深拷贝覆盖值，避免后续解析器修改共享的 map 和切片
+func copyValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			m[k] = copyValue(item)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(val))
+		for i, item := range val {
+			s[i] = copyValue(item)
+		}
+		return s
+	default:
+		return v
+	}
+}
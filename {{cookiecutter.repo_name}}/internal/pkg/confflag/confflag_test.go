@@ -0,0 +1,83 @@
+package confflag
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOverrides(t *testing.T) {
+	var o Overrides
+	for _, s := range []string{
+		"server.http.addr=:9000",
+		"log.level=debug",
+		"remote.etcd.endpoints=[10.0.0.1:2379, 10.0.0.2:2379]",
+		"server.api_version.min=\"1.0\"",
+		"data.redis.password=",
+		"log.level=info",
+	} {
+		if err := o.Set(s); err != nil {
+			t.Fatalf("Set(%q): %v", s, err)
+		}
+	}
+
+	input := map[string]interface{}{
+		"server": map[string]interface{}{
+			"http": map[string]interface{}{"addr": ":8000", "timeout": "1s"},
+		},
+		"log": map[string]interface{}{"level": "warn"},
+	}
+	if err := o.Resolve(input); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"server": map[string]interface{}{
+			"http":        map[string]interface{}{"addr": ":9000", "timeout": "1s"},
+			"api_version": map[string]interface{}{"min": "1.0"},
+		},
+		"log":    map[string]interface{}{"level": "info"},
+		"remote": map[string]interface{}{"etcd": map[string]interface{}{"endpoints": []interface{}{"10.0.0.1:2379", "10.0.0.2:2379"}}},
+		"data":   map[string]interface{}{"redis": map[string]interface{}{"password": ""}},
+	}
+	if !reflect.DeepEqual(input, want) {
+		t.Errorf("resolved = %v, want %v", input, want)
+	}
+
+	// 每次解析写入独立的副本，修改结果不影响下一次
+	input["remote"].(map[string]interface{})["etcd"].(map[string]interface{})["endpoints"].([]interface{})[0] = "changed"
+	again := map[string]interface{}{}
+	if err := o.Resolve(again); err != nil {
+		t.Fatal(err)
+	}
+	if got := again["remote"].(map[string]interface{})["etcd"].(map[string]interface{})["endpoints"].([]interface{})[0]; got != "10.0.0.1:2379" {
+		t.Errorf("endpoints[0] = %v after modifying a previous result", got)
+	}
+}
+
+func TestOverridesInvalid(t *testing.T) {
+	var o Overrides
+	for _, s := range []string{"log.level", "=debug", "log..level=debug", "log.level=[unclosed"} {
+		if err := o.Set(s); err == nil {
+			t.Errorf("Set(%q): want error", s)
+		}
+	}
+
+	if err := o.Set("log.level.name=debug"); err != nil {
+		t.Fatal(err)
+	}
+	input := map[string]interface{}{"log": map[string]interface{}{"level": "warn"}}
+	if err := o.Resolve(input); err == nil {
+		t.Error("override below a scalar: want error")
+	}
+}
+
+func TestOverridesString(t *testing.T) {
+	var o Overrides
+	_ = o.Set("a=1")
+	_ = o.Set("b.c=x")
+	if got := o.String(); got != "a=1,b.c=x" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := (*Overrides)(nil).String(); got != "" {
+		t.Errorf("nil String() = %q", got)
+	}
+}
@@ -0,0 +1,168 @@
+package confschema
+
+import (
+	"encoding/json"
+	"strings"
+
+	"{{cookiecutter.module_name}}/internal/conf"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Draft 生成的 JSON Schema 版本，编辑器和校验工具支持最广
+const Draft = "http://json-schema.org/draft-07/schema#"
+
+const (
+	// placeholderPattern 环境变量占位符，非字符串字段也可以写成 ${NAME:default}
+	placeholderPattern = `^\$\{[A-Za-z_][A-Za-z0-9_]*(:[^}]*)?\}$`
+	// durationPattern Go 时长格式，如 200ms、1m30s
+	durationPattern = `^-?(0|([0-9]+(\.[0-9]*)?(ns|us|µs|ms|s|m|h))+)$`
+	// sizePattern 带单位的大小，如 512KB、1.5GiB
+	sizePattern = `^[0-9]+(\.[0-9]+)?\s*([KkMmGgTt]([Ii]?[Bb])?|[Bb])?$`
+)
+
+// Schema JSON Schema 节点
+type Schema map[string]interface{}
+
+// Generate 按 proto 消息定义生成配置的 JSON Schema
+//
+//	go run ./cmd/server config schema > config.schema.json
+//
+// 属性名使用 proto 字段名，与配置文件一致；嵌套消息放在 definitions 中按全名引用。
+// Duration 字段接受 Go 时长格式，带 (size) 选项的字段接受带单位的大小，
+// 非字符串字段同时接受环境变量占位符，与配置加载时的 Resolver 保持一致
+func Generate(m proto.Message) Schema {
+	g := &generator{defs: Schema{}}
+	md := m.ProtoReflect().Descriptor()
+	s := g.object(md)
+	s["$schema"] = Draft
+	s["title"] = string(md.FullName())
+	if len(g.defs) > 0 {
+		s["definitions"] = g.defs
+	}
+	return s
+}
+
+// Marshal 生成缩进格式的 JSON Schema
+func Marshal(m proto.Message) ([]byte, error) {
+	return json.MarshalIndent(Generate(m), "", "  ")
+}
+
+// generator 记录已生成的消息定义，避免重复生成和递归引用死循环
+type generator struct {
+	defs Schema
+}
+
+// object 消息对应的对象定义，未知字段视为错误
+func (g *generator) object(md protoreflect.MessageDescriptor) Schema {
+	props := Schema{}
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		props[string(fd.Name())] = g.field(fd)
+	}
+	return Schema{
+		"type":                 "object",
+		"properties":           props,
+		"additionalProperties": false,
+	}
+}
+
+// field 字段定义，map 和 repeated 字段包装为对象和数组
+func (g *generator) field(fd protoreflect.FieldDescriptor) Schema {
+	switch {
+	case fd.IsMap():
+		return Schema{"type": "object", "additionalProperties": g.value(fd.MapValue())}
+	case fd.IsList():
+		return Schema{"type": "array", "items": g.value(fd)}
+	default:
+		return g.value(fd)
+	}
+}
+
+// value 单个值的定义
+func (g *generator) value(fd protoreflect.FieldDescriptor) Schema {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return scalar("boolean")
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if unit := proto.GetExtension(fd.Options(), conf.E_Size).(conf.SizeUnit); unit != conf.SizeUnit_SIZE_UNIT_UNSPECIFIED {
+			return Schema{
+				"description": "size in " + unit.String() + ", or with a unit, eg: 512KB, 100MB",
+				"anyOf": []Schema{
+					{"type": "integer", "minimum": 0},
+					{"type": "string", "pattern": sizePattern},
+					placeholder(),
+				},
+			}
+		}
+		return scalar("integer")
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return scalar("number")
+	case protoreflect.StringKind:
+		return Schema{"type": "string"}
+	case protoreflect.BytesKind:
+		return Schema{"type": "string", "contentEncoding": "base64"}
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		names := make([]string, 0, values.Len())
+		for i := 0; i < values.Len(); i++ {
+			names = append(names, string(values.Get(i).Name()))
+		}
+		return Schema{"anyOf": []Schema{
+			{"type": "string", "enum": names},
+			{"type": "integer"},
+			placeholder(),
+		}}
+	default:
+		return g.message(fd.Message())
+	}
+}
+
+// message 消息类型的值，常用的 well-known 类型按 protojson 的格式展开，其他消息引用 definitions
+func (g *generator) message(md protoreflect.MessageDescriptor) Schema {
+	name := string(md.FullName())
+	switch name {
+	case "google.protobuf.Duration":
+		return Schema{
+			"description": "duration, eg: 200ms, 1m30s",
+			"anyOf": []Schema{
+				{"type": "string", "pattern": durationPattern},
+				placeholder(),
+			},
+		}
+	case "google.protobuf.Timestamp":
+		return Schema{"type": "string", "format": "date-time"}
+	case "google.protobuf.Struct":
+		return Schema{"type": "object"}
+	case "google.protobuf.ListValue":
+		return Schema{"type": "array"}
+	case "google.protobuf.Value":
+		return Schema{}
+	}
+	if strings.HasPrefix(name, "google.protobuf.") && strings.HasSuffix(name, "Value") {
+		// 包装类型，如 google.protobuf.Int64Value，按内部的 value 字段展开
+		if fd := md.Fields().ByName("value"); fd != nil {
+			return g.value(fd)
+		}
+	}
+	if _, ok := g.defs[name]; !ok {
+		g.defs[name] = Schema{}
+		g.defs[name] = g.object(md)
+	}
+	return Schema{"$ref": "#/definitions/" + name}
+}
+
+// scalar 标量类型，同时接受环境变量占位符
+func scalar(typ string) Schema {
+	typed := Schema{"type": typ}
+	return Schema{"anyOf": []Schema{typed, placeholder()}}
+}
+
+// placeholder 环境变量占位符
+func placeholder() Schema {
+	return Schema{"type": "string", "pattern": placeholderPattern}
+}
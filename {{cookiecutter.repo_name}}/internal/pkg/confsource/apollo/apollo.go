@@ -0,0 +1,308 @@
+package apollo
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"{{cookiecutter.module_name}}/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/config"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// pollTimeout 服务端长轮询最长 60s 返回 304
+const pollTimeout = 90 * time.Second
+
+// errNotModified 配置未变更
+var errNotModified = errors.New("apollo: not modified")
+
+var _ config.Source = (*Source)(nil)
+
+// Source Apollo 配置源，基于 Config Service HTTP 接口，通过 notifications/v2 长轮询监听配置变更
+// 每个 namespace 对应一个 KeyValue，properties 格式的点分隔 key 转换为嵌套结构
+type Source struct {
+	addr       string
+	appID      string
+	cluster    string
+	namespaces []string
+	secret     string
+	timeout    time.Duration
+	client     *http.Client
+	log        *log.Helper
+
+	mu       sync.Mutex
+	releases map[string]string
+}
+
+// New 创建 Apollo 配置源
+func New(c *conf.Remote_Apollo, logger log.Logger) (*Source, error) {
+	if c.Addr == "" || c.AppId == "" {
+		return nil, errors.New("apollo: addr and app_id are required")
+	}
+	s := &Source{
+		addr:       strings.TrimRight(c.Addr, "/"),
+		appID:      c.AppId,
+		cluster:    c.Cluster,
+		namespaces: c.Namespaces,
+		secret:     c.Secret,
+		timeout:    3 * time.Second,
+		client:     &http.Client{},
+		log:        log.NewHelper(logger),
+		releases:   make(map[string]string),
+	}
+	if s.cluster == "" {
+		s.cluster = "default"
+	}
+	if len(s.namespaces) == 0 {
+		s.namespaces = []string{"application"}
+	}
+	if c.Timeout != nil {
+		s.timeout = c.Timeout.AsDuration()
+	}
+	return s, nil
+}
+
+// Load 实现 config.Source 接口
+// Apollo 不可用时只输出警告并返回空配置，服务使用本地文件启动
+func (s *Source) Load() ([]*config.KeyValue, error) {
+	kvs := make([]*config.KeyValue, 0, len(s.namespaces))
+	for _, ns := range s.namespaces {
+		kv, err := s.fetch(context.Background(), ns, false)
+		if err != nil {
+			s.log.Warnf("apollo: load namespace %s failed, fallback to local config: %v", ns, err)
+			return nil, nil
+		}
+		kvs = append(kvs, kv)
+	}
+	return kvs, nil
+}
+
+// Watch 实现 config.Source 接口
+func (s *Source) Watch() (config.Watcher, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ids := make(map[string]int64, len(s.namespaces))
+	for _, ns := range s.namespaces {
+		ids[ns] = -1
+	}
+	return &watcher{source: s, ctx: ctx, cancel: cancel, ids: ids}, nil
+}
+
+// fetch 获取 namespace 的配置，onlyChanged 为 true 且 releaseKey 未变化时返回 errNotModified
+func (s *Source) fetch(ctx context.Context, ns string, onlyChanged bool) (*config.KeyValue, error) {
+	q := url.Values{}
+	if onlyChanged {
+		s.mu.Lock()
+		if key := s.releases[ns]; key != "" {
+			q.Set("releaseKey", key)
+		}
+		s.mu.Unlock()
+	}
+	api := fmt.Sprintf("/configs/%s/%s/%s", url.PathEscape(s.appID), url.PathEscape(s.cluster), url.PathEscape(ns))
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	b, err := s.get(ctx, api, q)
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Configurations map[string]string `json:"configurations"`
+		ReleaseKey     string            `json:"releaseKey"`
+	}
+	if err := json.Unmarshal(b, &res); err != nil {
+		return nil, err
+	}
+	kv, err := keyValue(ns, res.Configurations)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.releases[ns] = res.ReleaseKey
+	s.mu.Unlock()
+	return kv, nil
+}
+
+// get 发送 GET 请求，开启访问密钥时附带签名
+func (s *Source) get(ctx context.Context, api string, q url.Values) ([]byte, error) {
+	pathWithQuery := api
+	if len(q) > 0 {
+		pathWithQuery += "?" + q.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.addr+pathWithQuery, nil)
+	if err != nil {
+		return nil, err
+	}
+	if s.secret != "" {
+		ts := strconv.FormatInt(time.Now().UnixMilli(), 10)
+		req.Header.Set("Authorization", "Apollo "+s.appID+":"+sign(s.secret, ts, pathWithQuery))
+		req.Header.Set("Timestamp", ts)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return b, nil
+	case http.StatusNotModified:
+		return nil, errNotModified
+	}
+	return nil, fmt.Errorf("apollo: GET %s: %s %s", api, resp.Status, strings.TrimSpace(string(b)))
+}
+
+// sign 访问密钥签名: base64(HmacSHA1(secret, timestamp + "\n" + pathWithQuery))
+func sign(secret, ts, pathWithQuery string) string {
+	h := hmac.New(sha1.New, []byte(secret))
+	h.Write([]byte(ts + "\n" + pathWithQuery))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// keyValue namespace 配置转换为 KeyValue
+// yaml、json 等格式的 namespace 内容在 content 字段，properties 格式的点分隔 key 转换为嵌套 JSON
+func keyValue(ns string, configurations map[string]string) (*config.KeyValue, error) {
+	if ext := strings.TrimPrefix(path.Ext(ns), "."); ext != "" && ext != "properties" {
+		if ext == "yml" {
+			ext = "yaml"
+		}
+		return &config.KeyValue{Key: ns, Value: []byte(configurations["content"]), Format: ext}, nil
+	}
+
+	root := make(map[string]interface{})
+	for k, v := range configurations {
+		parts := strings.Split(k, ".")
+		m := root
+		for _, p := range parts[:len(parts)-1] {
+			next, ok := m[p].(map[string]interface{})
+			if !ok {
+				next = make(map[string]interface{})
+				m[p] = next
+			}
+			m = next
+		}
+		m[parts[len(parts)-1]] = value(v)
+	}
+	b, err := json.Marshal(root)
+	if err != nil {
+		return nil, err
+	}
+	return &config.KeyValue{Key: ns, Value: b, Format: "json"}, nil
+}
+
+// value properties 的值都是字符串，布尔值需要还原，protojson 不接受带引号的布尔值
+func value(v string) interface{} {
+	switch v {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	return v
+}
+
+var _ config.Watcher = (*watcher)(nil)
+
+// watcher Apollo 配置变更监听
+type watcher struct {
+	source *Source
+	ctx    context.Context
+	cancel context.CancelFunc
+	ids    map[string]int64
+}
+
+// notification 长轮询返回的变更通知
+type notification struct {
+	NamespaceName  string `json:"namespaceName"`
+	NotificationID int64  `json:"notificationId"`
+}
+
+// Next 实现 config.Watcher 接口，阻塞直到配置变更
+func (w *watcher) Next() ([]*config.KeyValue, error) {
+	for {
+		changed, err := w.poll()
+		if err != nil {
+			if w.ctx.Err() != nil {
+				return nil, w.ctx.Err()
+			}
+			return nil, err
+		}
+
+		var kvs []*config.KeyValue
+		for _, ns := range changed {
+			kv, err := w.source.fetch(w.ctx, ns, true)
+			if errors.Is(err, errNotModified) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			kvs = append(kvs, kv)
+		}
+		if len(kvs) > 0 {
+			return kvs, nil
+		}
+	}
+}
+
+// poll 长轮询变更通知，返回有变更的 namespace
+func (w *watcher) poll() ([]string, error) {
+	list := make([]notification, 0, len(w.ids))
+	for _, ns := range w.source.namespaces {
+		list = append(list, notification{NamespaceName: ns, NotificationID: w.ids[ns]})
+	}
+	b, err := json.Marshal(list)
+	if err != nil {
+		return nil, err
+	}
+	q := url.Values{
+		"appId":         {w.source.appID},
+		"cluster":       {w.source.cluster},
+		"notifications": {string(b)},
+	}
+
+	ctx, cancel := context.WithTimeout(w.ctx, pollTimeout)
+	defer cancel()
+	b, err = w.source.get(ctx, "/notifications/v2", q)
+	if errors.Is(err, errNotModified) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var res []notification
+	if err := json.Unmarshal(b, &res); err != nil {
+		return nil, err
+	}
+	changed := make([]string, 0, len(res))
+	for _, n := range res {
+		if _, ok := w.ids[n.NamespaceName]; !ok {
+			continue
+		}
+		w.ids[n.NamespaceName] = n.NotificationID
+		changed = append(changed, n.NamespaceName)
+	}
+	return changed, nil
+}
+
+// Stop 实现 config.Watcher 接口
+func (w *watcher) Stop() error {
+	w.cancel()
+	return nil
+}
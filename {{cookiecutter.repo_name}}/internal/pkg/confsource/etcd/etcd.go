@@ -0,0 +1,162 @@
+package etcd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"{{cookiecutter.module_name}}/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/config"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+var _ config.Source = (*Source)(nil)
+
+// Source etcd v3 配置源，读取前缀下的全部 key，每个 key 为一个配置文件
+type Source struct {
+	client  *clientv3.Client
+	prefix  string
+	timeout time.Duration
+}
+
+// New 创建 etcd 配置源
+func New(c *conf.Remote_Etcd) (*Source, error) {
+	if len(c.Endpoints) == 0 || c.Prefix == "" {
+		return nil, errors.New("etcd: endpoints and prefix are required")
+	}
+	timeout := 3 * time.Second
+	if c.Timeout != nil {
+		timeout = c.Timeout.AsDuration()
+	}
+	tlsConfig, err := newTLSConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   c.Endpoints,
+		Username:    c.Username,
+		Password:    c.Password,
+		DialTimeout: timeout,
+		TLS:         tlsConfig,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Source{client: client, prefix: c.Prefix, timeout: timeout}, nil
+}
+
+// Load 实现 config.Source 接口
+func (s *Source) Load() ([]*config.KeyValue, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	return s.load(ctx)
+}
+
+// Watch 实现 config.Source 接口
+func (s *Source) Watch() (config.Watcher, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &watcher{
+		source: s,
+		ctx:    ctx,
+		cancel: cancel,
+		ch:     s.client.Watch(ctx, s.prefix, clientv3.WithPrefix()),
+	}, nil
+}
+
+// Close 关闭 etcd 连接
+func (s *Source) Close() error {
+	return s.client.Close()
+}
+
+// load 读取前缀下的全部配置
+func (s *Source) load(ctx context.Context) ([]*config.KeyValue, error) {
+	resp, err := s.client.Get(ctx, s.prefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+	kvs := make([]*config.KeyValue, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		key := string(kv.Key)
+		format := strings.TrimPrefix(path.Ext(key), ".")
+		if format == "" || format == "yml" {
+			format = "yaml"
+		}
+		kvs = append(kvs, &config.KeyValue{
+			Key:    key,
+			Value:  kv.Value,
+			Format: format,
+		})
+	}
+	return kvs, nil
+}
+
+// newTLSConfig 根据证书配置创建 TLS 配置，未配置 CA 证书时不开启 TLS
+func newTLSConfig(c *conf.Remote_Etcd) (*tls.Config, error) {
+	if c.CaFile == "" {
+		return nil, nil
+	}
+	ca, err := os.ReadFile(c.CaFile)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("etcd: invalid ca file %s", c.CaFile)
+	}
+	cfg := &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	if c.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
+var _ config.Watcher = (*watcher)(nil)
+
+// watcher etcd 配置变更监听
+type watcher struct {
+	source *Source
+	ctx    context.Context
+	cancel context.CancelFunc
+	ch     clientv3.WatchChan
+}
+
+// Next 实现 config.Watcher 接口，前缀下任意 key 变更时重新读取全部配置
+func (w *watcher) Next() ([]*config.KeyValue, error) {
+	select {
+	case <-w.ctx.Done():
+		return nil, w.ctx.Err()
+	case resp, ok := <-w.ch:
+		if !ok {
+			// 监听被关闭，如连接断开，重新建立监听
+			w.ch = w.source.client.Watch(w.ctx, w.source.prefix, clientv3.WithPrefix())
+			return nil, errors.New("etcd: watch channel closed")
+		}
+		if err := resp.Err(); err != nil {
+			if resp.CompactRevision > 0 {
+				// 监听的版本已被压缩，从最新版本重新监听
+				w.ch = w.source.client.Watch(w.ctx, w.source.prefix, clientv3.WithPrefix())
+			}
+			return nil, err
+		}
+	}
+	ctx, cancel := context.WithTimeout(w.ctx, w.source.timeout)
+	defer cancel()
+	return w.source.load(ctx)
+}
+
+// Stop 实现 config.Watcher 接口
+func (w *watcher) Stop() error {
+	w.cancel()
+	return nil
+}
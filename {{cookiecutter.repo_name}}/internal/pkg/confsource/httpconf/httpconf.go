@@ -0,0 +1,209 @@
+package httpconf
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"{{cookiecutter.module_name}}/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/config"
+)
+
+// SignatureHeader 响应体签名的响应头，值为 base64 编码的 ed25519 签名
+const SignatureHeader = "X-Config-Signature"
+
+// maxSize 配置文件大小上限
+const maxSize = 4 << 20
+
+var (
+	// ErrInvalidSignature 配置签名校验失败
+	ErrInvalidSignature = errors.New("httpconf: invalid signature")
+	// errNotModified 配置未变更
+	errNotModified = errors.New("httpconf: not modified")
+)
+
+var _ config.Source = (*Source)(nil)
+
+// Source HTTP 配置源，从一个 URL 下载配置文件，按间隔带 If-None-Match 轮询，内容变化时热更新
+// 适用于没有配置中心、配置文件放在对象存储或内部静态服务上的场景
+type Source struct {
+	url      string
+	format   string
+	token    string
+	headers  map[string]string
+	key      ed25519.PublicKey
+	interval time.Duration
+	client   *http.Client
+
+	mu   sync.Mutex
+	etag string
+	sum  [sha256.Size]byte // 不支持 ETag 的服务通过内容摘要判断是否变更
+}
+
+// New 创建 HTTP 配置源
+func New(c *conf.Remote_HTTP) (*Source, error) {
+	u, err := url.Parse(c.Url)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("httpconf: invalid url %q", c.Url)
+	}
+	s := &Source{
+		url:      c.Url,
+		format:   c.Format,
+		token:    c.Token,
+		headers:  c.Headers,
+		interval: 30 * time.Second,
+		client:   &http.Client{Timeout: 3 * time.Second},
+	}
+	if s.format == "" {
+		s.format = strings.TrimPrefix(path.Ext(u.Path), ".")
+	}
+	if c.PublicKey != "" {
+		key, err := base64.StdEncoding.DecodeString(c.PublicKey)
+		if err != nil || len(key) != ed25519.PublicKeySize {
+			return nil, errors.New("httpconf: public_key must be a base64 ed25519 public key")
+		}
+		s.key = key
+	}
+	if c.Interval != nil {
+		s.interval = c.Interval.AsDuration()
+	}
+	if c.Timeout != nil {
+		s.client.Timeout = c.Timeout.AsDuration()
+	}
+	return s, nil
+}
+
+// Load 实现 config.Source 接口
+func (s *Source) Load() ([]*config.KeyValue, error) {
+	kv, err := s.fetch(context.Background(), false)
+	if err != nil {
+		return nil, err
+	}
+	return []*config.KeyValue{kv}, nil
+}
+
+// Watch 实现 config.Source 接口
+func (s *Source) Watch() (config.Watcher, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &watcher{source: s, ctx: ctx, cancel: cancel}, nil
+}
+
+// fetch 下载配置，onlyChanged 为 true 且 ETag 或内容未变化时返回 errNotModified
+// 配置了公钥时校验签名，校验失败的配置不会被加载
+func (s *Source) fetch(ctx context.Context, onlyChanged bool) (*config.KeyValue, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+	if onlyChanged {
+		s.mu.Lock()
+		if s.etag != "" {
+			req.Header.Set("If-None-Match", s.etag)
+		}
+		s.mu.Unlock()
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotModified:
+		return nil, errNotModified
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("httpconf: GET %s: %s", s.url, resp.Status)
+	case len(b) > maxSize:
+		return nil, fmt.Errorf("httpconf: GET %s: config exceeds %d bytes", s.url, maxSize)
+	}
+	if s.key != nil {
+		sig, err := base64.StdEncoding.DecodeString(resp.Header.Get(SignatureHeader))
+		if err != nil || !ed25519.Verify(s.key, b, sig) {
+			return nil, ErrInvalidSignature
+		}
+	}
+	sum := sha256.Sum256(b)
+	s.mu.Lock()
+	unchanged := sum == s.sum
+	s.etag, s.sum = resp.Header.Get("ETag"), sum
+	s.mu.Unlock()
+	if onlyChanged && unchanged {
+		return nil, errNotModified
+	}
+	return &config.KeyValue{Key: s.url, Value: b, Format: s.formatOf(resp.Header.Get("Content-Type"))}, nil
+}
+
+// formatOf 配置格式，依次使用配置的格式、URL 扩展名和 Content-Type，默认 yaml
+func (s *Source) formatOf(contentType string) string {
+	switch s.format {
+	case "yaml", "json", "toml", "xml":
+		return s.format
+	case "yml":
+		return "yaml"
+	}
+	mt, _, _ := mime.ParseMediaType(contentType)
+	if strings.HasSuffix(mt, "json") {
+		return "json"
+	}
+	return "yaml"
+}
+
+var _ config.Watcher = (*watcher)(nil)
+
+// watcher HTTP 配置轮询
+type watcher struct {
+	source *Source
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// Next 实现 config.Watcher 接口，阻塞直到配置变更
+func (w *watcher) Next() ([]*config.KeyValue, error) {
+	ticker := time.NewTicker(w.source.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.ctx.Done():
+			return nil, w.ctx.Err()
+		case <-ticker.C:
+		}
+		kv, err := w.source.fetch(w.ctx, true)
+		if errors.Is(err, errNotModified) {
+			continue
+		}
+		if err != nil {
+			if w.ctx.Err() != nil {
+				return nil, w.ctx.Err()
+			}
+			return nil, err
+		}
+		return []*config.KeyValue{kv}, nil
+	}
+}
+
+// Stop 实现 config.Watcher 接口
+func (w *watcher) Stop() error {
+	w.cancel()
+	return nil
+}
@@ -0,0 +1,164 @@
+package kube
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-kratos/kratos/v2/config"
+)
+
+// debounce 一次 ConfigMap 更新会产生多个文件事件，合并后只重新加载一次
+const debounce = 100 * time.Millisecond
+
+var _ config.Source = (*Source)(nil)
+
+// Source Kubernetes ConfigMap / Secret 挂载目录配置源
+// 目录中每个文件对应一个 key：带扩展名的文件按扩展名解析为配置文件，如 config.yaml；
+// 不带扩展名的文件名作为配置路径，文件内容为值，如 Secret key data.database.password。
+// 以 . 开头的文件被忽略，包括 kubelet 的 ..data 和 ..<timestamp> 目录
+type Source struct {
+	dirs []string
+}
+
+// New 创建挂载目录配置源，后面目录中的配置覆盖前面的
+func New(dirs ...string) (*Source, error) {
+	if len(dirs) == 0 {
+		return nil, errors.New("kube: dirs are required")
+	}
+	return &Source{dirs: dirs}, nil
+}
+
+// Load 实现 config.Source 接口，同一目录内按文件名排序
+func (s *Source) Load() ([]*config.KeyValue, error) {
+	var kvs []*config.KeyValue
+	for _, dir := range s.dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(entries))
+		for _, e := range entries {
+			if !strings.HasPrefix(e.Name(), ".") {
+				names = append(names, e.Name())
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			path := filepath.Join(dir, name)
+			// ConfigMap 的 key 是指向 ..data/<key> 的符号链接，Stat 跟随链接判断
+			fi, err := os.Stat(path)
+			if err != nil {
+				if errors.Is(err, os.ErrNotExist) {
+					// 更新过程中链接暂时失效
+					continue
+				}
+				return nil, err
+			}
+			if fi.IsDir() {
+				continue
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, err
+			}
+			kvs = append(kvs, keyValue(name, data))
+		}
+	}
+	return kvs, nil
+}
+
+// Watch 实现 config.Source 接口
+func (s *Source) Watch() (config.Watcher, error) {
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	for _, dir := range s.dirs {
+		// 监听目录而不是文件：kubelet 通过原子替换 ..data 链接更新内容，文件本身的监听会失效
+		if err := fw.Add(dir); err != nil {
+			_ = fw.Close()
+			return nil, err
+		}
+	}
+	return &watcher{source: s, fw: fw}, nil
+}
+
+// keyValue 文件转换为配置项，格式为空时 Kratos 将 key 作为配置路径
+func keyValue(name string, data []byte) *config.KeyValue {
+	format := strings.TrimPrefix(filepath.Ext(name), ".")
+	switch format {
+	case "yaml", "json", "toml", "xml", "proto":
+	case "yml":
+		format = "yaml"
+	default:
+		// 不带扩展名或扩展名无法解析，如 data.redis.addr、tls.crt
+		format = ""
+		data = []byte(strings.TrimRight(string(data), "\r\n"))
+	}
+	return &config.KeyValue{Key: name, Value: data, Format: format}
+}
+
+var _ config.Watcher = (*watcher)(nil)
+
+// watcher 挂载目录变更监听
+type watcher struct {
+	source *Source
+	fw     *fsnotify.Watcher
+}
+
+// Next 实现 config.Watcher 接口，目录内任意文件变更时重新加载全部目录
+func (w *watcher) Next() ([]*config.KeyValue, error) {
+	if err := w.wait(); err != nil {
+		return nil, err
+	}
+	timer := time.NewTimer(debounce)
+	defer timer.Stop()
+	for {
+		select {
+		case _, ok := <-w.fw.Events:
+			if !ok {
+				return nil, errors.New("kube: watcher stopped")
+			}
+			timer.Reset(debounce)
+		case err, ok := <-w.fw.Errors:
+			if !ok {
+				return nil, errors.New("kube: watcher stopped")
+			}
+			return nil, err
+		case <-timer.C:
+			return w.source.Load()
+		}
+	}
+}
+
+// wait 等待相关的文件事件，kubelet 更新时 ..data 被替换，普通挂载目录中的文件直接变更
+func (w *watcher) wait() error {
+	for {
+		select {
+		case ev, ok := <-w.fw.Events:
+			if !ok {
+				return errors.New("kube: watcher stopped")
+			}
+			name := filepath.Base(ev.Name)
+			if ev.Has(fsnotify.Chmod) || (strings.HasPrefix(name, ".") && name != "..data") {
+				continue
+			}
+			return nil
+		case err, ok := <-w.fw.Errors:
+			if !ok {
+				return errors.New("kube: watcher stopped")
+			}
+			return err
+		}
+	}
+}
+
+// Stop 实现 config.Watcher 接口
+func (w *watcher) Stop() error {
+	return w.fw.Close()
+}
@@ -0,0 +1,152 @@
+package layered
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/config"
+	"github.com/go-kratos/kratos/v2/config/file"
+)
+
+// EnvKey 部署环境变量，如 APP_ENV=prod 时在 config.yaml 之上合并 config.prod.yaml
+const EnvKey = "APP_ENV"
+
+var _ config.Source = (*Source)(nil)
+
+// Source 分层本地配置源
+// 先加载基础文件，再按文件名 <name>.<env>.<ext> 合并当前环境的覆盖文件，
+// 其他环境的覆盖文件被忽略，各环境只需维护与基础文件不同的配置项。
+// 目录中可以按关注点拆分多个基础文件，如 server.yaml、data.yaml、log.yaml，
+// 基础文件和覆盖文件各自按文件名字典序合并，同名配置项以后合并的为准
+type Source struct {
+	dir  config.Source
+	path string
+	only string // path 为文件时只加载该文件及其覆盖文件
+	env  string
+}
+
+// New 创建分层本地配置源，path 为配置目录或基础配置文件，env 为空时不合并覆盖文件
+func New(path, env string) *Source {
+	s := &Source{env: env}
+	if fi, err := os.Stat(path); err == nil && !fi.IsDir() {
+		s.only = filepath.Base(path)
+		path = filepath.Dir(path)
+	}
+	s.path = path
+	s.dir = file.NewSource(path)
+	return s
+}
+
+// Load 实现 config.Source 接口，基础文件在前，当前环境的覆盖文件在后
+// 目录中只加载 .yaml 和 .yml 文件，path 为文件时不限制扩展名
+func (s *Source) Load() ([]*config.KeyValue, error) {
+	all, err := s.dir.Load()
+	if err != nil {
+		return nil, err
+	}
+	kvs := all[:0]
+	names := make(map[string]bool, len(all))
+	for _, kv := range all {
+		if isConfig(kv.Key) || kv.Key == s.only {
+			kvs = append(kvs, kv)
+			names[kv.Key] = true
+		}
+	}
+	// 合并顺序不依赖文件系统的遍历顺序
+	sort.Slice(kvs, func(i, j int) bool { return kvs[i].Key < kvs[j].Key })
+	var bases, overlays []*config.KeyValue
+	for _, kv := range kvs {
+		base, env, ok := overlay(kv.Key)
+		if ok && names[base] {
+			if env == s.env && s.selected(base) {
+				overlays = append(overlays, kv)
+			}
+			continue
+		}
+		if s.selected(kv.Key) {
+			bases = append(bases, kv)
+		}
+	}
+	return append(bases, overlays...), nil
+}
+
+// Watch 实现 config.Source 接口
+func (s *Source) Watch() (config.Watcher, error) {
+	w, err := s.dir.Watch()
+	if err != nil {
+		return nil, err
+	}
+	return &watcher{source: s, dir: w}, nil
+}
+
+// selected 是否加载该基础文件
+func (s *Source) selected(name string) bool {
+	return s.only == "" || s.only == name
+}
+
+// relevant 文件变更是否影响当前配置
+func (s *Source) relevant(name string) bool {
+	if !isConfig(name) {
+		return name == s.only
+	}
+	base, env, ok := overlay(name)
+	if !ok {
+		return s.selected(name)
+	}
+	if _, err := os.Stat(filepath.Join(s.path, base)); err != nil {
+		// 没有对应的基础文件，不是覆盖文件
+		return s.selected(name)
+	}
+	return env == s.env && s.selected(base)
+}
+
+// isConfig 是否为配置文件，目录中的说明文档、备份文件等被忽略
+func isConfig(name string) bool {
+	switch filepath.Ext(name) {
+	case ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// overlay 解析覆盖文件名，config.prod.yaml 返回 config.yaml 和 prod
+func overlay(name string) (base, env string, ok bool) {
+	ext := filepath.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	i := strings.LastIndex(stem, ".")
+	if i <= 0 || i == len(stem)-1 {
+		return "", "", false
+	}
+	return stem[:i] + ext, stem[i+1:], true
+}
+
+var _ config.Watcher = (*watcher)(nil)
+
+// watcher 分层配置变更监听
+type watcher struct {
+	source *Source
+	dir    config.Watcher
+}
+
+// Next 实现 config.Watcher 接口
+// 文件监听只返回变更的单个文件，直接合并会让基础文件覆盖环境配置，这里重新按顺序加载全部文件
+func (w *watcher) Next() ([]*config.KeyValue, error) {
+	for {
+		kvs, err := w.dir.Next()
+		if err != nil {
+			return nil, err
+		}
+		for _, kv := range kvs {
+			if w.source.relevant(kv.Key) {
+				return w.source.Load()
+			}
+		}
+	}
+}
+
+// Stop 实现 config.Watcher 接口
+func (w *watcher) Stop() error {
+	return w.dir.Stop()
+}
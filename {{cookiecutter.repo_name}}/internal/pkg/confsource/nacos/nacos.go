@@ -0,0 +1,320 @@
+package nacos
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"{{cookiecutter.module_name}}/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/config"
+)
+
+// pollTimeout 长轮询超时时间，服务端在配置变更或超时后返回
+const pollTimeout = 30 * time.Second
+
+var _ config.Source = (*Source)(nil)
+
+// Source Nacos 配置源，基于 Nacos Open API，通过长轮询监听配置变更
+type Source struct {
+	addrs     []string
+	namespace string
+	group     string
+	dataID    string
+	username  string
+	password  string
+	timeout   time.Duration
+	client    *http.Client
+
+	mu      sync.Mutex
+	current int
+	token   string
+	expire  time.Time
+}
+
+// New 创建 Nacos 配置源
+func New(c *conf.Remote_Nacos) (*Source, error) {
+	if len(c.Addrs) == 0 || c.DataId == "" {
+		return nil, errors.New("nacos: addrs and data_id are required")
+	}
+	s := &Source{
+		addrs:     c.Addrs,
+		namespace: c.Namespace,
+		group:     c.Group,
+		dataID:    c.DataId,
+		username:  c.Username,
+		password:  c.Password,
+		timeout:   3 * time.Second,
+		client:    &http.Client{},
+	}
+	if s.group == "" {
+		s.group = "DEFAULT_GROUP"
+	}
+	if c.Timeout != nil {
+		s.timeout = c.Timeout.AsDuration()
+	}
+	return s, nil
+}
+
+// Load 实现 config.Source 接口
+func (s *Source) Load() ([]*config.KeyValue, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	content, err := s.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return []*config.KeyValue{s.keyValue(content)}, nil
+}
+
+// Watch 实现 config.Source 接口
+func (s *Source) Watch() (config.Watcher, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &watcher{source: s, ctx: ctx, cancel: cancel}
+	// 以当前内容为基线，只在之后发生变更时返回
+	loadCtx, loadCancel := context.WithTimeout(ctx, s.timeout)
+	defer loadCancel()
+	content, err := s.get(loadCtx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	w.md5 = digest(content)
+	return w, nil
+}
+
+// keyValue 配置内容转换为 KeyValue，格式取 dataId 的扩展名
+func (s *Source) keyValue(content string) *config.KeyValue {
+	format := strings.TrimPrefix(path.Ext(s.dataID), ".")
+	if format == "" || format == "yml" {
+		format = "yaml"
+	}
+	return &config.KeyValue{
+		Key:    s.dataID,
+		Value:  []byte(content),
+		Format: format,
+	}
+}
+
+// get 获取配置内容
+func (s *Source) get(ctx context.Context) (string, error) {
+	q := s.params()
+	b, err := s.do(ctx, http.MethodGet, "/nacos/v1/cs/configs", q, nil, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// listen 长轮询监听配置变更，配置变更时返回 true
+func (s *Source) listen(ctx context.Context, md5 string) (bool, error) {
+	// 格式: dataId^2group^2md5[^2tenant]^1
+	listening := s.dataID + "\x02" + s.group + "\x02" + md5
+	if s.namespace != "" {
+		listening += "\x02" + s.namespace
+	}
+	form := url.Values{"Listening-Configs": {listening + "\x01"}}
+	header := http.Header{
+		"Long-Pulling-Timeout": {fmt.Sprint(pollTimeout.Milliseconds())},
+		"Content-Type":         {"application/x-www-form-urlencoded"},
+	}
+	ctx, cancel := context.WithTimeout(ctx, pollTimeout+s.timeout)
+	defer cancel()
+	b, err := s.do(ctx, http.MethodPost, "/nacos/v1/cs/configs/listener", nil, header, strings.NewReader(form.Encode()))
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(string(b)) != "", nil
+}
+
+// params 配置查询参数
+func (s *Source) params() url.Values {
+	q := url.Values{
+		"dataId": {s.dataID},
+		"group":  {s.group},
+	}
+	if s.namespace != "" {
+		q.Set("tenant", s.namespace)
+	}
+	return q
+}
+
+// do 发送请求，失败时切换到下一个服务地址
+func (s *Source) do(ctx context.Context, method, api string, q url.Values, header http.Header, body io.ReadSeeker) ([]byte, error) {
+	var lastErr error
+	for range s.addrs {
+		addr := s.addr()
+		b, err := s.request(ctx, addr, method, api, q, header, body)
+		if err == nil {
+			return b, nil
+		}
+		lastErr = err
+		if ctx.Err() != nil {
+			break
+		}
+		s.next(addr)
+		if body != nil {
+			_, _ = body.Seek(0, io.SeekStart)
+		}
+	}
+	return nil, lastErr
+}
+
+// request 向单个服务地址发送请求
+func (s *Source) request(ctx context.Context, addr, method, api string, q url.Values, header http.Header, body io.Reader) ([]byte, error) {
+	if q == nil {
+		q = url.Values{}
+	}
+	if s.username != "" {
+		token, err := s.login(ctx, addr)
+		if err != nil {
+			return nil, err
+		}
+		q.Set("accessToken", token)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(addr, "/")+api+"?"+q.Encode(), body)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return b, nil
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("nacos: config %s/%s not found", s.group, s.dataID)
+	case http.StatusForbidden, http.StatusUnauthorized:
+		// token 过期，下次请求重新登录
+		s.mu.Lock()
+		s.token = ""
+		s.mu.Unlock()
+	}
+	return nil, fmt.Errorf("nacos: %s %s: %s %s", method, api, resp.Status, strings.TrimSpace(string(b)))
+}
+
+// login 登录获取 accessToken，在过期前复用
+func (s *Source) login(ctx context.Context, addr string) (string, error) {
+	s.mu.Lock()
+	if s.token != "" && time.Now().Before(s.expire) {
+		token := s.token
+		s.mu.Unlock()
+		return token, nil
+	}
+	s.mu.Unlock()
+
+	form := url.Values{"username": {s.username}, "password": {s.password}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(addr, "/")+"/nacos/v1/auth/login", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("nacos: login failed: %s", resp.Status)
+	}
+	var res struct {
+		AccessToken string `json:"accessToken"`
+		TokenTTL    int64  `json:"tokenTtl"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = res.AccessToken
+	// 提前刷新，避免请求过程中过期
+	s.expire = time.Now().Add(time.Duration(res.TokenTTL) * time.Second * 9 / 10)
+	return s.token, nil
+}
+
+// addr 当前使用的服务地址
+func (s *Source) addr() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addrs[s.current]
+}
+
+// next 当前地址请求失败，切换到下一个地址
+func (s *Source) next(failed string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.addrs[s.current] == failed {
+		s.current = (s.current + 1) % len(s.addrs)
+		s.token = ""
+	}
+}
+
+// digest 配置内容的 MD5，用于长轮询比较
+func digest(content string) string {
+	sum := md5.Sum([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+var _ config.Watcher = (*watcher)(nil)
+
+// watcher Nacos 配置变更监听
+type watcher struct {
+	source *Source
+	md5    string
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// Next 实现 config.Watcher 接口，阻塞直到配置变更
+func (w *watcher) Next() ([]*config.KeyValue, error) {
+	for {
+		changed, err := w.source.listen(w.ctx, w.md5)
+		if err != nil {
+			if w.ctx.Err() != nil {
+				return nil, w.ctx.Err()
+			}
+			return nil, err
+		}
+		if !changed {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(w.ctx, w.source.timeout)
+		content, err := w.source.get(ctx)
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+		sum := digest(content)
+		if sum == w.md5 {
+			continue
+		}
+		w.md5 = sum
+		return []*config.KeyValue{w.source.keyValue(content)}, nil
+	}
+}
+
+// Stop 实现 config.Watcher 接口
+func (w *watcher) Stop() error {
+	w.cancel()
+	return nil
+}
@@ -0,0 +1,212 @@
+package confunit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"{{cookiecutter.module_name}}/internal/conf"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// durationName google.protobuf.Duration 的全名
+const durationName = "google.protobuf.Duration"
+
+// units 大小单位对应的字节数，按 1024 进制，KB 与 KiB 等价
+var units = map[string]int64{
+	"":    1,
+	"b":   1,
+	"k":   1 << 10,
+	"kb":  1 << 10,
+	"kib": 1 << 10,
+	"m":   1 << 20,
+	"mb":  1 << 20,
+	"mib": 1 << 20,
+	"g":   1 << 30,
+	"gb":  1 << 30,
+	"gib": 1 << 30,
+	"t":   1 << 40,
+	"tb":  1 << 40,
+	"tib": 1 << 40,
+}
+
+// fieldUnits 字段单位选项对应的字节数
+var fieldUnits = map[conf.SizeUnit]int64{
+	conf.SizeUnit_BYTES: 1,
+	conf.SizeUnit_KB:    1 << 10,
+	conf.SizeUnit_MB:    1 << 20,
+	conf.SizeUnit_GB:    1 << 30,
+}
+
+// Resolver 按配置的 proto 定义转换带单位的值，用于 config.WithResolver
+//
+//	timeout: 1m30s     # google.protobuf.Duration 字段接受 Go 时长格式，转换为 protojson 的 90s
+//	max_size: 100MB    # 带 (size) 选项的整数字段接受带单位的大小，转换为字段单位的整数
+//	enable: ${X:true}  # 布尔字段接受环境变量展开后的 true、false 字符串
+//
+// 时长字段写成不带单位的数字、大小不能整除字段单位时报错；不带单位的整数大小仍按字段单位解释
+func Resolver(m proto.Message) func(map[string]interface{}) error {
+	md := m.ProtoReflect().Descriptor()
+	return func(input map[string]interface{}) error {
+		return resolveMessage(md, input)
+	}
+}
+
+// ParseSize 解析大小，如 512、64KB、1.5GiB，单位不区分大小写，返回字节数
+func ParseSize(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool { return unicode.IsLetter(r) })
+	num, unit := s, ""
+	if i >= 0 {
+		num, unit = strings.TrimSpace(s[:i]), strings.ToLower(s[i:])
+	}
+	mul, ok := units[unit]
+	if !ok {
+		return 0, fmt.Errorf("invalid size %q: unknown unit %q", s, unit)
+	}
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil || f < 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	n := f * float64(mul)
+	if n > math.MaxInt64 || n != math.Trunc(n) {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return int64(n), nil
+}
+
+// resolveMessage 按消息定义遍历配置，配置中的键为 proto 字段名或 JSON 名
+func resolveMessage(md protoreflect.MessageDescriptor, input map[string]interface{}) error {
+	for k, v := range input {
+		fd := md.Fields().ByTextName(k)
+		if fd == nil {
+			fd = md.Fields().ByJSONName(k)
+		}
+		if fd == nil {
+			continue
+		}
+		nv, err := resolveField(fd, v)
+		if err != nil {
+			return fmt.Errorf("%s: %w", k, err)
+		}
+		input[k] = nv
+	}
+	return nil
+}
+
+// resolveField 转换单个字段的值，repeated 和 map 字段逐个元素转换
+func resolveField(fd protoreflect.FieldDescriptor, v interface{}) (interface{}, error) {
+	switch {
+	case fd.IsMap():
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return v, nil
+		}
+		for k, item := range m {
+			nv, err := resolveValue(fd.MapValue(), item)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+			m[k] = nv
+		}
+		return m, nil
+	case fd.IsList():
+		list, ok := v.([]interface{})
+		if !ok {
+			return resolveValue(fd, v)
+		}
+		for i, item := range list {
+			nv, err := resolveValue(fd, item)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			list[i] = nv
+		}
+		return list, nil
+	default:
+		return resolveValue(fd, v)
+	}
+}
+
+// resolveValue 转换单个值，其他类型的字段原样返回
+func resolveValue(fd protoreflect.FieldDescriptor, v interface{}) (interface{}, error) {
+	if msg := fd.Message(); msg != nil {
+		if msg.FullName() == durationName {
+			return duration(v)
+		}
+		if m, ok := v.(map[string]interface{}); ok && !strings.HasPrefix(string(msg.FullName()), "google.protobuf.") {
+			return m, resolveMessage(msg, m)
+		}
+		return v, nil
+	}
+	if fd.Kind() == protoreflect.BoolKind {
+		return boolean(v)
+	}
+	unit := proto.GetExtension(fd.Options(), conf.E_Size).(conf.SizeUnit)
+	if unit == conf.SizeUnit_SIZE_UNIT_UNSPECIFIED {
+		return v, nil
+	}
+	return size(v, fieldUnits[unit])
+}
+
+// duration 将 Go 时长格式转换为 protojson 格式，如 1m30s 转换为 90s，200ms 转换为 0.2s
+func duration(v interface{}) (interface{}, error) {
+	s, ok := v.(string)
+	if !ok {
+		if v == nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("duration %v needs a unit, e.g. %vs", v, v)
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(s))
+	if err != nil {
+		return nil, err
+	}
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	frac := strings.TrimRight(fmt.Sprintf("%09d", d%time.Second), "0")
+	if frac != "" {
+		frac = "." + frac
+	}
+	return fmt.Sprintf("%s%d%ss", sign, d/time.Second, frac), nil
+}
+
+// boolean 环境变量展开后的 true、false 字符串转换为布尔值，protojson 不接受字符串形式的布尔值
+func boolean(v interface{}) (interface{}, error) {
+	s, ok := v.(string)
+	if !ok {
+		return v, nil
+	}
+	b, err := strconv.ParseBool(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("invalid bool %q", s)
+	}
+	return b, nil
+}
+
+// size 将带单位的大小换算为字段单位，数字原样返回
+func size(v interface{}, unit int64) (interface{}, error) {
+	s, ok := v.(string)
+	if !ok {
+		return v, nil
+	}
+	if _, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64); err == nil {
+		// 环境变量展开后的纯数字，按字段单位解释
+		return v, nil
+	}
+	n, err := ParseSize(s)
+	if err != nil {
+		return nil, err
+	}
+	if n%unit != 0 {
+		return nil, fmt.Errorf("size %s is not a multiple of the field unit (%d bytes)", s, unit)
+	}
+	return n / unit, nil
+}
@@ -0,0 +1,141 @@
+package confunit
+
+import (
+	"testing"
+
+	"{{cookiecutter.module_name}}/internal/conf"
+)
+
+func TestResolverBool(t *testing.T) {
+	resolve := Resolver(&conf.Bootstrap{})
+
+	for _, tt := range []struct {
+		in   interface{}
+		want interface{}
+	}{
+		{"true", true},
+		{"false", false},
+		{" 1 ", true},
+		{true, true},
+	} {
+		input := map[string]interface{}{
+			"data": map[string]interface{}{
+				"tenancy": map[string]interface{}{"enable": tt.in},
+			},
+		}
+		if err := resolve(input); err != nil {
+			t.Errorf("enable %v: %v", tt.in, err)
+			continue
+		}
+		got := input["data"].(map[string]interface{})["tenancy"].(map[string]interface{})["enable"]
+		if got != tt.want {
+			t.Errorf("enable %v = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	input := map[string]interface{}{
+		"data": map[string]interface{}{
+			"tenancy": map[string]interface{}{"enable": "yes"},
+		},
+	}
+	if err := resolve(input); err == nil {
+		t.Error("enable yes: want error")
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	for in, want := range map[string]int64{
+		"512":    512,
+		"64KB":   64 << 10,
+		"64 kib": 64 << 10,
+		"1.5GiB": 3 << 29,
+		"100m":   100 << 20,
+		"2T":     2 << 40,
+		"0":      0,
+	} {
+		got, err := ParseSize(in)
+		if err != nil || got != want {
+			t.Errorf("ParseSize(%q) = %d, %v, want %d", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "MB", "-1KB", "10PB", "0.5B", "1e30GB"} {
+		if _, err := ParseSize(in); err == nil {
+			t.Errorf("ParseSize(%q): want error", in)
+		}
+	}
+}
+
+func TestResolverSize(t *testing.T) {
+	resolve := Resolver(&conf.Bootstrap{})
+
+	for _, tt := range []struct {
+		in   interface{}
+		want interface{}
+	}{
+		{"100MB", int64(100)},
+		{"1GiB", int64(1024)},
+		{"300", "300"}, // 不带单位的整数按字段单位解释
+		{300, 300},
+	} {
+		input := map[string]interface{}{"log": map[string]interface{}{"max_size": tt.in}}
+		if err := resolve(input); err != nil {
+			t.Errorf("max_size %v: %v", tt.in, err)
+			continue
+		}
+		if got := input["log"].(map[string]interface{})["max_size"]; got != tt.want {
+			t.Errorf("max_size %v = %#v, want %#v", tt.in, got, tt.want)
+		}
+	}
+
+	// 不能整除字段单位
+	input := map[string]interface{}{"log": map[string]interface{}{"max_size": "1500KB"}}
+	if err := resolve(input); err == nil {
+		t.Error("max_size 1500KB: want error")
+	}
+	// 嵌套消息中字节单位的字段
+	input = map[string]interface{}{"log": map[string]interface{}{
+		"access": map[string]interface{}{"maxBodySize": "2KB"},
+	}}
+	if err := resolve(input); err != nil {
+		t.Fatal(err)
+	}
+	if got := input["log"].(map[string]interface{})["access"].(map[string]interface{})["maxBodySize"]; got != int64(2048) {
+		t.Errorf("access.maxBodySize = %#v, want 2048", got)
+	}
+}
+
+func TestResolverDuration(t *testing.T) {
+	resolve := Resolver(&conf.Bootstrap{})
+
+	for _, tt := range []struct {
+		in   interface{}
+		want interface{}
+	}{
+		{"1m30s", "90s"},
+		{"200ms", "0.2s"},
+		{"1.5s", "1.5s"},
+		{"-2h", "-7200s"},
+		{"0s", "0s"},
+		{nil, nil},
+	} {
+		input := map[string]interface{}{"server": map[string]interface{}{
+			"http": map[string]interface{}{"timeout": tt.in},
+		}}
+		if err := resolve(input); err != nil {
+			t.Errorf("timeout %v: %v", tt.in, err)
+			continue
+		}
+		if got := input["server"].(map[string]interface{})["http"].(map[string]interface{})["timeout"]; got != tt.want {
+			t.Errorf("timeout %v = %#v, want %#v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []interface{}{30, "30", "soon"} {
+		input := map[string]interface{}{"server": map[string]interface{}{
+			"http": map[string]interface{}{"timeout": in},
+		}}
+		if err := resolve(input); err == nil {
+			t.Errorf("timeout %v: want error", in)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package diagnose
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"runtime/metrics"
+	"runtime/pprof"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+)
+
+// 采样的 runtime 指标
+const (
+	metricAllocBytes   = "/gc/heap/allocs:bytes"
+	metricAllocObjects = "/gc/heap/allocs:objects"
+	metricCPU          = "/cpu/classes/user:cpu-seconds"
+)
+
+// Stat 单个接口的采样统计
+type Stat struct {
+	Operation    string  `json:"operation"`
+	Samples      int64   `json:"samples"`
+	AllocBytes   uint64  `json:"allocBytes"`   // 平均每请求分配字节数
+	AllocObjects uint64  `json:"allocObjects"` // 平均每请求分配对象数
+	CPUSeconds   float64 `json:"cpuSeconds"`   // 平均每请求用户态 CPU 时间
+	Latency      float64 `json:"latency"`      // 平均耗时，秒
+
+	totalBytes   uint64
+	totalObjects uint64
+	totalCPU     float64
+	totalLatency float64
+}
+
+// Collector 请求级内存分配和 CPU 诊断
+// 按采样率对请求前后读取 runtime/metrics 计算差值，并以 operation 作为 pprof 标签，
+// 定期输出分配最多的接口；runtime 指标为进程级别，并发请求的分配会计入差值，结果为上界估计，
+// 精确定位可结合 CPU profile 中的 operation 标签
+type Collector struct {
+	rate     float64
+	top      int
+	interval time.Duration
+	log      *log.Helper
+
+	mu    sync.Mutex
+	stats map[string]*Stat
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// New 创建诊断采集器，rate 为采样比例 (0, 1]，top 为报告的接口数
+func New(rate float64, top int, interval time.Duration, logger log.Logger) *Collector {
+	if rate <= 0 || rate > 1 {
+		rate = 0.01
+	}
+	if top <= 0 {
+		top = 10
+	}
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+	return &Collector{
+		rate:     rate,
+		top:      top,
+		interval: interval,
+		log:      log.NewHelper(logger),
+		stats:    make(map[string]*Stat),
+		done:     make(chan struct{}),
+	}
+}
+
+// Middleware 采样中间件
+func (c *Collector) Middleware() middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (reply interface{}, err error) {
+			if rand.Float64() >= c.rate {
+				return handler(ctx, req)
+			}
+			operation := "unknown"
+			if tr, ok := transport.FromServerContext(ctx); ok {
+				operation = tr.Operation()
+			}
+
+			before := read()
+			start := time.Now()
+			pprof.Do(ctx, pprof.Labels("operation", operation), func(ctx context.Context) {
+				reply, err = handler(ctx, req)
+			})
+			latency := time.Since(start)
+			after := read()
+
+			c.record(operation, after[0].Value.Uint64()-before[0].Value.Uint64(),
+				after[1].Value.Uint64()-before[1].Value.Uint64(),
+				after[2].Value.Float64()-before[2].Value.Float64(), latency)
+			return
+		}
+	}
+}
+
+// Start 启动定期报告
+func (c *Collector) Start() {
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				c.report()
+			case <-c.done:
+				return
+			}
+		}
+	}()
+}
+
+// Stop 停止定期报告
+func (c *Collector) Stop() {
+	close(c.done)
+	c.wg.Wait()
+}
+
+// Top 按平均分配字节数降序返回前 n 个接口
+func (c *Collector) Top(n int) []Stat {
+	c.mu.Lock()
+	list := make([]Stat, 0, len(c.stats))
+	for _, s := range c.stats {
+		st := *s
+		samples := uint64(st.Samples)
+		st.AllocBytes = st.totalBytes / samples
+		st.AllocObjects = st.totalObjects / samples
+		st.CPUSeconds = st.totalCPU / float64(samples)
+		st.Latency = st.totalLatency / float64(samples)
+		list = append(list, st)
+	}
+	c.mu.Unlock()
+	sort.Slice(list, func(i, j int) bool { return list[i].AllocBytes > list[j].AllocBytes })
+	if n > 0 && len(list) > n {
+		list = list[:n]
+	}
+	return list
+}
+
+// ServeHTTP 以 JSON 返回分配最多的接口，可通过 ?reset=true 清空统计
+func (c *Collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	top := c.Top(c.top)
+	if r.URL.Query().Get("reset") == "true" {
+		c.mu.Lock()
+		c.stats = make(map[string]*Stat)
+		c.mu.Unlock()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"sampleRate": c.rate,
+		"operations": top,
+	})
+}
+
+// record 累加一次采样
+func (c *Collector) record(operation string, bytes, objects uint64, cpu float64, latency time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s, ok := c.stats[operation]
+	if !ok {
+		s = &Stat{Operation: operation}
+		c.stats[operation] = s
+	}
+	s.Samples++
+	s.totalBytes += bytes
+	s.totalObjects += objects
+	s.totalCPU += cpu
+	s.totalLatency += latency.Seconds()
+}
+
+// report 输出分配最多的接口
+func (c *Collector) report() {
+	for i, s := range c.Top(c.top) {
+		c.log.Infow(
+			log.DefaultMessageKey, "request allocation report",
+			"rank", i+1,
+			"operation", s.Operation,
+			"samples", s.Samples,
+			"alloc_bytes", s.AllocBytes,
+			"alloc_objects", s.AllocObjects,
+			"cpu_seconds", s.CPUSeconds,
+			"latency", s.Latency,
+		)
+	}
+}
+
+// read 读取采样的 runtime 指标
+func read() []metrics.Sample {
+	samples := []metrics.Sample{
+		{Name: metricAllocBytes},
+		{Name: metricAllocObjects},
+		{Name: metricCPU},
+	}
+	metrics.Read(samples)
+	return samples
+}
@@ -0,0 +1,51 @@
+package dict
+
+import (
+	"fmt"
+	"sync"
+
+	"{{cookiecutter.module_name}}/internal/pkg/i18n"
+)
+
+var (
+	mu     sync.RWMutex
+	fields = make(map[string]string)
+)
+
+// Bind 声明 proto 字段使用的字典类型，field 为字段全名，如 helloworld.v1.Order.status
+func Bind(field, dictType string) {
+	mu.Lock()
+	defer mu.Unlock()
+	fields[field] = dictType
+}
+
+// TypeOf 获取字段绑定的字典类型
+func TypeOf(field string) (string, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	t, ok := fields[field]
+	return t, ok
+}
+
+// Register 注册字典项的显示名称，items 为 code -> label，翻译 key 为 dict.{dictType}.{code}
+func Register(dictType, lang string, items map[string]string) {
+	messages := make(map[string]string, len(items))
+	for code, label := range items {
+		messages[Key(dictType, code)] = label
+	}
+	i18n.Register(lang, messages)
+}
+
+// Label 获取字典项的显示名称，没有翻译时返回 code
+func Label(dictType, lang string, code interface{}) string {
+	c := fmt.Sprint(code)
+	if v, ok := i18n.Translate(lang, Key(dictType, c)); ok {
+		return v
+	}
+	return c
+}
+
+// Key 字典项的翻译 key
+func Key(dictType, code string) string {
+	return "dict." + dictType + "." + code
+}
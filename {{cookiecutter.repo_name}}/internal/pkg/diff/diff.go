@@ -0,0 +1,209 @@
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Mask 敏感字段变更前后的占位值
+const Mask = "******"
+
+// Change 字段变更
+type Change struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+// ChangeSet 实体的字段变更集合，按字段定义顺序排列
+type ChangeSet []Change
+
+// Fields 发生变更的字段名
+func (c ChangeSet) Fields() []string {
+	fields := make([]string, 0, len(c))
+	for _, ch := range c {
+		fields = append(fields, ch.Field)
+	}
+	return fields
+}
+
+// Has 字段是否发生变更
+func (c ChangeSet) Has(field string) bool {
+	for _, ch := range c {
+		if ch.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Compare 比较同一类型实体更新前后的值，返回字段级变更集合
+//
+// 字段名优先取 diff 标签，其次为 gorm 的 column、json 标签，都没有时使用蛇形命名的字段名；
+// 嵌套结构体展开为 parent.child，匿名嵌入的结构体 (如 gorm.Model) 直接展开，
+// 切片和 map 整体比较。通过 diff 标签控制:
+//
+//	Password string `diff:"-"`            // 忽略
+//	Phone    string `diff:",sensitive"`    // 记录变更但前后值脱敏
+//	Name     string `diff:"nickname"`      // 指定字段名
+func Compare(before, after interface{}) (ChangeSet, error) {
+	bv, av := indirect(reflect.ValueOf(before)), indirect(reflect.ValueOf(after))
+	if !bv.IsValid() && !av.IsValid() {
+		return nil, nil
+	}
+	// 新建或删除时一侧为 nil，使用零值比较
+	if !bv.IsValid() {
+		bv = reflect.New(av.Type()).Elem()
+	}
+	if !av.IsValid() {
+		av = reflect.New(bv.Type()).Elem()
+	}
+	if bv.Type() != av.Type() {
+		return nil, fmt.Errorf("diff: type mismatch %s and %s", bv.Type(), av.Type())
+	}
+	if bv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("diff: %s is not a struct", bv.Type())
+	}
+	var changes ChangeSet
+	compareStruct("", bv, av, &changes)
+	return changes, nil
+}
+
+// compareStruct 逐字段比较结构体
+func compareStruct(prefix string, bv, av reflect.Value, changes *ChangeSet) {
+	t := bv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, sensitive, ok := fieldName(f)
+		if !ok {
+			continue
+		}
+		b, a := bv.Field(i), av.Field(i)
+
+		if nested(f.Type) {
+			if f.Anonymous {
+				compareStruct(prefix, indirectOrZero(b), indirectOrZero(a), changes)
+			} else {
+				compareStruct(prefix+name+".", indirectOrZero(b), indirectOrZero(a), changes)
+			}
+			continue
+		}
+
+		if equal(b, a) {
+			continue
+		}
+		ch := Change{Field: prefix + name, Old: value(b), New: value(a)}
+		if sensitive {
+			ch.Old, ch.New = Mask, Mask
+		}
+		*changes = append(*changes, ch)
+	}
+}
+
+// fieldName 解析字段名和标签，ok 为 false 时忽略该字段
+func fieldName(f reflect.StructField) (name string, sensitive bool, ok bool) {
+	tag := f.Tag.Get("diff")
+	if tag == "-" {
+		return "", false, false
+	}
+	parts := strings.Split(tag, ",")
+	name = parts[0]
+	for _, opt := range parts[1:] {
+		if opt == "sensitive" {
+			sensitive = true
+		}
+	}
+	if name == "" {
+		for _, s := range strings.Split(f.Tag.Get("gorm"), ";") {
+			if c, found := strings.CutPrefix(strings.TrimSpace(s), "column:"); found {
+				name = c
+				break
+			}
+		}
+	}
+	if name == "" {
+		if j := strings.Split(f.Tag.Get("json"), ",")[0]; j != "" && j != "-" {
+			name = j
+		}
+	}
+	if name == "" {
+		name = snake(f.Name)
+	}
+	return name, sensitive, true
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// nested 是否按字段展开比较，time.Time 作为单个值比较
+func nested(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && t != timeType
+}
+
+// equal 比较两个字段值，time.Time 使用 Equal 忽略时区和单调时钟
+func equal(b, a reflect.Value) bool {
+	bi, ai := indirect(b), indirect(a)
+	if !bi.IsValid() || !ai.IsValid() {
+		return bi.IsValid() == ai.IsValid()
+	}
+	if bi.Type() == timeType {
+		return bi.Interface().(time.Time).Equal(ai.Interface().(time.Time))
+	}
+	return reflect.DeepEqual(bi.Interface(), ai.Interface())
+}
+
+// value 字段值，nil 指针返回 nil
+func value(v reflect.Value) interface{} {
+	v = indirect(v)
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// indirect 解引用指针和接口，nil 时返回无效值
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// indirectOrZero 解引用嵌套结构体，nil 指针使用零值
+func indirectOrZero(v reflect.Value) reflect.Value {
+	if d := indirect(v); d.IsValid() {
+		return d
+	}
+	t := v.Type()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return reflect.New(t).Elem()
+}
+
+// snake 驼峰转蛇形，如 UserID -> user_id
+func snake(s string) string {
+	var b strings.Builder
+	runes := []rune(s)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
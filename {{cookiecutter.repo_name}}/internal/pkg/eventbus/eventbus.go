@@ -0,0 +1,32 @@
+package eventbus
+
+import (
+	"context"
+	"time"
+)
+
+// Event 事件
+type Event struct {
+	// ID 由事件总线在发布时生成
+	ID      string
+	Topic   string
+	Key     string
+	Payload []byte
+	Headers map[string]string
+	Time    time.Time
+	// Attempts 投递次数，首次投递为 1
+	Attempts int64
+}
+
+// Handler 事件处理函数，返回 nil 表示处理成功，返回错误的事件会在稍后重新投递
+type Handler func(ctx context.Context, e *Event) error
+
+// Bus 事件总线，提供至少一次的异步事件投递，同一 group 内的事件只由一个消费者处理
+type Bus interface {
+	// Publish 发布事件，返回事件 ID
+	Publish(ctx context.Context, e *Event) (string, error)
+	// Subscribe 以 group 订阅 topic，后台消费直到 ctx 结束或 Close
+	Subscribe(ctx context.Context, topic, group string, h Handler) error
+	// Close 停止所有订阅并等待正在处理的事件完成
+	Close() error
+}
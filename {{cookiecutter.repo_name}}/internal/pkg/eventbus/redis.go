@@ -0,0 +1,391 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"{{cookiecutter.module_name}}/internal/pkg/module"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
+)
+
+var _ Bus = (*RedisBus)(nil)
+
+// ErrClosed 事件总线已关闭
+var ErrClosed = errors.New("eventbus: closed")
+
+// Option Redis 事件总线配置项
+type Option func(*options)
+
+type options struct {
+	prefix     string
+	consumer   string
+	maxLen     int64
+	maxAge     time.Duration
+	batch      int64
+	block      time.Duration
+	claimIdle  time.Duration
+	maxRetries int64
+	sw         *module.Switch
+}
+
+// WithPrefix stream key 前缀，默认 eventbus:
+func WithPrefix(prefix string) Option {
+	return func(o *options) {
+		o.prefix = prefix
+	}
+}
+
+// WithConsumer 消费者名称，同一 group 内必须唯一，默认 hostname-pid
+func WithConsumer(name string) Option {
+	return func(o *options) {
+		o.consumer = name
+	}
+}
+
+// WithMaxLen 按长度裁剪，发布时近似保留最新的 n 条事件
+func WithMaxLen(n int64) Option {
+	return func(o *options) {
+		o.maxLen = n
+	}
+}
+
+// WithMaxAge 按时间裁剪，发布时近似删除早于 d 的事件，与 WithMaxLen 同时设置时以 WithMaxLen 为准
+func WithMaxAge(d time.Duration) Option {
+	return func(o *options) {
+		o.maxAge = d
+	}
+}
+
+// WithBatch 每次读取的最大事件数
+func WithBatch(n int64) Option {
+	return func(o *options) {
+		o.batch = n
+	}
+}
+
+// WithClaimIdle 待确认事件空闲超过 d 后由其他消费者认领重新处理，
+// 用于消费者宕机或处理失败后的重试
+func WithClaimIdle(d time.Duration) Option {
+	return func(o *options) {
+		o.claimIdle = d
+	}
+}
+
+// WithMaxRetries 最大投递次数，超出后事件转入 <topic>.dead 死信 stream 并确认
+func WithMaxRetries(n int64) Option {
+	return func(o *options) {
+		o.maxRetries = n
+	}
+}
+
+// WithSwitch 模块开关，暂停时停止拉取新事件和认领超时事件
+func WithSwitch(sw *module.Switch) Option {
+	return func(o *options) {
+		o.sw = sw
+	}
+}
+
+// RedisBus 基于 Redis Streams 的事件总线
+// topic 对应一个 stream，订阅使用消费者组，处理成功后 XACK，
+// 失败或消费者宕机的事件留在 PEL 中，空闲超过 claimIdle 后通过 XCLAIM 重新投递
+type RedisBus struct {
+	client redis.UniversalClient
+	opts   options
+	log    *log.Helper
+
+	mu     sync.Mutex
+	closed bool
+	cancel []context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewRedisBus 创建 Redis Streams 事件总线
+func NewRedisBus(client redis.UniversalClient, logger log.Logger, opts ...Option) *RedisBus {
+	host, _ := os.Hostname()
+	o := options{
+		prefix:     "eventbus:",
+		consumer:   fmt.Sprintf("%s-%d", host, os.Getpid()),
+		batch:      16,
+		block:      5 * time.Second,
+		claimIdle:  time.Minute,
+		maxRetries: 16,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &RedisBus{
+		client: client,
+		opts:   o,
+		log:    log.NewHelper(logger),
+	}
+}
+
+// Publish 实现 Bus 接口
+func (b *RedisBus) Publish(ctx context.Context, e *Event) (string, error) {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	values, err := encode(e)
+	if err != nil {
+		return "", err
+	}
+	args := &redis.XAddArgs{
+		Stream: b.stream(e.Topic),
+		Values: values,
+		Approx: true,
+	}
+	switch {
+	case b.opts.maxLen > 0:
+		args.MaxLen = b.opts.maxLen
+	case b.opts.maxAge > 0:
+		args.MinID = strconv.FormatInt(time.Now().Add(-b.opts.maxAge).UnixMilli(), 10)
+	}
+	id, err := b.client.XAdd(ctx, args).Result()
+	if err != nil {
+		return "", err
+	}
+	e.ID = id
+	return id, nil
+}
+
+// Subscribe 实现 Bus 接口
+func (b *RedisBus) Subscribe(ctx context.Context, topic, group string, h Handler) error {
+	stream := b.stream(topic)
+	err := b.client.XGroupCreateMkStream(ctx, stream, group, "$").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return ErrClosed
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	b.cancel = append(b.cancel, cancel)
+	b.wg.Add(2)
+	go func() {
+		defer b.wg.Done()
+		b.consume(ctx, topic, group, h)
+	}()
+	go func() {
+		defer b.wg.Done()
+		b.claim(ctx, topic, group, h)
+	}()
+	return nil
+}
+
+// Close 实现 Bus 接口
+func (b *RedisBus) Close() error {
+	b.mu.Lock()
+	b.closed = true
+	cancels := b.cancel
+	b.cancel = nil
+	b.mu.Unlock()
+
+	for _, cancel := range cancels {
+		cancel()
+	}
+	b.wg.Wait()
+	return nil
+}
+
+// consume 读取新事件
+func (b *RedisBus) consume(ctx context.Context, topic, group string, h Handler) {
+	stream := b.stream(topic)
+	for ctx.Err() == nil {
+		if err := b.opts.sw.Wait(ctx); err != nil {
+			return
+		}
+		res, err := b.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    group,
+			Consumer: b.opts.consumer,
+			Streams:  []string{stream, ">"},
+			Count:    b.opts.batch,
+			Block:    b.opts.block,
+		}).Result()
+		if err != nil {
+			if errors.Is(err, redis.Nil) || ctx.Err() != nil {
+				continue
+			}
+			b.log.Errorf("eventbus: read %s/%s failed: %v", stream, group, err)
+			b.sleep(ctx, time.Second)
+			continue
+		}
+		for _, s := range res {
+			for _, msg := range s.Messages {
+				b.handle(ctx, topic, group, msg, 1, h)
+			}
+		}
+	}
+}
+
+// claim 定期认领空闲超时的待确认事件，投递次数超限的转入死信
+func (b *RedisBus) claim(ctx context.Context, topic, group string, h Handler) {
+	stream := b.stream(topic)
+	ticker := time.NewTicker(b.opts.claimIdle / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if b.opts.sw.Paused() {
+			continue
+		}
+
+		pending, err := b.client.XPendingExt(ctx, &redis.XPendingExtArgs{
+			Stream: stream,
+			Group:  group,
+			Idle:   b.opts.claimIdle,
+			Start:  "-",
+			End:    "+",
+			Count:  b.opts.batch,
+		}).Result()
+		if err != nil {
+			if ctx.Err() == nil {
+				b.log.Errorf("eventbus: pending %s/%s failed: %v", stream, group, err)
+			}
+			continue
+		}
+
+		attempts := make(map[string]int64, len(pending))
+		ids := make([]string, 0, len(pending))
+		for _, p := range pending {
+			if b.opts.maxRetries > 0 && p.RetryCount >= b.opts.maxRetries {
+				b.dead(ctx, topic, group, p.ID, p.RetryCount)
+				continue
+			}
+			attempts[p.ID] = p.RetryCount + 1
+			ids = append(ids, p.ID)
+		}
+		if len(ids) == 0 {
+			continue
+		}
+
+		msgs, err := b.client.XClaim(ctx, &redis.XClaimArgs{
+			Stream:   stream,
+			Group:    group,
+			Consumer: b.opts.consumer,
+			MinIdle:  b.opts.claimIdle,
+			Messages: ids,
+		}).Result()
+		if err != nil {
+			if ctx.Err() == nil {
+				b.log.Errorf("eventbus: claim %s/%s failed: %v", stream, group, err)
+			}
+			continue
+		}
+		for _, msg := range msgs {
+			b.handle(ctx, topic, group, msg, attempts[msg.ID], h)
+		}
+	}
+}
+
+// handle 处理单个事件，成功后确认
+func (b *RedisBus) handle(ctx context.Context, topic, group string, msg redis.XMessage, attempts int64, h Handler) {
+	stream := b.stream(topic)
+	e, err := decode(topic, msg)
+	if err != nil {
+		// 无法解析的事件重试也不会成功，直接确认丢弃
+		b.log.Errorf("eventbus: decode %s %s failed: %v", stream, msg.ID, err)
+		_ = b.client.XAck(context.WithoutCancel(ctx), stream, group, msg.ID).Err()
+		return
+	}
+	e.Attempts = attempts
+	if err := h(ctx, e); err != nil {
+		b.log.Warnf("eventbus: handle %s %s failed, attempt %d: %v", stream, msg.ID, attempts, err)
+		return
+	}
+	if err := b.client.XAck(context.WithoutCancel(ctx), stream, group, msg.ID).Err(); err != nil {
+		b.log.Errorf("eventbus: ack %s %s failed: %v", stream, msg.ID, err)
+	}
+}
+
+// dead 将事件转入死信 stream 并确认
+func (b *RedisBus) dead(ctx context.Context, topic, group, id string, attempts int64) {
+	stream := b.stream(topic)
+	msgs, err := b.client.XRangeN(ctx, stream, id, id, 1).Result()
+	if err != nil {
+		b.log.Errorf("eventbus: read %s %s failed: %v", stream, id, err)
+		return
+	}
+	if len(msgs) > 0 {
+		values := msgs[0].Values
+		values["group"] = group
+		values["origin_id"] = id
+		values["attempts"] = attempts
+		if err := b.client.XAdd(ctx, &redis.XAddArgs{Stream: stream + ".dead", Values: values}).Err(); err != nil {
+			b.log.Errorf("eventbus: dead letter %s %s failed: %v", stream, id, err)
+			return
+		}
+	}
+	b.log.Warnf("eventbus: %s %s moved to dead letter after %d attempts", stream, id, attempts)
+	_ = b.client.XAck(ctx, stream, group, id).Err()
+}
+
+// stream topic 对应的 stream key
+func (b *RedisBus) stream(topic string) string {
+	return b.opts.prefix + topic
+}
+
+// sleep 等待 d 或 ctx 结束
+func (b *RedisBus) sleep(ctx context.Context, d time.Duration) {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+	case <-t.C:
+	}
+}
+
+// encode 事件编码为 stream 字段
+func encode(e *Event) (map[string]interface{}, error) {
+	values := map[string]interface{}{
+		"key":     e.Key,
+		"payload": e.Payload,
+		"time":    e.Time.UnixMilli(),
+	}
+	if len(e.Headers) > 0 {
+		headers, err := json.Marshal(e.Headers)
+		if err != nil {
+			return nil, err
+		}
+		values["headers"] = headers
+	}
+	return values, nil
+}
+
+// decode stream 字段解码为事件
+func decode(topic string, msg redis.XMessage) (*Event, error) {
+	e := &Event{ID: msg.ID, Topic: topic}
+	if v, ok := msg.Values["key"].(string); ok {
+		e.Key = v
+	}
+	if v, ok := msg.Values["payload"].(string); ok {
+		e.Payload = []byte(v)
+	}
+	if v, ok := msg.Values["time"].(string); ok {
+		ms, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		e.Time = time.UnixMilli(ms)
+	}
+	if v, ok := msg.Values["headers"].(string); ok && v != "" {
+		if err := json.Unmarshal([]byte(v), &e.Headers); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
@@ -0,0 +1,90 @@
+package feature
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+)
+
+// OverrideHeader 请求级开关覆盖请求头，如 new_checkout=true,search_limit=10
+const OverrideHeader = "X-Feature-Override"
+
+type contextKey struct{}
+
+// NewContext 将开关值快照放入 ctx
+func NewContext(ctx context.Context, values Values) context.Context {
+	return context.WithValue(ctx, contextKey{}, values)
+}
+
+// FromContext 获取 ctx 中的开关值快照，不存在时返回 nil
+func FromContext(ctx context.Context) Values {
+	values, _ := ctx.Value(contextKey{}).(Values)
+	return values
+}
+
+// Option 中间件配置项
+type Option func(*options)
+
+type options struct {
+	override bool
+}
+
+// WithOverride 允许通过 X-Feature-Override 请求头覆盖开关，只用于测试环境
+func WithOverride(override bool) Option {
+	return func(o *options) {
+		o.override = override
+	}
+}
+
+// Server 功能开关中间件，请求开始时将开关值快照放入 ctx，biz 层通过 Flag.Get(ctx) 读取，
+// 请求处理过程中配置变更不影响本次请求
+func Server(r *Registry, opts ...Option) middleware.Middleware {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			values := r.Snapshot()
+			if o.override {
+				if tr, ok := transport.FromServerContext(ctx); ok {
+					values = override(r, values, tr.RequestHeader().Get(OverrideHeader))
+				}
+			}
+			return handler(NewContext(ctx, values), req)
+		}
+	}
+}
+
+// override 合并请求头中的覆盖值，只接受已定义且能解析的开关
+func override(r *Registry, values Values, header string) Values {
+	if header == "" {
+		return values
+	}
+	var merged Values
+	for _, pair := range strings.Split(header, ",") {
+		name, v, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			continue
+		}
+		r.mu.Lock()
+		d, defined := r.defs[name]
+		r.mu.Unlock()
+		if !defined || d.check(v) != nil {
+			continue
+		}
+		if merged == nil {
+			merged = make(Values, len(values)+1)
+			for k, v := range values {
+				merged[k] = v
+			}
+		}
+		merged[name] = v
+	}
+	if merged == nil {
+		return values
+	}
+	return merged
+}
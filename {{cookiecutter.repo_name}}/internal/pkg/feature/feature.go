@@ -0,0 +1,240 @@
+package feature
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// Default 默认注册表，包级函数 Bool、Int 等在其上定义开关
+var Default = NewRegistry(log.GetLogger())
+
+// Values 开关值快照，值统一为字符串，按开关类型解析，不可修改
+type Values map[string]string
+
+// Registry 功能开关注册表
+// 开关值来自本地配置和远程开关服务两层，远程优先，未配置时使用定义时的默认值
+type Registry struct {
+	log *log.Helper
+
+	mu     sync.Mutex
+	defs   map[string]*def
+	local  Values
+	remote Values
+	values atomic.Pointer[Values] // 合并后的快照
+}
+
+// def 开关定义
+type def struct {
+	name  string
+	kind  string
+	value string // 默认值
+	usage string
+	check func(string) error
+}
+
+// NewRegistry 创建功能开关注册表
+func NewRegistry(logger log.Logger) *Registry {
+	r := &Registry{
+		log:  log.NewHelper(logger),
+		defs: make(map[string]*def),
+	}
+	r.values.Store(&Values{})
+	return r
+}
+
+// Flag 类型化的功能开关
+type Flag[T any] struct {
+	r     *Registry
+	name  string
+	value T
+	parse func(string) (T, error)
+}
+
+// Define 在注册表上定义开关，重复定义 panic，parse 用于解析配置中的值，解析失败时使用默认值
+func Define[T any](r *Registry, name string, value T, parse func(string) (T, error), usage string) *Flag[T] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.defs[name]; ok {
+		panic("feature: flag redefined: " + name)
+	}
+	r.defs[name] = &def{
+		name:  name,
+		kind:  fmt.Sprintf("%T", value),
+		value: fmt.Sprint(value),
+		usage: usage,
+		check: func(s string) error {
+			_, err := parse(s)
+			return err
+		},
+	}
+	return &Flag[T]{r: r, name: name, value: value, parse: parse}
+}
+
+// Bool 在默认注册表上定义布尔开关
+func Bool(name string, value bool, usage string) *Flag[bool] {
+	return Define(Default, name, value, strconv.ParseBool, usage)
+}
+
+// Int 在默认注册表上定义整数开关，如限流阈值、分页大小
+func Int(name string, value int, usage string) *Flag[int] {
+	return Define(Default, name, value, strconv.Atoi, usage)
+}
+
+// Float 在默认注册表上定义浮点数开关
+func Float(name string, value float64, usage string) *Flag[float64] {
+	return Define(Default, name, value, func(s string) (float64, error) {
+		return strconv.ParseFloat(s, 64)
+	}, usage)
+}
+
+// String 在默认注册表上定义字符串开关，如算法版本
+func String(name string, value string, usage string) *Flag[string] {
+	return Define(Default, name, value, func(s string) (string, error) { return s, nil }, usage)
+}
+
+// Duration 在默认注册表上定义时长开关，值的格式如 500ms、3s
+func Duration(name string, value time.Duration, usage string) *Flag[time.Duration] {
+	return Define(Default, name, value, time.ParseDuration, usage)
+}
+
+// Name 开关名
+func (f *Flag[T]) Name() string {
+	return f.name
+}
+
+// Get 获取开关值，优先使用 ctx 中的请求级快照，保证同一请求内的多次判断结果一致
+func (f *Flag[T]) Get(ctx context.Context) T {
+	values := FromContext(ctx)
+	if values == nil {
+		values = f.r.Snapshot()
+	}
+	s, ok := values[f.name]
+	if !ok {
+		return f.value
+	}
+	v, err := f.parse(s)
+	if err != nil {
+		return f.value
+	}
+	return v
+}
+
+// Rollout 百分比灰度开关，值为 0-100
+type Rollout struct {
+	*Flag[int]
+}
+
+// Percent 在默认注册表上定义百分比灰度开关
+func Percent(name string, value int, usage string) Rollout {
+	return Rollout{Define(Default, name, value, func(s string) (int, error) {
+		n, err := strconv.Atoi(s)
+		if err == nil && (n < 0 || n > 100) {
+			err = fmt.Errorf("percent %d out of range 0-100", n)
+		}
+		return n, err
+	}, usage)}
+}
+
+// Enabled key 是否命中灰度，key 通常为用户 ID，同一 key 的结果稳定，调大比例时已命中的 key 保持命中
+func (r Rollout) Enabled(ctx context.Context, key string) bool {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(r.name + ":" + key))
+	return int(h.Sum32()%100) < r.Get(ctx)
+}
+
+// Snapshot 当前开关值快照
+func (r *Registry) Snapshot() Values {
+	return *r.values.Load()
+}
+
+// SetLocal 更新本地配置中的开关值，用于配置热更新
+func (r *Registry) SetLocal(values Values) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.local = values
+	r.merge()
+}
+
+// SetRemote 更新远程开关服务中的开关值
+func (r *Registry) SetRemote(values Values) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.remote = values
+	r.merge()
+}
+
+// merge 合并本地和远程开关值，无法解析的值忽略并告警，需持有锁
+func (r *Registry) merge() {
+	merged := make(Values, len(r.local)+len(r.remote))
+	layers := []struct {
+		name   string
+		values Values
+	}{
+		{"local", r.local},
+		{"remote", r.remote},
+	}
+	for _, layer := range layers {
+		for name, v := range layer.values {
+			if d, ok := r.defs[name]; ok {
+				if err := d.check(v); err != nil {
+					r.log.Warnf("feature %s: invalid %s value %q: %v", name, layer.name, v, err)
+					continue
+				}
+			}
+			merged[name] = v
+		}
+	}
+	r.values.Store(&merged)
+}
+
+// ServeHTTP 输出全部开关的定义和当前值
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	type item struct {
+		Name    string `json:"name"`
+		Type    string `json:"type,omitempty"`
+		Value   string `json:"value"`
+		Default string `json:"default,omitempty"`
+		Source  string `json:"source"`
+		Usage   string `json:"usage,omitempty"`
+	}
+	r.mu.Lock()
+	values := r.Snapshot()
+	items := make([]item, 0, len(r.defs))
+	seen := make(map[string]bool, len(r.defs))
+	for name, d := range r.defs {
+		it := item{Name: name, Type: d.kind, Value: d.value, Default: d.value, Source: "default", Usage: d.usage}
+		if v, ok := values[name]; ok {
+			it.Value, it.Source = v, r.source(name)
+		}
+		items = append(items, it)
+		seen[name] = true
+	}
+	// 配置了但代码中未定义的开关，通常是拼写错误或已下线的开关
+	for name, v := range values {
+		if !seen[name] {
+			items = append(items, item{Name: name, Value: v, Source: r.source(name) + " (undefined)"})
+		}
+	}
+	r.mu.Unlock()
+	sort.Slice(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(items)
+}
+
+// source 开关值的来源，需持有锁
+func (r *Registry) source(name string) string {
+	if v, ok := r.remote[name]; ok && v == r.Snapshot()[name] {
+		return "remote"
+	}
+	return "local"
+}
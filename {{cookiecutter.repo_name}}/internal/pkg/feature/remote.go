@@ -0,0 +1,98 @@
+package feature
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Provider 远程开关服务
+type Provider interface {
+	Fetch(ctx context.Context) (Values, error)
+}
+
+// HTTPProvider 通过 HTTP 拉取开关值，响应为 {"name": value} 形式的 JSON 对象
+type HTTPProvider struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPProvider 创建 HTTP 远程开关服务
+func NewHTTPProvider(url string, timeout time.Duration) *HTTPProvider {
+	return &HTTPProvider{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+// Fetch 实现 Provider 接口
+func (p *HTTPProvider) Fetch(ctx context.Context) (Values, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("feature: %s returned %s", p.url, resp.Status)
+	}
+	var m map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&m); err != nil {
+		return nil, fmt.Errorf("feature: decode %s: %w", p.url, err)
+	}
+	return ToValues(m), nil
+}
+
+// ToValues 将 JSON/YAML 解析出的值转换为开关值，布尔和数字转为字符串，嵌套对象和数组忽略
+func ToValues(m map[string]interface{}) Values {
+	values := make(Values, len(m))
+	for name, v := range m {
+		switch val := v.(type) {
+		case string:
+			values[name] = val
+		case bool, float64, int, int64:
+			values[name] = fmt.Sprint(val)
+		}
+	}
+	return values
+}
+
+// Watch 定期从远程开关服务拉取开关值，首次拉取同步执行，拉取失败时保留上次的值
+// 返回的函数用于停止拉取
+func (r *Registry) Watch(p Provider, interval time.Duration) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	r.fetch(ctx, p, interval)
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.fetch(ctx, p, interval)
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// fetch 拉取一次远程开关值
+func (r *Registry) fetch(ctx context.Context, p Provider, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	values, err := p.Fetch(ctx)
+	if err != nil {
+		r.log.Warnf("feature: fetch remote flags failed, keeping previous values: %v", err)
+		return
+	}
+	r.SetRemote(values)
+}
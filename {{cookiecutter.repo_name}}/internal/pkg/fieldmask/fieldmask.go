@@ -0,0 +1,158 @@
+package fieldmask
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// labelSuffix 本地化显示名称字段后缀，与 localize.LabelSuffix 一致，请求 status 时保留 statusLabel
+const labelSuffix = "Label"
+
+// node 字段路径树，children 为空表示保留整个字段
+type node map[string]node
+
+// ResponseEncoder 按字段掩码裁剪响应的编码器，包装 next 编码器
+// 请求带 ?fields=id,name,items.id 或 ?read_mask=... 时只返回指定字段，
+// 路径以 . 分隔嵌套字段，数组字段对每个元素生效，字段名可以使用 JSON 名称或 proto 名称
+func ResponseEncoder(next khttp.EncodeResponseFunc) khttp.EncodeResponseFunc {
+	return func(w http.ResponseWriter, r *http.Request, v interface{}) error {
+		paths := Paths(r)
+		if len(paths) == 0 {
+			return next(w, r, v)
+		}
+		bw := &bufferWriter{ResponseWriter: w, status: http.StatusOK}
+		if err := next(bw, r, v); err != nil {
+			return err
+		}
+		body := bw.buf.Bytes()
+		var out interface{}
+		if err := json.Unmarshal(body, &out); err == nil {
+			if b, err := json.Marshal(Prune(out, paths)); err == nil {
+				body = b
+			}
+		}
+		w.WriteHeader(bw.status)
+		_, err := w.Write(body)
+		return err
+	}
+}
+
+// Paths 获取请求中的字段掩码，fields 优先于 read_mask，支持逗号分隔和多次传参
+func Paths(r *http.Request) []string {
+	q := r.URL.Query()
+	values := q["fields"]
+	if len(values) == 0 {
+		values = q["read_mask"]
+	}
+	var paths []string
+	for _, v := range values {
+		for _, p := range strings.Split(v, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				paths = append(paths, p)
+			}
+		}
+	}
+	return paths
+}
+
+// Prune 按字段路径裁剪 JSON 解码后的值，非对象和数组的值原样返回
+func Prune(v interface{}, paths []string) interface{} {
+	tree := node{}
+	for _, p := range paths {
+		cur := tree
+		names := strings.Split(p, ".")
+		for i, name := range names {
+			name = jsonName(name)
+			if i == len(names)-1 {
+				// 请求完整字段，覆盖更细的路径
+				cur[name] = nil
+				break
+			}
+			child, ok := cur[name]
+			if ok && child == nil {
+				// 已请求完整字段，忽略更细的路径
+				break
+			}
+			if !ok {
+				child = node{}
+				cur[name] = child
+			}
+			cur = child
+		}
+	}
+	return prune(v, tree)
+}
+
+// prune 递归裁剪
+func prune(v interface{}, n node) interface{} {
+	if n == nil {
+		return v
+	}
+	switch val := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(n))
+		for k, fv := range val {
+			child, ok := n[k]
+			if !ok {
+				if base := strings.TrimSuffix(k, labelSuffix); base != k {
+					if _, ok := n[base]; ok {
+						out[k] = fv
+					}
+				}
+				continue
+			}
+			out[k] = prune(fv, child)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, item := range val {
+			out[i] = prune(item, n)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// jsonName 将 proto 字段名转换为 JSON 名称，如 user_name -> userName
+func jsonName(name string) string {
+	if !strings.Contains(name, "_") {
+		return name
+	}
+	var b strings.Builder
+	upper := false
+	for _, c := range name {
+		if c == '_' {
+			upper = true
+			continue
+		}
+		if upper && c >= 'a' && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		upper = false
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// bufferWriter 缓存响应体，裁剪后再写入
+type bufferWriter struct {
+	http.ResponseWriter
+	buf    bytes.Buffer
+	status int
+}
+
+// WriteHeader 记录状态码，延迟到裁剪后写入
+func (w *bufferWriter) WriteHeader(status int) {
+	w.status = status
+}
+
+// Write 写入缓存
+func (w *bufferWriter) Write(p []byte) (int, error) {
+	return w.buf.Write(p)
+}
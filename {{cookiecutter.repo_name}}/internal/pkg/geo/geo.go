@@ -0,0 +1,160 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	geopb "{{cookiecutter.module_name}}/api/geo"
+)
+
+// SRID WGS84 坐标系，数据库中的空间列统一使用该坐标系
+const SRID = 4326
+
+// earthRadius 地球平均半径，单位米
+const earthRadius = 6371008.8
+
+// ErrInvalidPoint 坐标超出范围
+var ErrInvalidPoint = errors.New("geo: coordinate out of range")
+
+// Point WGS84 坐标点，单位为度
+type Point struct {
+	Lng float64
+	Lat float64
+}
+
+// Polygon 多边形，第一个环为外环，其余为内环（洞），环首尾点可以不重复
+type Polygon [][]Point
+
+// Bounds 矩形范围
+type Bounds struct {
+	SouthWest Point
+	NorthEast Point
+}
+
+// Validate 检查经纬度范围
+func (p Point) Validate() error {
+	if math.IsNaN(p.Lat) || math.IsNaN(p.Lng) || p.Lat < -90 || p.Lat > 90 || p.Lng < -180 || p.Lng > 180 {
+		return fmt.Errorf("%w: (%g, %g)", ErrInvalidPoint, p.Lat, p.Lng)
+	}
+	return nil
+}
+
+// Distance 两点间的球面距离，单位米
+func Distance(a, b Point) float64 {
+	lat1, lat2 := radians(a.Lat), radians(b.Lat)
+	dLat, dLng := lat2-lat1, radians(b.Lng-a.Lng)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// BoundsAround 以 center 为中心、半径 meters 的外接矩形，用于距离查询前利用空间索引预过滤
+// 不处理跨越 180 度经线的情况，范围会被截断到 -180 ~ 180
+func BoundsAround(center Point, meters float64) Bounds {
+	d := meters / earthRadius
+	dLat := degrees(d)
+	// 圆在球面上的最大经度跨度，靠近极点时覆盖全部经度
+	dLng := 180.0
+	if s, cos := math.Sin(d), math.Cos(radians(center.Lat)); s < cos {
+		dLng = degrees(math.Asin(s / cos))
+	}
+	return Bounds{
+		SouthWest: Point{Lng: math.Max(-180, center.Lng-dLng), Lat: math.Max(-90, center.Lat-dLat)},
+		NorthEast: Point{Lng: math.Min(180, center.Lng+dLng), Lat: math.Min(90, center.Lat+dLat)},
+	}
+}
+
+// Contains 点是否在矩形范围内
+func (b Bounds) Contains(p Point) bool {
+	return p.Lat >= b.SouthWest.Lat && p.Lat <= b.NorthEast.Lat && p.Lng >= b.SouthWest.Lng && p.Lng <= b.NorthEast.Lng
+}
+
+// Polygon 矩形对应的多边形
+func (b Bounds) Polygon() Polygon {
+	sw, ne := b.SouthWest, b.NorthEast
+	ring := []Point{sw, {Lng: ne.Lng, Lat: sw.Lat}, ne, {Lng: sw.Lng, Lat: ne.Lat}, sw}
+	return Polygon{ring}
+}
+
+// Contains 点是否在多边形内，在外环内且不在任何内环内，按平面坐标计算，适用于城市级别的区域
+func (p Polygon) Contains(pt Point) bool {
+	if len(p) == 0 || !ringContains(p[0], pt) {
+		return false
+	}
+	for _, hole := range p[1:] {
+		if ringContains(hole, pt) {
+			return false
+		}
+	}
+	return true
+}
+
+// ringContains 射线法判断点是否在环内
+func ringContains(ring []Point, pt Point) bool {
+	in := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a.Lat > pt.Lat) != (b.Lat > pt.Lat) &&
+			pt.Lng < (b.Lng-a.Lng)*(pt.Lat-a.Lat)/(b.Lat-a.Lat)+a.Lng {
+			in = !in
+		}
+	}
+	return in
+}
+
+// closed 返回首尾闭合的环，WKT 和 GeoJSON 都要求环闭合
+func closed(ring []Point) []Point {
+	if len(ring) > 0 && ring[0] != ring[len(ring)-1] {
+		return append(ring[:len(ring):len(ring)], ring[0])
+	}
+	return ring
+}
+
+// PointFromProto 转换 API 坐标
+func PointFromProto(p *geopb.LatLng) Point {
+	return Point{Lng: p.GetLng(), Lat: p.GetLat()}
+}
+
+// ToProto 转换为 API 坐标
+func (p Point) ToProto() *geopb.LatLng {
+	return &geopb.LatLng{Lat: p.Lat, Lng: p.Lng}
+}
+
+// BoundsFromProto 转换 API 矩形范围
+func BoundsFromProto(b *geopb.BoundingBox) Bounds {
+	return Bounds{SouthWest: PointFromProto(b.GetSouthWest()), NorthEast: PointFromProto(b.GetNorthEast())}
+}
+
+// ToProto 转换为 API 矩形范围
+func (b Bounds) ToProto() *geopb.BoundingBox {
+	return &geopb.BoundingBox{SouthWest: b.SouthWest.ToProto(), NorthEast: b.NorthEast.ToProto()}
+}
+
+// PolygonFromProto 转换 API 多边形，API 中的多边形只有外环
+func PolygonFromProto(p *geopb.Polygon) Polygon {
+	ring := make([]Point, 0, len(p.GetPoints()))
+	for _, pt := range p.GetPoints() {
+		ring = append(ring, PointFromProto(pt))
+	}
+	return Polygon{ring}
+}
+
+// ToProto 转换为 API 多边形，只保留外环，末尾的闭合点会被去掉
+func (p Polygon) ToProto() *geopb.Polygon {
+	out := &geopb.Polygon{}
+	if len(p) == 0 {
+		return out
+	}
+	ring := p[0]
+	if n := len(ring); n > 1 && ring[0] == ring[n-1] {
+		ring = ring[:n-1]
+	}
+	for _, pt := range ring {
+		out.Points = append(out.Points, pt.ToProto())
+	}
+	return out
+}
+
+func radians(d float64) float64 { return d * math.Pi / 180 }
+
+func degrees(r float64) float64 { return r * 180 / math.Pi }
@@ -0,0 +1,115 @@
+package geo
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// geometry GeoJSON 几何对象
+type geometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// Feature GeoJSON 要素，Geometry 为 Point、Polygon 或其指针，如门店位置和名称
+type Feature struct {
+	ID         interface{}            `json:"id,omitempty"`
+	Geometry   interface{}            `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// MarshalJSON 输出 "type": "Feature"
+func (f Feature) MarshalJSON() ([]byte, error) {
+	type plain Feature
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		plain
+	}{"Feature", plain(f)})
+}
+
+// FeatureCollection GeoJSON 要素集合，可以直接交给地图组件渲染
+type FeatureCollection []Feature
+
+// MarshalJSON 输出 "type": "FeatureCollection"
+func (fc FeatureCollection) MarshalJSON() ([]byte, error) {
+	features := []Feature(fc)
+	if features == nil {
+		features = []Feature{}
+	}
+	return json.Marshal(struct {
+		Type     string    `json:"type"`
+		Features []Feature `json:"features"`
+	}{"FeatureCollection", features})
+}
+
+// MarshalJSON 输出 GeoJSON Point，坐标顺序为 [经度, 纬度]
+func (p Point) MarshalJSON() ([]byte, error) {
+	return marshalGeometry("Point", [2]float64{p.Lng, p.Lat})
+}
+
+// UnmarshalJSON 解析 GeoJSON Point
+func (p *Point) UnmarshalJSON(b []byte) error {
+	var c [2]float64
+	if err := unmarshalGeometry(b, "Point", &c); err != nil {
+		return err
+	}
+	*p = Point{Lng: c[0], Lat: c[1]}
+	return p.Validate()
+}
+
+// MarshalJSON 输出 GeoJSON Polygon，环自动闭合
+func (p Polygon) MarshalJSON() ([]byte, error) {
+	rings := make([][][2]float64, 0, len(p))
+	for _, ring := range p {
+		coords := make([][2]float64, 0, len(ring)+1)
+		for _, pt := range closed(ring) {
+			coords = append(coords, [2]float64{pt.Lng, pt.Lat})
+		}
+		rings = append(rings, coords)
+	}
+	return marshalGeometry("Polygon", rings)
+}
+
+// UnmarshalJSON 解析 GeoJSON Polygon
+func (p *Polygon) UnmarshalJSON(b []byte) error {
+	var rings [][][2]float64
+	if err := unmarshalGeometry(b, "Polygon", &rings); err != nil {
+		return err
+	}
+	out := make(Polygon, 0, len(rings))
+	for _, coords := range rings {
+		if len(coords) < 4 {
+			return fmt.Errorf("geo: polygon ring needs at least 4 positions, got %d", len(coords))
+		}
+		ring := make([]Point, 0, len(coords))
+		for _, c := range coords {
+			pt := Point{Lng: c[0], Lat: c[1]}
+			if err := pt.Validate(); err != nil {
+				return err
+			}
+			ring = append(ring, pt)
+		}
+		out = append(out, ring)
+	}
+	*p = out
+	return nil
+}
+
+func marshalGeometry(typ string, coords interface{}) ([]byte, error) {
+	raw, err := json.Marshal(coords)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(geometry{Type: typ, Coordinates: raw})
+}
+
+func unmarshalGeometry(b []byte, typ string, coords interface{}) error {
+	var g geometry
+	if err := json.Unmarshal(b, &g); err != nil {
+		return err
+	}
+	if g.Type != typ {
+		return fmt.Errorf("geo: want GeoJSON %s, got %q", typ, g.Type)
+	}
+	return json.Unmarshal(g.Coordinates, coords)
+}
@@ -0,0 +1,98 @@
+package geo
+
+import (
+	"strconv"
+
+	"gorm.io/gorm/clause"
+)
+
+// 空间查询条件，driver 为 conf.Data_Database.Driver 或 GORM 的 db.Dialector.Name()，
+// 支持 mysql（8.0+）和 postgres/pgx（PostGIS），column 为列名，不做转义，不能来自用户输入
+//
+//	db.Where(geo.Within("mysql", "location", center, 3000)).
+//		Clauses(clause.OrderBy{Expression: geo.DistanceTo("mysql", "location", center)}).
+//		Limit(20).Find(&stores)
+//
+// 返回的 clause.Expr 也可以用于原生 SQL，占位符为 ?，PostgreSQL 原生 SQL 需要自行替换为 $n
+
+// GeomFromText 将 WKT 参数转换为空间类型的 SQL 片段
+// MySQL 中 SRID 4326 默认按纬度、经度的顺序解析 WKT，这里统一为经度、纬度
+func GeomFromText(driver string) string {
+	if isPostgres(driver) {
+		return "ST_GeomFromText(?, " + strconv.Itoa(SRID) + ")"
+	}
+	return "ST_GeomFromText(?, " + strconv.Itoa(SRID) + ", 'axis-order=long-lat')"
+}
+
+// Within 与 center 的球面距离不超过 meters，如附近门店
+// MySQL 先用外接矩形过滤以利用空间索引，再精确计算距离
+func Within(driver, column string, center Point, meters float64) clause.Expr {
+	if isPostgres(driver) {
+		return clause.Expr{
+			SQL:  "ST_DWithin(" + column + "::geography, " + geography() + ", ?)",
+			Vars: []interface{}{center.Lng, center.Lat, meters},
+		}
+	}
+	return clause.Expr{
+		SQL: "MBRContains(" + GeomFromText(driver) + ", " + column + ") AND " +
+			"ST_Distance_Sphere(" + column + ", " + GeomFromText(driver) + ") <= ?",
+		Vars: []interface{}{BoundsAround(center, meters).Polygon().WKT(), center.WKT(), meters},
+	}
+}
+
+// InBounds 在矩形范围内，如地图可视区域
+func InBounds(driver, column string, b Bounds) clause.Expr {
+	if isPostgres(driver) {
+		return clause.Expr{
+			SQL:  column + " && ST_MakeEnvelope(?, ?, ?, ?, " + strconv.Itoa(SRID) + ")",
+			Vars: []interface{}{b.SouthWest.Lng, b.SouthWest.Lat, b.NorthEast.Lng, b.NorthEast.Lat},
+		}
+	}
+	return clause.Expr{
+		SQL:  "MBRContains(" + GeomFromText(driver) + ", " + column + ")",
+		Vars: []interface{}{b.Polygon().WKT()},
+	}
+}
+
+// InPolygon 在多边形内，如查询配送区域内的订单
+func InPolygon(driver, column string, p Polygon) clause.Expr {
+	return clause.Expr{
+		SQL:  "ST_Contains(" + GeomFromText(driver) + ", " + column + ")",
+		Vars: []interface{}{p.WKT()},
+	}
+}
+
+// Covers 多边形列包含该点，如查询覆盖收货地址的配送区域
+func Covers(driver, column string, p Point) clause.Expr {
+	return clause.Expr{
+		SQL:  "ST_Contains(" + column + ", " + GeomFromText(driver) + ")",
+		Vars: []interface{}{p.WKT()},
+	}
+}
+
+// DistanceTo 与 center 的球面距离，单位米，用于排序或 SELECT
+func DistanceTo(driver, column string, center Point) clause.Expr {
+	if isPostgres(driver) {
+		return clause.Expr{
+			SQL:  "ST_Distance(" + column + "::geography, " + geography() + ")",
+			Vars: []interface{}{center.Lng, center.Lat},
+		}
+	}
+	return clause.Expr{
+		SQL:  "ST_Distance_Sphere(" + column + ", " + GeomFromText(driver) + ")",
+		Vars: []interface{}{center.WKT()},
+	}
+}
+
+// geography PostGIS 中以经度、纬度参数构造 geography 点
+func geography() string {
+	return "ST_SetSRID(ST_MakePoint(?, ?), " + strconv.Itoa(SRID) + ")::geography"
+}
+
+func isPostgres(driver string) bool {
+	switch driver {
+	case "postgres", "pgx", "postgis":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,243 @@
+package geo
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// WKB 几何类型
+const (
+	wkbPoint   = 1
+	wkbPolygon = 3
+	// ewkbSRID PostGIS EWKB 中带 SRID 的标志位
+	ewkbSRID = 0x20000000
+)
+
+// errWKB WKB 数据格式错误
+var errWKB = errors.New("geo: invalid WKB")
+
+// Scan 实现 sql.Scanner，支持 MySQL 内部格式（SRID + WKB）和 PostGIS EWKB（含 hex 文本）
+func (p *Point) Scan(src interface{}) error {
+	g, err := scanGeometry(src)
+	if err != nil || g == nil {
+		*p = Point{}
+		return err
+	}
+	pt, ok := g.(Point)
+	if !ok {
+		return fmt.Errorf("geo: want POINT, got %T", g)
+	}
+	*p = pt
+	return nil
+}
+
+// Value 实现 driver.Valuer，返回 WKT，原生 SQL 中需要配合 GeomFromText 使用
+func (p Point) Value() (driver.Value, error) {
+	return p.WKT(), nil
+}
+
+// WKT 如 POINT(116.397 39.908)
+func (p Point) WKT() string {
+	return "POINT(" + coord(p) + ")"
+}
+
+// GormDataType 实现 GORM 数据类型接口
+func (Point) GormDataType() string {
+	return "geometry"
+}
+
+// GormDBDataType 建表时的列类型
+func (Point) GormDBDataType(db *gorm.DB, _ *schema.Field) string {
+	return columnType(db.Dialector.Name(), "Point")
+}
+
+// GormValue 写入时转换为数据库空间类型
+func (p Point) GormValue(_ context.Context, db *gorm.DB) clause.Expr {
+	return clause.Expr{SQL: GeomFromText(db.Dialector.Name()), Vars: []interface{}{p.WKT()}}
+}
+
+// Scan 实现 sql.Scanner
+func (p *Polygon) Scan(src interface{}) error {
+	g, err := scanGeometry(src)
+	if err != nil || g == nil {
+		*p = nil
+		return err
+	}
+	poly, ok := g.(Polygon)
+	if !ok {
+		return fmt.Errorf("geo: want POLYGON, got %T", g)
+	}
+	*p = poly
+	return nil
+}
+
+// Value 实现 driver.Valuer，返回 WKT
+func (p Polygon) Value() (driver.Value, error) {
+	if len(p) == 0 {
+		return nil, nil
+	}
+	return p.WKT(), nil
+}
+
+// WKT 如 POLYGON((116.3 39.9, 116.4 39.9, 116.4 40, 116.3 39.9))，环自动闭合
+func (p Polygon) WKT() string {
+	var b strings.Builder
+	b.WriteString("POLYGON(")
+	for i, ring := range p {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteByte('(')
+		for j, pt := range closed(ring) {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(coord(pt))
+		}
+		b.WriteByte(')')
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+// GormDataType 实现 GORM 数据类型接口
+func (Polygon) GormDataType() string {
+	return "geometry"
+}
+
+// GormDBDataType 建表时的列类型
+func (Polygon) GormDBDataType(db *gorm.DB, _ *schema.Field) string {
+	return columnType(db.Dialector.Name(), "Polygon")
+}
+
+// GormValue 写入时转换为数据库空间类型
+func (p Polygon) GormValue(_ context.Context, db *gorm.DB) clause.Expr {
+	if len(p) == 0 {
+		return clause.Expr{SQL: "NULL"}
+	}
+	return clause.Expr{SQL: GeomFromText(db.Dialector.Name()), Vars: []interface{}{p.WKT()}}
+}
+
+// columnType 空间列类型，MySQL 需要声明 SRID 才能使用空间索引
+func columnType(driver, typ string) string {
+	if isPostgres(driver) {
+		return "geometry(" + typ + "," + strconv.Itoa(SRID) + ")"
+	}
+	return strings.ToUpper(typ) + " SRID " + strconv.Itoa(SRID)
+}
+
+func coord(p Point) string {
+	return strconv.FormatFloat(p.Lng, 'f', -1, 64) + " " + strconv.FormatFloat(p.Lat, 'f', -1, 64)
+}
+
+// scanGeometry 解析数据库返回的空间数据，NULL 返回 nil
+func scanGeometry(src interface{}) (interface{}, error) {
+	var b []byte
+	switch v := src.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return nil, fmt.Errorf("geo: unsupported scan type %T", src)
+	}
+	// PostGIS 文本协议返回 hex 编码的 EWKB
+	if len(b) > 0 && b[0] == '0' {
+		if raw, err := hex.DecodeString(string(b)); err == nil {
+			b = raw
+		}
+	}
+	if g, err := parseWKB(b); err == nil {
+		return g, nil
+	}
+	// MySQL 内部格式：4 字节 SRID + WKB
+	if len(b) > 4 {
+		return parseWKB(b[4:])
+	}
+	return nil, errWKB
+}
+
+// parseWKB 解析 WKB / EWKB，要求消费全部数据
+func parseWKB(b []byte) (interface{}, error) {
+	r := bytes.NewReader(b)
+	order, err := r.ReadByte()
+	if err != nil || order > 1 {
+		return nil, errWKB
+	}
+	var bo binary.ByteOrder = binary.BigEndian
+	if order == 1 {
+		bo = binary.LittleEndian
+	}
+	var typ uint32
+	if err := binary.Read(r, bo, &typ); err != nil {
+		return nil, errWKB
+	}
+	if typ&ewkbSRID != 0 {
+		var srid uint32
+		if err := binary.Read(r, bo, &srid); err != nil {
+			return nil, errWKB
+		}
+		typ &^= ewkbSRID
+	}
+
+	var g interface{}
+	switch typ {
+	case wkbPoint:
+		pt, err := readPoint(r, bo)
+		if err != nil {
+			return nil, err
+		}
+		g = pt
+	case wkbPolygon:
+		var n uint32
+		if err := binary.Read(r, bo, &n); err != nil || int(n) > r.Len() {
+			return nil, errWKB
+		}
+		poly := make(Polygon, 0, n)
+		for i := uint32(0); i < n; i++ {
+			var m uint32
+			if err := binary.Read(r, bo, &m); err != nil || int(m)*16 > r.Len() {
+				return nil, errWKB
+			}
+			ring := make([]Point, 0, m)
+			for j := uint32(0); j < m; j++ {
+				pt, err := readPoint(r, bo)
+				if err != nil {
+					return nil, err
+				}
+				ring = append(ring, pt)
+			}
+			poly = append(poly, ring)
+		}
+		g = poly
+	default:
+		// 包括带 Z/M 坐标的类型
+		return nil, fmt.Errorf("geo: unsupported WKB geometry type %d", typ)
+	}
+	if r.Len() != 0 {
+		return nil, errWKB
+	}
+	return g, nil
+}
+
+func readPoint(r *bytes.Reader, bo binary.ByteOrder) (Point, error) {
+	var c [2]uint64
+	if err := binary.Read(r, bo, &c); err != nil {
+		return Point{}, errWKB
+	}
+	return Point{Lng: math.Float64frombits(c[0]), Lat: math.Float64frombits(c[1])}, nil
+}
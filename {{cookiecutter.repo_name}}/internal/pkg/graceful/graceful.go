@@ -0,0 +1,125 @@
+package graceful
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"sync"
+)
+
+// envListeners 传递给新进程的监听地址列表，顺序与继承的文件描述符一致
+const envListeners = "GRACEFUL_LISTENERS"
+
+// 继承的文件描述符从 3 开始，0、1、2 为标准输入输出
+const listenFDStart = 3
+
+type listener struct {
+	key string
+	ln  net.Listener
+}
+
+var (
+	mu        sync.Mutex
+	inherited = map[string]net.Listener{}
+	listeners []listener
+	once      sync.Once
+)
+
+// Listen 创建监听，热重启拉起的新进程优先复用父进程传递的监听
+func Listen(network, addr string) (net.Listener, error) {
+	once.Do(inherit)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	key := network + "://" + addr
+	ln, ok := inherited[key]
+	if ok {
+		delete(inherited, key)
+	} else {
+		var err error
+		if ln, err = net.Listen(network, addr); err != nil {
+			return nil, err
+		}
+	}
+	listeners = append(listeners, listener{key: key, ln: ln})
+	return ln, nil
+}
+
+// Restart 以当前参数启动新进程并传递所有监听，返回新进程 pid
+// 调用方在新进程启动后停止当前进程，由 kratos 完成存量请求的优雅退出
+func Restart() (int, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	keys := make([]string, 0, len(listeners))
+	files := make([]*os.File, 0, len(listeners))
+	for _, l := range listeners {
+		fl, ok := l.ln.(interface{ File() (*os.File, error) })
+		if !ok {
+			return 0, fmt.Errorf("listener %s does not support file handoff", l.key)
+		}
+		f, err := fl.File()
+		if err != nil {
+			return 0, err
+		}
+		defer f.Close()
+		keys = append(keys, l.key)
+		files = append(files, f)
+	}
+
+	var env []string
+	for _, e := range os.Environ() {
+		if !strings.HasPrefix(e, envListeners+"=") {
+			env = append(env, e)
+		}
+	}
+	env = append(env, envListeners+"="+strings.Join(keys, ","))
+
+	cmd := exec.Command(os.Args[0], os.Args[1:]...)
+	cmd.Env = env
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.ExtraFiles = files
+	if err := cmd.Start(); err != nil {
+		return 0, err
+	}
+	return cmd.Process.Pid, nil
+}
+
+// Notify 收到重启信号时调用 fn，不支持重启信号的平台直接返回
+func Notify(fn func()) {
+	if restartSignal == nil {
+		return
+	}
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, restartSignal)
+	go func() {
+		for range ch {
+			fn()
+		}
+	}()
+}
+
+// inherit 解析父进程传递的监听
+func inherit() {
+	v := os.Getenv(envListeners)
+	if v == "" {
+		return
+	}
+	os.Unsetenv(envListeners)
+
+	for i, key := range strings.Split(v, ",") {
+		f := os.NewFile(uintptr(listenFDStart+i), key)
+		ln, err := net.FileListener(f)
+		f.Close()
+		if err != nil {
+			continue
+		}
+		inherited[key] = ln
+	}
+}
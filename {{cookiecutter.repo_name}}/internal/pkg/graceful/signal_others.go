@@ -0,0 +1,11 @@
+//go:build !windows
+
+package graceful
+
+import (
+	"os"
+	"syscall"
+)
+
+// restartSignal 热重启信号，kill -USR2 <pid>
+var restartSignal os.Signal = syscall.SIGUSR2
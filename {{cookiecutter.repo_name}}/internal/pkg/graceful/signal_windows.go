@@ -0,0 +1,8 @@
+//go:build windows
+
+package graceful
+
+import "os"
+
+// restartSignal Windows 不支持传递监听句柄，不启用热重启
+var restartSignal os.Signal
@@ -0,0 +1,114 @@
+package hashring
+
+import (
+	"hash/crc32"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// DefaultReplicas 每个节点默认的虚拟节点数
+const DefaultReplicas = 160
+
+// Ring 带虚拟节点的一致性哈希环，节点增减时只有少量 key 迁移
+type Ring struct {
+	mu       sync.RWMutex
+	replicas int
+	hashes   []uint32
+	owners   map[uint32]string
+	members  map[string]struct{}
+}
+
+// New 创建一致性哈希环，replicas 为每个节点的虚拟节点数，<=0 时使用默认值
+func New(replicas int, nodes ...string) *Ring {
+	if replicas <= 0 {
+		replicas = DefaultReplicas
+	}
+	r := &Ring{
+		replicas: replicas,
+		owners:   make(map[uint32]string),
+		members:  make(map[string]struct{}),
+	}
+	r.Set(nodes...)
+	return r
+}
+
+// Add 添加节点
+func (r *Ring) Add(nodes ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, n := range nodes {
+		r.members[n] = struct{}{}
+	}
+	r.rebuild()
+}
+
+// Remove 移除节点
+func (r *Ring) Remove(nodes ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, n := range nodes {
+		delete(r.members, n)
+	}
+	r.rebuild()
+}
+
+// Set 替换全部节点
+func (r *Ring) Set(nodes ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.members = make(map[string]struct{}, len(nodes))
+	for _, n := range nodes {
+		r.members[n] = struct{}{}
+	}
+	r.rebuild()
+}
+
+// Get 获取 key 所属的节点，环为空时返回空字符串
+func (r *Ring) Get(key string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.hashes) == 0 {
+		return ""
+	}
+	h := crc32.ChecksumIEEE([]byte(key))
+	i := sort.Search(len(r.hashes), func(i int) bool { return r.hashes[i] >= h })
+	if i == len(r.hashes) {
+		i = 0
+	}
+	return r.owners[r.hashes[i]]
+}
+
+// Owns 判断 key 是否属于 node，用于判断当前实例是否负责某个分片
+func (r *Ring) Owns(node, key string) bool {
+	return r.Get(key) == node
+}
+
+// Members 获取全部节点，按名称排序
+func (r *Ring) Members() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	nodes := make([]string, 0, len(r.members))
+	for n := range r.members {
+		nodes = append(nodes, n)
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+// rebuild 重建虚拟节点，调用方需持有写锁
+func (r *Ring) rebuild() {
+	r.hashes = r.hashes[:0]
+	r.owners = make(map[uint32]string, len(r.members)*r.replicas)
+	for n := range r.members {
+		for i := 0; i < r.replicas; i++ {
+			h := crc32.ChecksumIEEE([]byte(strconv.Itoa(i) + "#" + n))
+			if _, ok := r.owners[h]; ok {
+				continue
+			}
+			r.owners[h] = n
+			r.hashes = append(r.hashes, h)
+		}
+	}
+	sort.Slice(r.hashes, func(i, j int) bool { return r.hashes[i] < r.hashes[j] })
+}
@@ -0,0 +1,25 @@
+package hashring
+
+import "hash/fnv"
+
+// JumpHash Google 跳跃一致性哈希，将 key 映射到 [0, buckets) 的分片
+// 无需存储虚拟节点，适合分片编号连续且只在末尾增减的场景，如定时任务分片
+func JumpHash(key uint64, buckets int) int {
+	if buckets <= 0 {
+		return -1
+	}
+	var b, j int64 = -1, 0
+	for j < int64(buckets) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return int(b)
+}
+
+// JumpHashString 对字符串 key 计算跳跃一致性哈希
+func JumpHashString(key string, buckets int) int {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	return JumpHash(h.Sum64(), buckets)
+}
@@ -0,0 +1,42 @@
+package hashring
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/registry"
+)
+
+// Watch 根据服务发现的实例列表维护哈希环成员，节点名为实例 ID，直到 ctx 结束
+func Watch(ctx context.Context, discovery registry.Discovery, service string, ring *Ring, logger log.Logger) error {
+	watcher, err := discovery.Watch(ctx, service)
+	if err != nil {
+		return err
+	}
+	helper := log.NewHelper(logger)
+	go func() {
+		<-ctx.Done()
+		_ = watcher.Stop()
+	}()
+	go func() {
+		for {
+			instances, err := watcher.Next()
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				helper.Errorf("hashring: watch %s failed: %v", service, err)
+				time.Sleep(time.Second)
+				continue
+			}
+			nodes := make([]string, 0, len(instances))
+			for _, ins := range instances {
+				nodes = append(nodes, ins.ID)
+			}
+			ring.Set(nodes...)
+			helper.Infof("hashring: %s members changed: %v", service, nodes)
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,84 @@
+package i18n
+
+import (
+	"sync"
+
+	"golang.org/x/text/language"
+)
+
+var (
+	mu          sync.RWMutex
+	catalogs    = make(map[string]map[string]string)
+	defaultLang = "zh-CN"
+	matcher     language.Matcher
+	tags        []language.Tag
+)
+
+// SetDefault 设置默认语言，请求语言没有对应的翻译时使用
+func SetDefault(lang string) {
+	mu.Lock()
+	defer mu.Unlock()
+	defaultLang = lang
+	rebuildMatcher()
+}
+
+// Register 注册语言的翻译，key 相同时覆盖
+func Register(lang string, messages map[string]string) {
+	mu.Lock()
+	defer mu.Unlock()
+	c, ok := catalogs[lang]
+	if !ok {
+		c = make(map[string]string, len(messages))
+		catalogs[lang] = c
+	}
+	for k, v := range messages {
+		c[k] = v
+	}
+	rebuildMatcher()
+}
+
+// Translate 翻译 key，指定语言没有翻译时使用默认语言，都没有时返回 false
+func Translate(lang, key string) (string, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if v, ok := catalogs[lang][key]; ok {
+		return v, true
+	}
+	v, ok := catalogs[defaultLang][key]
+	return v, ok
+}
+
+// Negotiate 根据 Accept-Language 请求头选择已注册的语言，无法匹配时返回默认语言
+func Negotiate(acceptLanguage string) string {
+	mu.RLock()
+	defer mu.RUnlock()
+	if matcher == nil || acceptLanguage == "" {
+		return defaultLang
+	}
+	prefs, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(prefs) == 0 {
+		return defaultLang
+	}
+	_, index, confidence := matcher.Match(prefs...)
+	if confidence == language.No {
+		return defaultLang
+	}
+	return tags[index].String()
+}
+
+// rebuildMatcher 重建语言匹配器，默认语言排在第一位作为兜底
+func rebuildMatcher() {
+	tags = tags[:0]
+	if t, err := language.Parse(defaultLang); err == nil {
+		tags = append(tags, t)
+	}
+	for lang := range catalogs {
+		if lang == defaultLang {
+			continue
+		}
+		if t, err := language.Parse(lang); err == nil {
+			tags = append(tags, t)
+		}
+	}
+	matcher = language.NewMatcher(tags)
+}
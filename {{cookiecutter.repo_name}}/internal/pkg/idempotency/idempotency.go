@@ -0,0 +1,77 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// KeyHeader 幂等键请求头，与 duplicate 中间件使用同一个请求头
+	KeyHeader = "Idempotency-Key"
+	// DefaultTTL 结果保留时长，覆盖客户端的重试窗口
+	DefaultTTL = 24 * time.Hour
+)
+
+// ErrInProgress 相同幂等键的请求仍在处理中，客户端稍后重试即可拿到结果
+var ErrInProgress = errors.Conflict("IDEMPOTENCY_IN_PROGRESS", "a request with the same idempotency key is in progress")
+
+// Store 幂等键存储，记录首次请求的处理结果，重试时直接返回
+type Store interface {
+	// Reserve 占用 key，首次请求返回 ok=true，调用方处理完成后调用 Save，失败时调用 Release；
+	// 已完成的 key 返回保存的 payload，仍在处理中返回 ErrInProgress
+	Reserve(ctx context.Context, key string, ttl time.Duration) (payload []byte, ok bool, err error)
+	// Save 保存处理结果，ttl 内的重试返回该结果
+	Save(ctx context.Context, key string, payload []byte, ttl time.Duration) error
+	// Release 释放处理中的 key，业务失败后允许客户端使用同一个 key 重试
+	Release(ctx context.Context, key string) error
+}
+
+// KeyFromContext 读取请求头中的幂等键，未提供时返回空字符串
+func KeyFromContext(ctx context.Context) string {
+	if tr, ok := transport.FromServerContext(ctx); ok {
+		return tr.RequestHeader().Get(KeyHeader)
+	}
+	return ""
+}
+
+// Do 按幂等键执行 fn，同一个 key 在 ttl 内只执行一次，重试时返回首次成功的响应；
+// key 为空时直接执行，fn 返回错误时不保存结果，客户端可以用同一个 key 重试
+//
+//	reply, err := idempotency.Do(ctx, s.idem, "SayHello:"+idempotency.KeyFromContext(ctx), idempotency.DefaultTTL,
+//		func(ctx context.Context) (*v1.HelloReply, error) { ... })
+//
+// 调用方应在 key 中加上接口名、租户或用户 ID 等前缀，避免不同接口或不同用户的 key 冲突
+func Do[T proto.Message](ctx context.Context, s Store, key string, ttl time.Duration, fn func(ctx context.Context) (T, error)) (T, error) {
+	var zero T
+	if key == "" || s == nil {
+		return fn(ctx)
+	}
+	payload, ok, err := s.Reserve(ctx, key, ttl)
+	if err != nil {
+		return zero, err
+	}
+	if !ok {
+		reply := zero.ProtoReflect().New().Interface().(T)
+		if err := proto.Unmarshal(payload, reply); err != nil {
+			return zero, err
+		}
+		return reply, nil
+	}
+	reply, err := fn(ctx)
+	if err != nil {
+		_ = s.Release(context.WithoutCancel(ctx), key)
+		return zero, err
+	}
+	b, err := proto.Marshal(reply)
+	if err != nil {
+		return zero, err
+	}
+	if err := s.Save(context.WithoutCancel(ctx), key, b, ttl); err != nil {
+		return zero, err
+	}
+	return reply, nil
+}
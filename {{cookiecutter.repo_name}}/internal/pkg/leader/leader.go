@@ -0,0 +1,149 @@
+package leader
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Backend 选主后端，基于带过期时间的租约实现，可对接 Redis、etcd、Consul
+type Backend interface {
+	// Acquire 尝试获取租约，成功返回 true
+	Acquire(ctx context.Context, key, id string, ttl time.Duration) (bool, error)
+	// Renew 续约，租约已不属于 id 时返回 false
+	Renew(ctx context.Context, key, id string, ttl time.Duration) (bool, error)
+	// Release 释放租约，租约不属于 id 时忽略
+	Release(ctx context.Context, key, id string) error
+}
+
+// Elector 选主器，保证标记为单例的后台任务只在一个副本上运行
+// 主节点宕机或续约失败后，租约过期由其他副本接管
+type Elector struct {
+	backend Backend
+	key     string
+	id      string
+	ttl     time.Duration
+	log     *log.Helper
+
+	leader atomic.Bool
+	gauge  metric.Int64UpDownCounter
+}
+
+// NewElector 创建选主器，key 为选主的资源名，id 为当前副本标识，ttl 为租约时长，每 ttl/3 尝试获取或续约
+func NewElector(backend Backend, key, id string, ttl time.Duration, logger log.Logger) (*Elector, error) {
+	if ttl <= 0 {
+		return nil, fmt.Errorf("leader: ttl must be positive, got %s", ttl)
+	}
+	gauge, _ := otel.Meter("leader").Int64UpDownCounter(
+		"leader.is_leader",
+		metric.WithDescription("1 when this replica holds the leadership"),
+	)
+	return &Elector{
+		backend: backend,
+		key:     key,
+		id:      id,
+		ttl:     ttl,
+		log:     log.NewHelper(logger),
+		gauge:   gauge,
+	}, nil
+}
+
+// IsLeader 当前副本是否为主
+func (e *Elector) IsLeader() bool {
+	return e.leader.Load()
+}
+
+// Run 参与选主，成为主节点后执行 fn，失去主节点身份时取消 fn 的 ctx，
+// fn 返回后重新参与选主，直到 ctx 结束
+func (e *Elector) Run(ctx context.Context, fn func(ctx context.Context)) {
+	interval := e.ttl / 3
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		ok, err := e.backend.Acquire(ctx, e.key, e.id, e.ttl)
+		if err != nil && ctx.Err() == nil {
+			e.log.Errorf("leader: acquire %s failed: %v", e.key, err)
+		}
+		if ok {
+			e.lead(ctx, interval, fn)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// lead 作为主节点执行任务并定期续约
+func (e *Elector) lead(ctx context.Context, interval time.Duration, fn func(ctx context.Context)) {
+	e.setLeader(true)
+	defer e.setLeader(false)
+
+	leaderCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(leaderCtx)
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			cancel()
+			e.release()
+			return
+		case <-ticker.C:
+			ok, err := e.backend.Renew(ctx, e.key, e.id, e.ttl)
+			if err != nil || !ok {
+				cancel()
+				<-done
+				// 退出时续约被取消，仍主动释放租约
+				if ctx.Err() != nil {
+					e.release()
+					return
+				}
+				e.log.Warnf("leader: lost leadership of %s, renew ok=%v err=%v", e.key, ok, err)
+				return
+			}
+		case <-ctx.Done():
+			cancel()
+			<-done
+			e.release()
+			return
+		}
+	}
+}
+
+// release 主动释放租约，便于其他副本尽快接管
+func (e *Elector) release() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := e.backend.Release(ctx, e.key, e.id); err != nil {
+		e.log.Errorf("leader: release %s failed: %v", e.key, err)
+	}
+}
+
+// setLeader 更新主节点状态，记录日志和指标
+func (e *Elector) setLeader(leader bool) {
+	if e.leader.Swap(leader) == leader {
+		return
+	}
+	attrs := metric.WithAttributes(attribute.String("key", e.key), attribute.String("id", e.id))
+	if leader {
+		e.log.Infof("leader: %s became leader of %s", e.id, e.key)
+		e.gauge.Add(context.Background(), 1, attrs)
+	} else {
+		e.log.Infof("leader: %s stepped down from %s", e.id, e.key)
+		e.gauge.Add(context.Background(), -1, attrs)
+	}
+}
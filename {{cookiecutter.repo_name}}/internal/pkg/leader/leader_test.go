@@ -0,0 +1,68 @@
+package leader
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestNewElectorRejectsTTL(t *testing.T) {
+	for _, ttl := range []time.Duration{0, -time.Second} {
+		if _, err := NewElector(nil, "job", "a", ttl, log.DefaultLogger); err == nil {
+			t.Errorf("NewElector(ttl=%s): want error", ttl)
+		}
+	}
+}
+
+func TestElectorFailover(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+	backend := NewRedisBackend(rdb, "leader:")
+
+	a, err := NewElector(backend, "job", "a", 300*time.Millisecond, log.DefaultLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := NewElector(backend, "job", "b", 300*time.Millisecond, log.DefaultLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctxA, stopA := context.WithCancel(context.Background())
+	defer stopA()
+	started := make(chan struct{})
+	go a.Run(ctxA, func(ctx context.Context) {
+		close(started)
+		<-ctx.Done()
+	})
+	<-started
+
+	ctxB, stopB := context.WithCancel(context.Background())
+	defer stopB()
+	taken := make(chan struct{})
+	go b.Run(ctxB, func(ctx context.Context) {
+		close(taken)
+		<-ctx.Done()
+	})
+	select {
+	case <-taken:
+		t.Fatal("b became leader while a holds the lease")
+	case <-time.After(400 * time.Millisecond):
+	}
+
+	// a 退出时释放租约，b 接管
+	stopA()
+	select {
+	case <-taken:
+	case <-time.After(2 * time.Second):
+		t.Fatal("b did not take over after a stepped down")
+	}
+	if !b.IsLeader() || a.IsLeader() {
+		t.Errorf("IsLeader: a=%v b=%v, want a=false b=true", a.IsLeader(), b.IsLeader())
+	}
+}
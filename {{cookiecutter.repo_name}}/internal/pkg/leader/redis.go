@@ -0,0 +1,54 @@
+package leader
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	renewScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+
+	releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+)
+
+var _ Backend = (*RedisBackend)(nil)
+
+// RedisBackend 基于 Redis SET NX PX 的租约
+type RedisBackend struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewRedisBackend 创建 Redis 选主后端，key 统一加上 prefix 前缀
+func NewRedisBackend(client redis.UniversalClient, prefix string) *RedisBackend {
+	return &RedisBackend{client: client, prefix: prefix}
+}
+
+// Acquire 实现 Backend 接口
+func (b *RedisBackend) Acquire(ctx context.Context, key, id string, ttl time.Duration) (bool, error) {
+	return b.client.SetNX(ctx, b.prefix+key, id, ttl).Result()
+}
+
+// Renew 实现 Backend 接口
+func (b *RedisBackend) Renew(ctx context.Context, key, id string, ttl time.Duration) (bool, error) {
+	n, err := renewScript.Run(ctx, b.client, []string{b.prefix + key}, id, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+// Release 实现 Backend 接口
+func (b *RedisBackend) Release(ctx context.Context, key, id string) error {
+	return releaseScript.Run(ctx, b.client, []string{b.prefix + key}, id).Err()
+}
@@ -0,0 +1,153 @@
+package license
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// PublicKey 校验离线 license 签名的 ed25519 公钥，base64 编码，构建时注入，避免通过配置替换
+//
+//	go build -ldflags "-X {{cookiecutter.module_name}}/internal/pkg/license.PublicKey=..."
+var PublicKey string
+
+var (
+	// ErrInvalidSignature license 签名校验失败
+	ErrInvalidSignature = errors.New("license: invalid signature")
+	// ErrNoPublicKey 构建时未注入公钥
+	ErrNoPublicKey = errors.New("license: public key not embedded at build time")
+)
+
+// Entitlement 授权信息
+type Entitlement struct {
+	Licensee  string    `json:"licensee"`
+	Modules   []string  `json:"modules"` // 授权的模块，* 表示全部
+	ExpiresAt time.Time `json:"expires_at"`
+	// Limits 其他授权限制，如最大用户数、节点数，由业务自行解释
+	Limits map[string]int64 `json:"limits,omitempty"`
+}
+
+// Allows 是否授权了 module，不检查过期时间
+func (e *Entitlement) Allows(module string) bool {
+	for _, m := range e.Modules {
+		if m == "*" || m == module {
+			return true
+		}
+	}
+	return false
+}
+
+// Checker 授权检查，企业可以实现自己的检查方式，如硬件绑定、第三方 license 服务
+type Checker interface {
+	Check(ctx context.Context) (*Entitlement, error)
+}
+
+// CheckerFunc 函数形式的 Checker
+type CheckerFunc func(ctx context.Context) (*Entitlement, error)
+
+// Check 实现 Checker 接口
+func (f CheckerFunc) Check(ctx context.Context) (*Entitlement, error) {
+	return f(ctx)
+}
+
+// FileChecker 离线 license 文件，内容为 base64(授权信息 JSON).base64(ed25519 签名)
+// 每次检查都重新读取文件，替换文件即可续期，无需重启
+type FileChecker struct {
+	path string
+	key  ed25519.PublicKey
+}
+
+// NewFileChecker 创建离线 license 文件检查，使用构建时注入的 PublicKey 校验签名
+func NewFileChecker(path string) (*FileChecker, error) {
+	if PublicKey == "" {
+		return nil, ErrNoPublicKey
+	}
+	key, err := base64.StdEncoding.DecodeString(PublicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("license: invalid public key")
+	}
+	return &FileChecker{path: path, key: key}, nil
+}
+
+// Check 实现 Checker 接口
+func (c *FileChecker) Check(context.Context) (*Entitlement, error) {
+	b, err := os.ReadFile(c.path)
+	if err != nil {
+		return nil, fmt.Errorf("license: %w", err)
+	}
+	return Verify(c.key, strings.TrimSpace(string(b)))
+}
+
+// Sign 签发离线 license，供发证工具使用，私钥不能随服务分发
+func Sign(key ed25519.PrivateKey, e *Entitlement) (string, error) {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+	sig := ed25519.Sign(key, payload)
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+// Verify 校验离线 license 签名并解析授权信息
+func Verify(key ed25519.PublicKey, license string) (*Entitlement, error) {
+	p, s, ok := strings.Cut(license, ".")
+	if !ok {
+		return nil, ErrInvalidSignature
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(p)
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || !ed25519.Verify(key, payload, sig) {
+		return nil, ErrInvalidSignature
+	}
+	var e Entitlement
+	if err := json.Unmarshal(payload, &e); err != nil {
+		return nil, fmt.Errorf("license: %w", err)
+	}
+	return &e, nil
+}
+
+// ServerChecker 在线 license 服务，GET 返回授权信息 JSON，非 200 视为未授权
+type ServerChecker struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// NewServerChecker 创建在线 license 服务检查，token 通过 Authorization: Bearer 传递
+func NewServerChecker(url, token string, timeout time.Duration) *ServerChecker {
+	return &ServerChecker{url: url, token: token, client: &http.Client{Timeout: timeout}}
+}
+
+// Check 实现 Checker 接口
+func (c *ServerChecker) Check(ctx context.Context) (*Entitlement, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("license: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("license: server returned %s", resp.Status)
+	}
+	var e Entitlement
+	if err := json.NewDecoder(resp.Body).Decode(&e); err != nil {
+		return nil, fmt.Errorf("license: decode response: %w", err)
+	}
+	return &e, nil
+}
@@ -0,0 +1,162 @@
+package license
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// ReasonNotEntitled 模块未授权
+const ReasonNotEntitled = "LICENSE_NOT_ENTITLED"
+
+var checks, _ = otel.Meter("license").Int64Counter(
+	"license.checks",
+	metric.WithDescription("Number of license checks by result: ok or error"),
+)
+
+// Status 授权状态，用于 /healthz 详情
+type Status struct {
+	Valid     bool      `json:"valid"`
+	Licensee  string    `json:"licensee,omitempty"`
+	Modules   []string  `json:"modules,omitempty"`
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	CheckedAt time.Time `json:"checked_at,omitempty"` // 最近一次成功检查的时间
+	Error     string    `json:"error,omitempty"`      // 最近一次检查的错误
+}
+
+// Manager 授权管理，启动时检查一次并定期复查
+// 复查失败（如 license 服务不可达）时在 grace 时间内沿用上次成功的结果
+type Manager struct {
+	checker  Checker
+	interval time.Duration
+	grace    time.Duration
+	log      *log.Helper
+
+	mu        sync.RWMutex
+	ent       *Entitlement
+	checkedAt time.Time
+	err       error
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewManager 创建授权管理
+func NewManager(checker Checker, interval, grace time.Duration, logger log.Logger) *Manager {
+	return &Manager{
+		checker:  checker,
+		interval: interval,
+		grace:    grace,
+		log:      log.NewHelper(logger),
+	}
+}
+
+// Check 执行一次授权检查，返回检查本身的错误
+func (m *Manager) Check(ctx context.Context) error {
+	ent, err := m.checker.Check(ctx)
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	checks.Add(ctx, 1, metric.WithAttributes(attribute.String("result", result)))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.err = err
+	if err != nil {
+		m.log.Errorf("license check failed: %v", err)
+		return err
+	}
+	m.ent, m.checkedAt = ent, time.Now()
+	if left := time.Until(ent.ExpiresAt); left < 30*24*time.Hour {
+		m.log.Warnf("license for %s expires in %s", ent.Licensee, left.Round(time.Hour))
+	}
+	return nil
+}
+
+// Start 定期复查授权，需在首次 Check 之后调用
+func (m *Manager) Start() {
+	m.stop, m.done = make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(m.done)
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-m.stop:
+				return
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+				_ = m.Check(ctx)
+				cancel()
+			}
+		}
+	}()
+}
+
+// Stop 停止定期复查
+func (m *Manager) Stop() {
+	if m.stop == nil {
+		return
+	}
+	close(m.stop)
+	<-m.done
+}
+
+// valid 当前授权是否有效，需持有锁
+func (m *Manager) valid(now time.Time) bool {
+	if m.ent == nil || !now.Before(m.ent.ExpiresAt) {
+		return false
+	}
+	return m.err == nil || now.Sub(m.checkedAt) < m.grace
+}
+
+// Allowed 模块是否已授权且授权有效，nil Manager 表示未启用授权检查，始终返回 true
+func (m *Manager) Allowed(module string) bool {
+	if m == nil {
+		return true
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.valid(time.Now()) && m.ent.Allows(module)
+}
+
+// Status 当前授权状态
+func (m *Manager) Status() Status {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	s := Status{Valid: m.valid(time.Now()), CheckedAt: m.checkedAt}
+	if m.ent != nil {
+		s.Licensee, s.Modules, s.ExpiresAt = m.ent.Licensee, m.ent.Modules, m.ent.ExpiresAt
+	}
+	if m.err != nil {
+		s.Error = m.err.Error()
+	}
+	return s
+}
+
+// Server 按接口限制授权模块，operations 为接口前缀到模块名的映射，如 /helloworld.v1.Greeter/ -> greeter
+// 未授权时返回 403 LICENSE_NOT_ENTITLED
+func Server(m *Manager, operations map[string]string) middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			if tr, ok := transport.FromServerContext(ctx); ok {
+				for prefix, module := range operations {
+					if strings.HasPrefix(tr.Operation(), prefix) && !m.Allowed(module) {
+						return nil, errors.Forbidden(ReasonNotEntitled, "module "+module+" is not licensed")
+					}
+				}
+			}
+			return handler(ctx, req)
+		}
+	}
+}
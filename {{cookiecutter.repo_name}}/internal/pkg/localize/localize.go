@@ -0,0 +1,114 @@
+package localize
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"{{cookiecutter.module_name}}/internal/pkg/dict"
+	"{{cookiecutter.module_name}}/internal/pkg/i18n"
+
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// LabelSuffix 显示名称字段的后缀，如 status 的显示名称为 statusLabel
+const LabelSuffix = "Label"
+
+// ResponseEncoder 响应本地化编码器
+// 请求带 ?display=true 或 Accept-Language 时，为枚举字段和绑定了字典的字段追加 xxxLabel 显示名称，
+// 枚举的翻译 key 为 enum.{枚举全名}.{枚举值}，没有翻译时使用枚举值名称
+func ResponseEncoder() khttp.EncodeResponseFunc {
+	return func(w http.ResponseWriter, r *http.Request, v interface{}) error {
+		m, ok := v.(proto.Message)
+		if !ok || (r.URL.Query().Get("display") != "true" && r.Header.Get("Accept-Language") == "") {
+			return khttp.DefaultResponseEncoder(w, r, v)
+		}
+		lang := i18n.Negotiate(r.Header.Get("Accept-Language"))
+
+		b, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(m)
+		if err != nil {
+			return err
+		}
+		var out map[string]interface{}
+		if err := json.Unmarshal(b, &out); err != nil {
+			return err
+		}
+		annotate(m.ProtoReflect(), out, lang)
+		if b, err = json.Marshal(out); err != nil {
+			return err
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Language", lang)
+		_, err = w.Write(b)
+		return err
+	}
+}
+
+// annotate 为消息中的枚举和字典字段追加显示名称
+func annotate(m protoreflect.Message, out map[string]interface{}, lang string) {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.IsMap() {
+			continue
+		}
+		name := fd.JSONName()
+		v := m.Get(fd)
+		switch {
+		case fd.Kind() == protoreflect.EnumKind:
+			if fd.IsList() {
+				list := v.List()
+				labels := make([]string, list.Len())
+				for j := 0; j < list.Len(); j++ {
+					labels[j] = enumLabel(fd.Enum(), list.Get(j).Enum(), lang)
+				}
+				out[name+LabelSuffix] = labels
+			} else {
+				out[name+LabelSuffix] = enumLabel(fd.Enum(), v.Enum(), lang)
+			}
+		case fd.Message() != nil:
+			if fd.IsList() {
+				list := v.List()
+				items, _ := out[name].([]interface{})
+				for j := 0; j < list.Len() && j < len(items); j++ {
+					if item, ok := items[j].(map[string]interface{}); ok {
+						annotate(list.Get(j).Message(), item, lang)
+					}
+				}
+			} else if m.Has(fd) {
+				if sub, ok := out[name].(map[string]interface{}); ok {
+					annotate(v.Message(), sub, lang)
+				}
+			}
+		default:
+			dictType, ok := dict.TypeOf(string(fd.FullName()))
+			if !ok {
+				continue
+			}
+			if fd.IsList() {
+				list := v.List()
+				labels := make([]string, list.Len())
+				for j := 0; j < list.Len(); j++ {
+					labels[j] = dict.Label(dictType, lang, list.Get(j).Interface())
+				}
+				out[name+LabelSuffix] = labels
+			} else {
+				out[name+LabelSuffix] = dict.Label(dictType, lang, v.Interface())
+			}
+		}
+	}
+}
+
+// enumLabel 获取枚举值的显示名称
+func enumLabel(ed protoreflect.EnumDescriptor, n protoreflect.EnumNumber, lang string) string {
+	ev := ed.Values().ByNumber(n)
+	if ev == nil {
+		return ""
+	}
+	if label, ok := i18n.Translate(lang, "enum."+string(ed.FullName())+"."+string(ev.Name())); ok {
+		return label
+	}
+	return string(ev.Name())
+}
@@ -0,0 +1,47 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// Every 定时任务，多个实例同时运行时每个周期只有一个实例执行 fn，直到 ctx 结束
+//
+//	go lock.Every(ctx, locker, "job:cleanup", time.Hour, uc.CleanupExpired, logger)
+//
+// 各实例在周期边界（按 interval 对齐的时间点）竞争以周期起始时间命名的锁，
+// 获取失败说明本周期已由其他实例执行；执行期间自动续期，fn 的 ctx 在锁丢失时取消，
+// 执行结束后不主动释放，锁保留到周期结束，避免时钟稍慢的实例在同一周期内重复执行
+func Every(ctx context.Context, l *Locker, key string, interval time.Duration, fn func(ctx context.Context) error, logger log.Logger) {
+	helper := log.NewHelper(log.With(logger, "job", key))
+	for {
+		now := time.Now()
+		next := now.Truncate(interval).Add(interval)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(next.Sub(now)):
+		}
+
+		lk, err := l.TryAcquire(ctx, key+":"+strconv.FormatInt(next.UnixMilli(), 10), interval)
+		if errors.Is(err, ErrNotAcquired) {
+			helper.Debugf("skipped, running on another instance")
+			continue
+		}
+		if err != nil {
+			helper.Errorf("acquire lock: %v", err)
+			continue
+		}
+		start := time.Now()
+		if err := fn(lk.Context()); err != nil {
+			helper.Errorf("run failed after %s: %v", time.Since(start), err)
+		} else {
+			helper.Infof("run finished in %s", time.Since(start))
+		}
+		lk.stopRefresh()
+	}
+}
@@ -0,0 +1,211 @@
+package lock
+
+import (
+	"context"
+	crand "crypto/rand"
+	"encoding/hex"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	// ErrNotAcquired 锁被其他持有者占用
+	ErrNotAcquired = errors.New("lock: not acquired")
+	// ErrNotHeld 锁已过期或被其他持有者获取
+	ErrNotHeld = errors.New("lock: not held")
+)
+
+var (
+	extendScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+
+	releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+)
+
+// Option 锁配置项
+type Option func(*Locker)
+
+// WithPrefix key 前缀，如 {{cookiecutter.repo_name}}:lock:
+func WithPrefix(prefix string) Option {
+	return func(l *Locker) {
+		l.prefix = prefix
+	}
+}
+
+// WithRetryDelay Acquire 等待锁时的重试间隔，实际间隔在 [delay/2, delay) 之间随机，默认 100ms
+func WithRetryDelay(delay time.Duration) Option {
+	return func(l *Locker) {
+		l.retryDelay = delay
+	}
+}
+
+// Locker 基于 Redis 的分布式锁，单个 Redis 实例上 SET NX PX 加锁，
+// 持有期间按 ttl/3 自动续期，释放时校验持有者随机值，不会误删其他持有者的锁
+type Locker struct {
+	client     redis.UniversalClient
+	prefix     string
+	retryDelay time.Duration
+}
+
+// New 创建分布式锁
+func New(client redis.UniversalClient, opts ...Option) *Locker {
+	l := &Locker{
+		client:     client,
+		retryDelay: 100 * time.Millisecond,
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Acquire 获取锁，被占用时按重试间隔等待直到 ctx 结束，ctx 结束时返回 ErrNotAcquired
+//
+//	lk, err := locker.Acquire(ctx, "order:"+id, 10*time.Second)
+//	if err != nil {
+//		return err
+//	}
+//	defer lk.Release(context.Background())
+//	// lk.Context() 在锁丢失时取消，耗时操作应使用它
+func (l *Locker) Acquire(ctx context.Context, key string, ttl time.Duration) (*Lock, error) {
+	for {
+		lk, err := l.TryAcquire(ctx, key, ttl)
+		if err != nil && ctx.Err() != nil {
+			// ctx 在请求 Redis 时结束
+			return nil, ErrNotAcquired
+		}
+		if !errors.Is(err, ErrNotAcquired) {
+			return lk, err
+		}
+		delay := l.retryDelay/2 + rand.N(l.retryDelay/2+1)
+		select {
+		case <-ctx.Done():
+			return nil, ErrNotAcquired
+		case <-time.After(delay):
+		}
+	}
+}
+
+// TryAcquire 尝试获取一次锁，被占用时立即返回 ErrNotAcquired
+func (l *Locker) TryAcquire(ctx context.Context, key string, ttl time.Duration) (*Lock, error) {
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+	ok, err := l.client.SetNX(ctx, l.prefix+key, token, ttl).Result()
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrNotAcquired
+	}
+	lk := &Lock{
+		locker: l,
+		key:    l.prefix + key,
+		token:  token,
+		ttl:    ttl,
+		stop:   make(chan struct{}),
+	}
+	lk.ctx, lk.cancel = context.WithCancel(context.WithoutCancel(ctx))
+	lk.wg.Add(1)
+	go lk.refresh()
+	return lk, nil
+}
+
+// Lock 已持有的锁
+type Lock struct {
+	locker *Locker
+	key    string
+	token  string
+	ttl    time.Duration
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	stop   chan struct{}
+	once   sync.Once
+	wg     sync.WaitGroup
+}
+
+// Context 锁丢失或释放时取消，受锁保护的操作应使用它，及时停止
+func (lk *Lock) Context() context.Context {
+	return lk.ctx
+}
+
+// Extend 立即续期为 ttl，锁已不属于当前持有者时返回 ErrNotHeld
+func (lk *Lock) Extend(ctx context.Context, ttl time.Duration) error {
+	n, err := extendScript.Run(ctx, lk.locker.client, []string{lk.key}, lk.token, ttl.Milliseconds()).Int()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotHeld
+	}
+	return nil
+}
+
+// Release 停止续期并释放锁，锁已过期或被其他持有者获取时返回 ErrNotHeld
+func (lk *Lock) Release(ctx context.Context) error {
+	lk.stopRefresh()
+	n, err := releaseScript.Run(ctx, lk.locker.client, []string{lk.key}, lk.token).Int()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotHeld
+	}
+	return nil
+}
+
+// stopRefresh 停止自动续期并取消 Context，锁保留到 ttl 过期
+func (lk *Lock) stopRefresh() {
+	lk.once.Do(func() {
+		close(lk.stop)
+		lk.wg.Wait()
+		lk.cancel()
+	})
+}
+
+// refresh 按 ttl/3 自动续期，锁被其他持有者获取或超过 ttl 未能续期时视为丢失
+func (lk *Lock) refresh() {
+	defer lk.wg.Done()
+	ticker := time.NewTicker(lk.ttl / 3)
+	defer ticker.Stop()
+	deadline := time.Now().Add(lk.ttl)
+	for {
+		select {
+		case <-lk.stop:
+			return
+		case <-ticker.C:
+		}
+		ctx, cancel := context.WithTimeout(lk.ctx, lk.ttl/3)
+		err := lk.Extend(ctx, lk.ttl)
+		cancel()
+		switch {
+		case err == nil:
+			deadline = time.Now().Add(lk.ttl)
+		case errors.Is(err, ErrNotHeld) || time.Now().After(deadline):
+			lk.cancel()
+			return
+		}
+	}
+}
+
+// newToken 持有者随机值
+func newToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := crand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
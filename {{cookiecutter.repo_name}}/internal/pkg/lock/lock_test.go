@@ -0,0 +1,145 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestLocker(t *testing.T, opts ...Option) (*Locker, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return New(client, append([]Option{WithPrefix("test:lock:")}, opts...)...), mr
+}
+
+func TestTryAcquire(t *testing.T) {
+	l, mr := newTestLocker(t)
+	ctx := context.Background()
+
+	lk, err := l.TryAcquire(ctx, "job", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !mr.Exists("test:lock:job") {
+		t.Fatal("lock key not set with the prefix")
+	}
+	if _, err := l.TryAcquire(ctx, "job", time.Second); !errors.Is(err, ErrNotAcquired) {
+		t.Fatalf("second acquire: err = %v, want ErrNotAcquired", err)
+	}
+	if err := lk.Release(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if lk.Context().Err() == nil {
+		t.Error("lock context not canceled after release")
+	}
+	if _, err := l.TryAcquire(ctx, "job", time.Second); err != nil {
+		t.Fatalf("acquire after release: %v", err)
+	}
+}
+
+func TestReleaseNotHeld(t *testing.T) {
+	l, mr := newTestLocker(t)
+	ctx := context.Background()
+
+	lk, err := l.TryAcquire(ctx, "job", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 锁过期后被其他持有者获取，释放时不删除对方的锁
+	mr.Set("test:lock:job", "other")
+	if err := lk.Release(ctx); !errors.Is(err, ErrNotHeld) {
+		t.Fatalf("release: err = %v, want ErrNotHeld", err)
+	}
+	if got, _ := mr.Get("test:lock:job"); got != "other" {
+		t.Errorf("lock value = %q, release removed another holder's lock", got)
+	}
+	if err := lk.Extend(ctx, time.Second); !errors.Is(err, ErrNotHeld) {
+		t.Errorf("extend: err = %v, want ErrNotHeld", err)
+	}
+}
+
+func TestAcquireWaits(t *testing.T) {
+	l, _ := newTestLocker(t, WithRetryDelay(10*time.Millisecond))
+	ctx := context.Background()
+
+	lk, err := l.TryAcquire(ctx, "job", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.AfterFunc(50*time.Millisecond, func() { _ = lk.Release(context.Background()) })
+	wctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	next, err := l.Acquire(wctx, "job", time.Second)
+	if err != nil {
+		t.Fatalf("acquire after the holder released: %v", err)
+	}
+	defer next.Release(ctx)
+
+	// 等待超时返回 ErrNotAcquired
+	tctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if _, err := l.Acquire(tctx, "job", time.Second); !errors.Is(err, ErrNotAcquired) {
+		t.Fatalf("acquire held lock: err = %v, want ErrNotAcquired", err)
+	}
+}
+
+func TestAutoExtend(t *testing.T) {
+	l, mr := newTestLocker(t)
+	ctx := context.Background()
+
+	ttl := 300 * time.Millisecond
+	lk, err := l.TryAcquire(ctx, "job", ttl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lk.Release(ctx)
+	// 持有时间超过 ttl，续期后锁仍然存在
+	for i := 0; i < 4; i++ {
+		time.Sleep(ttl / 2)
+		mr.FastForward(ttl / 2)
+	}
+	if !mr.Exists("test:lock:job") || lk.Context().Err() != nil {
+		t.Fatal("lock expired while held")
+	}
+
+	// 被其他持有者获取后视为丢失，Context 取消
+	mr.Set("test:lock:job", "other")
+	select {
+	case <-lk.Context().Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("lock context not canceled after the lock was lost")
+	}
+}
+
+func TestEvery(t *testing.T) {
+	l, _ := newTestLocker(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 350*time.Millisecond)
+	defer cancel()
+
+	// 两个实例同时运行，每个周期只执行一次
+	var runs atomic.Int32
+	fn := func(context.Context) error {
+		runs.Add(1)
+		return nil
+	}
+	done := make(chan struct{}, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			Every(ctx, l, "job", 100*time.Millisecond, fn, log.DefaultLogger)
+			done <- struct{}{}
+		}()
+	}
+	<-done
+	<-done
+	if n := runs.Load(); n < 2 || n > 4 {
+		t.Errorf("runs = %d in 3 periods, want one per period", n)
+	}
+}
@@ -0,0 +1,217 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"{{cookiecutter.module_name}}/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// ObjectStorage 对象存储接口，S3/OSS 等实现该接口即可用于日志归档
+type ObjectStorage interface {
+	// Upload 上传本地文件到指定 key
+	Upload(ctx context.Context, key string, filename string) error
+	// Size 获取对象大小，对象不存在时返回 os.ErrNotExist
+	Size(ctx context.Context, key string) (int64, error)
+}
+
+// Archiver 日志归档上传器
+// 定期扫描日志目录，将已轮转完成的备份文件上传到对象存储，
+// 上传并校验大小一致后才删除本地文件，本地文件至少保留 maxAge 天，超出 maxBackups 的上传后即删除；
+// 开启归档时 RotateWriter 不再清理备份文件，避免未上传的备份被删除
+type Archiver struct {
+	storage    ObjectStorage
+	filename   string
+	prefix     string
+	host       string
+	interval   time.Duration
+	settle     time.Duration
+	maxAge     int // days
+	maxBackups int
+	dailyDir   bool
+	log        *log.Helper
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewArchiver 创建日志归档上传器
+func NewArchiver(c *conf.Log, storage ObjectStorage, logger log.Logger) *Archiver {
+	interval := time.Minute
+	if c.Archive.Interval != nil && c.Archive.Interval.AsDuration() > 0 {
+		interval = c.Archive.Interval.AsDuration()
+	}
+	host, _ := os.Hostname()
+	return &Archiver{
+		storage:    storage,
+		filename:   logFilename(c),
+		prefix:     strings.Trim(c.Archive.Prefix, "/"),
+		host:       host,
+		interval:   interval,
+		settle:     time.Minute,
+		maxAge:     int(c.MaxAge),
+		maxBackups: int(c.MaxBackups),
+		dailyDir:   c.DailyDir,
+		log:        log.NewHelper(logger),
+		done:       make(chan struct{}),
+	}
+}
+
+// Start 启动后台上传
+func (a *Archiver) Start() {
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		ticker := time.NewTicker(a.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				a.archive(context.Background())
+			case <-a.done:
+				return
+			}
+		}
+	}()
+}
+
+// Stop 停止后台上传并等待当前批次完成
+func (a *Archiver) Stop() {
+	close(a.done)
+	a.wg.Wait()
+}
+
+// archive 扫描并上传一批备份文件
+func (a *Archiver) archive(ctx context.Context) {
+	files, err := a.backupFiles()
+	if err != nil {
+		a.log.Errorf("archive: list log files failed: %v", err)
+		return
+	}
+	// 按修改时间从新到旧排序，超出 max_backups 的备份上传后删除
+	modTimes := make(map[string]time.Time, len(files))
+	for _, f := range files {
+		if info, err := os.Stat(f); err == nil {
+			modTimes[f] = info.ModTime()
+		}
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		return modTimes[files[i]].After(modTimes[files[j]])
+	})
+	for i, f := range files {
+		select {
+		case <-a.done:
+			return
+		default:
+		}
+		excess := a.maxBackups > 0 && i >= a.maxBackups
+		if err := a.archiveFile(ctx, f, excess); err != nil {
+			a.log.Errorf("archive: upload %s failed: %v", f, err)
+		}
+	}
+}
+
+// archiveFile 上传单个文件，校验成功且超过本地保留期或超出保留数量（excess）后删除
+func (a *Archiver) archiveFile(ctx context.Context, filename string, excess bool) error {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return err
+	}
+	// 压缩或轮转可能仍在写入，跳过最近修改过的文件
+	if time.Since(info.ModTime()) < a.settle {
+		return nil
+	}
+
+	key := a.objectKey(filename, info.ModTime())
+	size, err := a.storage.Size(ctx, key)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err != nil || size != info.Size() {
+		if err := a.storage.Upload(ctx, key, filename); err != nil {
+			return err
+		}
+		if size, err = a.storage.Size(ctx, key); err != nil {
+			return err
+		}
+		if size != info.Size() {
+			return fmt.Errorf("size mismatch after upload: local %d, remote %d", info.Size(), size)
+		}
+	}
+
+	if !excess && a.maxAge > 0 && time.Since(info.ModTime()) < time.Duration(a.maxAge)*24*time.Hour {
+		return nil
+	}
+	if err := os.Remove(filename); err != nil {
+		return err
+	}
+	// 按日期分目录时，目录清空后一并删除，非空目录删除会失败
+	if a.dailyDir {
+		_ = os.Remove(filepath.Dir(filename))
+	}
+	return nil
+}
+
+// objectKey 生成对象 key，格式: prefix/yyyy/MM/dd/hostname/filename，
+// 按日期分目录时 filename 带上日期目录，如 2024-05-01/app-1.log，不同日期的同名备份不会互相覆盖
+func (a *Archiver) objectKey(filename string, t time.Time) string {
+	name := filepath.Base(filename)
+	if rel, err := filepath.Rel(filepath.Dir(a.filename), filename); err == nil {
+		name = filepath.ToSlash(rel)
+	}
+	return path.Join(a.prefix, t.Format("2006/01/02"), a.host, name)
+}
+
+// backupFiles 获取已轮转完成的备份文件，不包括正在写入的日志文件
+func (a *Archiver) backupFiles() ([]string, error) {
+	dir := filepath.Dir(a.filename)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	base := filepath.Base(a.filename)
+	prefix := strings.TrimSuffix(base, filepath.Ext(base)) + "-"
+
+	var files []string
+	for _, e := range entries {
+		if e.IsDir() {
+			if a.dailyDir {
+				files = append(files, dailyBackupFiles(filepath.Join(dir, e.Name()), prefix)...)
+			}
+			continue
+		}
+		if e.Name() == base || !strings.HasPrefix(e.Name(), prefix) {
+			continue
+		}
+		files = append(files, filepath.Join(dir, e.Name()))
+	}
+	return files, nil
+}
+
+// dailyBackupFiles 日期子目录中的备份文件，目录名不是日期时忽略
+func dailyBackupFiles(dir, prefix string) []string {
+	if _, err := time.Parse("2006-01-02", filepath.Base(dir)); err != nil {
+		return nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var files []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasPrefix(e.Name(), prefix) {
+			files = append(files, filepath.Join(dir, e.Name()))
+		}
+	}
+	return files
+}
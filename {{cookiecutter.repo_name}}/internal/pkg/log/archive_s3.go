@@ -0,0 +1,50 @@
+package log
+
+import (
+	"context"
+	"os"
+
+	"{{cookiecutter.module_name}}/internal/conf"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+var _ ObjectStorage = (*S3Storage)(nil)
+
+// S3Storage 基于 S3 协议的对象存储，兼容 AWS S3、阿里云 OSS、MinIO 等
+type S3Storage struct {
+	client *minio.Client
+	bucket string
+}
+
+// NewS3Storage 创建 S3 协议对象存储
+func NewS3Storage(c *conf.Log_Archive) (*S3Storage, error) {
+	client, err := minio.New(c.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(c.AccessKey, c.SecretKey, ""),
+		Secure: c.Secure,
+		Region: c.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &S3Storage{client: client, bucket: c.Bucket}, nil
+}
+
+// Upload 上传本地文件
+func (s *S3Storage) Upload(ctx context.Context, key string, filename string) error {
+	_, err := s.client.FPutObject(ctx, s.bucket, key, filename, minio.PutObjectOptions{})
+	return err
+}
+
+// Size 获取对象大小
+func (s *S3Storage) Size(ctx context.Context, key string) (int64, error) {
+	info, err := s.client.StatObject(ctx, s.bucket, key, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return 0, os.ErrNotExist
+		}
+		return 0, err
+	}
+	return info.Size, nil
+}
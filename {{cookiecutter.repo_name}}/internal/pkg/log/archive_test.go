@@ -0,0 +1,120 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+func TestArchiverBackupFilesDailyDir(t *testing.T) {
+	dir := t.TempDir()
+	for _, f := range []string{
+		"app.log",
+		"app-2024-05-01-1.log.gz",
+		"other.log",
+		"2024-05-01/app-1.log.gz",
+		"2024-05-01/app-2.log",
+		"2024-05-02/app-1.log",
+		"tmp/app-1.log",
+	} {
+		path := filepath.Join(dir, f)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	a := &Archiver{filename: filepath.Join(dir, "app.log"), prefix: "logs", host: "h1", dailyDir: true}
+
+	files, err := a.backupFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keys []string
+	for _, f := range files {
+		keys = append(keys, a.objectKey(f, time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)))
+	}
+	sort.Strings(keys)
+	want := []string{
+		"logs/2024/05/02/h1/2024-05-01/app-1.log.gz",
+		"logs/2024/05/02/h1/2024-05-01/app-2.log",
+		"logs/2024/05/02/h1/2024-05-02/app-1.log",
+		"logs/2024/05/02/h1/app-2024-05-01-1.log.gz",
+	}
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("keys = %v, want %v", keys, want)
+	}
+}
+
+// memStorage 内存对象存储，failing 中的文件上传失败
+type memStorage struct {
+	objects map[string]int64
+	failing map[string]bool
+}
+
+func (s *memStorage) Upload(_ context.Context, key string, filename string) error {
+	if s.failing[filepath.Base(filename)] {
+		return errors.New("upload failed")
+	}
+	info, err := os.Stat(filename)
+	if err != nil {
+		return err
+	}
+	s.objects[key] = info.Size()
+	return nil
+}
+
+func (s *memStorage) Size(_ context.Context, key string) (int64, error) {
+	if n, ok := s.objects[key]; ok {
+		return n, nil
+	}
+	return 0, os.ErrNotExist
+}
+
+func TestArchiverPrunesOnlyUploaded(t *testing.T) {
+	dir := t.TempDir()
+	old := time.Now().Add(-time.Hour)
+	// 序号越大越新
+	for i, f := range []string{"app-2024-05-01-1.log", "app-2024-05-01-2.log", "app-2024-05-01-3.log", "app-2024-05-01-4.log"} {
+		path := filepath.Join(dir, f)
+		if err := os.WriteFile(path, []byte("line\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		mt := old.Add(time.Duration(i) * time.Minute)
+		if err := os.Chtimes(path, mt, mt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	storage := &memStorage{objects: map[string]int64{}, failing: map[string]bool{"app-2024-05-01-1.log": true}}
+	a := &Archiver{storage: storage, filename: filepath.Join(dir, "app.log"), host: "h1", maxAge: 7, maxBackups: 2, log: log.NewHelper(log.DefaultLogger), done: make(chan struct{})}
+	a.archive(context.Background())
+
+	var got []string
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		got = append(got, e.Name())
+	}
+	// 超出 max_backups 的 2 已上传后删除，1 上传失败仍保留，3、4 在保留期内
+	want := []string{"app-2024-05-01-1.log", "app-2024-05-01-3.log", "app-2024-05-01-4.log"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("files = %v, want %v", got, want)
+	}
+	if len(storage.objects) != 3 {
+		t.Errorf("uploaded %d objects, want 3", len(storage.objects))
+	}
+
+	// 开启归档时轮转写入器不清理备份文件
+	w := NewRotateWriter(filepath.Join(dir, "app.log"), 1, 0, 1, false, WithArchive())
+	w.mill()
+	if entries, _ := os.ReadDir(dir); len(entries) != len(want) {
+		t.Errorf("mill removed backups pending upload: %d files left, want %d", len(entries), len(want))
+	}
+}
@@ -0,0 +1,122 @@
+package log
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// bufferKey 请求日志缓冲区在日志字段和上下文中的 key，输出前会被移除
+const bufferKey = "log.buffer"
+
+type bufferContextKey struct{}
+
+var _ log.Logger = (*BufferLogger)(nil)
+
+// RequestBuffer 单个请求的日志缓冲区
+type RequestBuffer struct {
+	mu      sync.Mutex
+	entries []bufferEntry
+	max     int
+	dropped int
+}
+
+// bufferEntry 缓冲的一条日志
+type bufferEntry struct {
+	logger  log.Logger
+	level   log.Level
+	keyvals []interface{}
+	at      time.Time
+}
+
+// NewRequestBuffer 创建请求日志缓冲区，最多缓冲 max 条，超出时丢弃最早的日志
+func NewRequestBuffer(max int) *RequestBuffer {
+	if max <= 0 {
+		max = 256
+	}
+	return &RequestBuffer{max: max}
+}
+
+// WithRequestBuffer 将缓冲区写入上下文
+func WithRequestBuffer(ctx context.Context, b *RequestBuffer) context.Context {
+	return context.WithValue(ctx, bufferContextKey{}, b)
+}
+
+// Len 缓冲的日志条数，包括已丢弃的
+func (b *RequestBuffer) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.entries) + b.dropped
+}
+
+// Flush 按顺序输出缓冲的日志，并附加原始记录时间 buffered_at
+func (b *RequestBuffer) Flush() {
+	b.mu.Lock()
+	entries, dropped := b.entries, b.dropped
+	b.entries, b.dropped = nil, 0
+	b.mu.Unlock()
+
+	for i, e := range entries {
+		kvs := make([]interface{}, 0, len(e.keyvals)+4)
+		kvs = append(kvs, e.keyvals...)
+		kvs = append(kvs, "buffered_at", e.at.Format(time.RFC3339Nano))
+		if i == 0 && dropped > 0 {
+			kvs = append(kvs, "buffer_dropped", dropped)
+		}
+		_ = e.logger.Log(e.level, kvs...)
+	}
+}
+
+// add 缓冲一条日志
+func (b *RequestBuffer) add(e bufferEntry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.entries) >= b.max {
+		copy(b.entries, b.entries[1:])
+		b.entries = b.entries[:len(b.entries)-1]
+		b.dropped++
+	}
+	b.entries = append(b.entries, e)
+}
+
+// BufferLogger 请求级日志缓冲包装器
+// 上下文中带有 RequestBuffer 时，低于 level 的日志先写入缓冲区，由中间件在请求失败或超时时输出，
+// 否则直接透传；需要配合 BufferValuers 使用以便从日志上下文中获取缓冲区
+type BufferLogger struct {
+	logger log.Logger
+	level  log.Level
+}
+
+// NewBufferLogger 创建请求级日志缓冲包装器，level 及以上级别的日志始终直接输出
+func NewBufferLogger(logger log.Logger, level log.Level) *BufferLogger {
+	return &BufferLogger{logger: logger, level: level}
+}
+
+// BufferValuers 从上下文中获取请求日志缓冲区的字段，追加到 log.With 的字段中
+func BufferValuers() []interface{} {
+	return []interface{}{bufferKey, log.Valuer(func(ctx context.Context) interface{} {
+		b, _ := ctx.Value(bufferContextKey{}).(*RequestBuffer)
+		return b
+	})}
+}
+
+// Log 实现 log.Logger 接口
+func (l *BufferLogger) Log(level log.Level, keyvals ...interface{}) error {
+	var buf *RequestBuffer
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		if k, ok := keyvals[i].(string); ok && k == bufferKey {
+			buf, _ = keyvals[i+1].(*RequestBuffer)
+			kvs := make([]interface{}, 0, len(keyvals)-2)
+			kvs = append(kvs, keyvals[:i]...)
+			keyvals = append(kvs, keyvals[i+2:]...)
+			break
+		}
+	}
+	if buf == nil || level >= l.level {
+		return l.logger.Log(level, keyvals...)
+	}
+	buf.add(bufferEntry{logger: l.logger, level: level, keyvals: keyvals, at: time.Now()})
+	return nil
+}
@@ -0,0 +1,142 @@
+package log
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+var _ log.Logger = (*DedupLogger)(nil)
+
+// DedupLogger 重复日志抑制包装器
+// 窗口期内相同的日志只输出第一条，其余仅计数，
+// 窗口结束后补一条带 repeated 次数的汇总日志，避免依赖故障或死循环时刷出海量相同日志
+type DedupLogger struct {
+	logger log.Logger
+	window time.Duration
+	level  log.Level
+
+	mu      sync.Mutex
+	entries map[string]*dedupEntry
+
+	done chan struct{}
+	once sync.Once
+}
+
+// dedupEntry 窗口期内某一类日志的计数
+type dedupEntry struct {
+	level   log.Level
+	keyvals []interface{}
+	start   time.Time
+	count   int
+}
+
+// NewDedupLogger 创建重复日志抑制包装器
+// window 为去重窗口，level 为参与去重的最低级别，低于该级别的日志直接透传
+func NewDedupLogger(logger log.Logger, window time.Duration, level log.Level) *DedupLogger {
+	l := &DedupLogger{
+		logger:  logger,
+		window:  window,
+		level:   level,
+		entries: make(map[string]*dedupEntry),
+		done:    make(chan struct{}),
+	}
+	go l.run()
+	return l
+}
+
+// Log 实现 log.Logger 接口
+func (l *DedupLogger) Log(level log.Level, keyvals ...interface{}) error {
+	if level < l.level {
+		return l.logger.Log(level, keyvals...)
+	}
+
+	key := dedupKey(level, keyvals)
+	now := time.Now()
+
+	l.mu.Lock()
+	e, ok := l.entries[key]
+	if ok && now.Sub(e.start) < l.window {
+		e.count++
+		l.mu.Unlock()
+		return nil
+	}
+	l.entries[key] = &dedupEntry{level: level, keyvals: keyvals, start: now}
+	l.mu.Unlock()
+
+	if ok {
+		l.summary(e)
+	}
+	return l.logger.Log(level, keyvals...)
+}
+
+// Close 停止后台清理并输出剩余的汇总日志
+func (l *DedupLogger) Close() error {
+	l.once.Do(func() {
+		close(l.done)
+		l.flush(time.Time{})
+	})
+	return nil
+}
+
+// run 定期输出已过期窗口的汇总日志
+func (l *DedupLogger) run() {
+	ticker := time.NewTicker(l.window)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			l.flush(time.Now().Add(-l.window))
+		case <-l.done:
+			return
+		}
+	}
+}
+
+// flush 输出窗口开始时间早于 before 的汇总日志，before 为零值时输出全部
+func (l *DedupLogger) flush(before time.Time) {
+	var expired []*dedupEntry
+	l.mu.Lock()
+	for key, e := range l.entries {
+		if before.IsZero() || !e.start.After(before) {
+			expired = append(expired, e)
+			delete(l.entries, key)
+		}
+	}
+	l.mu.Unlock()
+
+	for _, e := range expired {
+		l.summary(e)
+	}
+}
+
+// summary 输出一条汇总日志，窗口期内没有重复时不输出
+func (l *DedupLogger) summary(e *dedupEntry) {
+	if e.count == 0 {
+		return
+	}
+	kvs := make([]interface{}, 0, len(e.keyvals)+2)
+	kvs = append(kvs, e.keyvals...)
+	kvs = append(kvs, "repeated", e.count)
+	_ = l.logger.Log(e.level, kvs...)
+}
+
+// dedupKey 生成去重键，优先使用 msg 字段，
+// 避免 trace.id、caller 等每条日志都不同的字段导致无法去重
+func dedupKey(level log.Level, keyvals []interface{}) string {
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		if k, ok := keyvals[i].(string); ok && k == log.DefaultMessageKey {
+			return level.String() + "|" + fmt.Sprint(keyvals[i+1])
+		}
+	}
+	var b strings.Builder
+	b.WriteString(level.String())
+	for _, v := range keyvals {
+		b.WriteString("|")
+		b.WriteString(fmt.Sprint(v))
+	}
+	return b.String()
+}
@@ -0,0 +1,85 @@
+package log
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+func TestDedupLogger(t *testing.T) {
+	rec := &recordLogger{}
+	l := NewDedupLogger(rec, time.Hour, log.LevelWarn)
+	defer l.Close()
+
+	for i := 0; i < 5; i++ {
+		_ = l.Log(log.LevelError, "msg", "db down", "trace.id", i)
+	}
+	if n, _ := rec.records(); n != 1 {
+		t.Fatalf("logged %d entries, want only the first", n)
+	}
+	// 低于去重级别的日志直接透传
+	_ = l.Log(log.LevelInfo, "msg", "db down")
+	_ = l.Log(log.LevelInfo, "msg", "db down")
+	// 消息不同或级别不同的日志不合并
+	_ = l.Log(log.LevelError, "msg", "cache down")
+	_ = l.Log(log.LevelWarn, "msg", "db down")
+	if n, _ := rec.records(); n != 5 {
+		t.Fatalf("logged %d entries, want 5", n)
+	}
+
+	// Close 时输出剩余窗口的汇总
+	_ = l.Close()
+	n, last := rec.records()
+	if n != 6 {
+		t.Fatalf("logged %d entries after Close, want one summary", n)
+	}
+	if last[0] != log.LevelError || value(last, "msg") != "db down" || value(last, "repeated") != 4 {
+		t.Errorf("summary = %v, want db down repeated 4 times", last)
+	}
+}
+
+func TestDedupLoggerWindow(t *testing.T) {
+	rec := &recordLogger{}
+	l := NewDedupLogger(rec, 20*time.Millisecond, log.LevelError)
+	defer l.Close()
+
+	_ = l.Log(log.LevelError, "msg", "db down")
+	_ = l.Log(log.LevelError, "msg", "db down")
+	// 窗口过期后由后台清理输出汇总
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		n, last := rec.records()
+		if n == 2 {
+			if value(last, "repeated") != 1 {
+				t.Fatalf("summary = %v, want repeated 1", last)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("logged %d entries, want a summary after the window", n)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	// 新窗口内的第一条重新输出
+	_ = l.Log(log.LevelError, "msg", "db down")
+	if n, _ := rec.records(); n != 3 {
+		t.Errorf("logged %d entries, want the next window to start over", n)
+	}
+}
+
+func TestDedupKey(t *testing.T) {
+	a := dedupKey(log.LevelError, []interface{}{"caller", "a.go:1", "msg", "x"})
+	b := dedupKey(log.LevelError, []interface{}{"caller", "b.go:2", "msg", "x"})
+	if a != b {
+		t.Errorf("keys with the same msg differ: %q, %q", a, b)
+	}
+	if dedupKey(log.LevelWarn, []interface{}{"msg", "x"}) == a {
+		t.Error("keys ignore the level")
+	}
+	c := dedupKey(log.LevelError, []interface{}{"err", "x"})
+	d := dedupKey(log.LevelError, []interface{}{"err", "y"})
+	if c == d {
+		t.Error("keys without msg ignore the other fields")
+	}
+}
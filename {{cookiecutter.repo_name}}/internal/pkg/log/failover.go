@@ -0,0 +1,105 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// failoverWriter 文件写入失败时降级写入标准输出
+// 磁盘写满、权限变更等导致写入失败时，日志改写到 stdout 并计数，
+// 降级期间按 retry 间隔关闭并重新打开文件尝试恢复，避免日志静默丢失
+type failoverWriter struct {
+	mu       sync.Mutex
+	w        io.Writer
+	fallback io.Writer
+	filename string
+	retry    time.Duration
+
+	failed    bool
+	nextRetry time.Time
+
+	errors metric.Int64Counter
+	lines  metric.Int64Counter
+}
+
+// newFailoverWriter 创建降级写入器
+func newFailoverWriter(w io.Writer, filename string, retry time.Duration) *failoverWriter {
+	if retry <= 0 {
+		retry = 30 * time.Second
+	}
+	meter := otel.Meter("log")
+	errors, _ := meter.Int64Counter(
+		"log.file.write_errors",
+		metric.WithDescription("Number of failed writes to the log file"),
+	)
+	lines, _ := meter.Int64Counter(
+		"log.file.failover_lines",
+		metric.WithDescription("Number of log lines written to stdout while the log file is unavailable"),
+	)
+	return &failoverWriter{
+		w:        w,
+		fallback: os.Stdout,
+		filename: filename,
+		retry:    retry,
+		errors:   errors,
+		lines:    lines,
+	}
+}
+
+// Write 实现 io.Writer 接口，降级写入成功时不返回错误
+func (f *failoverWriter) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	if !f.failed || !now.Before(f.nextRetry) {
+		if f.failed {
+			// 关闭后下次写入时重新打开文件，恢复被删除或权限变更的文件
+			if c, ok := f.w.(io.Closer); ok {
+				_ = c.Close()
+			}
+		}
+		n, err := f.w.Write(p)
+		if err == nil {
+			if f.failed {
+				f.failed = false
+				fmt.Fprintf(os.Stderr, "log: file %s recovered, stop writing to stdout\n", f.filename)
+			}
+			return n, nil
+		}
+		f.record(f.errors)
+		if !f.failed {
+			f.failed = true
+			fmt.Fprintf(os.Stderr, "log: write file %s failed, fall back to stdout: %v\n", f.filename, err)
+		}
+		f.nextRetry = now.Add(f.retry)
+	}
+
+	f.record(f.lines)
+	return f.fallback.Write(p)
+}
+
+// Close 关闭文件写入器
+func (f *failoverWriter) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if c, ok := f.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// record 计数，未配置 MeterProvider 时为空操作
+func (f *failoverWriter) record(c metric.Int64Counter) {
+	if c != nil {
+		c.Add(context.Background(), 1, metric.WithAttributes(attribute.String("file", f.filename)))
+	}
+}
@@ -0,0 +1,223 @@
+package log
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"{{cookiecutter.module_name}}/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+var (
+	_ log.Logger  = (*FluentLogger)(nil)
+	_ SpoolSender = (*FluentLogger)(nil)
+)
+
+// FluentLogger 通过 Fluentd forward 协议（TCP + msgpack）发送日志
+// 日志先写入内存队列由后台协程发送，队列满时丢弃；开启 ack 时等待服务端确认，失败后重连重试
+type FluentLogger struct {
+	addr       string
+	tag        string
+	timeout    time.Duration
+	requireAck bool
+	maxRetry   int
+
+	queue chan map[string]interface{}
+	done  chan struct{}
+	wg    sync.WaitGroup
+	mu    sync.Mutex // 保护 conn，后台协程和 Send 共用连接
+	conn  net.Conn
+}
+
+// NewFluentLogger 创建 Fluentd forward 协议日志输出
+func NewFluentLogger(c *conf.Log_Fluent) *FluentLogger {
+	timeout := 3 * time.Second
+	if c.Timeout != nil {
+		timeout = c.Timeout.AsDuration()
+	}
+	size := int(c.BufferSize)
+	if size <= 0 {
+		size = 8192
+	}
+	l := &FluentLogger{
+		addr:       c.Address,
+		tag:        c.Tag,
+		timeout:    timeout,
+		requireAck: c.RequireAck,
+		maxRetry:   3,
+		queue:      make(chan map[string]interface{}, size),
+		done:       make(chan struct{}),
+	}
+	l.wg.Add(1)
+	go l.run()
+	return l
+}
+
+// Log 实现 log.Logger 接口
+func (l *FluentLogger) Log(level log.Level, keyvals ...interface{}) error {
+	record := fluentRecord(level, keyvals)
+	select {
+	case l.queue <- record:
+		return nil
+	default:
+		return fmt.Errorf("fluent: queue full, log dropped")
+	}
+}
+
+// Send 实现 SpoolSender 接口，以 Forward 模式批量同步发送，开启 ack 时等待服务端确认
+func (l *FluentLogger) Send(_ context.Context, entries []SpoolEntry) error {
+	events := make([]interface{}, 0, len(entries))
+	for _, e := range entries {
+		events = append(events, []interface{}{e.Time.Unix(), fluentRecord(e.Level, e.KeyVals)})
+	}
+	var chunk string
+	option := map[string]interface{}{"size": len(events)}
+	if l.requireAck {
+		chunk = newChunkID()
+		option["chunk"] = chunk
+	}
+	b, err := msgpack.Marshal([]interface{}{l.tag, events, option})
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.write(b, chunk); err != nil {
+		if l.conn != nil {
+			l.conn.Close()
+			l.conn = nil
+		}
+		return err
+	}
+	return nil
+}
+
+// Close 发送队列中剩余的日志并关闭连接
+func (l *FluentLogger) Close() error {
+	close(l.done)
+	l.wg.Wait()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.conn != nil {
+		return l.conn.Close()
+	}
+	return nil
+}
+
+// run 后台发送日志
+func (l *FluentLogger) run() {
+	defer l.wg.Done()
+	for {
+		select {
+		case record := <-l.queue:
+			l.send(record)
+		case <-l.done:
+			for {
+				select {
+				case record := <-l.queue:
+					l.send(record)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// send 发送单条日志，失败时重连重试
+func (l *FluentLogger) send(record map[string]interface{}) {
+	var chunk string
+	option := map[string]interface{}{}
+	if l.requireAck {
+		chunk = newChunkID()
+		option["chunk"] = chunk
+	}
+	b, err := msgpack.Marshal([]interface{}{l.tag, time.Now().Unix(), record, option})
+	if err != nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i := 0; i < l.maxRetry; i++ {
+		if err = l.write(b, chunk); err == nil {
+			return
+		}
+		if l.conn != nil {
+			l.conn.Close()
+			l.conn = nil
+		}
+		time.Sleep(time.Duration(i+1) * 100 * time.Millisecond)
+	}
+}
+
+// write 写入一条消息，开启 ack 时等待服务端返回相同的 chunk
+func (l *FluentLogger) write(b []byte, chunk string) error {
+	if l.conn == nil {
+		conn, err := net.DialTimeout("tcp", l.addr, l.timeout)
+		if err != nil {
+			return err
+		}
+		l.conn = conn
+	}
+	if err := l.conn.SetDeadline(time.Now().Add(l.timeout)); err != nil {
+		return err
+	}
+	if _, err := l.conn.Write(b); err != nil {
+		return err
+	}
+	if chunk == "" {
+		return nil
+	}
+
+	var resp struct {
+		Ack string `msgpack:"ack"`
+	}
+	if err := msgpack.NewDecoder(l.conn).Decode(&resp); err != nil {
+		return err
+	}
+	if resp.Ack != chunk {
+		return fmt.Errorf("fluent: ack mismatch, want %s got %s", chunk, resp.Ack)
+	}
+	return nil
+}
+
+// fluentRecord 将日志字段转换为 Fluentd 记录
+func fluentRecord(level log.Level, keyvals []interface{}) map[string]interface{} {
+	record := make(map[string]interface{}, len(keyvals)/2+1)
+	record[log.LevelKey] = level.String()
+	for i := 0; i < len(keyvals); i += 2 {
+		var v interface{}
+		if i+1 < len(keyvals) {
+			v = keyvals[i+1]
+		}
+		record[fmt.Sprint(keyvals[i])] = fluentValue(v)
+	}
+	return record
+}
+
+// fluentValue 转换 msgpack 不支持的值类型
+func fluentValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case nil, string, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return v
+	case error:
+		return v.Error()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// newChunkID 生成 ack 使用的 chunk 标识
+func newChunkID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return base64.StdEncoding.EncodeToString(b)
+}
@@ -0,0 +1,252 @@
+package log
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"{{cookiecutter.module_name}}/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const slowQueryStartKey = "slow_query:start"
+
+var _ gorm.Plugin = (*SlowQueryPlugin)(nil)
+
+// SlowQueryOption 慢查询插件配置项
+type SlowQueryOption func(*SlowQueryPlugin)
+
+// WithExplain 慢 SELECT 执行 EXPLAIN 并将执行计划写入慢日志和当前 span，
+// EXPLAIN 按每分钟次数限流并有独立超时，未启用时不生效
+func WithExplain(c *conf.Log_Slow_Explain) SlowQueryOption {
+	return func(p *SlowQueryPlugin) {
+		if !c.GetEnable() {
+			return
+		}
+		e := &explainer{
+			rate:    6,
+			timeout: time.Second,
+			maxSize: 4096,
+		}
+		if c.RateLimit > 0 {
+			e.rate = int(c.RateLimit)
+		}
+		if c.Timeout != nil {
+			e.timeout = c.Timeout.AsDuration()
+		}
+		if c.MaxSize > 0 {
+			e.maxSize = int(c.MaxSize)
+		}
+		e.tokens = float64(e.rate)
+		e.last = time.Now()
+		p.explain = e
+	}
+}
+
+// SlowQueryPlugin GORM 慢查询插件，耗时超过 threshold 的 SQL 写入慢日志
+// 使用: db.Use(log.NewSlowQueryPlugin(slowLogger, threshold, log.WithExplain(c.Slow.Explain)))
+type SlowQueryPlugin struct {
+	logger    log.Logger
+	threshold time.Duration
+	explain   *explainer
+}
+
+// NewSlowQueryPlugin 创建 GORM 慢查询插件
+func NewSlowQueryPlugin(logger log.Logger, threshold time.Duration, opts ...SlowQueryOption) *SlowQueryPlugin {
+	p := &SlowQueryPlugin{logger: logger, threshold: threshold}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Name 实现 gorm.Plugin 接口
+func (p *SlowQueryPlugin) Name() string {
+	return "slow_query"
+}
+
+// Initialize 实现 gorm.Plugin 接口，在各类操作前后注册计时回调
+func (p *SlowQueryPlugin) Initialize(db *gorm.DB) error {
+	type register func(name string, fn func(*gorm.DB)) error
+
+	cb := db.Callback()
+	hooks := []struct {
+		name          string
+		before, after register
+	}{
+		{"create", cb.Create().Before("gorm:create").Register, cb.Create().After("gorm:create").Register},
+		{"query", cb.Query().Before("gorm:query").Register, cb.Query().After("gorm:query").Register},
+		{"update", cb.Update().Before("gorm:update").Register, cb.Update().After("gorm:update").Register},
+		{"delete", cb.Delete().Before("gorm:delete").Register, cb.Delete().After("gorm:delete").Register},
+		{"row", cb.Row().Before("gorm:row").Register, cb.Row().After("gorm:row").Register},
+		{"raw", cb.Raw().Before("gorm:raw").Register, cb.Raw().After("gorm:raw").Register},
+	}
+	for _, h := range hooks {
+		if err := h.before("slow_query:before_"+h.name, p.before); err != nil {
+			return err
+		}
+		if err := h.after("slow_query:after_"+h.name, p.after); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// before 记录 SQL 开始时间
+func (p *SlowQueryPlugin) before(db *gorm.DB) {
+	db.InstanceSet(slowQueryStartKey, time.Now())
+}
+
+// after 计算 SQL 耗时，超过阈值时记录慢查询
+func (p *SlowQueryPlugin) after(db *gorm.DB) {
+	v, ok := db.InstanceGet(slowQueryStartKey)
+	if !ok {
+		return
+	}
+	start, ok := v.(time.Time)
+	if !ok {
+		return
+	}
+	elapsed := time.Since(start)
+	if elapsed < p.threshold {
+		return
+	}
+
+	kvs := []interface{}{
+		"msg", "slow query",
+		"latency", elapsed.Seconds(),
+		"threshold", p.threshold.Seconds(),
+		"table", db.Statement.Table,
+		"rows", db.Statement.RowsAffected,
+		"sql", db.Dialector.Explain(db.Statement.SQL.String(), db.Statement.Vars...),
+	}
+	if db.Error != nil {
+		kvs = append(kvs, "error", db.Error.Error())
+	}
+	if p.explain != nil {
+		if plan, err := p.explain.run(db); err != nil {
+			kvs = append(kvs, "plan_error", err.Error())
+		} else if plan != "" {
+			kvs = append(kvs, "plan", plan)
+			trace.SpanFromContext(db.Statement.Context).AddEvent("slow query", trace.WithAttributes(
+				attribute.String("db.statement", db.Statement.SQL.String()),
+				attribute.String("db.plan", plan),
+			))
+		}
+	}
+	_ = log.WithContext(db.Statement.Context, p.logger).Log(log.LevelWarn, kvs...)
+}
+
+// explainer 慢查询执行计划采集，令牌桶限流
+type explainer struct {
+	rate    int
+	timeout time.Duration
+	maxSize int
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// allow 获取一个令牌
+func (e *explainer) allow() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	now := time.Now()
+	e.tokens += now.Sub(e.last).Minutes() * float64(e.rate)
+	if e.tokens > float64(e.rate) {
+		e.tokens = float64(e.rate)
+	}
+	e.last = now
+	if e.tokens < 1 {
+		return false
+	}
+	e.tokens--
+	return true
+}
+
+// run 对慢 SELECT 执行 EXPLAIN，非 SELECT、不支持的数据库或被限流时返回空
+// 使用连接池而不是当前事务执行，EXPLAIN 失败不会影响事务状态
+func (e *explainer) run(db *gorm.DB) (string, error) {
+	query := strings.TrimSpace(db.Statement.SQL.String())
+	head := strings.ToUpper(query[:min(len(query), 6)])
+	if head != "SELECT" && !strings.HasPrefix(head, "WITH") {
+		return "", nil
+	}
+	var prefix string
+	switch db.Dialector.Name() {
+	case "mysql", "postgres":
+		prefix = "EXPLAIN "
+	case "sqlite":
+		prefix = "EXPLAIN QUERY PLAN "
+	default:
+		return "", nil
+	}
+	if !e.allow() {
+		return "", nil
+	}
+
+	ctx := context.Background()
+	if db.Statement.Context != nil {
+		ctx = context.WithoutCancel(db.Statement.Context)
+	}
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+	rows, err := db.Config.ConnPool.QueryContext(ctx, prefix+query, db.Statement.Vars...)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+	return formatPlan(rows, e.maxSize)
+}
+
+// formatPlan 将执行计划格式化为文本，每行一条，多列时以 col=value 形式输出
+func formatPlan(rows *sql.Rows, maxSize int) (string, error) {
+	cols, err := rows.Columns()
+	if err != nil {
+		return "", err
+	}
+	values := make([]sql.NullString, len(cols))
+	dest := make([]interface{}, len(cols))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	var b strings.Builder
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return "", err
+		}
+		if b.Len() > 0 {
+			b.WriteByte('\n')
+		}
+		for i, v := range values {
+			if len(cols) == 1 {
+				b.WriteString(v.String)
+				break
+			}
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+			fmt.Fprintf(&b, "%s=%s", cols[i], v.String)
+		}
+		if maxSize > 0 && b.Len() > maxSize {
+			break
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+	plan := b.String()
+	if maxSize > 0 && len(plan) > maxSize {
+		plan = plan[:maxSize] + "...(truncated)"
+	}
+	return plan, nil
+}
@@ -0,0 +1,100 @@
+package log
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+var _ log.Logger = (*HookLogger)(nil)
+
+// Hook 日志钩子，在日志输出后调用，可用于告警、统计等
+// 钩子在调用日志的协程中同步执行，耗时操作需要自行异步处理
+type Hook func(level log.Level, msg string, fields map[string]interface{})
+
+var (
+	hooksMu sync.RWMutex
+	hooks   []hookEntry
+)
+
+// hookEntry 全局注册的钩子及其触发的最低级别
+type hookEntry struct {
+	level log.Level
+	hook  Hook
+}
+
+// RegisterHook 注册全局日志钩子，level 及以上级别的日志触发，对所有通过 NewLogger 创建的日志器生效
+func RegisterHook(level log.Level, hook Hook) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	hooks = append(hooks, hookEntry{level: level, hook: hook})
+}
+
+// HookLogger 日志钩子包装器
+type HookLogger struct {
+	logger log.Logger
+	hooks  []hookEntry
+}
+
+// NewHookLogger 创建日志钩子包装器，除全局钩子外还可以通过 AddHook 添加只对该日志器生效的钩子
+func NewHookLogger(logger log.Logger) *HookLogger {
+	return &HookLogger{logger: logger}
+}
+
+// AddHook 添加钩子，需要在日志器开始使用前调用
+func (l *HookLogger) AddHook(level log.Level, hook Hook) {
+	l.hooks = append(l.hooks, hookEntry{level: level, hook: hook})
+}
+
+// Log 实现 log.Logger 接口
+func (l *HookLogger) Log(level log.Level, keyvals ...interface{}) error {
+	err := l.logger.Log(level, keyvals...)
+
+	hooksMu.RLock()
+	global := hooks
+	hooksMu.RUnlock()
+	if len(global) == 0 && len(l.hooks) == 0 {
+		return err
+	}
+
+	var (
+		msg    string
+		fields map[string]interface{}
+	)
+	fire := func(e hookEntry) {
+		if level < e.level {
+			return
+		}
+		if fields == nil {
+			msg, fields = hookFields(keyvals)
+		}
+		e.hook(level, msg, fields)
+	}
+	for _, e := range global {
+		fire(e)
+	}
+	for _, e := range l.hooks {
+		fire(e)
+	}
+	return err
+}
+
+// hookFields 拆分出 msg 字段和其余字段
+func hookFields(keyvals []interface{}) (string, map[string]interface{}) {
+	var msg string
+	fields := make(map[string]interface{}, len(keyvals)/2)
+	for i := 0; i < len(keyvals); i += 2 {
+		key := fmt.Sprint(keyvals[i])
+		var v interface{}
+		if i+1 < len(keyvals) {
+			v = keyvals[i+1]
+		}
+		if key == log.DefaultMessageKey {
+			msg = fmt.Sprint(v)
+			continue
+		}
+		fields[key] = v
+	}
+	return msg, fields
+}
@@ -0,0 +1,130 @@
+package log
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"{{cookiecutter.module_name}}/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+var _ log.Logger = (*JournaldLogger)(nil)
+
+// journalSocket systemd-journald 原生协议套接字
+const journalSocket = "/run/systemd/journal/socket"
+
+// journalPriority 日志级别到 syslog PRIORITY 的映射
+var journalPriority = map[log.Level]string{
+	log.LevelDebug: "7",
+	log.LevelInfo:  "6",
+	log.LevelWarn:  "4",
+	log.LevelError: "3",
+	log.LevelFatal: "2",
+}
+
+// JournaldLogger 通过原生协议写入 systemd-journald，仅支持 Linux
+// msg 映射为 MESSAGE，级别映射为 PRIORITY，caller 映射为 CODE_FILE/CODE_LINE，
+// 其他字段名转换为大写并将非法字符替换为下划线，如 trace.id -> TRACE_ID，可通过 journalctl TRACE_ID=xxx 过滤
+type JournaldLogger struct {
+	conn       journalConn
+	identifier string
+	level      log.Level
+}
+
+// NewJournaldLogger 创建 journald 日志输出，identifier 默认为 SYSLOG_IDENTIFIER
+func NewJournaldLogger(c *conf.Log_Journald, identifier string) (*JournaldLogger, error) {
+	conn, err := dialJournal(journalSocket)
+	if err != nil {
+		return nil, err
+	}
+	if c.Identifier != "" {
+		identifier = c.Identifier
+	}
+	level := log.LevelDebug
+	if c.Level != "" {
+		level = GetLogLevel(c.Level)
+	}
+	return &JournaldLogger{conn: conn, identifier: identifier, level: level}, nil
+}
+
+// Log 实现 log.Logger 接口
+func (l *JournaldLogger) Log(level log.Level, keyvals ...interface{}) error {
+	if level < l.level {
+		return nil
+	}
+	var buf bytes.Buffer
+	writeJournalField(&buf, "PRIORITY", journalPriority[level])
+	if l.identifier != "" {
+		writeJournalField(&buf, "SYSLOG_IDENTIFIER", l.identifier)
+	}
+	var hasMessage bool
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		key := fmt.Sprint(keyvals[i])
+		val := fmt.Sprint(keyvals[i+1])
+		switch key {
+		case log.DefaultMessageKey:
+			writeJournalField(&buf, "MESSAGE", val)
+			hasMessage = true
+		case "caller":
+			if idx := strings.LastIndexByte(val, ':'); idx > 0 {
+				writeJournalField(&buf, "CODE_FILE", val[:idx])
+				writeJournalField(&buf, "CODE_LINE", val[idx+1:])
+				continue
+			}
+			writeJournalField(&buf, "CODE_FILE", val)
+		default:
+			writeJournalField(&buf, journalFieldName(key), val)
+		}
+	}
+	// journald 要求 MESSAGE 字段，没有 msg 时使用级别名称
+	if !hasMessage {
+		writeJournalField(&buf, "MESSAGE", level.String())
+	}
+	return l.conn.send(buf.Bytes())
+}
+
+// Close 关闭套接字
+func (l *JournaldLogger) Close() error {
+	return l.conn.close()
+}
+
+// writeJournalField 按原生协议写入字段，值包含换行时使用二进制格式
+func writeJournalField(buf *bytes.Buffer, name, value string) {
+	buf.WriteString(name)
+	if !strings.ContainsRune(value, '\n') {
+		buf.WriteByte('=')
+		buf.WriteString(value)
+		buf.WriteByte('\n')
+		return
+	}
+	buf.WriteByte('\n')
+	_ = binary.Write(buf, binary.LittleEndian, uint64(len(value)))
+	buf.WriteString(value)
+	buf.WriteByte('\n')
+}
+
+// journalFieldName 转换为合法的 journal 字段名: 大写字母、数字和下划线，不以下划线或数字开头，最长 64 字符
+func journalFieldName(key string) string {
+	b := make([]byte, 0, len(key))
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		switch {
+		case c >= 'a' && c <= 'z':
+			b = append(b, c-'a'+'A')
+		case c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+			b = append(b, c)
+		default:
+			b = append(b, '_')
+		}
+	}
+	if len(b) == 0 || b[0] == '_' || (b[0] >= '0' && b[0] <= '9') {
+		b = append([]byte("F_"), b...)
+	}
+	if len(b) > 64 {
+		b = b[:64]
+	}
+	return string(b)
+}
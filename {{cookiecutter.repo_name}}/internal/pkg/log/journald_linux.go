@@ -0,0 +1,57 @@
+//go:build linux
+
+package log
+
+import (
+	"errors"
+	"net"
+	"os"
+	"syscall"
+)
+
+// journalConn journald 套接字连接
+type journalConn struct {
+	conn *net.UnixConn
+	addr *net.UnixAddr
+}
+
+// dialJournal 连接 journald 套接字
+func dialJournal(socket string) (journalConn, error) {
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Net: "unixgram"})
+	if err != nil {
+		return journalConn{}, err
+	}
+	addr := &net.UnixAddr{Name: socket, Net: "unixgram"}
+	return journalConn{conn: conn, addr: addr}, nil
+}
+
+// send 发送一条日志，超出数据报大小限制时写入临时文件并传递文件描述符
+func (c journalConn) send(b []byte) error {
+	_, _, err := c.conn.WriteMsgUnix(b, nil, c.addr)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, syscall.EMSGSIZE) && !errors.Is(err, syscall.ENOBUFS) {
+		return err
+	}
+
+	f, err := os.CreateTemp("/dev/shm", "journal.*")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	// 删除文件名，journald 通过文件描述符读取内容
+	if err := os.Remove(f.Name()); err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		return err
+	}
+	_, _, err = c.conn.WriteMsgUnix(nil, syscall.UnixRights(int(f.Fd())), c.addr)
+	return err
+}
+
+// close 关闭套接字
+func (c journalConn) close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,21 @@
+//go:build !linux
+
+package log
+
+import "errors"
+
+// journalConn journald 套接字连接，非 Linux 系统不支持
+type journalConn struct{}
+
+// dialJournal 非 Linux 系统不支持 journald
+func dialJournal(string) (journalConn, error) {
+	return journalConn{}, errors.New("journald is only supported on linux")
+}
+
+func (journalConn) send([]byte) error {
+	return nil
+}
+
+func (journalConn) close() error {
+	return nil
+}
@@ -0,0 +1,59 @@
+package log
+
+import (
+	"io"
+	"os"
+	"sync"
+)
+
+// lockedWriter 写入前加进程间文件锁，保证多个进程写同一日志文件时每条日志完整
+type lockedWriter struct {
+	mu       sync.Mutex
+	w        io.Writer
+	lockPath string
+	lockFile *os.File
+}
+
+// newLockedWriter 创建加锁写入器，lockPath 为锁文件路径
+func newLockedWriter(w io.Writer, lockPath string) *lockedWriter {
+	return &lockedWriter{w: w, lockPath: lockPath}
+}
+
+// Write 实现 io.Writer 接口
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.lockFile == nil {
+		f, err := os.OpenFile(l.lockPath, os.O_CREATE|os.O_RDWR, 0644)
+		if err != nil {
+			return 0, err
+		}
+		l.lockFile = f
+	}
+
+	if err := lockFile(l.lockFile); err != nil {
+		return 0, err
+	}
+	defer unlockFile(l.lockFile)
+
+	return l.w.Write(p)
+}
+
+// Close 关闭写入器和锁文件，下次写入时重新打开
+func (l *lockedWriter) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var err error
+	if c, ok := l.w.(io.Closer); ok {
+		err = c.Close()
+	}
+	if l.lockFile != nil {
+		if e := l.lockFile.Close(); e != nil && err == nil {
+			err = e
+		}
+		l.lockFile = nil
+	}
+	return err
+}
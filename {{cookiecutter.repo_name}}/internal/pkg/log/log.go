@@ -14,7 +14,6 @@ import (
 	"github.com/go-kratos/kratos/v2/log"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
-	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 // NewLogger 创建一个新的日志记录器
@@ -26,19 +25,122 @@ func NewLogger(c *conf.Log) log.Logger {
 
 	format := strings.ToLower(c.Format)
 
+	var (
+		logger log.Logger
+		file   io.Closer
+	)
 	switch format {
 	case "json":
-		return newJSONLogger(c)
+		logger, file = newJSONLogger(c)
 	case "text", "":
-		return newTextLogger(c)
+		logger, file = newTextLogger(c)
 	default:
 		// 默认使用文本格式
-		return newTextLogger(c)
+		logger, file = newTextLogger(c)
 	}
+
+	// 错误日志附加调用堆栈
+	if c.Stacktrace {
+		logger = NewStackLogger(logger, log.LevelError)
+	}
+
+	// 日志钩子，错误日志告警
+	var closers []io.Closer
+	hookLogger := NewHookLogger(logger)
+	if c.GetAlert().GetEnable() {
+		alert := NewWebhookAlert(c.Alert)
+		level := log.LevelError
+		if c.Alert.Level != "" {
+			level = GetLogLevel(c.Alert.Level)
+		}
+		hookLogger.AddHook(level, alert.Fire)
+		closers = append(closers, alert)
+	}
+	logger = hookLogger
+
+	// 错误日志按调用位置限流
+	if c.ErrorRateLimit > 0 {
+		logger = NewRateLimitLogger(logger, int(c.ErrorRateLimit), int(c.ErrorBurst), log.LevelError)
+	}
+
+	// 重复日志抑制
+	if c.DedupWindow != nil && c.DedupWindow.AsDuration() > 0 {
+		level := log.LevelError
+		if c.DedupLevel != "" {
+			level = GetLogLevel(c.DedupLevel)
+		}
+		dedup := NewDedupLogger(logger, c.DedupWindow.AsDuration(), level)
+		// 先输出去重汇总日志，再关闭告警
+		closers = append([]io.Closer{dedup}, closers...)
+		logger = dedup
+	}
+	// 去重汇总和告警输出完成后再关闭日志文件
+	if file != nil {
+		closers = append(closers, file)
+	}
+	if len(closers) > 0 {
+		return &closableLogger{Logger: logger, closers: closers}
+	}
+	return logger
+}
+
+// closableLogger 持有需要释放资源的日志器，Close 时按顺序释放
+type closableLogger struct {
+	log.Logger
+	closers []io.Closer
+}
+
+// Close 实现 io.Closer 接口
+func (l *closableLogger) Close() error {
+	var err error
+	for _, c := range l.closers {
+		if e := c.Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// NewAccessLogger 创建访问日志记录器，写入独立的访问日志文件
+func NewAccessLogger(c *conf.Log) log.Logger {
+	return newChannelLogger(c, c.Access.Filename)
+}
+
+// NewSlowLogger 创建慢日志记录器，写入独立的慢日志文件
+func NewSlowLogger(c *conf.Log) log.Logger {
+	return newChannelLogger(c, c.Slow.Filename)
 }
 
-// newJSONLogger 创建JSON格式的日志记录器（使用zap）
-func newJSONLogger(c *conf.Log) log.Logger {
+// NewAuditLogger 创建审计日志记录器，写入独立的审计日志文件
+func NewAuditLogger(c *conf.Log) log.Logger {
+	return newChannelLogger(c, c.Audit.Filename)
+}
+
+// newChannelLogger 创建写入独立文件的日志记录器，轮转参数与应用日志一致
+func newChannelLogger(c *conf.Log, filename string) log.Logger {
+	return NewLogger(&conf.Log{
+		Level:         "info",
+		Filename:      filename,
+		MaxSize:       c.MaxSize,
+		MaxAge:        c.MaxAge,
+		MaxBackups:    c.MaxBackups,
+		Compress:      c.Compress,
+		DailyDir:      c.DailyDir,
+		Format:        c.Format,
+		MultiProcess:  c.MultiProcess,
+		TimeFormat:    c.TimeFormat,
+		TimeZone:      c.TimeZone,
+		FileMode:      c.FileMode,
+		DirMode:       c.DirMode,
+		Uid:           c.Uid,
+		Gid:           c.Gid,
+		FailoverRetry: c.FailoverRetry,
+		Environment:   c.Environment,
+	})
+}
+
+// newJSONLogger 创建JSON格式的日志记录器（使用zap），未写入文件时返回的 closer 为 nil
+func newJSONLogger(c *conf.Log) (log.Logger, io.Closer) {
 	// 配置编码器为JSON格式
 	encoderConfig := zap.NewProductionEncoderConfig()
 	encoderConfig.TimeKey = "timestamp"
@@ -46,9 +148,10 @@ func newJSONLogger(c *conf.Log) log.Logger {
 	encoderConfig.MessageKey = "msg"
 	// 禁用zap自带的caller，使用Kratos的caller
 	encoderConfig.CallerKey = ""
-	// 使用自定义时间格式，移除时区和T分隔符
+	// 使用配置的时间格式和时区
+	layout, loc := timeFormat(c), timeLocation(c)
 	encoderConfig.EncodeTime = func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
-		enc.AppendString(t.Format("2006-01-02 15:04:05.000000"))
+		enc.AppendString(t.In(loc).Format(layout))
 	}
 	encoderConfig.EncodeLevel = zapcore.LowercaseLevelEncoder
 
@@ -64,23 +167,10 @@ func newJSONLogger(c *conf.Log) log.Logger {
 	}
 
 	// 文件输出
+	var file io.WriteCloser
 	if c.Filename != "" {
-		// 确保日志目录存在
-		logDir := filepath.Dir(c.Filename)
-		if err := os.MkdirAll(logDir, 0755); err != nil {
-			panic(fmt.Sprintf("failed to create log directory: %v", err))
-		}
-
-		// 配置日志轮转
-		lumberjackLogger := &lumberjack.Logger{
-			Filename:   c.Filename,
-			MaxSize:    int(c.MaxSize), // MB
-			MaxAge:     int(c.MaxAge),  // days
-			MaxBackups: int(c.MaxBackups),
-			Compress:   c.Compress,
-		}
-
-		fileCore := zapcore.NewCore(encoder, zapcore.AddSync(lumberjackLogger), getZapLevel(c.Level))
+		file = newFileWriter(c)
+		fileCore := zapcore.NewCore(encoder, zapcore.AddSync(file), getZapLevel(c.Level))
 		cores = append(cores, fileCore)
 	}
 
@@ -94,13 +184,22 @@ func newJSONLogger(c *conf.Log) log.Logger {
 	core := zapcore.NewTee(cores...)
 	zapLogger := zap.New(core)
 
+	// 服务名、版本、主机名、进程号、环境作为顶层字段
+	kvs := resourceFields(c)
+	fields := make([]zap.Field, 0, len(kvs)/2)
+	for i := 0; i+1 < len(kvs); i += 2 {
+		fields = append(fields, zap.Any(kvs[i].(string), kvs[i+1]))
+	}
+	zapLogger = zapLogger.With(fields...)
+
 	// 包装为Kratos Logger
-	return zaplog.NewLogger(zapLogger)
+	return zaplog.NewLogger(zapLogger), file
 }
 
-// newTextLogger 创建文本格式的日志记录器（使用Kratos标准实现）
-func newTextLogger(c *conf.Log) log.Logger {
+// newTextLogger 创建文本格式的日志记录器，格式与Kratos标准实现一致，未写入文件时返回的 closer 为 nil
+func newTextLogger(c *conf.Log) (log.Logger, io.Closer) {
 	var writers []io.Writer
+	var file io.WriteCloser
 
 	// 如果启用控制台输出
 	if c.Console {
@@ -109,22 +208,8 @@ func newTextLogger(c *conf.Log) log.Logger {
 
 	// 如果配置了文件输出
 	if c.Filename != "" {
-		// 确保日志目录存在
-		logDir := filepath.Dir(c.Filename)
-		if err := os.MkdirAll(logDir, 0755); err != nil {
-			panic(fmt.Sprintf("failed to create log directory: %v", err))
-		}
-
-		// 使用lumberjack进行日志轮转
-		lumberjackLogger := &lumberjack.Logger{
-			Filename:   c.Filename,
-			MaxSize:    int(c.MaxSize), // MB
-			MaxAge:     int(c.MaxAge),  // days
-			MaxBackups: int(c.MaxBackups),
-			Compress:   c.Compress,
-		}
-
-		writers = append(writers, lumberjackLogger)
+		file = newFileWriter(c)
+		writers = append(writers, file)
 	}
 
 	// 如果没有配置任何输出，默认使用标准输出
@@ -140,7 +225,87 @@ func newTextLogger(c *conf.Log) log.Logger {
 		writer = io.MultiWriter(writers...)
 	}
 
-	return log.NewStdLogger(writer)
+	// 使用与JSON格式一致的时间格式和时区
+	// 服务名、版本、主机名、进程号、环境作为顶层字段
+	return NewTextLogger(writer, timeFormat(c), timeLocation(c)).WithFields(resourceFields(c)...), file
+}
+
+// timeFormat 获取日志时间格式，支持 Go 时间布局或 RFC3339、RFC3339Nano 等名称
+// 默认 2006-01-02 15:04:05.000000
+func timeFormat(c *conf.Log) string {
+	switch strings.ToUpper(c.TimeFormat) {
+	case "":
+		return "2006-01-02 15:04:05.000000"
+	case "RFC3339":
+		return time.RFC3339
+	case "RFC3339NANO":
+		return time.RFC3339Nano
+	case "ISO8601":
+		return "2006-01-02T15:04:05.000Z0700"
+	default:
+		return c.TimeFormat
+	}
+}
+
+// timeLocation 获取日志时区，如 UTC、Asia/Shanghai，默认本地时区
+func timeLocation(c *conf.Log) *time.Location {
+	if c.TimeZone == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(c.TimeZone)
+	if err != nil {
+		panic(fmt.Sprintf("failed to load log time zone: %v", err))
+	}
+	return loc
+}
+
+// newFileWriter 创建带轮转的日志文件写入器，Close 时关闭文件并停止后台压缩清理
+func newFileWriter(c *conf.Log) io.WriteCloser {
+	filename := logFilename(c)
+
+	// 确保日志目录存在，并按配置的权限和所有者创建日志文件
+	perm := newFilePerm(c)
+	logDir := filepath.Dir(filename)
+	if err := perm.mkdir(logDir); err != nil {
+		panic(fmt.Sprintf("failed to create log directory: %v", err))
+	}
+	if err := perm.create(filename); err != nil {
+		panic(fmt.Sprintf("failed to create log file: %v", err))
+	}
+
+	// 日志轮转，轮转后的新文件同样按配置的权限和所有者创建
+	opts := []RotateOption{WithFileMode(perm.fileMode), WithDirMode(perm.dirMode), WithOwner(perm.uid, perm.gid)}
+	if c.DailyDir {
+		opts = append(opts, WithDailyDir())
+	}
+	// 开启归档时备份文件上传后再清理
+	if c.GetArchive().GetEnable() {
+		opts = append(opts, WithArchive())
+	}
+	lock := strings.ToLower(c.MultiProcess) == "lock"
+	if lock {
+		opts = append(opts, WithSharedFile())
+	}
+	var writer io.Writer = NewRotateWriter(filename, int(c.MaxSize), int(c.MaxAge), int(c.MaxBackups), c.Compress, opts...)
+
+	// 多进程写同一文件时，每次写入前加文件锁，锁内检查其他进程是否已轮转
+	if lock {
+		writer = newLockedWriter(writer, filename+".lock")
+	}
+
+	// 写入失败时降级到标准输出
+	return newFailoverWriter(writer, filename, c.FailoverRetry.AsDuration())
+}
+
+// logFilename 获取实际写入的日志文件名
+// 多进程按 pid 区分时文件名追加进程号，如 app.log -> app.12345.log，
+// 同一服务的日志文件仍以相同前缀开头，便于按前缀合并采集
+func logFilename(c *conf.Log) string {
+	if strings.ToLower(c.MultiProcess) != "pid" {
+		return c.Filename
+	}
+	ext := filepath.Ext(c.Filename)
+	return fmt.Sprintf("%s.%d%s", strings.TrimSuffix(c.Filename, ext), os.Getpid(), ext)
 }
 
 // getZapLevel 将字符串级别转换为zap级别
@@ -0,0 +1,43 @@
+package log
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"{{cookiecutter.module_name}}/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+func TestNewLoggerClosesFile(t *testing.T) {
+	if _, err := os.Stat("/proc/self/fd"); err != nil {
+		t.Skip("/proc/self/fd not available")
+	}
+	openFds := func() int {
+		entries, err := os.ReadDir("/proc/self/fd")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return len(entries)
+	}
+
+	dir := t.TempDir()
+	for _, format := range []string{"text", "json"} {
+		before := openFds()
+		l := NewLogger(&conf.Log{Filename: filepath.Join(dir, format+".log"), Format: format, MultiProcess: "lock"})
+		_ = l.Log(log.LevelInfo, "msg", "hello")
+		c, ok := l.(io.Closer)
+		if !ok {
+			t.Fatalf("%s logger with a file does not implement io.Closer", format)
+		}
+		if err := c.Close(); err != nil {
+			t.Fatal(err)
+		}
+		// 日志文件和锁文件均已关闭，重新加载配置时不泄漏文件描述符
+		if after := openFds(); after != before {
+			t.Errorf("%s logger: %d fds open after Close, want %d", format, after, before)
+		}
+	}
+}
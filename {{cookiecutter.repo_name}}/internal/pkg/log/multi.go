@@ -0,0 +1,38 @@
+package log
+
+import (
+	"errors"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+var _ log.Logger = (*multiLogger)(nil)
+
+// multiLogger 将日志同时写入多个日志器
+type multiLogger []log.Logger
+
+// Multi 将日志依次写入每个日志器，某个日志器出错不影响其余日志器，返回合并后的错误
+func Multi(loggers ...log.Logger) log.Logger {
+	var ls multiLogger
+	for _, l := range loggers {
+		switch v := l.(type) {
+		case nil:
+		case multiLogger:
+			ls = append(ls, v...)
+		default:
+			ls = append(ls, v)
+		}
+	}
+	return ls
+}
+
+// Log 实现 log.Logger 接口
+func (m multiLogger) Log(level log.Level, keyvals ...interface{}) error {
+	var errs []error
+	for _, l := range m {
+		if err := l.Log(level, keyvals...); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,154 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"{{cookiecutter.module_name}}/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+var (
+	_ log.Logger  = (*OTLPLogger)(nil)
+	_ SpoolSender = (*OTLPLogger)(nil)
+)
+
+// OTLPLogger 通过 OTLP/gRPC 将日志导出到 OpenTelemetry Collector
+type OTLPLogger struct {
+	provider *sdklog.LoggerProvider
+	logger   otellog.Logger
+	exporter sdklog.Exporter
+}
+
+// NewOTLPLogger 创建 OTLP 日志导出器
+// name、version、id 作为 service.name、service.version、service.instance.id 资源属性
+func NewOTLPLogger(c *conf.Log_OTLP, name, version, id string) (*OTLPLogger, error) {
+	opts := []otlploggrpc.Option{
+		otlploggrpc.WithEndpoint(c.Endpoint),
+	}
+	if c.Insecure {
+		opts = append(opts, otlploggrpc.WithInsecure())
+	}
+	if len(c.Headers) > 0 {
+		opts = append(opts, otlploggrpc.WithHeaders(c.Headers))
+	}
+	if c.Timeout != nil {
+		opts = append(opts, otlploggrpc.WithTimeout(c.Timeout.AsDuration()))
+	}
+	exporter, err := otlploggrpc.New(context.Background(), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	attrs := []attribute.KeyValue{
+		attribute.String("service.name", name),
+		attribute.String("service.version", version),
+		attribute.String("service.instance.id", id),
+	}
+	for k, v := range c.Attributes {
+		attrs = append(attrs, attribute.String(k, v))
+	}
+
+	provider := sdklog.NewLoggerProvider(
+		sdklog.WithResource(resource.NewSchemaless(attrs...)),
+		sdklog.WithProcessor(&spoolProcessor{Processor: sdklog.NewBatchProcessor(exporter)}),
+	)
+	return &OTLPLogger{
+		provider: provider,
+		logger:   provider.Logger(name),
+		exporter: exporter,
+	}, nil
+}
+
+// Log 实现 log.Logger 接口
+func (l *OTLPLogger) Log(level log.Level, keyvals ...interface{}) error {
+	l.logger.Emit(context.Background(), otelRecord(time.Now(), level, keyvals))
+	return nil
+}
+
+// Send 实现 SpoolSender 接口，同步导出一批日志并返回导出结果
+func (l *OTLPLogger) Send(ctx context.Context, entries []SpoolEntry) error {
+	var batch spoolBatch
+	bctx := context.WithValue(ctx, spoolBatchKey{}, &batch)
+	for _, e := range entries {
+		l.logger.Emit(bctx, otelRecord(e.Time, e.Level, e.KeyVals))
+	}
+	return l.exporter.Export(ctx, batch.records)
+}
+
+// otelRecord 将日志字段转换为 OpenTelemetry 日志记录
+func otelRecord(t time.Time, level log.Level, keyvals []interface{}) otellog.Record {
+	var record otellog.Record
+	record.SetTimestamp(t)
+	record.SetObservedTimestamp(time.Now())
+	record.SetSeverity(otelSeverity(level))
+	record.SetSeverityText(level.String())
+
+	for i := 0; i < len(keyvals); i += 2 {
+		key := fmt.Sprint(keyvals[i])
+		var val interface{}
+		if i+1 < len(keyvals) {
+			val = keyvals[i+1]
+		}
+		if key == log.DefaultMessageKey {
+			record.SetBody(otellog.StringValue(fmt.Sprint(val)))
+			continue
+		}
+		record.AddAttributes(otellog.String(key, fmt.Sprint(val)))
+	}
+	return record
+}
+
+// spoolBatchKey Send 收集记录的上下文 key
+type spoolBatchKey struct{}
+
+// spoolBatch Send 期间收集的记录，记录经过 LoggerProvider 处理后带有资源属性
+type spoolBatch struct {
+	records []sdklog.Record
+}
+
+// spoolProcessor 上下文中带有 spoolBatch 时收集记录由 Send 同步导出，否则交给批量处理器异步导出
+type spoolProcessor struct {
+	sdklog.Processor
+}
+
+// OnEmit 实现 sdklog.Processor 接口
+func (p *spoolProcessor) OnEmit(ctx context.Context, record *sdklog.Record) error {
+	if batch, ok := ctx.Value(spoolBatchKey{}).(*spoolBatch); ok {
+		batch.records = append(batch.records, record.Clone())
+		return nil
+	}
+	return p.Processor.OnEmit(ctx, record)
+}
+
+// Close 刷新缓冲并关闭导出器
+func (l *OTLPLogger) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return l.provider.Shutdown(ctx)
+}
+
+// otelSeverity 将 Kratos 日志级别转换为 OpenTelemetry 日志级别
+func otelSeverity(level log.Level) otellog.Severity {
+	switch level {
+	case log.LevelDebug:
+		return otellog.SeverityDebug
+	case log.LevelInfo:
+		return otellog.SeverityInfo
+	case log.LevelWarn:
+		return otellog.SeverityWarn
+	case log.LevelError:
+		return otellog.SeverityError
+	case log.LevelFatal:
+		return otellog.SeverityFatal
+	default:
+		return otellog.SeverityInfo
+	}
+}
@@ -0,0 +1,87 @@
+package log
+
+import (
+	"os"
+	"strconv"
+
+	"{{cookiecutter.module_name}}/internal/conf"
+)
+
+const (
+	defaultFileMode os.FileMode = 0644
+	defaultDirMode  os.FileMode = 0755
+)
+
+// filePerm 日志文件和目录的权限及所有者
+type filePerm struct {
+	fileMode os.FileMode
+	dirMode  os.FileMode
+	uid      int // -1 表示不修改
+	gid      int // -1 表示不修改
+}
+
+// newFilePerm 从配置解析日志文件权限
+func newFilePerm(c *conf.Log) filePerm {
+	p := filePerm{
+		fileMode: parseMode(c.FileMode, defaultFileMode),
+		dirMode:  parseMode(c.DirMode, defaultDirMode),
+		uid:      -1,
+		gid:      -1,
+	}
+	if c.Uid > 0 {
+		p.uid = int(c.Uid)
+	}
+	if c.Gid > 0 {
+		p.gid = int(c.Gid)
+	}
+	return p
+}
+
+// parseMode 解析八进制权限字符串，如 "0640"，为空或无效时返回默认值
+func parseMode(s string, def os.FileMode) os.FileMode {
+	if s == "" {
+		return def
+	}
+	m, err := strconv.ParseUint(s, 8, 32)
+	if err != nil {
+		return def
+	}
+	return os.FileMode(m).Perm()
+}
+
+// mkdir 创建目录，新建的目录设置权限和所有者，不受 umask 影响
+func (p filePerm) mkdir(dir string) error {
+	if _, err := os.Stat(dir); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(dir, p.dirMode); err != nil {
+		return err
+	}
+	if err := os.Chmod(dir, p.dirMode); err != nil {
+		return err
+	}
+	return chownFile(dir, p.uid, p.gid)
+}
+
+// apply 设置文件权限和所有者，不受 umask 影响
+func (p filePerm) apply(name string) error {
+	if err := os.Chmod(name, p.fileMode); err != nil {
+		return err
+	}
+	return chownFile(name, p.uid, p.gid)
+}
+
+// create 日志文件不存在时按配置的权限创建
+func (p filePerm) create(name string) error {
+	if _, err := os.Stat(name); err == nil {
+		return nil
+	}
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY, p.fileMode)
+	if err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return p.apply(name)
+}
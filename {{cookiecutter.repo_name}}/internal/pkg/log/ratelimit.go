@@ -0,0 +1,118 @@
+package log
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+var _ log.Logger = (*RateLimitLogger)(nil)
+
+// rateLimitMaxKeys 最多跟踪的限流键数量，超出时淘汰最久未使用的令牌桶
+const rateLimitMaxKeys = 1024
+
+// RateLimitLogger 错误日志限流包装器
+// 按调用位置使用令牌桶限流，下游故障时同一位置的错误日志每秒最多输出 rate 条，
+// 被丢弃的条数在该位置下一次输出时通过 dropped 字段带出。
+// 令牌桶按 LRU 保留至多 rateLimitMaxKeys 个，消息各不相同时内存不会无限增长
+type RateLimitLogger struct {
+	logger log.Logger
+	level  log.Level
+	rate   float64
+	burst  float64
+	size   int
+
+	mu      sync.Mutex
+	buckets map[string]*list.Element
+	lru     *list.List
+}
+
+// bucket 令牌桶
+type bucket struct {
+	key     string
+	tokens  float64
+	last    time.Time
+	dropped int
+}
+
+// NewRateLimitLogger 创建错误日志限流包装器
+// rate 为每秒产生的令牌数，burst 为桶容量，level 及以上级别的日志参与限流
+func NewRateLimitLogger(logger log.Logger, rate, burst int, level log.Level) *RateLimitLogger {
+	if burst < rate {
+		burst = rate
+	}
+	return &RateLimitLogger{
+		logger:  logger,
+		level:   level,
+		rate:    float64(rate),
+		burst:   float64(burst),
+		size:    rateLimitMaxKeys,
+		buckets: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// Log 实现 log.Logger 接口
+func (l *RateLimitLogger) Log(level log.Level, keyvals ...interface{}) error {
+	if level < l.level {
+		return l.logger.Log(level, keyvals...)
+	}
+
+	key := rateLimitKey(level, keyvals)
+	now := time.Now()
+
+	l.mu.Lock()
+	b := l.bucket(key, now)
+	b.tokens += now.Sub(b.last).Seconds() * l.rate
+	if b.tokens > l.burst {
+		b.tokens = l.burst
+	}
+	b.last = now
+	if b.tokens < 1 {
+		b.dropped++
+		l.mu.Unlock()
+		return nil
+	}
+	b.tokens--
+	dropped := b.dropped
+	b.dropped = 0
+	l.mu.Unlock()
+
+	if dropped > 0 {
+		kvs := make([]interface{}, 0, len(keyvals)+2)
+		kvs = append(kvs, keyvals...)
+		kvs = append(kvs, "dropped", dropped)
+		return l.logger.Log(level, kvs...)
+	}
+	return l.logger.Log(level, keyvals...)
+}
+
+// bucket 取出 key 对应的令牌桶并标记为最近使用，调用方需持有 mu
+// 超出容量时淘汰最久未使用的桶，其未带出的 dropped 计数随之丢弃
+func (l *RateLimitLogger) bucket(key string, now time.Time) *bucket {
+	if e, ok := l.buckets[key]; ok {
+		l.lru.MoveToFront(e)
+		return e.Value.(*bucket)
+	}
+	if l.lru.Len() >= l.size {
+		oldest := l.lru.Back()
+		l.lru.Remove(oldest)
+		delete(l.buckets, oldest.Value.(*bucket).key)
+	}
+	b := &bucket{key: key, tokens: l.burst, last: now}
+	l.buckets[key] = l.lru.PushFront(b)
+	return b
+}
+
+// rateLimitKey 生成限流键，优先使用调用位置，没有 caller 字段时退化为按消息限流
+func rateLimitKey(level log.Level, keyvals []interface{}) string {
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		if k, ok := keyvals[i].(string); ok && k == "caller" {
+			return level.String() + "|" + fmt.Sprint(keyvals[i+1])
+		}
+	}
+	return dedupKey(level, keyvals)
+}
@@ -0,0 +1,105 @@
+package log
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// recordLogger 记录每条日志，测试包装器的输出
+type recordLogger struct {
+	mu      sync.Mutex
+	entries [][]interface{}
+}
+
+func (r *recordLogger) Log(level log.Level, keyvals ...interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, append([]interface{}{level}, keyvals...))
+	return nil
+}
+
+// records 返回已记录的日志条数和最后一条
+func (r *recordLogger) records() (int, []interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.entries) == 0 {
+		return 0, nil
+	}
+	return len(r.entries), r.entries[len(r.entries)-1]
+}
+
+// value 返回日志中 key 对应的值
+func value(keyvals []interface{}, key string) interface{} {
+	for i := 1; i+1 < len(keyvals); i += 2 {
+		if keyvals[i] == key {
+			return keyvals[i+1]
+		}
+	}
+	return nil
+}
+
+func TestRateLimitLogger(t *testing.T) {
+	rec := &recordLogger{}
+	l := NewRateLimitLogger(rec, 1, 2, log.LevelError)
+
+	for i := 0; i < 5; i++ {
+		_ = l.Log(log.LevelError, "caller", "data.go:10", "msg", fmt.Sprint("timeout ", i))
+	}
+	if n, _ := rec.records(); n != 2 {
+		t.Fatalf("logged %d entries, want burst of 2", n)
+	}
+	// 低于限流级别的日志直接透传
+	for i := 0; i < 5; i++ {
+		_ = l.Log(log.LevelWarn, "caller", "data.go:10", "msg", "slow")
+	}
+	if n, _ := rec.records(); n != 7 {
+		t.Fatalf("logged %d entries, want warnings passed through", n)
+	}
+	// 不同调用位置各自计数
+	_ = l.Log(log.LevelError, "caller", "data.go:20", "msg", "timeout")
+	if n, _ := rec.records(); n != 8 {
+		t.Fatalf("logged %d entries, want another call site unaffected", n)
+	}
+
+	// 令牌恢复后输出，并带出期间丢弃的条数
+	l.mu.Lock()
+	b := l.buckets["ERROR|data.go:10"].Value.(*bucket)
+	b.last = b.last.Add(-time.Second)
+	l.mu.Unlock()
+	_ = l.Log(log.LevelError, "caller", "data.go:10", "msg", "timeout")
+	_, last := rec.records()
+	if got := value(last, "dropped"); got != 3 {
+		t.Errorf("dropped = %v, want 3", got)
+	}
+}
+
+func TestRateLimitLoggerEvicts(t *testing.T) {
+	rec := &recordLogger{}
+	l := NewRateLimitLogger(rec, 1, 1, log.LevelError)
+	l.size = 3
+
+	// 没有 caller 字段时按消息限流，消息各不相同也只保留 size 个令牌桶
+	for i := 0; i < 10; i++ {
+		_ = l.Log(log.LevelError, "msg", fmt.Sprint("user ", i, " not found"))
+	}
+	if len(l.buckets) != 3 || l.lru.Len() != 3 {
+		t.Fatalf("tracking %d buckets (lru %d), want 3", len(l.buckets), l.lru.Len())
+	}
+	if n, _ := rec.records(); n != 10 {
+		t.Fatalf("logged %d entries, want every distinct message", n)
+	}
+
+	// 最近使用的键保留，最久未使用的被淘汰
+	_ = l.Log(log.LevelError, "msg", "user 7 not found")
+	_ = l.Log(log.LevelError, "msg", "new")
+	if _, ok := l.buckets["ERROR|user 7 not found"]; !ok {
+		t.Error("recently used bucket was evicted")
+	}
+	if _, ok := l.buckets["ERROR|user 8 not found"]; ok {
+		t.Error("least recently used bucket was kept")
+	}
+}
@@ -0,0 +1,52 @@
+package log
+
+import (
+	"os"
+	"sync"
+
+	"{{cookiecutter.module_name}}/internal/conf"
+)
+
+// Resource 服务资源信息，作为顶层字段写入每条日志，便于日志平台直接按字段过滤
+type Resource struct {
+	Name        string // 服务名，通常来自 ldflags 注入的 main.Name
+	Version     string // 服务版本，通常来自 ldflags 注入的 main.Version
+	Environment string // 部署环境，默认使用日志配置中的 environment
+}
+
+var (
+	resourceMu      sync.RWMutex
+	currentResource Resource
+)
+
+// SetResource 设置服务资源信息，需要在 NewLogger 之前调用
+func SetResource(r Resource) {
+	resourceMu.Lock()
+	currentResource = r
+	resourceMu.Unlock()
+}
+
+// resourceFields 日志资源字段: service.name、service.version、host.name、process.pid、deployment.environment
+// 未设置的字段不输出
+func resourceFields(c *conf.Log) []interface{} {
+	resourceMu.RLock()
+	r := currentResource
+	resourceMu.RUnlock()
+	if c.Environment != "" {
+		r.Environment = c.Environment
+	}
+	host, _ := os.Hostname()
+
+	var kvs []interface{}
+	for _, kv := range [][2]string{
+		{"service.name", r.Name},
+		{"service.version", r.Version},
+		{"host.name", host},
+		{"deployment.environment", r.Environment},
+	} {
+		if kv[1] != "" {
+			kvs = append(kvs, kv[0], kv[1])
+		}
+	}
+	return append(kvs, "process.pid", os.Getpid())
+}
@@ -1,16 +1,27 @@
 package log
 
 import (
+	"compress/gzip"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 )
 
+const (
+	// compressSuffix 压缩后的备份文件后缀
+	compressSuffix = ".gz"
+	// defaultMaxSize 单个日志文件默认大小上限，单位 MB
+	defaultMaxSize = 100
+)
+
 // RotateWriter 自定义的日志轮转写入器
+// 备份文件的压缩和清理由单个后台协程串行执行，避免并发压缩和压缩过程中被清理
 type RotateWriter struct {
 	mu sync.Mutex
 
@@ -20,21 +31,89 @@ type RotateWriter struct {
 	maxAge     int   // days
 	maxBackups int
 	compress   bool
+	dailyDir   bool
+	shared     bool
+	archived   bool
+	perm       filePerm
 
 	// 运行时状态
 	file *os.File
 	size int64
+
+	millCh    chan struct{}
+	startMill sync.Once
+}
+
+// RotateOption 日志轮转写入器配置项
+type RotateOption func(*RotateWriter)
+
+// WithDailyDir 备份文件按日期写入子目录，如 logs/2024-05-01/app-1.log，
+// 便于按目录清理和归档
+func WithDailyDir() RotateOption {
+	return func(w *RotateWriter) {
+		w.dailyDir = true
+	}
 }
 
-// NewRotateWriter 创建一个新的日志轮转写入器
-func NewRotateWriter(filename string, maxSize int, maxAge int, maxBackups int, compress bool) *RotateWriter {
-	return &RotateWriter{
+// WithSharedFile 多个进程写同一日志文件，每次写入前重新检查文件，
+// 其他进程已轮转时改写新文件，并按文件实际大小判断是否轮转，需要在进程间文件锁内调用 Write
+func WithSharedFile() RotateOption {
+	return func(w *RotateWriter) {
+		w.shared = true
+	}
+}
+
+// WithArchive 备份文件由 Archiver 上传后清理，只压缩不按 maxBackups、maxAge 清理
+func WithArchive() RotateOption {
+	return func(w *RotateWriter) {
+		w.archived = true
+	}
+}
+
+// WithFileMode 日志文件权限，默认 0644
+func WithFileMode(mode os.FileMode) RotateOption {
+	return func(w *RotateWriter) {
+		w.perm.fileMode = mode
+	}
+}
+
+// WithDirMode 日志目录权限，默认 0755
+func WithDirMode(mode os.FileMode) RotateOption {
+	return func(w *RotateWriter) {
+		w.perm.dirMode = mode
+	}
+}
+
+// WithOwner 日志文件和目录的所有者，-1 表示不修改，仅 Linux 等类 Unix 系统生效
+func WithOwner(uid, gid int) RotateOption {
+	return func(w *RotateWriter) {
+		w.perm.uid = uid
+		w.perm.gid = gid
+	}
+}
+
+// NewRotateWriter 创建一个新的日志轮转写入器，maxSize 单位 MB，为 0 时默认 100MB
+func NewRotateWriter(filename string, maxSize int, maxAge int, maxBackups int, compress bool, opts ...RotateOption) *RotateWriter {
+	if maxSize <= 0 {
+		maxSize = defaultMaxSize
+	}
+	w := &RotateWriter{
 		filename:   filename,
 		maxSize:    int64(maxSize) * 1024 * 1024, // 转换为字节
 		maxAge:     maxAge,
 		maxBackups: maxBackups,
 		compress:   compress,
+		perm: filePerm{
+			fileMode: defaultFileMode,
+			dirMode:  defaultDirMode,
+			uid:      -1,
+			gid:      -1,
+		},
+	}
+	for _, opt := range opts {
+		opt(w)
 	}
+	return w
 }
 
 // Write 实现 io.Writer 接口
@@ -47,6 +126,12 @@ func (w *RotateWriter) Write(p []byte) (n int, err error) {
 		return 0, fmt.Errorf("write length %d exceeds maximum file size %d", writeLen, w.maxSize)
 	}
 
+	if w.shared && w.file != nil {
+		if err := w.reopenIfRotated(); err != nil {
+			return 0, err
+		}
+	}
+
 	if w.file == nil {
 		if err = w.openExistingOrNew(len(p)); err != nil {
 			return 0, err
@@ -69,6 +154,11 @@ func (w *RotateWriter) Write(p []byte) (n int, err error) {
 func (w *RotateWriter) Close() error {
 	w.mu.Lock()
 	defer w.mu.Unlock()
+	if w.millCh != nil {
+		close(w.millCh)
+		w.millCh = nil
+		w.startMill = sync.Once{}
+	}
 	return w.close()
 }
 
@@ -82,9 +172,26 @@ func (w *RotateWriter) close() error {
 	return err
 }
 
+// reopenIfRotated 日志文件已被其他进程轮转时关闭当前文件，未轮转时同步文件实际大小
+func (w *RotateWriter) reopenIfRotated() error {
+	info, err := os.Stat(w.filename)
+	if os.IsNotExist(err) {
+		return w.close()
+	}
+	if err != nil {
+		return fmt.Errorf("error getting log file info: %s", err)
+	}
+	current, err := w.file.Stat()
+	if err != nil || !os.SameFile(info, current) {
+		return w.close()
+	}
+	w.size = info.Size()
+	return nil
+}
+
 // openExistingOrNew 打开现有文件或创建新文件
 func (w *RotateWriter) openExistingOrNew(writeLen int) error {
-	w.mill()
+	w.millAsync()
 
 	filename := w.filename
 	info, err := os.Stat(filename)
@@ -99,7 +206,7 @@ func (w *RotateWriter) openExistingOrNew(writeLen int) error {
 		return w.rotate()
 	}
 
-	file, err := os.OpenFile(filename, os.O_APPEND|os.O_WRONLY, 0644)
+	file, err := openFile(filename, os.O_APPEND|os.O_WRONLY, w.perm.fileMode)
 	if err != nil {
 		return w.openNew()
 	}
@@ -110,35 +217,62 @@ func (w *RotateWriter) openExistingOrNew(writeLen int) error {
 
 // openNew 创建新文件
 func (w *RotateWriter) openNew() error {
-	err := os.MkdirAll(w.dir(), 0755)
+	err := w.perm.mkdir(w.dir())
 	if err != nil {
 		return fmt.Errorf("can't make directories for new logfile: %s", err)
 	}
 
 	name := w.filename
-	mode := os.FileMode(0644)
-	info, err := os.Stat(name)
-	if err == nil {
-		mode = info.Mode()
+	if _, err := os.Stat(name); err == nil {
 		newname := w.backupName(name, time.Now())
-		if err := os.Rename(name, newname); err != nil {
-			return fmt.Errorf("can't rename log file: %s", err)
+		if err := w.perm.mkdir(filepath.Dir(newname)); err != nil {
+			return fmt.Errorf("can't make directories for backup logfile: %s", err)
 		}
-
-		if w.compress {
-			go w.compressFile(newname)
+		if err := renameFile(name, newname, w.perm); err != nil {
+			return fmt.Errorf("can't rename log file: %s", err)
 		}
 	}
 
-	f, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	// 以追加方式打开，多进程共享日志文件时其他进程可能已创建新文件并写入
+	f, err := openFile(name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, w.perm.fileMode)
 	if err != nil {
 		return fmt.Errorf("can't open new logfile: %s", err)
 	}
+	if err := w.perm.apply(name); err != nil {
+		f.Close()
+		return fmt.Errorf("can't set logfile permission: %s", err)
+	}
 	w.file = f
 	w.size = 0
 	return nil
 }
 
+// copyTruncate 将日志文件内容复制到备份文件后截断原文件
+// 用于原文件被占用无法重命名的场景，复制期间写入的内容可能丢失，备份文件按 perm 设置权限和所有者
+func copyTruncate(name, backup string, perm filePerm) error {
+	src, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(backup, os.O_CREATE|os.O_WRONLY|os.O_EXCL, perm.fileMode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+	if err := perm.apply(backup); err != nil {
+		return err
+	}
+	return os.Truncate(name, 0)
+}
+
 // backupName 生成备份文件名
 func (w *RotateWriter) backupName(name string, t time.Time) string {
 	dir := filepath.Dir(name)
@@ -146,19 +280,33 @@ func (w *RotateWriter) backupName(name string, t time.Time) string {
 	ext := filepath.Ext(filename)
 	prefix := filename[:len(filename)-len(ext)]
 
-	// 格式: service-name.log-yyyy-MM-dd-{index}
+	// 格式: service-name-yyyy-MM-dd-{index}.log，与 timeFromName 的解析一致
 	timestamp := t.Format("2006-01-02")
 
-	// 查找当天已有的备份文件数量
+	// 按日期分目录时格式: yyyy-MM-dd/service-name-{index}.log
+	if w.dailyDir {
+		dir = filepath.Join(dir, timestamp)
+	}
+
+	// 序号取当天已有备份（含已压缩的备份）的最大序号加一，
+	// 不复用被清理掉的序号，保证序号越大备份越新
+	backupPrefix := fmt.Sprintf("%s-%s-", prefix, timestamp)
+	if w.dailyDir {
+		backupPrefix = prefix + "-"
+	}
 	index := 1
-	for {
-		backupName := fmt.Sprintf("%s-%s-%d%s", prefix, timestamp, index, ext)
-		backupPath := filepath.Join(dir, backupName)
-		if _, err := os.Stat(backupPath); os.IsNotExist(err) {
-			return backupPath
+	if entries, err := os.ReadDir(dir); err == nil {
+		for _, e := range entries {
+			n := strings.TrimSuffix(e.Name(), compressSuffix)
+			if !strings.HasPrefix(n, backupPrefix) || !strings.HasSuffix(n, ext) {
+				continue
+			}
+			if i, err := strconv.Atoi(n[len(backupPrefix) : len(n)-len(ext)]); err == nil && i >= index {
+				index = i + 1
+			}
 		}
-		index++
 	}
+	return filepath.Join(dir, fmt.Sprintf("%s%d%s", backupPrefix, index, ext))
 }
 
 // rotate 轮转日志文件
@@ -169,13 +317,32 @@ func (w *RotateWriter) rotate() error {
 	if err := w.openNew(); err != nil {
 		return err
 	}
-	w.mill()
+	w.millAsync()
 	return nil
 }
 
-// mill 清理旧的日志文件
+// millAsync 通知后台协程压缩和清理备份文件，协程繁忙时合并为一次
+func (w *RotateWriter) millAsync() {
+	w.startMill.Do(func() {
+		w.millCh = make(chan struct{}, 1)
+		go w.millRun(w.millCh)
+	})
+	select {
+	case w.millCh <- struct{}{}:
+	default:
+	}
+}
+
+// millRun 后台串行执行压缩和清理
+func (w *RotateWriter) millRun(ch <-chan struct{}) {
+	for range ch {
+		w.mill()
+	}
+}
+
+// mill 压缩未压缩的备份文件，并按数量和保留天数清理旧的备份文件，压缩后的备份文件同样计入
 func (w *RotateWriter) mill() {
-	if w.maxBackups == 0 && w.maxAge == 0 {
+	if !w.compress && w.maxBackups == 0 && w.maxAge == 0 {
 		return
 	}
 
@@ -184,6 +351,22 @@ func (w *RotateWriter) mill() {
 		return
 	}
 
+	// 重启前未完成压缩的备份文件也会在这里补充压缩
+	if w.compress {
+		for i, f := range files {
+			if strings.HasSuffix(f.path, compressSuffix) {
+				continue
+			}
+			if err := w.compressFile(f.path); err != nil {
+				continue
+			}
+			files[i].path = f.path + compressSuffix
+		}
+	}
+	if w.archived || (w.maxBackups == 0 && w.maxAge == 0) {
+		return
+	}
+
 	var deletes []logInfo
 
 	if w.maxBackups > 0 && w.maxBackups < len(files) {
@@ -202,7 +385,11 @@ func (w *RotateWriter) mill() {
 	}
 
 	for _, f := range deletes {
-		os.Remove(filepath.Join(w.dir(), f.Name()))
+		os.Remove(f.path)
+		// 按日期分目录时，目录清空后一并删除，非空目录删除会失败
+		if w.dailyDir {
+			os.Remove(filepath.Dir(f.path))
+		}
 	}
 }
 
@@ -218,10 +405,13 @@ func (w *RotateWriter) oldLogFiles() ([]logInfo, error) {
 
 	for _, f := range files {
 		if f.IsDir() {
+			if w.dailyDir {
+				logFiles = append(logFiles, w.dailyLogFiles(f.Name(), prefix, ext)...)
+			}
 			continue
 		}
-		if t, err := w.timeFromName(f.Name(), prefix, ext); err == nil {
-			logFiles = append(logFiles, logInfo{t, f})
+		if t, index, err := w.timeFromName(f.Name(), prefix, ext); err == nil {
+			logFiles = append(logFiles, logInfo{timestamp: t, index: index, path: filepath.Join(w.dir(), f.Name()), DirEntry: f})
 		}
 	}
 
@@ -230,25 +420,58 @@ func (w *RotateWriter) oldLogFiles() ([]logInfo, error) {
 	return logFiles, nil
 }
 
-// timeFromName 从文件名中提取时间
-func (w *RotateWriter) timeFromName(filename, prefix, ext string) (time.Time, error) {
+// dailyLogFiles 获取日期子目录中的备份文件，目录名不是日期时忽略
+func (w *RotateWriter) dailyLogFiles(day, prefix, ext string) []logInfo {
+	if _, err := time.Parse("2006-01-02", day); err != nil {
+		return nil
+	}
+	dir := filepath.Join(w.dir(), day)
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var logFiles []logInfo
+	for _, f := range files {
+		name := strings.TrimSuffix(f.Name(), compressSuffix)
+		if f.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		// 同一天内按修改时间排序
+		info, err := f.Info()
+		if err != nil {
+			continue
+		}
+		logFiles = append(logFiles, logInfo{timestamp: info.ModTime(), path: filepath.Join(dir, f.Name()), DirEntry: f})
+	}
+	return logFiles
+}
+
+// timeFromName 从文件名中提取时间和当天的序号，支持压缩后的备份文件
+func (w *RotateWriter) timeFromName(filename, prefix, ext string) (time.Time, int, error) {
+	filename = strings.TrimSuffix(filename, compressSuffix)
 	if !strings.HasPrefix(filename, prefix) {
-		return time.Time{}, fmt.Errorf("mismatched prefix")
+		return time.Time{}, 0, fmt.Errorf("mismatched prefix")
 	}
 	if !strings.HasSuffix(filename, ext) {
-		return time.Time{}, fmt.Errorf("mismatched extension")
+		return time.Time{}, 0, fmt.Errorf("mismatched extension")
 	}
 	ts := filename[len(prefix) : len(filename)-len(ext)]
 
-	// 解析格式: -yyyy-MM-dd-{index}
+	// 解析格式: yyyy-MM-dd-{index}，前缀已包含分隔符 -
 	parts := strings.Split(ts, "-")
-	if len(parts) < 4 {
-		return time.Time{}, fmt.Errorf("invalid timestamp format")
+	if len(parts) != 4 {
+		return time.Time{}, 0, fmt.Errorf("invalid timestamp format")
+	}
+	index, err := strconv.Atoi(parts[3])
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("invalid backup index")
 	}
 
 	// 重新组合日期部分
-	dateStr := strings.Join(parts[1:4], "-")
-	return time.Parse("2006-01-02", dateStr)
+	dateStr := strings.Join(parts[:3], "-")
+	t, err := time.Parse("2006-01-02", dateStr)
+	return t, index, err
 }
 
 // prefixAndExt 获取文件前缀和扩展名
@@ -264,15 +487,54 @@ func (w *RotateWriter) dir() string {
 	return filepath.Dir(w.filename)
 }
 
-// compressFile 压缩文件（如果启用压缩）
-func (w *RotateWriter) compressFile(filename string) {
-	// 这里可以实现文件压缩逻辑
-	// 为了简化，暂时不实现压缩功能
+// compressFile 使用 gzip 压缩备份文件，成功后删除原文件
+// 压缩中断时保留原文件，下次清理时重新压缩
+func (w *RotateWriter) compressFile(filename string) error {
+	src, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	info, err := src.Stat()
+	if err != nil {
+		return err
+	}
+
+	dstName := filename + compressSuffix
+	dst, err := os.OpenFile(dstName, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, w.perm.fileMode)
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		dst.Close()
+		os.Remove(dstName)
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		dst.Close()
+		os.Remove(dstName)
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(dstName)
+		return err
+	}
+	if err := w.perm.apply(dstName); err != nil {
+		return err
+	}
+	// 保留原文件的修改时间，按日期分目录时以修改时间排序
+	_ = os.Chtimes(dstName, info.ModTime(), info.ModTime())
+	src.Close()
+	return os.Remove(filename)
 }
 
 // logInfo 日志文件信息
 type logInfo struct {
 	timestamp time.Time
+	index     int // 同一天内的备份序号
+	path      string
 	os.DirEntry
 }
 
@@ -280,6 +542,9 @@ type logInfo struct {
 type byFormatTime []logInfo
 
 func (b byFormatTime) Less(i, j int) bool {
+	if b[i].timestamp.Equal(b[j].timestamp) {
+		return b[i].index > b[j].index
+	}
 	return b[i].timestamp.After(b[j].timestamp)
 }
 
@@ -0,0 +1,23 @@
+//go:build !windows
+
+package log
+
+import "os"
+
+// openFile 打开日志文件
+func openFile(name string, flag int, perm os.FileMode) (*os.File, error) {
+	return os.OpenFile(name, flag, perm)
+}
+
+// renameFile 重命名日志文件，重命名保留原文件的权限和所有者，perm 不使用
+func renameFile(oldname, newname string, perm filePerm) error {
+	return os.Rename(oldname, newname)
+}
+
+// chownFile 修改文件所有者，uid、gid 为 -1 时不修改
+func chownFile(name string, uid, gid int) error {
+	if uid < 0 && gid < 0 {
+		return nil
+	}
+	return os.Chown(name, uid, gid)
+}
@@ -0,0 +1,142 @@
+package log
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRotateDailyDir(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "app.log")
+	w := NewRotateWriter(name, 1, 0, 0, false, WithDailyDir())
+	defer w.Close()
+
+	chunk := bytes.Repeat([]byte("x"), 600<<10)
+	for i := 0; i < 3; i++ {
+		if _, err := w.Write(chunk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	day := filepath.Join(dir, time.Now().Format("2006-01-02"))
+	for _, backup := range []string{"app-1.log", "app-2.log"} {
+		info, err := os.Stat(filepath.Join(day, backup))
+		if err != nil {
+			t.Fatalf("backup %s: %v", backup, err)
+		}
+		if info.Size() != int64(len(chunk)) {
+			t.Errorf("backup %s size = %d, want %d", backup, info.Size(), len(chunk))
+		}
+	}
+	if info, err := os.Stat(name); err != nil || info.Size() != int64(len(chunk)) {
+		t.Errorf("log file after rotation: %v, %v", info, err)
+	}
+}
+
+func TestTimeFromName(t *testing.T) {
+	w := NewRotateWriter("/var/log/app.log", 1, 0, 0, false)
+	prefix, ext := w.prefixAndExt()
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		ok    bool
+		index int
+	}{
+		{"app-2024-05-01-3.log", true, 3},
+		{"app-2024-05-01-12.log.gz", true, 12},
+		{"app-2024-05-01.log", false, 0},
+		{"app-2024-05-01-x.log.gz", false, 0},
+		{"app-access-2024-05-01-1.log", false, 0},
+		{"other-2024-05-01-1.log", false, 0},
+		{"app-2024-05-01-1.txt", false, 0},
+		{"app.log", false, 0},
+	}
+	for _, tt := range tests {
+		ts, index, err := w.timeFromName(tt.name, prefix, ext)
+		if !tt.ok {
+			if err == nil {
+				t.Errorf("timeFromName(%q): want error", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("timeFromName(%q): %v", tt.name, err)
+			continue
+		}
+		if !ts.Equal(day) || index != tt.index {
+			t.Errorf("timeFromName(%q) = %v, %d, want %v, %d", tt.name, ts, index, day, tt.index)
+		}
+	}
+}
+
+func TestMillCompressAndPrune(t *testing.T) {
+	dir := t.TempDir()
+	for _, f := range []string{"app-2024-05-01-1.log.gz", "app-2024-05-01-2.log", "app-2024-05-02-1.log", "other-2024-05-01-1.log"} {
+		if err := os.WriteFile(filepath.Join(dir, f), []byte("line\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w := NewRotateWriter(filepath.Join(dir, "app.log"), 1, 0, 2, true)
+	w.mill()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Name())
+	}
+	// 已压缩的备份同样计入 max_backups，最旧的被清理
+	want := []string{"app-2024-05-01-2.log.gz", "app-2024-05-02-1.log.gz", "other-2024-05-01-1.log"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("files = %v, want %v", got, want)
+	}
+}
+
+func TestRotateSharedFile(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "app.log")
+	a := NewRotateWriter(name, 1, 0, 0, false, WithSharedFile())
+	defer a.Close()
+	b := NewRotateWriter(name, 1, 0, 0, false, WithSharedFile())
+	defer b.Close()
+
+	chunk := bytes.Repeat([]byte("x"), 400<<10)
+	// a、b 交替写入，按文件实际大小轮转，只有第三次写入触发轮转
+	for _, w := range []*RotateWriter{a, b, a, b} {
+		if _, err := w.Write(chunk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	backup := filepath.Join(dir, "app-"+time.Now().Format("2006-01-02")+"-1.log")
+	if info, err := os.Stat(backup); err != nil || info.Size() != int64(2*len(chunk)) {
+		t.Errorf("backup after rotation: %v, %v", info, err)
+	}
+	// b 未执行轮转，仍应写入新文件而不是备份文件
+	if info, err := os.Stat(name); err != nil || info.Size() != int64(2*len(chunk)) {
+		t.Errorf("log file after rotation: %v, %v", info, err)
+	}
+}
+
+func TestCopyTruncateFileMode(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "app.log")
+	backup := filepath.Join(dir, "app-2024-05-01-1.log")
+	if err := os.WriteFile(name, []byte("line\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := copyTruncate(name, backup, filePerm{fileMode: 0o600, dirMode: defaultDirMode, uid: -1, gid: -1}); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(backup); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("backup mode = %v, %v, want 0600", info, err)
+	}
+	if info, err := os.Stat(name); err != nil || info.Size() != 0 {
+		t.Errorf("log file after copyTruncate: %v, %v", info, err)
+	}
+}
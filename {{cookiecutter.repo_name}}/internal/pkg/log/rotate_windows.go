@@ -0,0 +1,55 @@
+//go:build windows
+
+package log
+
+import (
+	"os"
+	"syscall"
+)
+
+// openFile 以共享读、写、删除的方式打开日志文件
+// Windows 默认的打开方式不允许其他进程重命名或删除文件，
+// 采集程序或 tail 持有文件时会导致轮转失败
+func openFile(name string, flag int, perm os.FileMode) (*os.File, error) {
+	pathp, err := syscall.UTF16PtrFromString(name)
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	var access uint32 = syscall.GENERIC_WRITE
+	if flag&os.O_APPEND != 0 {
+		access = syscall.FILE_APPEND_DATA
+	}
+
+	var createmode uint32
+	switch {
+	case flag&(os.O_CREATE|os.O_TRUNC) == (os.O_CREATE | os.O_TRUNC):
+		createmode = syscall.CREATE_ALWAYS
+	case flag&os.O_CREATE == os.O_CREATE:
+		createmode = syscall.OPEN_ALWAYS
+	case flag&os.O_TRUNC == os.O_TRUNC:
+		createmode = syscall.TRUNCATE_EXISTING
+	default:
+		createmode = syscall.OPEN_EXISTING
+	}
+
+	share := uint32(syscall.FILE_SHARE_READ | syscall.FILE_SHARE_WRITE | syscall.FILE_SHARE_DELETE)
+	h, err := syscall.CreateFile(pathp, access, share, nil, createmode, syscall.FILE_ATTRIBUTE_NORMAL, 0)
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: name, Err: err}
+	}
+	return os.NewFile(uintptr(h), name), nil
+}
+
+// renameFile 重命名日志文件，文件被其他进程占用无法重命名时退化为复制后截断
+func renameFile(oldname, newname string, perm filePerm) error {
+	if err := os.Rename(oldname, newname); err == nil {
+		return nil
+	}
+	return copyTruncate(oldname, newname, perm)
+}
+
+// chownFile Windows 不支持修改文件所有者，忽略
+func chownFile(name string, uid, gid int) error {
+	return nil
+}
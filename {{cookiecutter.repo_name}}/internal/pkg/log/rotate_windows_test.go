@@ -0,0 +1,90 @@
+//go:build windows
+
+package log
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+// holdFile 模拟采集程序持有日志文件，share 为允许其他句柄执行的操作
+func holdFile(t *testing.T, name string, share uint32) {
+	t.Helper()
+	p, err := syscall.UTF16PtrFromString(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h, err := syscall.CreateFile(p, syscall.GENERIC_READ, share, nil, syscall.OPEN_EXISTING, syscall.FILE_ATTRIBUTE_NORMAL, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { syscall.CloseHandle(h) })
+}
+
+func TestRotateWhileOpen(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "app.log")
+	w := NewRotateWriter(name, 1, 0, 0, false)
+	defer w.Close()
+
+	first := bytes.Repeat([]byte("a"), 600<<10)
+	if _, err := w.Write(first); err != nil {
+		t.Fatal(err)
+	}
+	// 采集程序以共享读、写、删除的方式打开，不影响重命名
+	holdFile(t, name, syscall.FILE_SHARE_READ|syscall.FILE_SHARE_WRITE|syscall.FILE_SHARE_DELETE)
+
+	second := bytes.Repeat([]byte("b"), 600<<10)
+	if _, err := w.Write(second); err != nil {
+		t.Fatalf("write after rotation: %v", err)
+	}
+	backups, err := filepath.Glob(filepath.Join(dir, "app-*.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 1 {
+		t.Fatalf("backups = %v, want one", backups)
+	}
+	if b, _ := os.ReadFile(backups[0]); !bytes.Equal(b, first) {
+		t.Errorf("backup has %d bytes, want the first write", len(b))
+	}
+	if b, _ := os.ReadFile(name); !bytes.Equal(b, second) {
+		t.Errorf("log file has %d bytes, want the second write", len(b))
+	}
+}
+
+func TestRenameFileCopyTruncate(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "app.log")
+	backup := filepath.Join(dir, "app-2024-05-01-1.log")
+	content := []byte("line 1\nline 2\n")
+	if err := os.WriteFile(name, content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// 不允许删除的句柄使重命名失败，退化为复制后截断
+	holdFile(t, name, syscall.FILE_SHARE_READ|syscall.FILE_SHARE_WRITE)
+	if err := os.Rename(name, backup); err == nil {
+		t.Skip("rename succeeded while the file is held, copyTruncate is not exercised")
+	}
+
+	if err := renameFile(name, backup, filePerm{fileMode: 0o444, uid: -1, gid: -1}); err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := os.ReadFile(backup); !bytes.Equal(b, content) {
+		t.Errorf("backup = %q, want %q", b, content)
+	}
+	// 复制出的备份文件按配置的权限创建，Windows 上只读权限体现为只读属性
+	if info, err := os.Stat(backup); err != nil || info.Mode().Perm()&0o200 != 0 {
+		t.Errorf("backup mode = %v, %v, want read-only", info, err)
+	}
+	info, err := os.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() != 0 {
+		t.Errorf("log file size = %d after copyTruncate, want 0", info.Size())
+	}
+}
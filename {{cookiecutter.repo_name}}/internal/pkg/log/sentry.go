@@ -0,0 +1,137 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"{{cookiecutter.module_name}}/internal/conf"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ log.Logger = (*SentryLogger)(nil)
+
+// sentryTags 作为 Sentry tag 的日志字段，便于在 Sentry 中筛选
+var sentryTags = map[string]bool{
+	"service.id":      true,
+	"service.name":    true,
+	"service.version": true,
+	"trace.id":        true,
+	"span.id":         true,
+	"caller":          true,
+}
+
+// SentryLogger 将 error 及以上级别的日志连同调用堆栈上报到 Sentry
+type SentryLogger struct {
+	level log.Level
+}
+
+// NewSentryLogger 初始化 Sentry 客户端并创建日志输出
+func NewSentryLogger(c *conf.Log_Sentry) (*SentryLogger, error) {
+	sampleRate := 1.0
+	if c.SampleRate > 0 {
+		sampleRate = c.SampleRate
+	}
+	err := sentry.Init(sentry.ClientOptions{
+		Dsn:              c.Dsn,
+		Environment:      c.Environment,
+		Release:          c.Release,
+		SampleRate:       sampleRate,
+		AttachStacktrace: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	level := log.LevelError
+	if c.Level != "" {
+		level = GetLogLevel(c.Level)
+	}
+	return &SentryLogger{level: level}, nil
+}
+
+// Log 实现 log.Logger 接口
+func (l *SentryLogger) Log(level log.Level, keyvals ...interface{}) error {
+	if level < l.level {
+		return nil
+	}
+	msg, fields := hookFields(keyvals)
+
+	event := sentry.NewEvent()
+	event.Level = sentryLevel(level)
+	event.Message = msg
+	for k, v := range fields {
+		if sentryTags[k] {
+			event.Tags[k] = fmt.Sprint(v)
+			continue
+		}
+		event.Extra[k] = v
+	}
+	if err, ok := fields["error"].(error); ok {
+		exception := sentry.Exception{
+			Type:       fmt.Sprintf("%T", err),
+			Value:      err.Error(),
+			Stacktrace: sentry.NewStacktrace(),
+		}
+		event.Exception = []sentry.Exception{exception}
+	} else {
+		thread := sentry.Thread{Stacktrace: sentry.NewStacktrace(), Current: true}
+		event.Threads = []sentry.Thread{thread}
+	}
+	sentry.CaptureEvent(event)
+	return nil
+}
+
+// Close 发送缓冲中的事件
+func (l *SentryLogger) Close() error {
+	sentry.Flush(2 * time.Second)
+	return nil
+}
+
+// CapturePanic 上报 recovery 中间件捕获的 panic，附带请求上下文，Sentry 未初始化时忽略
+func CapturePanic(ctx context.Context, req, err interface{}) {
+	hub := sentry.CurrentHub().Clone()
+	if hub.Client() == nil {
+		return
+	}
+	hub.WithScope(func(scope *sentry.Scope) {
+		if tr, ok := transport.FromServerContext(ctx); ok {
+			scope.SetTag("transport", tr.Kind().String())
+			scope.SetTag("operation", tr.Operation())
+			if ht, ok := tr.(khttp.Transporter); ok {
+				scope.SetRequest(ht.Request())
+			}
+		}
+		if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+			scope.SetTag("trace.id", sc.TraceID().String())
+		}
+		if id, ok := ctx.Value(UserIDKey).(string); ok && id != "" {
+			scope.SetUser(sentry.User{ID: id})
+		}
+		if id, ok := ctx.Value(TenantIDKey).(string); ok && id != "" {
+			scope.SetTag("tenant.id", id)
+		}
+		scope.SetExtra("request", fmt.Sprintf("%+v", req))
+		hub.Recover(err)
+	})
+}
+
+// sentryLevel 转换日志级别
+func sentryLevel(level log.Level) sentry.Level {
+	switch level {
+	case log.LevelDebug:
+		return sentry.LevelDebug
+	case log.LevelInfo:
+		return sentry.LevelInfo
+	case log.LevelWarn:
+		return sentry.LevelWarning
+	case log.LevelFatal:
+		return sentry.LevelFatal
+	default:
+		return sentry.LevelError
+	}
+}
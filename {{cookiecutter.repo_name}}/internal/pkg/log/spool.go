@@ -0,0 +1,388 @@
+package log
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"{{cookiecutter.module_name}}/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+var _ log.Logger = (*SpoolLogger)(nil)
+
+const (
+	// spoolSuffix 分段文件后缀
+	spoolSuffix = ".seg"
+	// spoolCursor 已确认投递位置文件
+	spoolCursor = "cursor"
+	// spoolHeaderSize 记录头: 4 字节长度 + 4 字节 CRC32
+	spoolHeaderSize = 8
+	// spoolMaxRecord 单条记录最大长度，超出视为文件损坏
+	spoolMaxRecord = 4 << 20
+	// spoolBatchSize 每批投递的最大条数
+	spoolBatchSize = 100
+)
+
+// SpoolEntry 落盘的日志条目
+type SpoolEntry struct {
+	Time    time.Time     `json:"t"`
+	Level   log.Level     `json:"l"`
+	KeyVals []interface{} `json:"kv"`
+}
+
+// SpoolSender 远端日志输出，同步发送一批日志，返回 nil 表示已投递成功
+type SpoolSender interface {
+	Send(ctx context.Context, entries []SpoolEntry) error
+}
+
+// SpoolLogger 本地磁盘缓冲的远端日志输出，保证至少一次投递
+// 日志先追加写入本地分段文件，后台协程按顺序批量发送，发送成功后才推进投递位置，
+// 采集端故障期间日志积压在磁盘上，恢复后重放；进程重启后从上次确认的位置继续发送，
+// 超出 maxSize 时删除最早的分段，读取到损坏的记录时跳过
+type SpoolLogger struct {
+	dir         string
+	sender      SpoolSender
+	maxSize     int64
+	segmentSize int64
+	timeout     time.Duration
+
+	mu       sync.Mutex
+	writer   *os.File
+	writeSeq int64
+	written  int64
+
+	notify chan struct{}
+	done   chan struct{}
+	wg     sync.WaitGroup
+	once   sync.Once
+}
+
+// NewSpoolLogger 创建磁盘缓冲的远端日志输出，name 用于区分不同远端的缓冲目录
+func NewSpoolLogger(c *conf.Log_Spool, name string, sender SpoolSender) (*SpoolLogger, error) {
+	dir := c.Dir
+	if dir == "" {
+		dir = "./log/spool"
+	}
+	dir = filepath.Join(dir, name)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	maxSize := int64(c.MaxSize) << 20
+	if maxSize <= 0 {
+		maxSize = 1 << 30
+	}
+	segmentSize := int64(c.SegmentSize) << 20
+	if segmentSize <= 0 {
+		segmentSize = 16 << 20
+	}
+	l := &SpoolLogger{
+		dir:         dir,
+		sender:      sender,
+		maxSize:     maxSize,
+		segmentSize: segmentSize,
+		timeout:     10 * time.Second,
+		notify:      make(chan struct{}, 1),
+		done:        make(chan struct{}),
+	}
+	segs, err := l.segments()
+	if err != nil {
+		return nil, err
+	}
+	seq := int64(1)
+	if len(segs) > 0 {
+		seq = segs[len(segs)-1] + 1
+	}
+	if err := l.openSegment(seq); err != nil {
+		return nil, err
+	}
+	l.wg.Add(1)
+	go l.run()
+	return l, nil
+}
+
+// Log 实现 log.Logger 接口
+func (l *SpoolLogger) Log(level log.Level, keyvals ...interface{}) error {
+	kvs := make([]interface{}, len(keyvals))
+	for i, v := range keyvals {
+		kvs[i] = fluentValue(v)
+	}
+	b, err := json.Marshal(SpoolEntry{Time: time.Now(), Level: level, KeyVals: kvs})
+	if err != nil {
+		return err
+	}
+	if err := l.append(b); err != nil {
+		return err
+	}
+	select {
+	case l.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Close 停止后台发送，尚未投递的日志保留在磁盘上，下次启动时继续发送
+func (l *SpoolLogger) Close() error {
+	var err error
+	l.once.Do(func() {
+		close(l.done)
+		l.wg.Wait()
+		l.mu.Lock()
+		err = l.writer.Close()
+		l.mu.Unlock()
+	})
+	return err
+}
+
+// append 追加一条记录，当前分段写满时切换到新分段，总大小超出上限时删除最早的分段
+func (l *SpoolLogger) append(b []byte) error {
+	var header [spoolHeaderSize]byte
+	binary.LittleEndian.PutUint32(header[:4], uint32(len(b)))
+	binary.LittleEndian.PutUint32(header[4:], crc32.ChecksumIEEE(b))
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.written >= l.segmentSize {
+		if err := l.writer.Close(); err != nil {
+			return err
+		}
+		if err := l.openSegment(l.writeSeq + 1); err != nil {
+			return err
+		}
+		l.enforceMaxSize()
+	}
+	if _, err := l.writer.Write(append(header[:], b...)); err != nil {
+		return err
+	}
+	l.written += int64(spoolHeaderSize + len(b))
+	return nil
+}
+
+// openSegment 打开分段文件用于追加写入，调用方需持有锁
+func (l *SpoolLogger) openSegment(seq int64) error {
+	f, err := os.OpenFile(l.segmentPath(seq), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	l.writer, l.writeSeq, l.written = f, seq, info.Size()
+	return nil
+}
+
+// enforceMaxSize 总大小超出上限时删除最早的分段，不删除正在写入的分段，调用方需持有锁
+func (l *SpoolLogger) enforceMaxSize() {
+	segs, err := l.segments()
+	if err != nil {
+		return
+	}
+	var total int64
+	sizes := make(map[int64]int64, len(segs))
+	for _, seq := range segs {
+		if info, err := os.Stat(l.segmentPath(seq)); err == nil {
+			sizes[seq] = info.Size()
+			total += info.Size()
+		}
+	}
+	for _, seq := range segs {
+		if total <= l.maxSize || seq == l.writeSeq {
+			break
+		}
+		if err := os.Remove(l.segmentPath(seq)); err == nil {
+			total -= sizes[seq]
+			fmt.Fprintf(os.Stderr, "log: spool %s exceeds max size, dropped segment %d\n", l.dir, seq)
+		}
+	}
+}
+
+// run 后台按顺序发送缓冲的日志，失败时退避重试
+func (l *SpoolLogger) run() {
+	defer l.wg.Done()
+	backoff := time.Second
+	for {
+		progressed, err := l.deliver()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "log: spool %s deliver failed, retry in %s: %v\n", l.dir, backoff, err)
+			select {
+			case <-time.After(backoff):
+			case <-l.done:
+				return
+			}
+			if backoff *= 2; backoff > 30*time.Second {
+				backoff = 30 * time.Second
+			}
+			continue
+		}
+		backoff = time.Second
+		if progressed {
+			continue
+		}
+		select {
+		case <-l.notify:
+		case <-time.After(time.Second):
+		case <-l.done:
+			return
+		}
+	}
+}
+
+// deliver 从确认位置读取一批日志并发送，成功后推进确认位置，返回是否有进展
+func (l *SpoolLogger) deliver() (bool, error) {
+	seq, offset := l.loadCursor()
+	segs, err := l.segments()
+	if err != nil {
+		return false, err
+	}
+	// 确认位置所在分段已被删除时从最早的分段开始
+	for len(segs) > 0 && segs[0] < seq {
+		segs = segs[1:]
+	}
+	if len(segs) == 0 {
+		return false, nil
+	}
+	if segs[0] != seq {
+		seq, offset = segs[0], 0
+	}
+
+	entries, next, err := l.readBatch(seq, offset)
+	if err != nil {
+		return false, err
+	}
+	if len(entries) == 0 {
+		// 当前分段已读完且不是正在写入的分段，删除后继续下一个分段
+		l.mu.Lock()
+		writing := l.writeSeq
+		l.mu.Unlock()
+		if seq < writing {
+			_ = os.Remove(l.segmentPath(seq))
+			return true, l.saveCursor(seq+1, 0)
+		}
+		return false, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), l.timeout)
+	defer cancel()
+	if err := l.sender.Send(ctx, entries); err != nil {
+		return false, err
+	}
+	return true, l.saveCursor(seq, next)
+}
+
+// readBatch 从分段的 offset 处读取一批记录，跳过校验失败的记录，
+// 记录头损坏时放弃该分段剩余内容，返回下一条记录的位置
+func (l *SpoolLogger) readBatch(seq, offset int64) ([]SpoolEntry, int64, error) {
+	f, err := os.Open(l.segmentPath(seq))
+	if err != nil {
+		return nil, offset, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, offset, err
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, offset, err
+	}
+
+	r := bufio.NewReader(f)
+	var entries []SpoolEntry
+	var header [spoolHeaderSize]byte
+	for len(entries) < spoolBatchSize {
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			break
+		}
+		size := int64(binary.LittleEndian.Uint32(header[:4]))
+		if size > spoolMaxRecord {
+			fmt.Fprintf(os.Stderr, "log: spool %s segment %d corrupted at %d, skip rest\n", l.dir, seq, offset)
+			return entries, info.Size(), nil
+		}
+		if offset+spoolHeaderSize+size > info.Size() {
+			// 记录不完整，正在写入的分段等下次读取，已写完的分段由 deliver 删除
+			break
+		}
+		b := make([]byte, size)
+		if _, err := io.ReadFull(r, b); err != nil {
+			break
+		}
+		offset += spoolHeaderSize + size
+		if crc32.ChecksumIEEE(b) != binary.LittleEndian.Uint32(header[4:]) {
+			fmt.Fprintf(os.Stderr, "log: spool %s segment %d checksum mismatch, record skipped\n", l.dir, seq)
+			continue
+		}
+		var e SpoolEntry
+		if err := json.Unmarshal(b, &e); err != nil {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries, offset, nil
+}
+
+// segments 按序号升序列出分段
+func (l *SpoolLogger) segments() ([]int64, error) {
+	files, err := os.ReadDir(l.dir)
+	if err != nil {
+		return nil, err
+	}
+	var segs []int64
+	for _, f := range files {
+		name := f.Name()
+		if !strings.HasSuffix(name, spoolSuffix) {
+			continue
+		}
+		seq, err := strconv.ParseInt(strings.TrimSuffix(name, spoolSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		segs = append(segs, seq)
+	}
+	sort.Slice(segs, func(i, j int) bool { return segs[i] < segs[j] })
+	return segs, nil
+}
+
+// segmentPath 分段文件路径
+func (l *SpoolLogger) segmentPath(seq int64) string {
+	return filepath.Join(l.dir, fmt.Sprintf("%020d%s", seq, spoolSuffix))
+}
+
+// loadCursor 读取确认位置，不存在或损坏时从头开始
+func (l *SpoolLogger) loadCursor() (int64, int64) {
+	b, err := os.ReadFile(filepath.Join(l.dir, spoolCursor))
+	if err != nil {
+		return 0, 0
+	}
+	var seq, offset int64
+	if _, err := fmt.Sscanf(string(b), "%d %d", &seq, &offset); err != nil {
+		return 0, 0
+	}
+	return seq, offset
+}
+
+// saveCursor 原子写入确认位置
+func (l *SpoolLogger) saveCursor(seq, offset int64) error {
+	path := filepath.Join(l.dir, spoolCursor)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(fmt.Sprintf("%d %d", seq, offset)), 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return errors.Join(err, os.Remove(tmp))
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package log
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// maxStackDepth 堆栈最大帧数
+const maxStackDepth = 32
+
+var _ log.Logger = (*StackLogger)(nil)
+
+// skipPrefixes 堆栈中需要跳过的日志封装帧
+var skipPrefixes = []string{
+	"runtime.",
+	"github.com/go-kratos/kratos/v2/log.",
+	"go.uber.org/zap",
+	pkgPath() + ".",
+}
+
+// StackLogger 为 error、fatal 级别日志附加调用堆栈
+// 获取堆栈开销较大，只在开启配置时启用
+type StackLogger struct {
+	logger log.Logger
+	level  log.Level
+}
+
+// NewStackLogger 创建堆栈包装器，level 及以上级别的日志附加 stack 字段
+func NewStackLogger(logger log.Logger, level log.Level) *StackLogger {
+	return &StackLogger{logger: logger, level: level}
+}
+
+// Log 实现 log.Logger 接口
+func (l *StackLogger) Log(level log.Level, keyvals ...interface{}) error {
+	if level < l.level {
+		return l.logger.Log(level, keyvals...)
+	}
+	kvs := make([]interface{}, 0, len(keyvals)+2)
+	kvs = append(kvs, keyvals...)
+	kvs = append(kvs, "stack", stacktrace())
+	return l.logger.Log(level, kvs...)
+}
+
+// stacktrace 获取去掉日志封装帧后的调用堆栈
+func stacktrace() string {
+	pcs := make([]uintptr, maxStackDepth+16)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var (
+		b     strings.Builder
+		depth int
+	)
+	for {
+		frame, more := frames.Next()
+		if !skipFrame(frame.Function) {
+			fmt.Fprintf(&b, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+			depth++
+		}
+		if !more || depth >= maxStackDepth {
+			break
+		}
+	}
+	return b.String()
+}
+
+// skipFrame 判断是否为日志封装帧
+func skipFrame(function string) bool {
+	for _, p := range skipPrefixes {
+		if strings.HasPrefix(function, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// pkgPath 获取当前包的导入路径
+func pkgPath() string {
+	name := runtime.FuncForPC(reflect.ValueOf(NewStackLogger).Pointer()).Name()
+	return name[:strings.LastIndex(name, ".")]
+}
@@ -0,0 +1,55 @@
+package log
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+var _ log.Logger = (*SwapLogger)(nil)
+
+// SwapLogger 可原子替换底层实现的日志器
+// 配置变更时重建日志器并替换，无需重启 kratos.App，已通过 log.With 包装的日志器同样生效
+type SwapLogger struct {
+	core atomic.Pointer[swapCore]
+	mu   sync.Mutex
+}
+
+// swapCore 当前生效的日志器及其资源释放函数
+type swapCore struct {
+	logger log.Logger
+	closer func()
+}
+
+// NewSwapLogger 创建可替换的日志器，closer 用于释放 logger 持有的资源，可为 nil
+func NewSwapLogger(logger log.Logger, closer func()) *SwapLogger {
+	l := &SwapLogger{}
+	l.core.Store(&swapCore{logger: logger, closer: closer})
+	return l
+}
+
+// Log 实现 log.Logger 接口
+func (l *SwapLogger) Log(level log.Level, keyvals ...interface{}) error {
+	return l.core.Load().logger.Log(level, keyvals...)
+}
+
+// Swap 替换底层日志器并释放旧日志器的资源
+func (l *SwapLogger) Swap(logger log.Logger, closer func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	old := l.core.Swap(&swapCore{logger: logger, closer: closer})
+	if old.closer != nil {
+		old.closer()
+	}
+}
+
+// Close 释放当前日志器的资源
+func (l *SwapLogger) Close() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if c := l.core.Load(); c.closer != nil {
+		c.closer()
+		c.closer = nil
+	}
+}
@@ -0,0 +1,147 @@
+package log
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+var _ log.Logger = (*TextLogger)(nil)
+
+// textLevels 预先格式化的级别前缀
+var textLevels = map[log.Level]string{
+	log.LevelDebug: "DEBUG",
+	log.LevelInfo:  "INFO",
+	log.LevelWarn:  "WARN",
+	log.LevelError: "ERROR",
+	log.LevelFatal: "FATAL",
+}
+
+// textPool 复用格式化缓冲区
+var textPool = sync.Pool{
+	New: func() interface{} {
+		b := make([]byte, 0, 512)
+		return &b
+	},
+}
+
+// TextLogger 高性能文本日志，输出格式与 log.NewStdLogger 一致: LEVEL ts=... key=value ...
+// 使用池化缓冲区并直接追加常见类型的值，避免 fmt 格式化带来的内存分配
+type TextLogger struct {
+	mu     sync.Mutex
+	w      io.Writer
+	layout string
+	loc    *time.Location
+	fields []byte // 预先格式化的固定字段
+}
+
+// NewTextLogger 创建文本日志，layout 和 loc 为 ts 字段的时间格式和时区，layout 为空时不输出 ts
+func NewTextLogger(w io.Writer, layout string, loc *time.Location) *TextLogger {
+	if loc == nil {
+		loc = time.Local
+	}
+	return &TextLogger{w: w, layout: layout, loc: loc}
+}
+
+// WithFields 返回附加固定字段的文本日志，字段在创建时格式化一次，位于 ts 之后
+func (l *TextLogger) WithFields(keyvals ...interface{}) *TextLogger {
+	fields := append([]byte(nil), l.fields...)
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		fields = append(fields, ' ')
+		fields = appendValue(fields, keyvals[i])
+		fields = append(fields, '=')
+		fields = appendValue(fields, keyvals[i+1])
+	}
+	return &TextLogger{w: l.w, layout: l.layout, loc: l.loc, fields: fields}
+}
+
+// Log 实现 log.Logger 接口
+func (l *TextLogger) Log(level log.Level, keyvals ...interface{}) error {
+	if len(keyvals) == 0 {
+		return nil
+	}
+	if len(keyvals)%2 != 0 {
+		keyvals = append(keyvals, "KEYVALS UNPAIRED")
+	}
+
+	bp := textPool.Get().(*[]byte)
+	buf := (*bp)[:0]
+	if s, ok := textLevels[level]; ok {
+		buf = append(buf, s...)
+	} else {
+		buf = append(buf, level.String()...)
+	}
+	if l.layout != "" {
+		buf = append(buf, " ts="...)
+		buf = time.Now().In(l.loc).AppendFormat(buf, l.layout)
+	}
+	buf = append(buf, l.fields...)
+	for i := 0; i < len(keyvals); i += 2 {
+		buf = append(buf, ' ')
+		buf = appendValue(buf, keyvals[i])
+		buf = append(buf, '=')
+		buf = appendValue(buf, keyvals[i+1])
+	}
+	buf = append(buf, '\n')
+
+	l.mu.Lock()
+	_, err := l.w.Write(buf)
+	l.mu.Unlock()
+
+	*bp = buf
+	// 超大缓冲区不放回池中，避免长期占用内存
+	if cap(buf) <= 64<<10 {
+		textPool.Put(bp)
+	}
+	return err
+}
+
+// appendValue 追加值的文本形式，常见类型不经过 fmt
+func appendValue(buf []byte, v interface{}) []byte {
+	switch val := v.(type) {
+	case nil:
+		return append(buf, "<nil>"...)
+	case string:
+		return append(buf, val...)
+	case []byte:
+		return append(buf, val...)
+	case int:
+		return strconv.AppendInt(buf, int64(val), 10)
+	case int8:
+		return strconv.AppendInt(buf, int64(val), 10)
+	case int16:
+		return strconv.AppendInt(buf, int64(val), 10)
+	case int32:
+		return strconv.AppendInt(buf, int64(val), 10)
+	case int64:
+		return strconv.AppendInt(buf, val, 10)
+	case uint:
+		return strconv.AppendUint(buf, uint64(val), 10)
+	case uint8:
+		return strconv.AppendUint(buf, uint64(val), 10)
+	case uint16:
+		return strconv.AppendUint(buf, uint64(val), 10)
+	case uint32:
+		return strconv.AppendUint(buf, uint64(val), 10)
+	case uint64:
+		return strconv.AppendUint(buf, val, 10)
+	case float32:
+		return strconv.AppendFloat(buf, float64(val), 'g', -1, 32)
+	case float64:
+		return strconv.AppendFloat(buf, val, 'g', -1, 64)
+	case bool:
+		return strconv.AppendBool(buf, val)
+	case time.Duration:
+		return append(buf, val.String()...)
+	case error:
+		return append(buf, val.Error()...)
+	case fmt.Stringer:
+		return append(buf, val.String()...)
+	default:
+		return fmt.Appendf(buf, "%v", v)
+	}
+}
@@ -0,0 +1,76 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// benchKeyvals 典型的请求日志字段
+var benchKeyvals = []interface{}{
+	"msg", "request done",
+	"operation", "/helloworld.v1.Greeter/SayHello",
+	"code", 200,
+	"latency", 0.0032,
+	"ok", true,
+	"err", errors.New("upstream timeout"),
+}
+
+func TestTextLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewTextLogger(&buf, "", nil).WithFields("service.name", "greeter")
+	if err := l.Log(log.LevelWarn, "msg", "slow", "code", 200, "odd"); err != nil {
+		t.Fatal(err)
+	}
+	want := "WARN service.name=greeter msg=slow code=200 odd=KEYVALS UNPAIRED\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestTextLoggerMatchesStd(t *testing.T) {
+	var text, std bytes.Buffer
+	keyvals := []interface{}{"msg", "hello", "code", 200, "ok", true, "err", errors.New("boom")}
+	if err := NewTextLogger(&text, "", nil).Log(log.LevelError, keyvals...); err != nil {
+		t.Fatal(err)
+	}
+	if err := log.NewStdLogger(&std).Log(log.LevelError, keyvals...); err != nil {
+		t.Fatal(err)
+	}
+	if text.String() != std.String() {
+		t.Errorf("text logger = %q, std logger = %q", text.String(), std.String())
+	}
+}
+
+func BenchmarkTextLogger(b *testing.B) {
+	l := NewTextLogger(io.Discard, time.RFC3339, time.UTC).WithFields("service.name", "greeter")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = l.Log(log.LevelInfo, benchKeyvals...)
+	}
+}
+
+func BenchmarkTextLoggerParallel(b *testing.B) {
+	l := NewTextLogger(io.Discard, time.RFC3339, time.UTC)
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = l.Log(log.LevelInfo, benchKeyvals...)
+		}
+	})
+}
+
+// BenchmarkStdLogger 对照组: kratos 默认的 std logger
+func BenchmarkStdLogger(b *testing.B) {
+	l := log.With(log.NewStdLogger(io.Discard), "ts", log.Timestamp(time.RFC3339), "service.name", "greeter")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = l.Log(log.LevelInfo, benchKeyvals...)
+	}
+}
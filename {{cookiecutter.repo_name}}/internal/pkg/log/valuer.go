@@ -0,0 +1,87 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// contextKey 上下文字段的 key 类型，避免与其他包冲突
+type contextKey string
+
+const (
+	// UserIDKey 用户 ID 的上下文 key
+	UserIDKey contextKey = "user_id"
+	// TenantIDKey 租户 ID 的上下文 key
+	TenantIDKey contextKey = "tenant_id"
+	// RequestIDKey 请求 ID 的上下文 key
+	RequestIDKey contextKey = "request_id"
+)
+
+var (
+	fieldsMu sync.Mutex
+	fields   = []contextField{
+		{name: string(UserIDKey), key: UserIDKey},
+		{name: string(TenantIDKey), key: TenantIDKey},
+		{name: string(RequestIDKey), key: RequestIDKey},
+	}
+)
+
+// contextField 输出到日志的上下文字段
+type contextField struct {
+	name string
+	key  interface{}
+}
+
+// WithUserID 将用户 ID 写入上下文
+func WithUserID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, UserIDKey, id)
+}
+
+// WithTenantID 将租户 ID 写入上下文
+func WithTenantID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, TenantIDKey, id)
+}
+
+// WithRequestID 将请求 ID 写入上下文
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, RequestIDKey, id)
+}
+
+// RegisterContextField 注册自定义上下文字段，name 为日志字段名，key 为 context.Value 的 key
+// 需要在调用 ContextValuers 创建日志器之前注册
+func RegisterContextField(name string, key interface{}) {
+	fieldsMu.Lock()
+	defer fieldsMu.Unlock()
+	fields = append(fields, contextField{name: name, key: key})
+}
+
+// ContextValuer 从上下文中读取 key 对应的值，不存在时返回空字符串
+func ContextValuer(key interface{}) log.Valuer {
+	return func(ctx context.Context) interface{} {
+		if ctx == nil {
+			return ""
+		}
+		v := ctx.Value(key)
+		if v == nil {
+			return ""
+		}
+		if s, ok := v.(string); ok {
+			return s
+		}
+		return fmt.Sprint(v)
+	}
+}
+
+// ContextValuers 返回所有已注册上下文字段的 keyvals，用于 log.With
+func ContextValuers() []interface{} {
+	fieldsMu.Lock()
+	defer fieldsMu.Unlock()
+	kvs := make([]interface{}, 0, len(fields)*2)
+	for _, f := range fields {
+		kvs = append(kvs, f.name, ContextValuer(f.key))
+	}
+	return kvs
+}
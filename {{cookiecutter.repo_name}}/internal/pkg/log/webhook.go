@@ -0,0 +1,187 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"{{cookiecutter.module_name}}/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// WebhookAlert 日志告警，将错误日志 POST 到告警机器人，如钉钉、Slack
+// 按分钟限流，超出部分丢弃并在下一条告警中带出丢弃数量，发送在后台协程中执行，不阻塞日志调用
+type WebhookAlert struct {
+	url    string
+	format string
+	client *http.Client
+	host   string
+
+	mu      sync.Mutex
+	limit   bucket
+	rate    float64 // 每秒令牌数
+	burst   float64
+	entries chan alertEntry
+
+	done chan struct{}
+	wg   sync.WaitGroup
+	once sync.Once
+}
+
+// alertEntry 待发送的告警
+type alertEntry struct {
+	time    time.Time
+	level   log.Level
+	msg     string
+	fields  map[string]interface{}
+	dropped int
+}
+
+// NewWebhookAlert 创建日志告警
+func NewWebhookAlert(c *conf.Log_Alert) *WebhookAlert {
+	timeout := 3 * time.Second
+	if c.Timeout != nil {
+		timeout = c.Timeout.AsDuration()
+	}
+	perMinute := float64(c.RateLimit)
+	if perMinute <= 0 {
+		perMinute = 10
+	}
+	host, _ := os.Hostname()
+	a := &WebhookAlert{
+		url:     c.WebhookUrl,
+		format:  strings.ToLower(c.Format),
+		client:  &http.Client{Timeout: timeout},
+		host:    host,
+		rate:    perMinute / 60,
+		burst:   perMinute,
+		limit:   bucket{tokens: perMinute, last: time.Now()},
+		entries: make(chan alertEntry, 64),
+		done:    make(chan struct{}),
+	}
+	a.wg.Add(1)
+	go a.run()
+	return a
+}
+
+// Fire 实现 Hook
+func (a *WebhookAlert) Fire(level log.Level, msg string, fields map[string]interface{}) {
+	now := time.Now()
+	a.mu.Lock()
+	a.limit.tokens += now.Sub(a.limit.last).Seconds() * a.rate
+	if a.limit.tokens > a.burst {
+		a.limit.tokens = a.burst
+	}
+	a.limit.last = now
+	if a.limit.tokens < 1 {
+		a.limit.dropped++
+		a.mu.Unlock()
+		return
+	}
+	a.limit.tokens--
+	dropped := a.limit.dropped
+	a.limit.dropped = 0
+	a.mu.Unlock()
+
+	select {
+	case a.entries <- alertEntry{time: now, level: level, msg: msg, fields: fields, dropped: dropped}:
+	default:
+	}
+}
+
+// Close 停止后台发送，等待已入队的告警发送完成
+func (a *WebhookAlert) Close() error {
+	a.once.Do(func() {
+		close(a.done)
+		a.wg.Wait()
+	})
+	return nil
+}
+
+// run 后台发送告警
+func (a *WebhookAlert) run() {
+	defer a.wg.Done()
+	for {
+		select {
+		case e := <-a.entries:
+			a.send(e)
+		case <-a.done:
+			for {
+				select {
+				case e := <-a.entries:
+					a.send(e)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// send 发送单条告警，失败时输出到标准错误，避免告警失败再次触发告警
+func (a *WebhookAlert) send(e alertEntry) {
+	b, err := json.Marshal(a.payload(e))
+	if err != nil {
+		return
+	}
+	resp, err := a.client.Post(a.url, "application/json", bytes.NewReader(b))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "log alert: post webhook failed: %v\n", err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		fmt.Fprintf(os.Stderr, "log alert: unexpected status: %s\n", resp.Status)
+	}
+}
+
+// payload 按机器人类型生成请求体
+func (a *WebhookAlert) payload(e alertEntry) interface{} {
+	switch a.format {
+	case "dingtalk":
+		return map[string]interface{}{
+			"msgtype": "text",
+			"text":    map[string]string{"content": a.text(e)},
+		}
+	case "slack":
+		return map[string]string{"text": a.text(e)}
+	default:
+		fields := make(map[string]interface{}, len(e.fields))
+		for k, v := range e.fields {
+			fields[k] = fmt.Sprint(v)
+		}
+		return map[string]interface{}{
+			"time":    e.time.Format(time.RFC3339),
+			"level":   e.level.String(),
+			"host":    a.host,
+			"msg":     e.msg,
+			"fields":  fields,
+			"dropped": e.dropped,
+		}
+	}
+}
+
+// text 生成文本告警内容
+func (a *WebhookAlert) text(e alertEntry) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%s] %s\nhost: %s\ntime: %s\n", e.level.String(), e.msg, a.host, e.time.Format(time.RFC3339))
+	keys := make([]string, 0, len(e.fields))
+	for k := range e.fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(&b, "%s: %v\n", k, e.fields[k])
+	}
+	if e.dropped > 0 {
+		fmt.Fprintf(&b, "(%d alerts dropped by rate limit)\n", e.dropped)
+	}
+	return b.String()
+}
@@ -0,0 +1,219 @@
+package accesslog
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/go-kratos/kratos/v2/transport/http"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Option 访问日志配置项
+type Option func(*options)
+
+type options struct {
+	captureBody    bool
+	maxBodySize    int
+	contentTypes   []string
+	trustedProxies []*net.IPNet
+}
+
+// WithCaptureBody 记录请求和响应体
+func WithCaptureBody(capture bool) Option {
+	return func(o *options) {
+		o.captureBody = capture
+	}
+}
+
+// WithMaxBodySize 请求和响应体的最大记录长度，超出部分截断
+func WithMaxBodySize(size int) Option {
+	return func(o *options) {
+		o.maxBodySize = size
+	}
+}
+
+// WithContentTypes 只记录指定 Content-Type 的 HTTP 请求体，如 application/json
+func WithContentTypes(types ...string) Option {
+	return func(o *options) {
+		o.contentTypes = types
+	}
+}
+
+// WithTrustedProxies 可信代理的 IP 或 CIDR，如 10.0.0.0/8，
+// 直连地址为可信代理时才从 X-Forwarded-For、X-Real-IP 取客户端 IP，无效的地址忽略
+func WithTrustedProxies(proxies ...string) Option {
+	return func(o *options) {
+		for _, p := range proxies {
+			if n, err := ParseProxy(p); err == nil {
+				o.trustedProxies = append(o.trustedProxies, n)
+			}
+		}
+	}
+}
+
+// ParseProxy 解析可信代理的 IP 或 CIDR，单个 IP 视为只包含该地址的网段
+func ParseProxy(s string) (*net.IPNet, error) {
+	if strings.Contains(s, "/") {
+		_, n, err := net.ParseCIDR(s)
+		return n, err
+	}
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP %q", s)
+	}
+	bits := 8 * net.IPv4len
+	if ip.To4() == nil {
+		bits = 8 * net.IPv6len
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+}
+
+// Server 服务端访问日志中间件
+// 记录 method、path、status、latency、peer，以及可选的截断后的请求和响应体
+func Server(logger log.Logger, opts ...Option) middleware.Middleware {
+	o := &options{
+		maxBodySize:  1024,
+		contentTypes: []string{"application/json", "application/grpc"},
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (reply interface{}, err error) {
+			start := time.Now()
+			reply, err = handler(ctx, req)
+
+			var (
+				kind        string
+				method      string
+				path        string
+				peerAddr    string
+				contentType string
+			)
+			if tr, ok := transport.FromServerContext(ctx); ok {
+				kind = tr.Kind().String()
+				method = tr.Operation()
+				path = tr.Operation()
+				contentType = tr.RequestHeader().Get("Content-Type")
+				if ht, ok := tr.(http.Transporter); ok {
+					r := ht.Request()
+					method = r.Method
+					path = r.URL.Path
+					peerAddr = o.httpPeer(r)
+				}
+			}
+			if peerAddr == "" {
+				if p, ok := peer.FromContext(ctx); ok {
+					peerAddr = p.Addr.String()
+				}
+			}
+
+			status := 200
+			if err != nil {
+				status = int(errors.FromError(err).Code)
+			}
+
+			kvs := []interface{}{
+				"kind", kind,
+				"method", method,
+				"path", path,
+				"status", status,
+				"latency", time.Since(start).Seconds(),
+				"peer", peerAddr,
+			}
+			if err != nil {
+				kvs = append(kvs, "error", err.Error())
+			}
+			if o.captureBody && o.allowed(contentType) {
+				kvs = append(kvs,
+					"request", o.body(req),
+					"response", o.body(reply),
+				)
+			}
+			_ = logger.Log(log.LevelInfo, kvs...)
+			return
+		}
+	}
+}
+
+// allowed 判断 Content-Type 是否需要记录请求体
+func (o *options) allowed(contentType string) bool {
+	if len(o.contentTypes) == 0 {
+		return true
+	}
+	for _, t := range o.contentTypes {
+		if strings.HasPrefix(contentType, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// body 序列化请求或响应体并截断到最大长度
+func (o *options) body(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	var s string
+	if m, ok := v.(proto.Message); ok {
+		b, err := protojson.Marshal(m)
+		if err != nil {
+			return ""
+		}
+		s = string(b)
+	} else {
+		s = fmt.Sprintf("%+v", v)
+	}
+	if o.maxBodySize > 0 && len(s) > o.maxBodySize {
+		s = s[:o.maxBodySize] + "...(truncated)"
+	}
+	return s
+}
+
+// httpPeer 获取客户端 IP，直连地址为可信代理时才使用代理透传的 X-Forwarded-For 和 X-Real-IP，
+// X-Forwarded-For 从右向左跳过可信代理，取第一个不可信的地址，客户端伪造的左侧地址不会被采用
+func (o *options) httpPeer(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if !o.trusted(host) {
+		return host
+	}
+	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
+		hops := strings.Split(xff, ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			ip := strings.TrimSpace(hops[i])
+			if i == 0 || !o.trusted(ip) {
+				return ip
+			}
+		}
+	}
+	if ip := r.Header.Get("X-Real-IP"); ip != "" {
+		return ip
+	}
+	return host
+}
+
+// trusted 判断地址是否为可信代理
+func (o *options) trusted(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, n := range o.trustedProxies {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
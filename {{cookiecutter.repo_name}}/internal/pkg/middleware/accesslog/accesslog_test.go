@@ -0,0 +1,44 @@
+package accesslog
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPPeer(t *testing.T) {
+	o := &options{}
+	WithTrustedProxies("10.0.0.0/8", "192.168.1.1", "bad")(o)
+	if len(o.trustedProxies) != 2 {
+		t.Fatalf("parsed %d trusted proxies, want 2", len(o.trustedProxies))
+	}
+
+	tests := []struct {
+		name   string
+		remote string
+		xff    string
+		realIP string
+		want   string
+	}{
+		{"direct client ignores headers", "203.0.113.7:5000", "1.2.3.4", "5.6.7.8", "203.0.113.7"},
+		{"trusted proxy", "10.0.0.2:5000", "198.51.100.1", "", "198.51.100.1"},
+		{"spoofed left hop skipped", "10.0.0.2:5000", "1.2.3.4, 198.51.100.1, 10.0.0.5", "", "198.51.100.1"},
+		{"all hops trusted", "192.168.1.1:5000", "10.0.0.9, 10.0.0.5", "", "10.0.0.9"},
+		{"real ip from trusted proxy", "192.168.1.1:5000", "", "198.51.100.2", "198.51.100.2"},
+		{"trusted proxy without headers", "10.0.0.2:5000", "", "", "10.0.0.2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.RemoteAddr = tt.remote
+			if tt.xff != "" {
+				r.Header.Set("X-Forwarded-For", tt.xff)
+			}
+			if tt.realIP != "" {
+				r.Header.Set("X-Real-IP", tt.realIP)
+			}
+			if got := o.httpPeer(r); got != tt.want {
+				t.Errorf("httpPeer() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package deprecation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"{{cookiecutter.module_name}}/api/options"
+	pkglog "{{cookiecutter.module_name}}/internal/pkg/log"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const (
+	// DeprecationHeader 接口已废弃响应头
+	DeprecationHeader = "Deprecation"
+	// SunsetHeader 接口下线时间响应头，RFC 8594
+	SunsetHeader = "Sunset"
+	// LinkHeader 迁移文档响应头
+	LinkHeader = "Link"
+	// ClientHeader 调用方标识请求头，用于统计仍在调用废弃接口的客户端
+	ClientHeader = "X-Client-Name"
+)
+
+// info 废弃接口信息，nil 表示接口未废弃
+type info struct {
+	sunset      string
+	replacement string
+	link        string
+}
+
+// Server 废弃接口中间件
+// 根据 proto 中的 deprecated 和 (api.options.deprecation) 选项，
+// 返回 Deprecation/Sunset/Link 响应头，记录调用方日志并统计调用次数
+func Server(logger log.Logger) middleware.Middleware {
+	helper := log.NewHelper(logger)
+	counter, _ := otel.Meter("deprecation").Int64Counter(
+		"api.deprecated.requests",
+		metric.WithDescription("Number of calls to deprecated APIs"),
+	)
+	var cache sync.Map // operation -> *info
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok {
+				return handler(ctx, req)
+			}
+			v, ok := cache.Load(tr.Operation())
+			if !ok {
+				v, _ = cache.LoadOrStore(tr.Operation(), lookup(tr.Operation()))
+			}
+			d := v.(*info)
+			if d == nil {
+				return handler(ctx, req)
+			}
+
+			tr.ReplyHeader().Set(DeprecationHeader, "true")
+			if d.sunset != "" {
+				tr.ReplyHeader().Set(SunsetHeader, d.sunset)
+			}
+			if d.link != "" {
+				tr.ReplyHeader().Set(LinkHeader, fmt.Sprintf(`<%s>; rel="deprecation"`, d.link))
+			}
+
+			client := caller(ctx, tr)
+			if counter != nil {
+				counter.Add(ctx, 1, metric.WithAttributes(
+					attribute.String("operation", tr.Operation()),
+					attribute.String("client", client),
+				))
+			}
+			helper.WithContext(ctx).Warnw(
+				log.DefaultMessageKey, "deprecated api called",
+				"operation", tr.Operation(),
+				"client", client,
+				"sunset", d.sunset,
+				"replacement", d.replacement,
+			)
+			return handler(ctx, req)
+		}
+	}
+}
+
+// lookup 查找接口的废弃信息，operation 格式为 /package.Service/Method
+func lookup(operation string) *info {
+	name := protoreflect.FullName(strings.Replace(strings.TrimPrefix(operation, "/"), "/", ".", 1))
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(name)
+	if err != nil {
+		return nil
+	}
+	md, ok := desc.(protoreflect.MethodDescriptor)
+	if !ok {
+		return nil
+	}
+	opts, ok := md.Options().(*descriptorpb.MethodOptions)
+	if !ok || opts == nil {
+		return nil
+	}
+	ext, _ := proto.GetExtension(opts, options.E_Deprecation).(*options.Deprecation)
+	if !opts.GetDeprecated() && ext == nil {
+		return nil
+	}
+	d := &info{
+		replacement: ext.GetReplacement(),
+		link:        ext.GetLink(),
+	}
+	if ext.GetSunset() != "" {
+		if t, err := time.Parse("2006-01-02", ext.GetSunset()); err == nil {
+			d.sunset = t.UTC().Format(http.TimeFormat)
+		}
+	}
+	return d
+}
+
+// caller 调用方标识，依次使用上下文中的用户 ID、X-Client-Name、User-Agent
+func caller(ctx context.Context, tr transport.Transporter) string {
+	if v, ok := ctx.Value(pkglog.UserIDKey).(string); ok && v != "" {
+		return "user:" + v
+	}
+	if v := tr.RequestHeader().Get(ClientHeader); v != "" {
+		return v
+	}
+	if v := tr.RequestHeader().Get("User-Agent"); v != "" {
+		return v
+	}
+	return "unknown"
+}
@@ -0,0 +1,169 @@
+package duplicate
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	// IdempotencyKeyHeader 幂等键请求头，优先于请求 ID
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// RequestIDHeader 请求 ID 请求头
+	RequestIDHeader = "X-Request-ID"
+	// DuplicateHeader 重复请求响应头，值为窗口期内第几次收到该请求
+	DuplicateHeader = "X-Duplicate-Request"
+	// ReplayedHeader 响应为首次请求结果的重放
+	ReplayedHeader = "X-Duplicate-Replayed"
+
+	// ReasonInProgress 首次请求仍在处理中
+	ReasonInProgress = "DUPLICATE_REQUEST_IN_PROGRESS"
+)
+
+// Option 重复请求检测配置项
+type Option func(*options)
+
+type options struct {
+	window     time.Duration
+	dedup      bool
+	maxEntries int
+}
+
+// WithWindow 检测窗口，默认 1m
+func WithWindow(d time.Duration) Option {
+	return func(o *options) {
+		o.window = d
+	}
+}
+
+// WithDedup 自动去重，重复请求直接返回首次请求的结果，首次请求未完成时返回 409
+func WithDedup(dedup bool) Option {
+	return func(o *options) {
+		o.dedup = dedup
+	}
+}
+
+// WithMaxEntries 最多记录的请求数，超出后不再记录新请求，默认 100000
+func WithMaxEntries(n int) Option {
+	return func(o *options) {
+		o.maxEntries = n
+	}
+}
+
+// entry 窗口期内的一个请求
+type entry struct {
+	seen  time.Time
+	count int
+	done  bool
+	reply interface{}
+	err   error
+}
+
+// Server 重复请求检测中间件
+// 以 Idempotency-Key 或 X-Request-ID 识别客户端重试，重复请求计数并在响应头中标记，
+// 用于排查客户端重试和网关重试风暴，开启去重后重复请求不再执行业务逻辑
+func Server(logger log.Logger, opts ...Option) middleware.Middleware {
+	o := &options{
+		window:     time.Minute,
+		maxEntries: 100000,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	helper := log.NewHelper(logger)
+	counter, _ := otel.Meter("duplicate").Int64Counter(
+		"api.duplicate.requests",
+		metric.WithDescription("Number of duplicate requests retried by clients"),
+	)
+
+	var (
+		mu        sync.Mutex
+		entries   = make(map[string]*entry)
+		lastPurge time.Time
+	)
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok {
+				return handler(ctx, req)
+			}
+			header := IdempotencyKeyHeader
+			id := tr.RequestHeader().Get(header)
+			if id == "" {
+				header = RequestIDHeader
+				id = tr.RequestHeader().Get(header)
+			}
+			if id == "" {
+				return handler(ctx, req)
+			}
+			// 回显请求标识，便于客户端和网关日志关联
+			tr.ReplyHeader().Set(header, id)
+			key := tr.Operation() + "|" + id
+			now := time.Now()
+
+			mu.Lock()
+			if now.Sub(lastPurge) > o.window {
+				for k, e := range entries {
+					if now.Sub(e.seen) > o.window {
+						delete(entries, k)
+					}
+				}
+				lastPurge = now
+			}
+			e, ok := entries[key]
+			if ok && now.Sub(e.seen) > o.window {
+				ok = false
+			}
+			if !ok {
+				var stored *entry
+				if len(entries) < o.maxEntries {
+					stored = &entry{seen: now, count: 1}
+					entries[key] = stored
+				}
+				mu.Unlock()
+
+				reply, err := handler(ctx, req)
+				if stored != nil && o.dedup {
+					mu.Lock()
+					stored.done, stored.reply, stored.err = true, reply, err
+					mu.Unlock()
+				}
+				return reply, err
+			}
+			e.count++
+			count, done, reply, err := e.count, e.done, e.reply, e.err
+			mu.Unlock()
+
+			tr.ReplyHeader().Set(DuplicateHeader, strconv.Itoa(count))
+			if counter != nil {
+				counter.Add(ctx, 1, metric.WithAttributes(
+					attribute.String("operation", tr.Operation()),
+					attribute.Bool("deduplicated", o.dedup),
+				))
+			}
+			helper.WithContext(ctx).Warnw(
+				log.DefaultMessageKey, "duplicate request",
+				"operation", tr.Operation(),
+				"request_id", id,
+				"count", count,
+			)
+			if !o.dedup {
+				return handler(ctx, req)
+			}
+			if !done {
+				return nil, errors.Conflict(ReasonInProgress, "the original request is still in progress")
+			}
+			tr.ReplyHeader().Set(ReplayedHeader, "true")
+			return reply, err
+		}
+	}
+}
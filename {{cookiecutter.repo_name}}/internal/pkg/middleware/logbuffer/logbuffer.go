@@ -0,0 +1,78 @@
+package logbuffer
+
+import (
+	"context"
+	"time"
+
+	pkglog "{{cookiecutter.module_name}}/internal/pkg/log"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+)
+
+// Option 请求日志缓冲配置项
+type Option func(*options)
+
+type options struct {
+	budget   time.Duration
+	maxLines int
+}
+
+// WithLatencyBudget 耗时超过该值的请求输出缓冲的日志，0 表示只在失败时输出
+func WithLatencyBudget(d time.Duration) Option {
+	return func(o *options) {
+		o.budget = d
+	}
+}
+
+// WithMaxLines 单个请求最多缓冲的日志条数，默认 256
+func WithMaxLines(n int) Option {
+	return func(o *options) {
+		o.maxLines = n
+	}
+}
+
+// Server 请求日志缓冲中间件
+// 请求内的 debug/info 日志先写入缓冲区，请求返回错误、panic 或超过耗时预算时完整输出，
+// 否则只输出一条汇总日志，需要日志器使用 pkglog.BufferLogger 包装
+func Server(logger log.Logger, opts ...Option) middleware.Middleware {
+	o := &options{maxLines: 256}
+	for _, opt := range opts {
+		opt(o)
+	}
+	helper := log.NewHelper(logger)
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (reply interface{}, err error) {
+			buf := pkglog.NewRequestBuffer(o.maxLines)
+			start := time.Now()
+			defer func() {
+				if r := recover(); r != nil {
+					buf.Flush()
+					panic(r)
+				}
+			}()
+
+			reply, err = handler(pkglog.WithRequestBuffer(ctx, buf), req)
+
+			latency := time.Since(start)
+			if err != nil || (o.budget > 0 && latency > o.budget) {
+				buf.Flush()
+				return
+			}
+			if n := buf.Len(); n > 0 {
+				var operation string
+				if tr, ok := transport.FromServerContext(ctx); ok {
+					operation = tr.Operation()
+				}
+				helper.WithContext(ctx).Infow(
+					log.DefaultMessageKey, "request completed",
+					"operation", operation,
+					"latency", latency.Seconds(),
+					"suppressed", n,
+				)
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package slowlog
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/go-kratos/kratos/v2/transport/http"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Server 慢请求日志中间件，耗时超过 threshold 的请求记录完整的请求信息
+func Server(logger log.Logger, threshold time.Duration) middleware.Middleware {
+	return Dynamic(logger, func() time.Duration { return threshold })
+}
+
+// Dynamic 阈值可在运行时变更的慢请求日志中间件，每个请求结束时调用 threshold 获取当前阈值
+func Dynamic(logger log.Logger, threshold func() time.Duration) middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (reply interface{}, err error) {
+			start := time.Now()
+			reply, err = handler(ctx, req)
+			latency := time.Since(start)
+			limit := threshold()
+			if latency < limit {
+				return
+			}
+
+			kvs := []interface{}{
+				"latency", latency.Seconds(),
+				"threshold", limit.Seconds(),
+			}
+			if tr, ok := transport.FromServerContext(ctx); ok {
+				kvs = append(kvs,
+					"kind", tr.Kind().String(),
+					"operation", tr.Operation(),
+				)
+				if ht, ok := tr.(http.Transporter); ok {
+					r := ht.Request()
+					kvs = append(kvs,
+						"method", r.Method,
+						"path", r.URL.Path,
+						"query", r.URL.RawQuery,
+						"peer", r.RemoteAddr,
+					)
+				}
+				header := tr.RequestHeader()
+				for _, k := range header.Keys() {
+					kvs = append(kvs, "header."+k, header.Get(k))
+				}
+			}
+			code := 200
+			if err != nil {
+				code = int(errors.FromError(err).Code)
+				kvs = append(kvs, "error", err.Error())
+			}
+			kvs = append(kvs,
+				"status", code,
+				"request", body(req),
+			)
+			_ = log.WithContext(ctx, logger).Log(log.LevelWarn, kvs...)
+			return
+		}
+	}
+}
+
+// body 序列化请求体
+func body(v interface{}) string {
+	if m, ok := v.(proto.Message); ok {
+		b, err := protojson.Marshal(m)
+		if err != nil {
+			return ""
+		}
+		return string(b)
+	}
+	return fmt.Sprintf("%+v", v)
+}
@@ -0,0 +1,111 @@
+package timeout
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"{{cookiecutter.module_name}}/api/options"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Reason 超过接口延迟预算的错误原因
+const Reason = "DEADLINE_EXCEEDED"
+
+// budgets operation -> time.Duration，0 表示未声明
+var budgets sync.Map
+
+// Budget 接口在 proto 中通过 (api.options.timeout_ms) 声明的延迟预算，未声明时返回 0
+// operation 格式为 /package.Service/Method
+func Budget(operation string) time.Duration {
+	if v, ok := budgets.Load(operation); ok {
+		return v.(time.Duration)
+	}
+	v, _ := budgets.LoadOrStore(operation, lookup(operation))
+	return v.(time.Duration)
+}
+
+// Server 服务端接口超时中间件
+// 按 proto 中声明的延迟预算设置上下文超时，超时后返回 504，并统计超时次数
+// 预算只会缩短 server.http.timeout/server.grpc.timeout 的超时，不会延长
+func Server() middleware.Middleware {
+	counter, _ := otel.Meter("timeout").Int64Counter(
+		"api.timeout.exceeded",
+		metric.WithDescription("Number of requests exceeding the declared latency budget"),
+	)
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok {
+				return handler(ctx, req)
+			}
+			d := Budget(tr.Operation())
+			if d <= 0 {
+				return handler(ctx, req)
+			}
+			ctx, cancel := context.WithTimeout(ctx, d)
+			defer cancel()
+			reply, err := handler(ctx, req)
+			if err != nil && ctx.Err() == context.DeadlineExceeded {
+				if counter != nil {
+					counter.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", tr.Operation())))
+				}
+				return nil, errors.GatewayTimeout(Reason, "request exceeded latency budget of "+d.String()).WithCause(err)
+			}
+			return reply, err
+		}
+	}
+}
+
+// Client 客户端接口超时中间件
+// 调用方上下文未设置更短的超时时，按 proto 中声明的延迟预算设置超时
+func Client() middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tr, ok := transport.FromClientContext(ctx)
+			if !ok {
+				return handler(ctx, req)
+			}
+			d := Budget(tr.Operation())
+			if d <= 0 {
+				return handler(ctx, req)
+			}
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= d {
+				return handler(ctx, req)
+			}
+			ctx, cancel := context.WithTimeout(ctx, d)
+			defer cancel()
+			return handler(ctx, req)
+		}
+	}
+}
+
+// lookup 查找接口的延迟预算，operation 格式为 /package.Service/Method
+func lookup(operation string) time.Duration {
+	name := protoreflect.FullName(strings.Replace(strings.TrimPrefix(operation, "/"), "/", ".", 1))
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(name)
+	if err != nil {
+		return 0
+	}
+	md, ok := desc.(protoreflect.MethodDescriptor)
+	if !ok {
+		return 0
+	}
+	opts, ok := md.Options().(*descriptorpb.MethodOptions)
+	if !ok || opts == nil {
+		return 0
+	}
+	ms, _ := proto.GetExtension(opts, options.E_TimeoutMs).(int64)
+	return time.Duration(ms) * time.Millisecond
+}
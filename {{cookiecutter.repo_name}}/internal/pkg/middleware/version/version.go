@@ -0,0 +1,131 @@
+package version
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+)
+
+const (
+	// DefaultHeader 客户端 API 版本请求头
+	DefaultHeader = "X-API-Version"
+	// MinVersionHeader 服务端支持的最低版本响应头
+	MinVersionHeader = "X-API-Min-Version"
+	// MaxVersionHeader 服务端支持的最高版本响应头
+	MaxVersionHeader = "X-API-Max-Version"
+
+	// Reason 版本不兼容的错误原因
+	Reason = "API_VERSION_UNSUPPORTED"
+)
+
+// Option 版本协商配置项
+type Option func(*options)
+
+type options struct {
+	header   string
+	min      string
+	max      string
+	required bool
+}
+
+// WithHeader 客户端版本请求头，默认 X-API-Version
+func WithHeader(header string) Option {
+	return func(o *options) {
+		o.header = header
+	}
+}
+
+// WithMin 支持的最低版本，为空不限制
+func WithMin(v string) Option {
+	return func(o *options) {
+		o.min = v
+	}
+}
+
+// WithMax 支持的最高版本，为空不限制
+func WithMax(v string) Option {
+	return func(o *options) {
+		o.max = v
+	}
+}
+
+// WithRequired 客户端必须携带版本请求头
+func WithRequired(required bool) Option {
+	return func(o *options) {
+		o.required = required
+	}
+}
+
+// Server 版本协商中间件
+// 响应头返回支持的版本范围，客户端版本不在范围内时返回 426 Upgrade Required
+func Server(opts ...Option) middleware.Middleware {
+	o := &options{header: DefaultHeader}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok {
+				return handler(ctx, req)
+			}
+			if o.min != "" {
+				tr.ReplyHeader().Set(MinVersionHeader, o.min)
+			}
+			if o.max != "" {
+				tr.ReplyHeader().Set(MaxVersionHeader, o.max)
+			}
+
+			v := tr.RequestHeader().Get(o.header)
+			if v == "" {
+				if o.required {
+					return nil, o.unsupported(v, "missing api version")
+				}
+				return handler(ctx, req)
+			}
+			if o.min != "" && Compare(v, o.min) < 0 {
+				return nil, o.unsupported(v, fmt.Sprintf("api version %s is lower than minimum supported %s", v, o.min))
+			}
+			if o.max != "" && Compare(v, o.max) > 0 {
+				return nil, o.unsupported(v, fmt.Sprintf("api version %s is higher than maximum supported %s", v, o.max))
+			}
+			return handler(ctx, req)
+		}
+	}
+}
+
+// unsupported 版本不兼容错误，metadata 中携带支持的版本范围
+func (o *options) unsupported(v, message string) error {
+	return errors.New(426, Reason, message).WithMetadata(map[string]string{
+		"version":     v,
+		"min_version": o.min,
+		"max_version": o.max,
+	})
+}
+
+// Compare 比较点分隔的版本号，如 1.2 与 1.10，a<b 返回 -1，a>b 返回 1，相等返回 0
+func Compare(a, b string) int {
+	as := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bs := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,128 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm/schema"
+)
+
+// ErrSchemaDrift 数据库表结构与模型定义不一致
+var ErrSchemaDrift = errors.New("migrate: schema drift detected")
+
+// Table 模型期望的表结构
+type Table struct {
+	Name    string
+	Columns []string
+}
+
+// Drift 表结构差异
+type Drift struct {
+	Table  string
+	Column string // 为空时表示整张表缺失
+}
+
+// String 差异描述
+func (d Drift) String() string {
+	if d.Column == "" {
+		return "missing table " + d.Table
+	}
+	return "missing column " + d.Table + "." + d.Column
+}
+
+// TablesFromGORM 从 GORM 模型解析期望的表结构，使用默认命名策略，
+// 标记为 -:migration 的字段不参与检查
+func TablesFromGORM(models ...interface{}) ([]Table, error) {
+	cache := &sync.Map{}
+	tables := make([]Table, 0, len(models))
+	for _, m := range models {
+		s, err := schema.Parse(m, cache, schema.NamingStrategy{})
+		if err != nil {
+			return nil, err
+		}
+		t := Table{Name: s.Table}
+		for _, name := range s.DBNames {
+			if f := s.FieldsByDBName[name]; f != nil && f.IgnoreMigration {
+				continue
+			}
+			t.Columns = append(t.Columns, name)
+		}
+		tables = append(tables, t)
+	}
+	return tables, nil
+}
+
+// CheckDrift 比较期望的表结构与数据库实际结构，返回缺失的表和列
+// 数据库中多出的表和列不视为差异，支持 mysql、postgres、sqlite
+func CheckDrift(ctx context.Context, db *sql.DB, driver string, tables []Table) ([]Drift, error) {
+	live, err := liveColumns(ctx, db, driver, tables)
+	if err != nil {
+		return nil, err
+	}
+	var drifts []Drift
+	for _, t := range tables {
+		cols, ok := live[strings.ToLower(t.Name)]
+		if !ok {
+			drifts = append(drifts, Drift{Table: t.Name})
+			continue
+		}
+		for _, c := range t.Columns {
+			if !cols[strings.ToLower(c)] {
+				drifts = append(drifts, Drift{Table: t.Name, Column: c})
+			}
+		}
+	}
+	return drifts, nil
+}
+
+// liveColumns 查询数据库中的列，key 为小写的表名和列名
+func liveColumns(ctx context.Context, db *sql.DB, driver string, tables []Table) (map[string]map[string]bool, error) {
+	live := make(map[string]map[string]bool)
+	add := func(rows *sql.Rows) error {
+		defer rows.Close()
+		for rows.Next() {
+			var table, column string
+			if err := rows.Scan(&table, &column); err != nil {
+				return err
+			}
+			table = strings.ToLower(table)
+			if live[table] == nil {
+				live[table] = make(map[string]bool)
+			}
+			live[table][strings.ToLower(column)] = true
+		}
+		return rows.Err()
+	}
+
+	switch driver {
+	case "mysql":
+		rows, err := db.QueryContext(ctx, "SELECT table_name, column_name FROM information_schema.columns WHERE table_schema = DATABASE()")
+		if err != nil {
+			return nil, err
+		}
+		return live, add(rows)
+	case "postgres", "pgx":
+		rows, err := db.QueryContext(ctx, "SELECT table_name, column_name FROM information_schema.columns WHERE table_schema = current_schema()")
+		if err != nil {
+			return nil, err
+		}
+		return live, add(rows)
+	case "sqlite", "sqlite3":
+		for _, t := range tables {
+			rows, err := db.QueryContext(ctx, "SELECT ?, name FROM pragma_table_info(?)", t.Name, t.Name)
+			if err != nil {
+				return nil, err
+			}
+			if err := add(rows); err != nil {
+				return nil, err
+			}
+		}
+		return live, nil
+	default:
+		return nil, fmt.Errorf("migrate: unsupported driver %s", driver)
+	}
+}
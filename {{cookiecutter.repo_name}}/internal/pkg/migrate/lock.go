@@ -0,0 +1,107 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// ErrLockTimeout 等待迁移锁超时
+var ErrLockTimeout = fmt.Errorf("migrate: wait for lock timeout")
+
+// WithLock 持有数据库 advisory lock 执行迁移，保证滚动发布时只有一个副本执行迁移，
+// 其他副本阻塞等待，拿到锁后再执行 fn，此时迁移通常已完成，fn 需要是幂等的
+// 支持 mysql、postgres，sqlite 为单机数据库直接执行
+func WithLock(ctx context.Context, db *sql.DB, driver, name string, timeout time.Duration, logger log.Logger, fn func(ctx context.Context) error) error {
+	if driver == "sqlite" || driver == "sqlite3" {
+		return fn(ctx)
+	}
+	if timeout <= 0 {
+		timeout = 5 * time.Minute
+	}
+	helper := log.NewHelper(logger)
+
+	// advisory lock 与会话绑定，加锁和解锁必须使用同一个连接
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	helper.Infof("migrate: waiting for lock %s", name)
+	start := time.Now()
+	var unlock func() error
+	switch driver {
+	case "mysql":
+		unlock, err = mysqlLock(ctx, conn, name, timeout)
+	case "postgres", "pgx":
+		unlock, err = postgresLock(ctx, conn, name, timeout)
+	default:
+		return fmt.Errorf("migrate: unsupported driver %s", driver)
+	}
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := unlock(); err != nil {
+			helper.Errorf("migrate: release lock %s failed: %v", name, err)
+		}
+	}()
+	helper.Infof("migrate: acquired lock %s after %s", name, time.Since(start))
+
+	return fn(ctx)
+}
+
+// mysqlLock 使用 GET_LOCK 加锁
+func mysqlLock(ctx context.Context, conn *sql.Conn, name string, timeout time.Duration) (func() error, error) {
+	var ok sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", name, int(timeout.Seconds())).Scan(&ok); err != nil {
+		return nil, err
+	}
+	if !ok.Valid {
+		return nil, fmt.Errorf("migrate: get lock %s failed", name)
+	}
+	if ok.Int64 != 1 {
+		return nil, ErrLockTimeout
+	}
+	return func() error {
+		_, err := conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK(?)", name)
+		return err
+	}, nil
+}
+
+// postgresLock 使用 pg_try_advisory_lock 轮询加锁，直到超时
+func postgresLock(ctx context.Context, conn *sql.Conn, name string, timeout time.Duration) (func() error, error) {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+	key := int64(h.Sum64())
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		var ok bool
+		if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&ok); err != nil {
+			if ctx.Err() == context.DeadlineExceeded {
+				return nil, ErrLockTimeout
+			}
+			return nil, err
+		}
+		if ok {
+			return func() error {
+				_, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", key)
+				return err
+			}, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ErrLockTimeout
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"text/tabwriter"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pressly/goose/v3"
+)
+
+// Runner 执行嵌入的版本化 SQL 迁移，版本记录在 goose_db_version 表中
+//
+// 迁移文件使用 goose 格式，文件名以版本号开头，如 00002_add_index.sql：
+//
+//	-- +goose Up
+//	CREATE INDEX idx_hello ON t (hello);
+//	-- +goose Down
+//	DROP INDEX idx_hello ON t;
+type Runner struct {
+	provider *goose.Provider
+	db       *sql.DB
+	driver   string
+	lock     string
+	timeout  time.Duration
+	logger   log.Logger
+	log      *log.Helper
+}
+
+// NewRunner 创建迁移执行器，fsys 根目录下为当前驱动的迁移文件，lock 为迁移锁名称，连接池由调用方关闭
+func NewRunner(db *sql.DB, driver string, fsys fs.FS, lock string, timeout time.Duration, logger log.Logger) (*Runner, error) {
+	dialect, err := gooseDialect(driver)
+	if err != nil {
+		return nil, err
+	}
+	p, err := goose.NewProvider(dialect, db, fsys, goose.WithDisableGlobalRegistry(true))
+	if err != nil {
+		return nil, fmt.Errorf("migrate: %w", err)
+	}
+	return &Runner{
+		provider: p,
+		db:       db,
+		driver:   driver,
+		lock:     lock,
+		timeout:  timeout,
+		logger:   logger,
+		log:      log.NewHelper(logger),
+	}, nil
+}
+
+// Up 持有迁移锁执行全部未执行的迁移，已是最新版本时不做任何变更
+func (r *Runner) Up(ctx context.Context) error {
+	return WithLock(ctx, r.db, r.driver, r.lock, r.timeout, r.logger, func(ctx context.Context) error {
+		results, err := r.provider.Up(ctx)
+		for _, res := range results {
+			r.log.Infof("migrate: up %s (%s)", path.Base(res.Source.Path), res.Duration)
+		}
+		if err != nil {
+			return fmt.Errorf("migrate: up: %w", err)
+		}
+		if len(results) == 0 {
+			r.log.Info("migrate: no pending migrations")
+		}
+		return nil
+	})
+}
+
+// Down 持有迁移锁回滚最近一次迁移
+func (r *Runner) Down(ctx context.Context) error {
+	return WithLock(ctx, r.db, r.driver, r.lock, r.timeout, r.logger, func(ctx context.Context) error {
+		res, err := r.provider.Down(ctx)
+		if err != nil {
+			return fmt.Errorf("migrate: down: %w", err)
+		}
+		r.log.Infof("migrate: down %s (%s)", path.Base(res.Source.Path), res.Duration)
+		return nil
+	})
+}
+
+// Status 输出每个迁移的执行状态，只读操作不需要加锁
+func (r *Runner) Status(ctx context.Context, w io.Writer) error {
+	statuses, err := r.provider.Status(ctx)
+	if err != nil {
+		return fmt.Errorf("migrate: status: %w", err)
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "VERSION\tSTATE\tAPPLIED AT\tFILE")
+	for _, s := range statuses {
+		applied := "-"
+		if !s.AppliedAt.IsZero() {
+			applied = s.AppliedAt.Local().Format(time.DateTime)
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", s.Source.Version, s.State, applied, path.Base(s.Source.Path))
+	}
+	return tw.Flush()
+}
+
+// gooseDialect 驱动对应的 goose 方言
+func gooseDialect(driver string) (goose.Dialect, error) {
+	switch driver {
+	case "mysql":
+		return goose.DialectMySQL, nil
+	case "postgres", "pgx":
+		return goose.DialectPostgres, nil
+	case "sqlite", "sqlite3":
+		return goose.DialectSQLite3, nil
+	default:
+		return "", fmt.Errorf("migrate: unsupported driver %s", driver)
+	}
+}
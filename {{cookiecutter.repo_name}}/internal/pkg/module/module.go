@@ -0,0 +1,197 @@
+package module
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// Switch 模块运行开关，暂停时后台任务在 Wait 处阻塞，恢复后继续
+// 正在执行的任务不会被中断，模块在每批任务开始前调用 Wait
+type Switch struct {
+	name string
+
+	mu     sync.Mutex
+	paused bool
+	resume chan struct{}
+}
+
+// Name 模块名
+func (s *Switch) Name() string {
+	return s.name
+}
+
+// Paused 是否已暂停，nil Switch 始终返回 false
+func (s *Switch) Paused() bool {
+	if s == nil {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.paused
+}
+
+// Wait 暂停时阻塞直到恢复或 ctx 结束，未暂停时立即返回，nil Switch 不阻塞
+func (s *Switch) Wait(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	if !s.paused {
+		s.mu.Unlock()
+		return nil
+	}
+	ch := s.resume
+	s.mu.Unlock()
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// set 设置暂停状态，状态变化时返回 true
+func (s *Switch) set(paused bool) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.paused == paused {
+		return false
+	}
+	s.paused = paused
+	if paused {
+		s.resume = make(chan struct{})
+	} else {
+		close(s.resume)
+	}
+	return true
+}
+
+// Registry 模块开关注册表，可通过管理接口或配置热更新暂停和恢复模块，
+// 用于故障期间在不重新部署的情况下停止定时任务、消息消费等后台负载
+type Registry struct {
+	log *log.Helper
+
+	mu       sync.Mutex
+	switches map[string]*Switch
+	paused   map[string]bool // 配置中要求暂停的模块，对之后注册的模块同样生效
+}
+
+// NewRegistry 创建模块开关注册表
+func NewRegistry(logger log.Logger) *Registry {
+	return &Registry{
+		log:      log.NewHelper(logger),
+		switches: make(map[string]*Switch),
+		paused:   make(map[string]bool),
+	}
+}
+
+// Switch 获取模块开关，不存在时创建
+func (r *Registry) Switch(name string) *Switch {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.switches[name]
+	if !ok {
+		s = &Switch{name: name}
+		if r.paused[name] {
+			s.set(true)
+		}
+		r.switches[name] = s
+	}
+	return s
+}
+
+// Pause 暂停模块，模块不存在时返回 false
+func (r *Registry) Pause(name string) bool {
+	return r.set(name, true)
+}
+
+// Resume 恢复模块，模块不存在时返回 false
+func (r *Registry) Resume(name string) bool {
+	return r.set(name, false)
+}
+
+// SetPaused 按配置设置暂停的模块，未列出的模块全部恢复，配置变更会覆盖管理接口的操作
+func (r *Registry) SetPaused(names []string) {
+	paused := make(map[string]bool, len(names))
+	for _, n := range names {
+		paused[n] = true
+	}
+	r.mu.Lock()
+	r.paused = paused
+	switches := make([]*Switch, 0, len(r.switches))
+	for _, s := range r.switches {
+		switches = append(switches, s)
+	}
+	r.mu.Unlock()
+
+	for _, s := range switches {
+		if s.set(paused[s.name]) {
+			r.log.Infof("module %s paused=%t by config", s.name, paused[s.name])
+		}
+	}
+}
+
+// set 设置模块暂停状态
+func (r *Registry) set(name string, paused bool) bool {
+	r.mu.Lock()
+	s, ok := r.switches[name]
+	r.mu.Unlock()
+	if !ok {
+		return false
+	}
+	if s.set(paused) {
+		r.log.Infof("module %s paused=%t", name, paused)
+	}
+	return true
+}
+
+// Status 模块状态
+type Status struct {
+	Name   string `json:"name"`
+	Paused bool   `json:"paused"`
+}
+
+// List 全部模块状态，按名称排序
+func (r *Registry) List() []Status {
+	r.mu.Lock()
+	list := make([]Status, 0, len(r.switches))
+	for _, s := range r.switches {
+		list = append(list, Status{Name: s.name, Paused: s.Paused()})
+	}
+	r.mu.Unlock()
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// ServeHTTP 模块管理接口，挂载在管理端口
+//
+//	GET  /modules              全部模块状态
+//	POST /modules/{name}:pause  暂停模块
+//	POST /modules/{name}:resume 恢复模块
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	name := strings.Trim(strings.TrimPrefix(req.URL.Path, "/modules"), "/")
+	switch {
+	case req.Method == http.MethodGet && name == "":
+	case req.Method == http.MethodPost && strings.HasSuffix(name, ":pause"):
+		if !r.Pause(strings.TrimSuffix(name, ":pause")) {
+			http.Error(w, "module not found", http.StatusNotFound)
+			return
+		}
+	case req.Method == http.MethodPost && strings.HasSuffix(name, ":resume"):
+		if !r.Resume(strings.TrimSuffix(name, ":resume")) {
+			http.Error(w, "module not found", http.StatusNotFound)
+			return
+		}
+	default:
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(r.List())
+}
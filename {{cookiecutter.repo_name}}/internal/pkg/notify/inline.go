@@ -0,0 +1,222 @@
+package notify
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	cssComment = regexp.MustCompile(`(?s)/\*.*?\*/`)
+	// simpleSelector 可内联的简单选择器: 标签、#id、.class 的组合，不含后代、伪类等
+	simpleSelector = regexp.MustCompile(`^([a-zA-Z][a-zA-Z0-9]*|\*)?(#[\w-]+)?((?:\.[\w-]+)*)$`)
+)
+
+// cssRule 可内联的样式规则
+type cssRule struct {
+	tag         string
+	id          string
+	classes     []string
+	decls       string
+	specificity int
+	order       int
+}
+
+// InlineCSS 将 <style> 中的简单规则内联到元素的 style 属性
+// 元素原有的 style 优先级最高，@media 等无法内联的规则保留在 <style> 中
+func InlineCSS(doc string) (string, error) {
+	root, err := html.Parse(strings.NewReader(doc))
+	if err != nil {
+		return "", err
+	}
+
+	var styles []*html.Node
+	walk(root, func(n *html.Node) {
+		if n.Type == html.ElementNode && n.DataAtom == atom.Style && attr(n, "data-inline") != "false" {
+			styles = append(styles, n)
+		}
+	})
+	if len(styles) == 0 {
+		return doc, nil
+	}
+
+	var rules []cssRule
+	for _, s := range styles {
+		var css strings.Builder
+		for c := s.FirstChild; c != nil; c = c.NextSibling {
+			css.WriteString(c.Data)
+		}
+		inlined, rest := parseCSS(css.String(), len(rules))
+		rules = append(rules, inlined...)
+		if strings.TrimSpace(rest) == "" {
+			s.Parent.RemoveChild(s)
+			continue
+		}
+		for c := s.FirstChild; c != nil; c = s.FirstChild {
+			s.RemoveChild(c)
+		}
+		s.AppendChild(&html.Node{Type: html.TextNode, Data: rest})
+	}
+	// 按优先级和出现顺序排列，后面的声明覆盖前面的
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].specificity != rules[j].specificity {
+			return rules[i].specificity < rules[j].specificity
+		}
+		return rules[i].order < rules[j].order
+	})
+
+	walk(root, func(n *html.Node) {
+		if n.Type != html.ElementNode {
+			return
+		}
+		var decls []string
+		for _, r := range rules {
+			if r.match(n) {
+				decls = append(decls, r.decls)
+			}
+		}
+		if len(decls) == 0 {
+			return
+		}
+		if own := strings.TrimSpace(attr(n, "style")); own != "" {
+			decls = append(decls, strings.TrimSuffix(own, ";"))
+		}
+		setAttr(n, "style", strings.Join(decls, "; "))
+	})
+
+	var b strings.Builder
+	if err := html.Render(&b, root); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// parseCSS 拆分样式表，返回可内联的规则和需要保留的样式
+func parseCSS(css string, order int) ([]cssRule, string) {
+	css = cssComment.ReplaceAllString(css, "")
+	var rules []cssRule
+	var rest strings.Builder
+	for {
+		css = strings.TrimSpace(css)
+		if css == "" {
+			break
+		}
+		open := strings.IndexByte(css, '{')
+		if open < 0 {
+			break
+		}
+		end := blockEnd(css, open)
+		selectors, body := strings.TrimSpace(css[:open]), css[open+1:end-1]
+		block := css[:end]
+		css = css[end:]
+
+		if strings.HasPrefix(selectors, "@") {
+			rest.WriteString(block + "\n")
+			continue
+		}
+		decls := strings.TrimSuffix(strings.TrimSpace(body), ";")
+		var keep []string
+		for _, sel := range strings.Split(selectors, ",") {
+			sel = strings.TrimSpace(sel)
+			m := simpleSelector.FindStringSubmatch(sel)
+			if m == nil || sel == "" || strings.Contains(decls, "!important") {
+				keep = append(keep, sel)
+				continue
+			}
+			r := cssRule{tag: strings.ToLower(m[1]), decls: decls, order: order}
+			if r.tag == "*" {
+				r.tag = ""
+			}
+			if m[2] != "" {
+				r.id = m[2][1:]
+				r.specificity += 100
+			}
+			if m[3] != "" {
+				r.classes = strings.Split(m[3][1:], ".")
+				r.specificity += 10 * len(r.classes)
+			}
+			if r.tag != "" {
+				r.specificity++
+			}
+			rules = append(rules, r)
+			order++
+		}
+		if len(keep) > 0 {
+			rest.WriteString(strings.Join(keep, ", ") + " {" + body + "}\n")
+		}
+	}
+	return rules, rest.String()
+}
+
+// blockEnd 匹配 { 对应的 }，返回 } 之后的位置
+func blockEnd(css string, open int) int {
+	depth := 0
+	for i := open; i < len(css); i++ {
+		switch css[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(css)
+}
+
+// match 元素是否匹配规则
+func (r cssRule) match(n *html.Node) bool {
+	if r.tag != "" && r.tag != n.Data {
+		return false
+	}
+	if r.id != "" && attr(n, "id") != r.id {
+		return false
+	}
+	if len(r.classes) > 0 {
+		have := strings.Fields(attr(n, "class"))
+		for _, c := range r.classes {
+			found := false
+			for _, h := range have {
+				if h == c {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// walk 深度优先遍历子节点
+func walk(n *html.Node, fn func(*html.Node)) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		fn(c)
+		walk(c, fn)
+	}
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func setAttr(n *html.Node, key, val string) {
+	for i, a := range n.Attr {
+		if a.Key == key {
+			n.Attr[i].Val = val
+			return
+		}
+	}
+	n.Attr = append(n.Attr, html.Attribute{Key: key, Val: val})
+}
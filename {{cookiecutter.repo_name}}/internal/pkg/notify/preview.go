@@ -0,0 +1,71 @@
+package notify
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"strings"
+)
+
+// previewIndex 模板列表页，使用 [[ ]] 分隔符，避免与脚手架的 Jinja 渲染冲突
+var previewIndex = template.Must(template.New("index").Delims("[[", "]]").Parse(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>Email templates</title></head>
+<body><h1>Email templates</h1><ul>
+[[range .]]<li>[[.]]: <a href="[[.]]">html</a> | <a href="[[.]]?part=text">text</a> | <a href="[[.]]?part=subject">subject</a></li>
+[[end]]</ul></body></html>`))
+
+// Preview 邮件模板预览，使用模板自带的示例数据渲染，只用于开发环境
+//
+//	GET {prefix}/              模板列表
+//	GET {prefix}/{name}        HTML 正文
+//	GET {prefix}/{name}?part=text|subject
+//
+// 每次请求都重新加载模板，修改数据库中的模板后刷新即可看到效果
+func Preview(prefix string, r *Renderer) http.Handler {
+	prefix = strings.TrimSuffix(prefix, "/")
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		name := strings.Trim(strings.TrimPrefix(req.URL.Path, prefix), "/")
+		if name == "" {
+			names, err := r.Names(req.Context())
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			if !strings.HasSuffix(req.URL.Path, "/") {
+				// 列表页的相对链接基于目录
+				http.Redirect(w, req, req.URL.Path+"/", http.StatusFound)
+				return
+			}
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_ = previewIndex.Execute(w, names)
+			return
+		}
+
+		r.Invalidate(name)
+		msg, err := r.Sample(req.Context(), name)
+		if errors.Is(err, ErrTemplateNotFound) {
+			http.NotFound(w, req)
+			return
+		}
+		if err != nil {
+			// 缺失变量等渲染错误直接展示，便于补全示例数据
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		switch req.URL.Query().Get("part") {
+		case "text":
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			_, _ = w.Write([]byte(msg.Text))
+		case "subject":
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			_, _ = w.Write([]byte(msg.Subject))
+		default:
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte(msg.HTML))
+		}
+	})
+}
@@ -0,0 +1,212 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	htemplate "html/template"
+	"os/exec"
+	"strings"
+	"sync"
+	ttemplate "text/template"
+)
+
+// Message 渲染后的邮件内容
+type Message struct {
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// Compiler 将 MJML 源码编译为 HTML
+type Compiler func(ctx context.Context, mjml string) (string, error)
+
+// MJMLCommand 使用 mjml 命令行编译，需要先安装 npm install -g mjml
+func MJMLCommand(bin string) Compiler {
+	if bin == "" {
+		bin = "mjml"
+	}
+	return func(ctx context.Context, src string) (string, error) {
+		cmd := exec.CommandContext(ctx, bin, "-i", "-s")
+		cmd.Stdin = strings.NewReader(src)
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout, cmd.Stderr = &stdout, &stderr
+		if err := cmd.Run(); err != nil {
+			return "", fmt.Errorf("notify: mjml: %v: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		return stdout.String(), nil
+	}
+}
+
+// Option 渲染器配置项
+type Option func(*Renderer)
+
+// WithMJML MJML 编译器，未配置时 MJML 模板无法加载
+func WithMJML(c Compiler) Option {
+	return func(r *Renderer) {
+		r.mjml = c
+	}
+}
+
+// WithInlineCSS 是否将 <style> 中的样式内联到元素的 style 属性，默认开启，
+// 部分邮件客户端会丢弃 <style>，无法内联的 @media 等规则保留在 <style> 中
+func WithInlineCSS(inline bool) Option {
+	return func(r *Renderer) {
+		r.inline = inline
+	}
+}
+
+// WithFuncs 模板中可用的自定义函数
+func WithFuncs(funcs map[string]interface{}) Option {
+	return func(r *Renderer) {
+		for k, v := range funcs {
+			r.funcs[k] = v
+		}
+	}
+}
+
+// Renderer 邮件模板渲染器
+// 模板变量缺失时报错而不是输出空值或 <no value>，避免发出内容残缺的邮件
+type Renderer struct {
+	store  Store
+	mjml   Compiler
+	inline bool
+	funcs  map[string]interface{}
+
+	mu    sync.RWMutex
+	cache map[string]*compiled
+}
+
+// compiled 解析后的模板
+type compiled struct {
+	subject *ttemplate.Template
+	html    *htemplate.Template
+	text    *ttemplate.Template
+	sample  map[string]interface{}
+}
+
+// NewRenderer 创建邮件模板渲染器
+func NewRenderer(store Store, opts ...Option) *Renderer {
+	r := &Renderer{
+		store:  store,
+		inline: true,
+		funcs:  map[string]interface{}{},
+		cache:  make(map[string]*compiled),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Render 使用 data 渲染模板，data 通常为 map[string]interface{} 或结构体
+func (r *Renderer) Render(ctx context.Context, name string, data interface{}) (*Message, error) {
+	t, err := r.load(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return r.execute(name, t, data)
+}
+
+// Sample 使用模板自带的示例数据渲染，用于预览
+func (r *Renderer) Sample(ctx context.Context, name string) (*Message, error) {
+	t, err := r.load(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	data := t.sample
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	return r.execute(name, t, data)
+}
+
+// Names 全部模板名称
+func (r *Renderer) Names(ctx context.Context) ([]string, error) {
+	return r.store.List(ctx)
+}
+
+// Invalidate 清除模板缓存，数据库中的模板修改后调用，name 为空时清除全部
+func (r *Renderer) Invalidate(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if name == "" {
+		r.cache = make(map[string]*compiled)
+		return
+	}
+	delete(r.cache, name)
+}
+
+// execute 渲染主题和正文
+func (r *Renderer) execute(name string, t *compiled, data interface{}) (*Message, error) {
+	var msg Message
+	var buf bytes.Buffer
+	if err := t.subject.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("notify: render %s subject: %w", name, err)
+	}
+	msg.Subject = strings.TrimSpace(buf.String())
+
+	buf.Reset()
+	if err := t.html.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("notify: render %s html: %w", name, err)
+	}
+	msg.HTML = buf.String()
+	if r.inline {
+		html, err := InlineCSS(msg.HTML)
+		if err != nil {
+			return nil, fmt.Errorf("notify: inline %s css: %w", name, err)
+		}
+		msg.HTML = html
+	}
+
+	if t.text != nil {
+		buf.Reset()
+		if err := t.text.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("notify: render %s text: %w", name, err)
+		}
+		msg.Text = buf.String()
+	}
+	return &msg, nil
+}
+
+// load 加载并解析模板，解析结果缓存
+func (r *Renderer) load(ctx context.Context, name string) (*compiled, error) {
+	r.mu.RLock()
+	t, ok := r.cache[name]
+	r.mu.RUnlock()
+	if ok {
+		return t, nil
+	}
+
+	src, err := r.store.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	body := src.Body
+	if src.Format == FormatMJML {
+		if r.mjml == nil {
+			return nil, fmt.Errorf("notify: %s is a MJML template but no compiler is configured", name)
+		}
+		if body, err = r.mjml(ctx, body); err != nil {
+			return nil, err
+		}
+	}
+
+	t = &compiled{sample: src.Sample}
+	if t.subject, err = ttemplate.New(name + ".subject").Option("missingkey=error").Funcs(r.funcs).Parse(src.Subject); err != nil {
+		return nil, fmt.Errorf("notify: parse %s subject: %w", name, err)
+	}
+	if t.html, err = htemplate.New(name + ".html").Option("missingkey=error").Funcs(r.funcs).Parse(body); err != nil {
+		return nil, fmt.Errorf("notify: parse %s html: %w", name, err)
+	}
+	if src.Text != "" {
+		if t.text, err = ttemplate.New(name + ".txt").Option("missingkey=error").Funcs(r.funcs).Parse(src.Text); err != nil {
+			return nil, fmt.Errorf("notify: parse %s text: %w", name, err)
+		}
+	}
+
+	r.mu.Lock()
+	r.cache[name] = t
+	r.mu.Unlock()
+	return t, nil
+}
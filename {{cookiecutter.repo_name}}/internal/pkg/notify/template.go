@@ -0,0 +1,210 @@
+package notify
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+// ErrTemplateNotFound 模板不存在
+var ErrTemplateNotFound = errors.New("notify: template not found")
+
+// Templates 内置邮件模板
+//
+//go:embed templates
+var Templates embed.FS
+
+// Format 邮件正文格式
+type Format string
+
+const (
+	// FormatHTML HTML 正文，直接作为 html/template 解析
+	FormatHTML Format = "html"
+	// FormatMJML MJML 正文，加载时先编译为 HTML
+	FormatMJML Format = "mjml"
+)
+
+// Template 邮件模板源码，主题、正文和纯文本正文均使用 Go 模板语法
+type Template struct {
+	Name    string
+	Subject string
+	Body    string
+	Format  Format
+	Text    string                 // 纯文本正文，为空时不生成
+	Sample  map[string]interface{} // 预览用的示例数据
+}
+
+// Store 模板存储
+type Store interface {
+	// Get 获取模板，不存在时返回 ErrTemplateNotFound
+	Get(ctx context.Context, name string) (*Template, error)
+	// List 全部模板名称
+	List(ctx context.Context) ([]string, error)
+}
+
+var _ Store = (*FSStore)(nil)
+
+// FSStore 文件系统模板存储，适用于 embed 打包的模板
+// 每个模板由同名的多个文件组成:
+//
+//	welcome.subject   主题
+//	welcome.html      HTML 正文，或 welcome.mjml
+//	welcome.txt       纯文本正文，可选
+//	welcome.json      预览示例数据，可选
+type FSStore struct {
+	fsys fs.FS
+}
+
+// NewFSStore 创建文件系统模板存储，dir 为模板所在目录
+func NewFSStore(fsys fs.FS, dir string) (*FSStore, error) {
+	sub, err := fs.Sub(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	return &FSStore{fsys: sub}, nil
+}
+
+// Get 实现 Store 接口
+func (s *FSStore) Get(_ context.Context, name string) (*Template, error) {
+	t := &Template{Name: name}
+	for _, f := range []Format{FormatHTML, FormatMJML} {
+		b, err := fs.ReadFile(s.fsys, name+"."+string(f))
+		if err == nil {
+			t.Body, t.Format = string(b), f
+			break
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	if t.Body == "" {
+		return nil, fmt.Errorf("%w: %s", ErrTemplateNotFound, name)
+	}
+	subject, err := s.read(name + ".subject")
+	if err != nil {
+		return nil, err
+	}
+	t.Subject = strings.TrimSpace(subject)
+	if t.Text, err = s.read(name + ".txt"); err != nil {
+		return nil, err
+	}
+	sample, err := s.read(name + ".json")
+	if err != nil {
+		return nil, err
+	}
+	if sample != "" {
+		if err := json.Unmarshal([]byte(sample), &t.Sample); err != nil {
+			return nil, fmt.Errorf("notify: %s.json: %w", name, err)
+		}
+	}
+	return t, nil
+}
+
+// List 实现 Store 接口
+func (s *FSStore) List(context.Context) ([]string, error) {
+	entries, err := fs.ReadDir(s.fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		ext := path.Ext(e.Name())
+		if !e.IsDir() && (ext == "."+string(FormatHTML) || ext == "."+string(FormatMJML)) {
+			names = append(names, strings.TrimSuffix(e.Name(), ext))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// read 读取可选文件，不存在时返回空字符串
+func (s *FSStore) read(name string) (string, error) {
+	b, err := fs.ReadFile(s.fsys, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	return string(b), err
+}
+
+var _ Store = (*SQLStore)(nil)
+
+// SQLStore 数据库模板存储，运营人员可在不发版的情况下修改模板
+//
+//	CREATE TABLE email_templates (
+//	  name    VARCHAR(64) PRIMARY KEY,
+//	  subject VARCHAR(255) NOT NULL,
+//	  body    TEXT NOT NULL,
+//	  format  VARCHAR(8) NOT NULL DEFAULT 'html',
+//	  text    TEXT,
+//	  sample  TEXT
+//	);
+type SQLStore struct {
+	db     *sql.DB
+	table  string
+	driver string
+}
+
+// NewSQLStore 创建数据库模板存储，driver 用于选择参数占位符
+func NewSQLStore(db *sql.DB, driver, table string) *SQLStore {
+	if table == "" {
+		table = "email_templates"
+	}
+	return &SQLStore{db: db, table: table, driver: driver}
+}
+
+// Get 实现 Store 接口
+func (s *SQLStore) Get(ctx context.Context, name string) (*Template, error) {
+	placeholder := "?"
+	if s.driver == "postgres" || s.driver == "pgx" {
+		placeholder = "$1"
+	}
+	var (
+		t              = &Template{Name: name}
+		format         string
+		text, sampleJS sql.NullString
+	)
+	err := s.db.QueryRowContext(ctx,
+		"SELECT subject, body, format, text, sample FROM "+s.table+" WHERE name = "+placeholder, name,
+	).Scan(&t.Subject, &t.Body, &format, &text, &sampleJS)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrTemplateNotFound, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	t.Format, t.Text = Format(format), text.String
+	if t.Format == "" {
+		t.Format = FormatHTML
+	}
+	if sampleJS.String != "" {
+		if err := json.Unmarshal([]byte(sampleJS.String), &t.Sample); err != nil {
+			return nil, fmt.Errorf("notify: %s sample: %w", name, err)
+		}
+	}
+	return t, nil
+}
+
+// List 实现 Store 接口
+func (s *SQLStore) List(ctx context.Context) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT name FROM "+s.table+" ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
@@ -0,0 +1,61 @@
+package operation
+
+import (
+	"net/http"
+	"strconv"
+
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// Register 注册 Operations API
+//
+//	GET    {prefix}/operations?name=&limit=   列出操作
+//	GET    {prefix}/operations/{id}           查询状态和结果
+//	POST   {prefix}/operations/{id}:cancel    取消操作
+//	DELETE {prefix}/operations/{id}           删除操作
+func Register(srv *khttp.Server, prefix string, m *Manager) {
+	r := srv.Route(prefix)
+	r.GET("/operations", func(ctx khttp.Context) error {
+		limit, _ := strconv.Atoi(ctx.Query().Get("limit"))
+		list, err := m.List(ctx, ctx.Query().Get("name"), limit)
+		if err != nil {
+			return err
+		}
+		return ctx.JSON(http.StatusOK, map[string]interface{}{"operations": list})
+	})
+	r.GET("/operations/{id}", func(ctx khttp.Context) error {
+		op, err := m.Get(ctx, ctx.Vars().Get("id"))
+		if err != nil {
+			return err
+		}
+		return ctx.JSON(http.StatusOK, op)
+	})
+	r.POST("/operations/{id}:cancel", func(ctx khttp.Context) error {
+		op, err := m.Cancel(ctx, ctx.Vars().Get("id"))
+		if err != nil {
+			return err
+		}
+		return ctx.JSON(http.StatusOK, op)
+	})
+	r.DELETE("/operations/{id}", func(ctx khttp.Context) error {
+		if err := m.Delete(ctx, ctx.Vars().Get("id")); err != nil {
+			return err
+		}
+		return ctx.JSON(http.StatusOK, map[string]interface{}{})
+	})
+}
+
+// Accepted 以 202 返回已提交的操作，Location 指向操作状态地址
+// 用于自定义路由中的长时间运行接口:
+//
+//	r.POST("/orders:import", func(ctx khttp.Context) error {
+//		op, err := m.Submit(ctx, "orders.import", fn)
+//		if err != nil {
+//			return err
+//		}
+//		return operation.Accepted(ctx, "/v1", op)
+//	})
+func Accepted(ctx khttp.Context, prefix string, op *Operation) error {
+	ctx.Response().Header().Set("Location", prefix+"/operations/"+op.ID)
+	return ctx.JSON(http.StatusAccepted, op)
+}
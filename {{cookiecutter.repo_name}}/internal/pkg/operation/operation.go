@@ -0,0 +1,320 @@
+package operation
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// Status 操作状态
+type Status string
+
+const (
+	StatusPending   Status = "PENDING"
+	StatusRunning   Status = "RUNNING"
+	StatusSucceeded Status = "SUCCEEDED"
+	StatusFailed    Status = "FAILED"
+	StatusCancelled Status = "CANCELLED"
+)
+
+var (
+	// ErrNotFound 操作不存在或已过期
+	ErrNotFound = errors.NotFound("OPERATION_NOT_FOUND", "operation not found")
+	// ErrQueueFull 任务队列已满
+	ErrQueueFull = errors.ServiceUnavailable("OPERATION_QUEUE_FULL", "too many pending operations")
+)
+
+// Operation 长时间运行的异步操作，参考 google.longrunning.Operation
+type Operation struct {
+	ID              string          `json:"id"`
+	Name            string          `json:"name"` // 操作类型，如 orders.import
+	Status          Status          `json:"status"`
+	Done            bool            `json:"done"`
+	Progress        int             `json:"progress"` // 进度百分比
+	Message         string          `json:"message,omitempty"`
+	Result          json.RawMessage `json:"result,omitempty"`
+	Error           *Error          `json:"error,omitempty"`
+	CancelRequested bool            `json:"cancelRequested,omitempty"`
+	CreatedAt       time.Time       `json:"createdAt"`
+	UpdatedAt       time.Time       `json:"updatedAt"`
+}
+
+// Error 操作失败的错误信息
+type Error struct {
+	Code    int32  `json:"code"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message"`
+}
+
+// Store 操作状态存储，多副本部署时需要使用 Redis 等共享存储
+type Store interface {
+	// Save 保存操作
+	Save(ctx context.Context, op *Operation) error
+	// Get 获取操作，不存在时返回 ErrNotFound
+	Get(ctx context.Context, id string) (*Operation, error)
+	// List 按创建时间倒序列出操作，name 为空时不过滤
+	List(ctx context.Context, name string, limit int) ([]*Operation, error)
+	// Delete 删除操作
+	Delete(ctx context.Context, id string) error
+}
+
+// Func 异步执行的操作，返回值序列化为 JSON 作为操作结果，ctx 在操作被取消时结束
+type Func func(ctx context.Context, p *Progress) (interface{}, error)
+
+// Progress 操作进度上报
+type Progress struct {
+	m  *Manager
+	op *Operation
+}
+
+// Set 更新进度百分比和说明
+func (p *Progress) Set(ctx context.Context, percent int, message string) error {
+	p.op.Progress = percent
+	p.op.Message = message
+	p.op.UpdatedAt = time.Now()
+	return p.m.store.Save(ctx, p.op)
+}
+
+// task 队列中的任务
+type task struct {
+	op *Operation
+	fn Func
+}
+
+// Manager 异步操作管理器，使用有界队列和固定数量的 worker 执行操作
+type Manager struct {
+	store         Store
+	queue         chan *task
+	workers       int
+	checkInterval time.Duration
+	log           *log.Helper
+
+	mu      sync.Mutex
+	running map[string]context.CancelFunc
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewManager 创建异步操作管理器，workers 为并发执行数，queueSize 为排队上限
+func NewManager(store Store, workers, queueSize int, logger log.Logger) *Manager {
+	if workers <= 0 {
+		workers = 4
+	}
+	if queueSize <= 0 {
+		queueSize = 1024
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Manager{
+		store:         store,
+		queue:         make(chan *task, queueSize),
+		workers:       workers,
+		checkInterval: 2 * time.Second,
+		log:           log.NewHelper(logger),
+		running:       make(map[string]context.CancelFunc),
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+}
+
+// Start 启动 worker
+func (m *Manager) Start() {
+	for i := 0; i < m.workers; i++ {
+		m.wg.Add(1)
+		go m.worker()
+	}
+}
+
+// Stop 取消正在执行的操作并等待 worker 退出，排队中的操作保持 PENDING 状态
+func (m *Manager) Stop() {
+	m.cancel()
+	m.wg.Wait()
+}
+
+// Submit 提交异步操作，立即返回 PENDING 状态的操作，调用方以 202 返回操作 ID
+func (m *Manager) Submit(ctx context.Context, name string, fn Func) (*Operation, error) {
+	now := time.Now()
+	op := &Operation{
+		ID:        newID(),
+		Name:      name,
+		Status:    StatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := m.store.Save(ctx, op); err != nil {
+		return nil, err
+	}
+	select {
+	case m.queue <- &task{op: op, fn: fn}:
+	default:
+		_ = m.store.Delete(ctx, op.ID)
+		return nil, ErrQueueFull
+	}
+	cp := *op
+	return &cp, nil
+}
+
+// Get 获取操作
+func (m *Manager) Get(ctx context.Context, id string) (*Operation, error) {
+	return m.store.Get(ctx, id)
+}
+
+// List 列出操作
+func (m *Manager) List(ctx context.Context, name string, limit int) ([]*Operation, error) {
+	return m.store.List(ctx, name, limit)
+}
+
+// Cancel 取消操作，排队中的操作直接标记为已取消，
+// 执行中的操作取消其 ctx，在其他副本上执行的操作通过 CancelRequested 标记在下次检查时取消
+func (m *Manager) Cancel(ctx context.Context, id string) (*Operation, error) {
+	op, err := m.store.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if op.Done {
+		return op, nil
+	}
+	op.CancelRequested = true
+	op.UpdatedAt = time.Now()
+	if op.Status == StatusPending {
+		op.Status = StatusCancelled
+		op.Done = true
+	}
+	if err := m.store.Save(ctx, op); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	cancel, ok := m.running[id]
+	m.mu.Unlock()
+	if ok {
+		cancel()
+	}
+	return op, nil
+}
+
+// Delete 删除操作记录，执行中的操作会被取消
+func (m *Manager) Delete(ctx context.Context, id string) error {
+	if _, err := m.Cancel(ctx, id); err != nil {
+		return err
+	}
+	return m.store.Delete(ctx, id)
+}
+
+// worker 从队列中取出操作执行
+func (m *Manager) worker() {
+	defer m.wg.Done()
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case t := <-m.queue:
+			m.run(t)
+		}
+	}
+}
+
+// run 执行单个操作并保存结果
+func (m *Manager) run(t *task) {
+	// 排队期间可能已被取消或删除
+	op, err := m.store.Get(m.ctx, t.op.ID)
+	if err != nil || op.Done {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(m.ctx)
+	defer cancel()
+	m.mu.Lock()
+	m.running[op.ID] = cancel
+	m.mu.Unlock()
+	defer func() {
+		m.mu.Lock()
+		delete(m.running, op.ID)
+		m.mu.Unlock()
+	}()
+
+	op.Status = StatusRunning
+	op.UpdatedAt = time.Now()
+	if err := m.store.Save(ctx, op); err != nil {
+		m.log.Errorf("operation: save %s failed: %v", op.ID, err)
+	}
+	go m.watchCancel(ctx, cancel, op.ID)
+
+	result, err := m.call(ctx, t.fn, &Progress{m: m, op: op})
+
+	// 使用独立的 ctx 保存结果，避免取消后无法写入
+	saveCtx, saveCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer saveCancel()
+	if latest, e := m.store.Get(saveCtx, op.ID); e == nil {
+		op.CancelRequested = latest.CancelRequested
+	}
+	op.Done = true
+	op.UpdatedAt = time.Now()
+	switch {
+	case op.CancelRequested:
+		op.Status = StatusCancelled
+	case err != nil:
+		op.Status = StatusFailed
+		e := errors.FromError(err)
+		op.Error = &Error{Code: e.Code, Reason: e.Reason, Message: e.Message}
+	default:
+		op.Status = StatusSucceeded
+		op.Progress = 100
+		if result != nil {
+			if op.Result, err = json.Marshal(result); err != nil {
+				op.Status = StatusFailed
+				op.Error = &Error{Code: 500, Message: err.Error()}
+			}
+		}
+	}
+	if err := m.store.Save(saveCtx, op); err != nil {
+		m.log.Errorf("operation: save %s failed: %v", op.ID, err)
+	}
+}
+
+// call 执行操作，panic 视为失败
+func (m *Manager) call(ctx context.Context, fn Func, p *Progress) (result interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			m.log.Errorf("operation: %s panic: %v", p.op.ID, r)
+			err = errors.InternalServer("OPERATION_PANIC", "operation panicked")
+		}
+	}()
+	return fn(ctx, p)
+}
+
+// watchCancel 定期检查存储中的取消标记，用于在其他副本上发起的取消
+func (m *Manager) watchCancel(ctx context.Context, cancel context.CancelFunc, id string) {
+	ticker := time.NewTicker(m.checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			op, err := m.store.Get(ctx, id)
+			if err == nil && !op.CancelRequested {
+				continue
+			}
+			if err != nil && !errors.Is(err, ErrNotFound) {
+				continue
+			}
+			cancel()
+			return
+		}
+	}
+}
+
+// newID 生成操作 ID
+func newID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,182 @@
+package operation
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var _ Store = (*MemoryStore)(nil)
+
+// MemoryStore 内存存储，仅适用于单副本部署，已完成的操作保留 ttl 后清理
+type MemoryStore struct {
+	mu  sync.Mutex
+	ops map[string]*Operation
+	ttl time.Duration
+}
+
+// NewMemoryStore 创建内存存储
+func NewMemoryStore(ttl time.Duration) *MemoryStore {
+	return &MemoryStore{ops: make(map[string]*Operation), ttl: ttl}
+}
+
+// Save 实现 Store 接口
+func (s *MemoryStore) Save(_ context.Context, op *Operation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cp := *op
+	s.ops[op.ID] = &cp
+	s.purge()
+	return nil
+}
+
+// Get 实现 Store 接口
+func (s *MemoryStore) Get(_ context.Context, id string) (*Operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	op, ok := s.ops[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	cp := *op
+	return &cp, nil
+}
+
+// List 实现 Store 接口
+func (s *MemoryStore) List(_ context.Context, name string, limit int) ([]*Operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var list []*Operation
+	for _, op := range s.ops {
+		if name != "" && op.Name != name {
+			continue
+		}
+		cp := *op
+		list = append(list, &cp)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].CreatedAt.After(list[j].CreatedAt)
+	})
+	if limit > 0 && len(list) > limit {
+		list = list[:limit]
+	}
+	return list, nil
+}
+
+// Delete 实现 Store 接口
+func (s *MemoryStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.ops, id)
+	return nil
+}
+
+// purge 清理过期的已完成操作
+func (s *MemoryStore) purge() {
+	if s.ttl <= 0 {
+		return
+	}
+	cutoff := time.Now().Add(-s.ttl)
+	for id, op := range s.ops {
+		if op.Done && op.UpdatedAt.Before(cutoff) {
+			delete(s.ops, id)
+		}
+	}
+}
+
+var _ Store = (*RedisStore)(nil)
+
+// RedisStore Redis 存储，操作以 JSON 保存并设置 ttl，创建时间索引保存在有序集合中
+type RedisStore struct {
+	client redis.UniversalClient
+	prefix string
+	ttl    time.Duration
+}
+
+// NewRedisStore 创建 Redis 存储，key 统一加上 prefix 前缀
+func NewRedisStore(client redis.UniversalClient, prefix string, ttl time.Duration) *RedisStore {
+	return &RedisStore{client: client, prefix: prefix, ttl: ttl}
+}
+
+// Save 实现 Store 接口
+func (s *RedisStore) Save(ctx context.Context, op *Operation) error {
+	b, err := json.Marshal(op)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.TxPipelined(ctx, func(p redis.Pipeliner) error {
+		p.Set(ctx, s.key(op.ID), b, s.ttl)
+		p.ZAdd(ctx, s.indexKey(), redis.Z{Score: float64(op.CreatedAt.UnixNano()), Member: op.ID})
+		return nil
+	})
+	return err
+}
+
+// Get 实现 Store 接口
+func (s *RedisStore) Get(ctx context.Context, id string) (*Operation, error) {
+	b, err := s.client.Get(ctx, s.key(id)).Bytes()
+	if err == redis.Nil {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var op Operation
+	if err := json.Unmarshal(b, &op); err != nil {
+		return nil, err
+	}
+	return &op, nil
+}
+
+// List 实现 Store 接口，索引中已过期的操作在遍历时清理
+func (s *RedisStore) List(ctx context.Context, name string, limit int) ([]*Operation, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	ids, err := s.client.ZRevRange(ctx, s.indexKey(), 0, int64(limit)*4).Result()
+	if err != nil {
+		return nil, err
+	}
+	var list []*Operation
+	for _, id := range ids {
+		op, err := s.Get(ctx, id)
+		if err == ErrNotFound {
+			s.client.ZRem(ctx, s.indexKey(), id)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if name != "" && op.Name != name {
+			continue
+		}
+		list = append(list, op)
+		if len(list) >= limit {
+			break
+		}
+	}
+	return list, nil
+}
+
+// Delete 实现 Store 接口
+func (s *RedisStore) Delete(ctx context.Context, id string) error {
+	_, err := s.client.TxPipelined(ctx, func(p redis.Pipeliner) error {
+		p.Del(ctx, s.key(id))
+		p.ZRem(ctx, s.indexKey(), id)
+		return nil
+	})
+	return err
+}
+
+func (s *RedisStore) key(id string) string {
+	return s.prefix + strings.TrimSpace(id)
+}
+
+func (s *RedisStore) indexKey() string {
+	return s.prefix + "index"
+}
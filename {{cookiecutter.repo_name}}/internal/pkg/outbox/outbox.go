@@ -0,0 +1,260 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"{{cookiecutter.module_name}}/internal/pkg/eventbus"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// DedupHeader 发布事件时携带的去重键，relay 可能重复发布同一条消息，消费者按该值去重
+const DedupHeader = "Outbox-Dedup-Key"
+
+var published, _ = otel.Meter("outbox").Int64Counter(
+	"outbox.messages",
+	metric.WithDescription("Number of outbox messages relayed by result: sent, retry or dead"),
+)
+
+// Message outbox 中待发布的消息
+type Message struct {
+	ID       int64
+	Topic    string
+	Key      string
+	DedupKey string
+	Payload  []byte
+	Headers  map[string]string
+	// Attempts 已发布失败的次数
+	Attempts int32
+}
+
+// Store outbox 存储，消息与业务数据在同一个事务中写入，relay 从中领取并发布
+type Store interface {
+	// Claim 领取最多 limit 条到期的待发送消息，lease 内其他副本不会领取同一条消息
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]*Message, error)
+	// MarkSent 标记消息已发布
+	MarkSent(ctx context.Context, ids []int64) error
+	// MarkFailed 记录发布失败，next 为下次重试时间，dead 为 true 时不再重试
+	MarkFailed(ctx context.Context, m *Message, cause error, next time.Time, dead bool) error
+	// Purge 删除 before 之前已发布的消息
+	Purge(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Publisher 消息发布端，eventbus.Bus 满足该接口
+type Publisher interface {
+	Publish(ctx context.Context, e *eventbus.Event) (string, error)
+}
+
+// Option relay 配置项
+type Option func(*options)
+
+type options struct {
+	batch       int
+	interval    time.Duration
+	maxAttempts int32
+	lease       time.Duration
+	retention   time.Duration
+}
+
+// WithBatch 每次领取的消息数，默认 100
+func WithBatch(n int) Option {
+	return func(o *options) {
+		o.batch = n
+	}
+}
+
+// WithInterval 没有待发送消息时的轮询间隔，默认 1s
+func WithInterval(d time.Duration) Option {
+	return func(o *options) {
+		o.interval = d
+	}
+}
+
+// WithMaxAttempts 最大发布次数，超出后标记为 dead，默认 10
+func WithMaxAttempts(n int32) Option {
+	return func(o *options) {
+		o.maxAttempts = n
+	}
+}
+
+// WithLease 领取的租期，应大于一批消息的发布时间，默认 30s
+func WithLease(d time.Duration) Option {
+	return func(o *options) {
+		o.lease = d
+	}
+}
+
+// WithRetention 已发布消息的保留时间，为 0 时不清理，默认 168h
+func WithRetention(d time.Duration) Option {
+	return func(o *options) {
+		o.retention = d
+	}
+}
+
+// Relay 将 outbox 中的消息发布到消息总线，实现 transport.Server，随应用启动和停止。
+// 发布成功后才标记为已发送，进程在两者之间退出时消息会在租期结束后重新发布，
+// 因此投递语义为至少一次，消费者按 DedupHeader 去重即可做到效果上的恰好一次
+type Relay struct {
+	store Store
+	pub   Publisher
+	opts  options
+	log   *log.Helper
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewRelay 创建 relay，多个副本可以同时运行，通过 Claim 的租期避免重复领取
+func NewRelay(store Store, pub Publisher, logger log.Logger, opts ...Option) *Relay {
+	o := options{
+		batch:       100,
+		interval:    time.Second,
+		maxAttempts: 10,
+		lease:       30 * time.Second,
+		retention:   7 * 24 * time.Hour,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Relay{
+		store: store,
+		pub:   pub,
+		opts:  o,
+		log:   log.NewHelper(log.With(logger, "module", "outbox")),
+	}
+}
+
+// Start 实现 transport.Server，循环领取并发布消息，直到 Stop 或 ctx 结束
+func (r *Relay) Start(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	r.mu.Lock()
+	r.cancel, r.done = cancel, make(chan struct{})
+	done := r.done
+	r.mu.Unlock()
+	defer close(done)
+
+	ticker := time.NewTicker(r.opts.interval)
+	defer ticker.Stop()
+	var purged time.Time
+	for {
+		n, err := r.RunOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			r.log.Errorf("relay: %v", err)
+		}
+		if r.opts.retention > 0 && time.Since(purged) > time.Hour {
+			purged = time.Now()
+			if _, err := r.store.Purge(ctx, purged.Add(-r.opts.retention)); err != nil && ctx.Err() == nil {
+				r.log.Warnf("purge sent messages: %v", err)
+			}
+		}
+		// 领满一批说明还有积压，立即继续
+		if err == nil && n == r.opts.batch {
+			if ctx.Err() != nil {
+				return nil
+			}
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop 实现 transport.Server，等待正在发布的一批消息处理完成
+func (r *Relay) Stop(ctx context.Context) error {
+	r.mu.Lock()
+	cancel, done := r.cancel, r.done
+	r.mu.Unlock()
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// RunOnce 领取并发布一批消息，返回领取的消息数
+func (r *Relay) RunOnce(ctx context.Context) (int, error) {
+	msgs, err := r.store.Claim(ctx, r.opts.batch, r.opts.lease)
+	if err != nil || len(msgs) == 0 {
+		return 0, err
+	}
+	// 已发布的消息必须标记，不随 ctx 取消
+	mctx := context.WithoutCancel(ctx)
+	sent := make([]int64, 0, len(msgs))
+	for _, m := range msgs {
+		if ctx.Err() != nil {
+			// 未发布的消息在租期结束后由其他副本领取
+			break
+		}
+		if err := r.publish(ctx, m); err != nil {
+			r.fail(mctx, m, err)
+			continue
+		}
+		sent = append(sent, m.ID)
+	}
+	if len(sent) > 0 {
+		if err := r.store.MarkSent(mctx, sent); err != nil {
+			return len(msgs), err
+		}
+		r.record(ctx, "sent", len(sent))
+	}
+	return len(msgs), nil
+}
+
+// publish 发布单条消息，附带去重键
+func (r *Relay) publish(ctx context.Context, m *Message) error {
+	headers := make(map[string]string, len(m.Headers)+1)
+	for k, v := range m.Headers {
+		headers[k] = v
+	}
+	headers[DedupHeader] = m.DedupKey
+	_, err := r.pub.Publish(ctx, &eventbus.Event{
+		Topic:   m.Topic,
+		Key:     m.Key,
+		Payload: m.Payload,
+		Headers: headers,
+	})
+	return err
+}
+
+// fail 记录失败并按指数退避安排重试，超过最大次数后标记为 dead
+func (r *Relay) fail(ctx context.Context, m *Message, cause error) {
+	attempts := m.Attempts + 1
+	dead := attempts >= r.opts.maxAttempts
+	if err := r.store.MarkFailed(ctx, m, cause, time.Now().Add(backoff(attempts)), dead); err != nil {
+		r.log.Errorf("mark message %d failed: %v", m.ID, err)
+	}
+	if dead {
+		r.log.Errorf("message %d (%s) is dead after %d attempts: %v", m.ID, m.Topic, attempts, cause)
+		r.record(ctx, "dead", 1)
+		return
+	}
+	r.log.Warnf("publish message %d (%s), attempt %d: %v", m.ID, m.Topic, attempts, cause)
+	r.record(ctx, "retry", 1)
+}
+
+// record 记录发布结果
+func (r *Relay) record(ctx context.Context, result string, n int) {
+	published.Add(ctx, int64(n), metric.WithAttributes(attribute.String("result", result)))
+}
+
+// backoff 第 n 次失败后的等待时间，从 1s 开始翻倍，最长 10m
+func backoff(n int32) time.Duration {
+	if n > 10 {
+		return 10 * time.Minute
+	}
+	return min(time.Second<<(n-1), 10*time.Minute)
+}
@@ -0,0 +1,154 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"{{cookiecutter.module_name}}/internal/pkg/eventbus"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// memStore 内存中的 Store，记录各消息的状态
+type memStore struct {
+	mu      sync.Mutex
+	pending []*Message
+	sent    []int64
+	failed  map[int64]int32
+	dead    []int64
+}
+
+func (s *memStore) Claim(_ context.Context, limit int, _ time.Duration) ([]*Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := min(limit, len(s.pending))
+	msgs := s.pending[:n]
+	s.pending = s.pending[n:]
+	return msgs, nil
+}
+
+func (s *memStore) MarkSent(_ context.Context, ids []int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = append(s.sent, ids...)
+	return nil
+}
+
+func (s *memStore) MarkFailed(_ context.Context, m *Message, _ error, _ time.Time, dead bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failed == nil {
+		s.failed = map[int64]int32{}
+	}
+	s.failed[m.ID] = m.Attempts + 1
+	if dead {
+		s.dead = append(s.dead, m.ID)
+	}
+	return nil
+}
+
+func (s *memStore) Purge(context.Context, time.Time) (int64, error) { return 0, nil }
+
+// memPublisher 记录发布的事件，topic 为 fail 的事件发布失败
+type memPublisher struct {
+	mu     sync.Mutex
+	events []*eventbus.Event
+}
+
+func (p *memPublisher) Publish(_ context.Context, e *eventbus.Event) (string, error) {
+	if e.Topic == "fail" {
+		return "", errors.New("broker unavailable")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, e)
+	return "1", nil
+}
+
+func (p *memPublisher) count() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.events)
+}
+
+func TestRelayRunOnce(t *testing.T) {
+	store := &memStore{pending: []*Message{
+		{ID: 1, Topic: "greeter.created", Key: "a", DedupKey: "d1", Payload: []byte("{}"), Headers: map[string]string{"trace": "t1"}},
+		{ID: 2, Topic: "fail", DedupKey: "d2"},
+		{ID: 3, Topic: "fail", DedupKey: "d3", Attempts: 2},
+		{ID: 4, Topic: "greeter.created", DedupKey: "d4"},
+	}}
+	pub := &memPublisher{}
+	r := NewRelay(store, pub, log.DefaultLogger, WithBatch(10), WithMaxAttempts(3))
+
+	n, err := r.RunOnce(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 4 {
+		t.Fatalf("claimed %d messages, want 4", n)
+	}
+	if len(store.sent) != 2 || store.sent[0] != 1 || store.sent[1] != 4 {
+		t.Errorf("sent = %v, want [1 4]", store.sent)
+	}
+	if store.failed[2] != 1 || store.failed[3] != 3 {
+		t.Errorf("failed attempts = %v, want 2:1 3:3", store.failed)
+	}
+	if len(store.dead) != 1 || store.dead[0] != 3 {
+		t.Errorf("dead = %v, want [3]", store.dead)
+	}
+
+	e := pub.events[0]
+	if e.Topic != "greeter.created" || e.Key != "a" || string(e.Payload) != "{}" {
+		t.Errorf("event = %+v", e)
+	}
+	if e.Headers[DedupHeader] != "d1" || e.Headers["trace"] != "t1" {
+		t.Errorf("headers = %v, want the dedup key and message headers", e.Headers)
+	}
+}
+
+func TestRelayStartStop(t *testing.T) {
+	store := &memStore{}
+	for i := int64(1); i <= 25; i++ {
+		store.pending = append(store.pending, &Message{ID: i, Topic: "greeter.created"})
+	}
+	pub := &memPublisher{}
+	r := NewRelay(store, pub, log.DefaultLogger, WithBatch(10), WithInterval(time.Hour), WithRetention(0))
+
+	errc := make(chan error, 1)
+	go func() { errc <- r.Start(context.Background()) }()
+	// 领满一批时立即继续，不等待轮询间隔
+	deadline := time.Now().Add(2 * time.Second)
+	for pub.count() < 25 {
+		if time.Now().After(deadline) {
+			t.Fatalf("published %d of 25 messages", pub.count())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := r.Stop(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-errc; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for n, want := range map[int32]time.Duration{
+		1:  time.Second,
+		2:  2 * time.Second,
+		5:  16 * time.Second,
+		10: 512 * time.Second,
+		11: 10 * time.Minute,
+		50: 10 * time.Minute,
+	} {
+		if got := backoff(n); got != want {
+			t.Errorf("backoff(%d) = %s, want %s", n, got, want)
+		}
+	}
+}
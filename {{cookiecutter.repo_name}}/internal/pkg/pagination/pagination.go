@@ -0,0 +1,116 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/go-kratos/kratos/v2/errors"
+)
+
+const (
+	// DefaultPageSize 未指定 page_size 时的每页条数
+	DefaultPageSize = 20
+	// MaxPageSize 每页条数上限，超过时按上限返回
+	MaxPageSize = 100
+)
+
+// ErrInvalidCursor 游标无法解析，通常是客户端修改或拼接了游标
+var ErrInvalidCursor = errors.BadRequest("INVALID_CURSOR", "invalid page cursor")
+
+// Request 分页参数，Cursor 不为空时按游标翻页并忽略 Page
+//
+// 偏移翻页适合跳页的管理后台，深翻页时数据库需要扫描并丢弃 offset 行；
+// 游标翻页按主键定位，适合无限滚动和导出，翻页期间插入的数据不会导致重复或遗漏
+type Request struct {
+	// Page 页码，从 1 开始
+	Page int
+	// PageSize 每页条数，默认 DefaultPageSize，最大 MaxPageSize
+	PageSize int
+	// Cursor 上一页返回的 NextCursor
+	Cursor string
+	// WithTotal 同时查询总数，大表上 COUNT 的代价较高，只在需要时开启
+	WithTotal bool
+}
+
+// Limit 每页条数
+func (r Request) Limit() int {
+	switch {
+	case r.PageSize <= 0:
+		return DefaultPageSize
+	case r.PageSize > MaxPageSize:
+		return MaxPageSize
+	default:
+		return r.PageSize
+	}
+}
+
+// Offset 偏移翻页跳过的条数，游标翻页时为 0
+func (r Request) Offset() int {
+	if r.Cursor != "" || r.Page <= 1 {
+		return 0
+	}
+	return (r.Page - 1) * r.Limit()
+}
+
+// AfterID 解析 id 游标，返回上一页最后一条的 id，未提供游标时返回 0
+func (r Request) AfterID() (int64, error) {
+	if r.Cursor == "" {
+		return 0, nil
+	}
+	var c idCursor
+	if err := DecodeCursor(r.Cursor, &c); err != nil {
+		return 0, err
+	}
+	return c.ID, nil
+}
+
+// Result 分页结果
+type Result struct {
+	// Total 总条数，未设置 WithTotal 时为 -1
+	Total int64
+	// NextCursor 下一页的游标，没有更多数据时为空
+	NextCursor string
+}
+
+// idCursor 按自增 id 翻页的游标内容
+type idCursor struct {
+	ID int64 `json:"id"`
+}
+
+// EncodeCursor 将排序键编码为不透明的游标，客户端只需原样传回
+func EncodeCursor(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// DecodeCursor 解析 EncodeCursor 生成的游标
+func DecodeCursor(s string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return ErrInvalidCursor
+	}
+	return nil
+}
+
+// Trim 处理多查询一条的结果：rows 最多 Limit()+1 条，超出时截断并按最后一条的 id 生成下一页游标
+//
+//	rows, err := query.Limit(req.Limit() + 1).Find(...)
+//	rows, next, err := pagination.Trim(rows, req, func(m *Model) int64 { return m.ID })
+func Trim[T any](rows []T, req Request, id func(T) int64) ([]T, string, error) {
+	limit := req.Limit()
+	if len(rows) <= limit {
+		return rows, "", nil
+	}
+	rows = rows[:limit]
+	next, err := EncodeCursor(idCursor{ID: id(rows[limit-1])})
+	if err != nil {
+		return nil, "", err
+	}
+	return rows, next, nil
+}
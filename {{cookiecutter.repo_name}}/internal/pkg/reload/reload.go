@@ -0,0 +1,62 @@
+package reload
+
+import (
+	"sync"
+
+	"github.com/go-kratos/kratos/v2/config"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// Callback 配置变更回调，v 为变更后 key 对应的值
+type Callback func(v config.Value) error
+
+// Registry 配置热更新回调注册表
+// 各模块通过 OnChange 注册关心的配置 key，配置源变更时依次调用回调，
+// 同一个 key 只向配置源注册一次监听
+type Registry struct {
+	config config.Config
+	log    *log.Helper
+
+	mu        sync.Mutex
+	callbacks map[string][]Callback
+}
+
+// New 创建配置热更新回调注册表
+func New(c config.Config, logger log.Logger) *Registry {
+	return &Registry{
+		config:    c,
+		log:       log.NewHelper(logger),
+		callbacks: make(map[string][]Callback),
+	}
+}
+
+// OnChange 注册 key 的变更回调，key 为点分隔的配置路径，如 log.level、server.duplicate
+func (r *Registry) OnChange(key string, fn Callback) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.callbacks[key]; !ok {
+		if err := r.config.Watch(key, r.dispatch); err != nil {
+			return err
+		}
+	}
+	r.callbacks[key] = append(r.callbacks[key], fn)
+	return nil
+}
+
+// dispatch 调用 key 的全部回调，单个回调失败不影响其他回调
+func (r *Registry) dispatch(key string, v config.Value) {
+	r.mu.Lock()
+	callbacks := append([]Callback(nil), r.callbacks[key]...)
+	r.mu.Unlock()
+
+	failed := 0
+	for _, fn := range callbacks {
+		if err := fn(v); err != nil {
+			r.log.Errorf("reload %s failed: %v", key, err)
+			failed++
+		}
+	}
+	if failed == 0 {
+		r.log.Infof("config %s reloaded", key)
+	}
+}
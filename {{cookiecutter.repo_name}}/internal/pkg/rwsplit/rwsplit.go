@@ -0,0 +1,20 @@
+package rwsplit
+
+import "context"
+
+// primaryKey 强制使用主库的 context key
+type primaryKey struct{}
+
+// ForcePrimary 标记 ctx 中的读请求使用主库，用于写后立即读，避免读到副本复制延迟前的旧数据
+//
+//	if err := uc.repo.Update(ctx, g); err != nil { ... }
+//	g, err := uc.repo.FindByID(rwsplit.ForcePrimary(ctx), id)
+func ForcePrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// IsPrimary ctx 是否强制使用主库
+func IsPrimary(ctx context.Context) bool {
+	v, _ := ctx.Value(primaryKey{}).(bool)
+	return v
+}
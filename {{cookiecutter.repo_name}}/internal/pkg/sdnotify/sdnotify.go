@@ -0,0 +1,75 @@
+package sdnotify
+
+import (
+	"context"
+	"net"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	// Ready 服务启动完成
+	Ready = "READY=1"
+	// Stopping 服务开始停止
+	Stopping = "STOPPING=1"
+	// Reloading 服务重新加载配置
+	Reloading = "RELOADING=1"
+	// Watchdog 看门狗心跳
+	Watchdog = "WATCHDOG=1"
+)
+
+// Notify 向 systemd 发送状态通知，未由 systemd 以 Type=notify 启动时忽略
+func Notify(state string) error {
+	socket := os.Getenv("NOTIFY_SOCKET")
+	if socket == "" {
+		return nil
+	}
+	// 以 @ 开头的为 Linux 抽象命名空间
+	if socket[0] == '@' {
+		socket = "\x00" + socket[1:]
+	}
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = conn.Write([]byte(state))
+	return err
+}
+
+// MainPID 通知 systemd 当前进程为主进程，热重启拉起的新进程需发送该通知
+func MainPID() string {
+	return "MAINPID=" + strconv.Itoa(os.Getpid())
+}
+
+// WatchdogInterval 获取 systemd 看门狗超时时间，未启用 WatchdogSec 时返回 0
+func WatchdogInterval() time.Duration {
+	usec, err := strconv.ParseInt(os.Getenv("WATCHDOG_USEC"), 10, 64)
+	if err != nil || usec <= 0 {
+		return 0
+	}
+	// WATCHDOG_PID 存在时只有对应进程需要发送心跳
+	if pid := os.Getenv("WATCHDOG_PID"); pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		return 0
+	}
+	return time.Duration(usec) * time.Microsecond
+}
+
+// RunWatchdog 按看门狗超时时间的一半发送心跳，直到 ctx 结束
+func RunWatchdog(ctx context.Context) {
+	interval := WatchdogInterval()
+	if interval == 0 {
+		return
+	}
+	ticker := time.NewTicker(interval / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			_ = Notify(Watchdog)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package seed
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gopkg.in/yaml.v3"
+)
+
+// Loader 导入 YAML 中一个顶层 key 下的记录，decode 将记录列表解码到传入的切片，
+// 重复执行时不能产生重复数据，已存在的记录应跳过
+type Loader func(ctx context.Context, decode func(v interface{}) error) error
+
+// Exec 执行一条 SQL 语句
+type Exec func(ctx context.Context, query string) error
+
+// Runner 按文件名顺序导入目录中的种子数据
+//
+//	seeds/
+//	  00_dict.sql       # 原样执行，语句需要自己保证幂等，如 INSERT IGNORE、ON CONFLICT DO NOTHING
+//	  10_users.yaml     # 顶层 key 对应 Register 注册的 Loader，经过 biz 层的校验和钩子写入
+type Runner struct {
+	exec    Exec
+	loaders map[string]Loader
+	log     *log.Helper
+}
+
+// New 创建导入器，exec 为 nil 时不支持 SQL 文件
+func New(exec Exec, logger log.Logger) *Runner {
+	return &Runner{
+		exec:    exec,
+		loaders: make(map[string]Loader),
+		log:     log.NewHelper(logger),
+	}
+}
+
+// Register 注册 YAML 顶层 key 的导入函数
+func (r *Runner) Register(key string, l Loader) {
+	r.loaders[key] = l
+}
+
+// Run 导入 dir 下的 .sql、.yaml、.yml 文件，其他文件忽略，遇到错误时停止
+func (r *Runner) Run(ctx context.Context, dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if !e.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		switch strings.ToLower(filepath.Ext(name)) {
+		case ".sql":
+			err = r.runSQL(ctx, path)
+		case ".yaml", ".yml":
+			err = r.runYAML(ctx, path)
+		default:
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("seed %s: %w", path, err)
+		}
+		r.log.Infof("seed: loaded %s", path)
+	}
+	return nil
+}
+
+// runSQL 按语句执行 SQL 文件，语句以行尾的分号结束
+func (r *Runner) runSQL(ctx context.Context, path string) error {
+	if r.exec == nil {
+		return errors.New("sql seeds require a database")
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	for _, stmt := range splitStatements(string(b)) {
+		if err := r.exec(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runYAML 按顶层 key 调用对应的 Loader，文件可以包含多个以 --- 分隔的文档
+func (r *Runner) runYAML(ctx context.Context, path string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	for {
+		var doc map[string]yaml.Node
+		if err := dec.Decode(&doc); errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		keys := make([]string, 0, len(doc))
+		for k := range doc {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			l, ok := r.loaders[k]
+			if !ok {
+				return fmt.Errorf("no loader registered for %q", k)
+			}
+			node := doc[k]
+			decode := func(v interface{}) error {
+				// 未知字段视为错误，避免拼写错误的字段被静默忽略
+				var buf bytes.Buffer
+				if err := yaml.NewEncoder(&buf).Encode(&node); err != nil {
+					return err
+				}
+				d := yaml.NewDecoder(&buf)
+				d.KnownFields(true)
+				return d.Decode(v)
+			}
+			if err := l(ctx, decode); err != nil {
+				return fmt.Errorf("%s: %w", k, err)
+			}
+		}
+	}
+}
+
+// splitStatements 按行尾的分号拆分语句，忽略 -- 注释行和空语句
+func splitStatements(s string) []string {
+	var (
+		stmts []string
+		cur   strings.Builder
+	)
+	for _, line := range strings.Split(s, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		cur.WriteString(line)
+		cur.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			if stmt := strings.TrimSpace(cur.String()); stmt != ";" {
+				stmts = append(stmts, stmt)
+			}
+			cur.Reset()
+		}
+	}
+	if stmt := strings.TrimSpace(cur.String()); stmt != "" {
+		stmts = append(stmts, stmt)
+	}
+	return stmts
+}
@@ -0,0 +1,181 @@
+package shutdown
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+)
+
+// Reporter 停机报告
+// 统计运行期间处理的请求数和停机时的在途请求，停机时等待后台任务并记录各钩子耗时，
+// 最后输出一条结构化的 shutdown report 日志，便于仅凭日志检查发布过程是否健康
+type Reporter struct {
+	log     *log.Helper
+	timeout time.Duration
+	start   time.Time
+
+	served   atomic.Int64
+	inflight atomic.Int64
+
+	mu             sync.Mutex
+	tasks          []task
+	hooks          []hookResult
+	stopAt         time.Time
+	inflightAtStop int64
+}
+
+// task 停机时需要等待的后台任务
+type task struct {
+	name string
+	stop func(ctx context.Context) error
+}
+
+// hookResult 钩子执行结果
+type hookResult struct {
+	name     string
+	duration time.Duration
+	err      error
+}
+
+// NewReporter 创建停机报告，timeout 为等待每个后台任务的超时时间，默认 10s
+func NewReporter(logger log.Logger, timeout time.Duration) *Reporter {
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return &Reporter{
+		log:     log.NewHelper(logger),
+		timeout: timeout,
+		start:   time.Now(),
+	}
+}
+
+// Middleware 统计请求数和在途请求数的中间件
+func (r *Reporter) Middleware() middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			r.inflight.Add(1)
+			defer func() {
+				r.inflight.Add(-1)
+				r.served.Add(1)
+			}()
+			return handler(ctx, req)
+		}
+	}
+}
+
+// Await 注册停机时需要等待的后台任务，stop 应停止任务并等待其退出，超时视为未完成
+func (r *Reporter) Await(name string, stop func(ctx context.Context) error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tasks = append(r.tasks, task{name: name, stop: stop})
+}
+
+// Hook 包装生命周期钩子，记录钩子耗时和错误
+func (r *Reporter) Hook(name string, fn func(ctx context.Context) error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		start := time.Now()
+		err := fn(ctx)
+		r.mu.Lock()
+		r.hooks = append(r.hooks, hookResult{name: name, duration: time.Since(start), err: err})
+		r.mu.Unlock()
+		return err
+	}
+}
+
+// BeforeStop 记录开始停机时的在途请求数，作为第一个 BeforeStop 钩子注册
+func (r *Reporter) BeforeStop(context.Context) error {
+	r.mu.Lock()
+	r.stopAt = time.Now()
+	r.inflightAtStop = r.inflight.Load()
+	r.mu.Unlock()
+	return nil
+}
+
+// AfterStop 服务停止后等待后台任务并输出停机报告，作为最后一个 AfterStop 钩子注册
+func (r *Reporter) AfterStop(ctx context.Context) error {
+	// AfterStop 时应用上下文已取消，等待后台任务只受 timeout 限制
+	ctx = context.WithoutCancel(ctx)
+	r.mu.Lock()
+	tasks := r.tasks
+	stopAt := r.stopAt
+	r.mu.Unlock()
+	if stopAt.IsZero() {
+		stopAt = time.Now()
+	}
+	drain := time.Since(stopAt)
+
+	var awaited, timedOut []string
+	for _, t := range tasks {
+		if r.await(ctx, t) {
+			awaited = append(awaited, t.name)
+		} else {
+			timedOut = append(timedOut, t.name)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	abandoned := r.inflight.Load()
+	drained := r.inflightAtStop - abandoned
+	if drained < 0 {
+		drained = 0
+	}
+	hooks := make([]string, 0, len(r.hooks))
+	var failed []string
+	for _, h := range r.hooks {
+		hooks = append(hooks, fmt.Sprintf("%s=%s", h.name, h.duration.Round(time.Millisecond)))
+		if h.err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", h.name, h.err))
+		}
+	}
+
+	kvs := []interface{}{
+		log.DefaultMessageKey, "shutdown report",
+		"uptime", time.Since(r.start).Round(time.Second).String(),
+		"requests_served", r.served.Load(),
+		"inflight_at_stop", r.inflightAtStop,
+		"inflight_drained", drained,
+		"inflight_abandoned", abandoned,
+		"drain_duration", drain.Round(time.Millisecond).String(),
+		"tasks_awaited", len(awaited),
+		"tasks_timed_out", len(timedOut),
+		"hooks", strings.Join(hooks, ","),
+	}
+	if len(timedOut) > 0 {
+		kvs = append(kvs, "timed_out", strings.Join(timedOut, ","))
+	}
+	if len(failed) > 0 {
+		kvs = append(kvs, "hook_errors", strings.Join(failed, "; "))
+	}
+	if len(timedOut) > 0 || len(failed) > 0 || abandoned > 0 {
+		r.log.Warnw(kvs...)
+	} else {
+		r.log.Infow(kvs...)
+	}
+	return nil
+}
+
+// await 等待后台任务停止，返回是否在超时前完成
+func (r *Reporter) await(ctx context.Context, t task) bool {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- t.stop(ctx)
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			r.log.Errorf("shutdown: stop %s failed: %v", t.name, err)
+		}
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,177 @@
+package sketch
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"reflect"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var (
+	bloomChecks, _ = otel.Meter("sketch").Int64Counter(
+		"sketch.bloom.checks",
+		metric.WithDescription("Number of bloom filter lookups by result: maybe or absent"),
+	)
+	bloomAdds, _ = otel.Meter("sketch").Int64Counter(
+		"sketch.bloom.adds",
+		metric.WithDescription("Number of items added to bloom filters"),
+	)
+)
+
+const (
+	// maxBits Redis 字符串最大 512MB
+	maxBits = 1 << 32
+	// batch 单条命令写入的元素个数
+	batch = 512
+)
+
+// Item 可放入布隆过滤器和 HyperLogLog 的元素类型
+type Item interface {
+	~string | ~[]byte | ~int | ~int32 | ~int64 | ~uint | ~uint32 | ~uint64
+}
+
+// Bloom 基于 Redis 位图的布隆过滤器，用于在查询缓存和数据库前判断数据是否可能存在，防止缓存穿透
+// 返回 false 时一定不存在，返回 true 时可能存在，误判率由创建时的参数决定；不支持删除
+type Bloom[T Item] struct {
+	client redis.UniversalClient
+	key    string
+	m      uint64 // 位数
+	k      int    // 哈希函数个数
+}
+
+// NewBloom 创建布隆过滤器，n 为预计元素个数，fp 为期望误判率，如 0.01
+// key 为 Redis 键名，参数变化后需要换一个 key 并重建，否则已写入的位无法对应
+func NewBloom[T Item](client redis.UniversalClient, key string, n uint64, fp float64) (*Bloom[T], error) {
+	if n == 0 || fp <= 0 || fp >= 1 {
+		return nil, fmt.Errorf("sketch: invalid bloom parameters n=%d fp=%g", n, fp)
+	}
+	m := math.Ceil(-float64(n) * math.Log(fp) / (math.Ln2 * math.Ln2))
+	if m > maxBits {
+		return nil, fmt.Errorf("sketch: bloom filter needs %.0f bits, exceeds redis string limit", m)
+	}
+	k := int(math.Max(1, math.Round(m/float64(n)*math.Ln2)))
+	return &Bloom[T]{client: client, key: key, m: uint64(m), k: k}, nil
+}
+
+// Add 添加元素
+func (b *Bloom[T]) Add(ctx context.Context, items ...T) error {
+	if len(items) == 0 {
+		return nil
+	}
+	if err := b.add(ctx, b.key, items); err != nil {
+		return err
+	}
+	bloomAdds.Add(ctx, int64(len(items)), metric.WithAttributes(attribute.String("filter", b.key)))
+	return nil
+}
+
+// MightContain 元素是否可能存在，Bloom[string] 实现 ttlcache.Guard 接口
+func (b *Bloom[T]) MightContain(ctx context.Context, item T) (bool, error) {
+	offsets := b.offsets(item)
+	pipe := b.client.Pipeline()
+	cmds := make([]*redis.IntCmd, len(offsets))
+	for i, off := range offsets {
+		cmds[i] = pipe.GetBit(ctx, b.key, int64(off))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, err
+	}
+	maybe := true
+	for _, c := range cmds {
+		if c.Val() == 0 {
+			maybe = false
+			break
+		}
+	}
+	result := "absent"
+	if maybe {
+		result = "maybe"
+	}
+	bloomChecks.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("filter", b.key),
+		attribute.String("result", result),
+	))
+	return maybe, nil
+}
+
+// Rebuild 重建过滤器，load 通过 add 写入全部现有数据，完成后原子替换，重建期间旧的过滤器仍可使用
+// 用于首次初始化、参数调整或数据大量删除后降低误判率
+func (b *Bloom[T]) Rebuild(ctx context.Context, load func(add func(items ...T) error) error) error {
+	tmp := b.key + ":rebuild"
+	if err := b.client.Del(ctx, tmp).Err(); err != nil {
+		return err
+	}
+	// 先设置最后一位，空数据时也能创建 key，RENAME 才不会失败
+	if err := b.client.SetBit(ctx, tmp, int64(b.m-1), 0).Err(); err != nil {
+		return err
+	}
+	n := 0
+	err := load(func(items ...T) error {
+		n += len(items)
+		return b.add(ctx, tmp, items)
+	})
+	if err != nil {
+		_ = b.client.Del(context.WithoutCancel(ctx), tmp).Err()
+		return err
+	}
+	if err := b.client.Rename(ctx, tmp, b.key).Err(); err != nil {
+		return err
+	}
+	bloomAdds.Add(ctx, int64(n), metric.WithAttributes(attribute.String("filter", b.key)))
+	return nil
+}
+
+// add 写入元素对应的位，每条 BITFIELD 命令最多写入 batch 个元素
+func (b *Bloom[T]) add(ctx context.Context, key string, items []T) error {
+	for len(items) > 0 {
+		n := min(len(items), batch)
+		args := make([]interface{}, 0, 2+n*b.k*4)
+		args = append(args, "BITFIELD", key)
+		for _, item := range items[:n] {
+			for _, off := range b.offsets(item) {
+				args = append(args, "SET", "u1", off, 1)
+			}
+		}
+		if err := b.client.Do(ctx, args...).Err(); err != nil {
+			return err
+		}
+		items = items[n:]
+	}
+	return nil
+}
+
+// offsets 双重哈希计算 k 个位偏移
+func (b *Bloom[T]) offsets(item T) []uint64 {
+	h := fnv.New128a()
+	_, _ = h.Write(encode(item))
+	sum := h.Sum(nil)
+	h1, h2 := binary.BigEndian.Uint64(sum[:8]), binary.BigEndian.Uint64(sum[8:])
+	out := make([]uint64, b.k)
+	for i := range out {
+		out[i] = (h1 + uint64(i)*h2) % b.m
+	}
+	return out
+}
+
+// encode 元素的字节表示，整数按十进制编码，与同值的字符串等价
+func encode[T Item](item T) []byte {
+	v := reflect.ValueOf(item)
+	switch v.Kind() {
+	case reflect.String:
+		return []byte(v.String())
+	case reflect.Slice:
+		return v.Bytes()
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(nil, v.Int(), 10)
+	default:
+		return strconv.AppendUint(nil, v.Uint(), 10)
+	}
+}
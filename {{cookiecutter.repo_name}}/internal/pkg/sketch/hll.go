@@ -0,0 +1,72 @@
+package sketch
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var hllAdds, _ = otel.Meter("sketch").Int64Counter(
+	"sketch.hll.adds",
+	metric.WithDescription("Number of items added to HyperLogLog counters"),
+)
+
+// HyperLogLog 基于 Redis PFADD/PFCOUNT 的基数估计，如按天统计独立访客，标准误差约 0.81%，每个计数器最多 12KB
+// 计数器按 bucket 区分，Redis 键名为 prefix + bucket，如 uv:2024-01-02
+type HyperLogLog[T Item] struct {
+	client redis.UniversalClient
+	prefix string
+	ttl    time.Duration
+}
+
+// NewHyperLogLog 创建基数计数器，ttl 大于 0 时每次写入后刷新过期时间，用于按时间分桶的统计自动清理
+func NewHyperLogLog[T Item](client redis.UniversalClient, prefix string, ttl time.Duration) *HyperLogLog[T] {
+	return &HyperLogLog[T]{client: client, prefix: prefix, ttl: ttl}
+}
+
+// Add 向 bucket 添加元素
+func (h *HyperLogLog[T]) Add(ctx context.Context, bucket string, items ...T) error {
+	if len(items) == 0 {
+		return nil
+	}
+	args := make([]interface{}, len(items))
+	for i, item := range items {
+		args[i] = encode(item)
+	}
+	key := h.prefix + bucket
+	pipe := h.client.Pipeline()
+	pipe.PFAdd(ctx, key, args...)
+	if h.ttl > 0 {
+		pipe.Expire(ctx, key, h.ttl)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+	hllAdds.Add(ctx, int64(len(items)), metric.WithAttributes(attribute.String("counter", h.prefix)))
+	return nil
+}
+
+// Count 估计 buckets 并集的基数，如传入最近 7 天的 bucket 得到周独立访客数
+func (h *HyperLogLog[T]) Count(ctx context.Context, buckets ...string) (int64, error) {
+	if len(buckets) == 0 {
+		return 0, nil
+	}
+	keys := make([]string, len(buckets))
+	for i, b := range buckets {
+		keys[i] = h.prefix + b
+	}
+	return h.client.PFCount(ctx, keys...).Result()
+}
+
+// Merge 将 buckets 合并到 dest，用于把日统计归档为月统计
+func (h *HyperLogLog[T]) Merge(ctx context.Context, dest string, buckets ...string) error {
+	keys := make([]string, len(buckets))
+	for i, b := range buckets {
+		keys[i] = h.prefix + b
+	}
+	return h.client.PFMerge(ctx, h.prefix+dest, keys...).Err()
+}
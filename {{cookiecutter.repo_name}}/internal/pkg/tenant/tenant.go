@@ -0,0 +1,156 @@
+package tenant
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+)
+
+const (
+	// DefaultHeader 租户 id 请求头
+	DefaultHeader = "X-Tenant-ID"
+	// maxLen 租户 id 最大长度，与 PostgreSQL 标识符长度上限一致
+	maxLen = 63
+)
+
+var (
+	// ErrMissing 请求未带租户 id
+	ErrMissing = errors.BadRequest("TENANT_MISSING", "tenant id is required")
+	// ErrMismatch 请求头中的租户与令牌中的租户不一致
+	ErrMismatch = errors.Forbidden("TENANT_MISMATCH", "tenant id does not match the token")
+)
+
+type (
+	tenantKey struct{}
+	headerKey struct{}
+)
+
+// NewContext 将租户 id 放入 ctx，后台任务按租户处理数据时也使用它
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, id)
+}
+
+// FromContext 取出 ctx 中的租户 id
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(tenantKey{}).(string)
+	return id, ok && id != ""
+}
+
+// FromHeader ctx 中的租户 id 是否来自请求头，请求头未经认证，data 层不为这类租户按模板创建连接
+func FromHeader(ctx context.Context) bool {
+	v, _ := ctx.Value(headerKey{}).(bool)
+	return v
+}
+
+// Valid 租户 id 只允许字母、数字、下划线和连字符，id 会替换到连接串和 schema 名中，不能带其他字符
+func Valid(id string) bool {
+	if id == "" || len(id) > maxLen {
+		return false
+	}
+	for _, c := range id {
+		if c != '_' && c != '-' && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// ClaimsFunc 取出认证中间件校验过的令牌 claims
+type ClaimsFunc func(ctx context.Context) (map[string]interface{}, bool)
+
+// Option 租户解析配置项
+type Option func(*options)
+
+type options struct {
+	header   string
+	claim    string
+	claims   ClaimsFunc
+	fallback string
+	required bool
+}
+
+// WithHeader 租户 id 请求头，默认 X-Tenant-ID，为空时不读取请求头
+func WithHeader(header string) Option {
+	return func(o *options) {
+		o.header = header
+	}
+}
+
+// WithClaim 从令牌的 name claim 读取租户 id，优先于请求头，需要放在认证中间件之后
+func WithClaim(name string, claims ClaimsFunc) Option {
+	return func(o *options) {
+		o.claim = name
+		o.claims = claims
+	}
+}
+
+// WithDefault 请求未带租户 id 时使用的租户
+func WithDefault(id string) Option {
+	return func(o *options) {
+		o.fallback = id
+	}
+}
+
+// WithRequired 请求必须带租户 id，否则返回 400
+func WithRequired(required bool) Option {
+	return func(o *options) {
+		o.required = required
+	}
+}
+
+// Server 解析请求的租户 id 放入 ctx，data 层按 ctx 中的租户选择数据库
+// 令牌 claim 优先，请求头与 claim 同时存在且不一致时返回 403，只来自请求头的租户 id 由 FromHeader 标记
+func Server(opts ...Option) middleware.Middleware {
+	o := &options{header: DefaultHeader}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			id, header, err := o.resolve(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if id == "" {
+				if o.required {
+					return nil, ErrMissing
+				}
+				return handler(ctx, req)
+			}
+			if !Valid(id) {
+				return nil, errors.BadRequest("TENANT_INVALID", fmt.Sprintf("invalid tenant id %q", id))
+			}
+			ctx = NewContext(ctx, id)
+			if header {
+				ctx = context.WithValue(ctx, headerKey{}, true)
+			}
+			return handler(ctx, req)
+		}
+	}
+}
+
+// resolve 按 claim、请求头、默认租户的顺序取租户 id，header 表示租户 id 只来自请求头
+func (o *options) resolve(ctx context.Context) (id string, header bool, err error) {
+	var fromClaim, fromHeader string
+	if o.claims != nil && o.claim != "" {
+		if claims, ok := o.claims(ctx); ok {
+			fromClaim, _ = claims[o.claim].(string)
+		}
+	}
+	if tr, ok := transport.FromServerContext(ctx); ok && o.header != "" {
+		fromHeader = tr.RequestHeader().Get(o.header)
+	}
+	switch {
+	case fromClaim != "" && fromHeader != "" && fromClaim != fromHeader:
+		return "", false, ErrMismatch
+	case fromClaim != "":
+		return fromClaim, false, nil
+	case fromHeader != "":
+		return fromHeader, true, nil
+	default:
+		return o.fallback, false, nil
+	}
+}
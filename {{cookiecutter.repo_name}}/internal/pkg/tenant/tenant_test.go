@@ -0,0 +1,112 @@
+package tenant
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport"
+)
+
+// headerCarrier 测试用请求头
+type headerCarrier http.Header
+
+func (h headerCarrier) Get(key string) string      { return http.Header(h).Get(key) }
+func (h headerCarrier) Set(key, value string)      { http.Header(h).Set(key, value) }
+func (h headerCarrier) Add(key, value string)      { http.Header(h).Add(key, value) }
+func (h headerCarrier) Values(key string) []string { return http.Header(h).Values(key) }
+func (h headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// fakeTransport 测试用 transport.Transporter
+type fakeTransport struct {
+	header headerCarrier
+}
+
+func (t *fakeTransport) Kind() transport.Kind            { return transport.KindHTTP }
+func (t *fakeTransport) Endpoint() string                { return "" }
+func (t *fakeTransport) Operation() string               { return "/test" }
+func (t *fakeTransport) RequestHeader() transport.Header { return t.header }
+func (t *fakeTransport) ReplyHeader() transport.Header   { return headerCarrier{} }
+
+func serve(t *testing.T, header, claim string, opts ...Option) (id string, fromHeader bool, err error) {
+	t.Helper()
+	h := headerCarrier{}
+	if header != "" {
+		h.Set(DefaultHeader, header)
+	}
+	ctx := transport.NewServerContext(context.Background(), &fakeTransport{header: h})
+	claims := func(context.Context) (map[string]interface{}, bool) {
+		if claim == "" {
+			return nil, false
+		}
+		return map[string]interface{}{"tenant_id": claim}, true
+	}
+	opts = append([]Option{WithClaim("tenant_id", claims)}, opts...)
+	_, err = Server(opts...)(func(ctx context.Context, req interface{}) (interface{}, error) {
+		id, _ = FromContext(ctx)
+		fromHeader = FromHeader(ctx)
+		return nil, nil
+	})(ctx, nil)
+	return id, fromHeader, err
+}
+
+func TestServer(t *testing.T) {
+	tests := []struct {
+		name       string
+		header     string
+		claim      string
+		opts       []Option
+		want       string
+		fromHeader bool
+		reason     string
+	}{
+		{name: "claim", claim: "acme", want: "acme"},
+		{name: "header", header: "acme", want: "acme", fromHeader: true},
+		{name: "claim and header agree", header: "acme", claim: "acme", want: "acme"},
+		{name: "claim and header differ", header: "evil", claim: "acme", reason: "TENANT_MISMATCH"},
+		{name: "default", opts: []Option{WithDefault("shared")}, want: "shared"},
+		{name: "none"},
+		{name: "required", opts: []Option{WithRequired(true)}, reason: "TENANT_MISSING"},
+		{name: "invalid", header: "a;drop", reason: "TENANT_INVALID"},
+		{name: "header disabled", header: "acme", opts: []Option{WithHeader("")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, fromHeader, err := serve(t, tt.header, tt.claim, tt.opts...)
+			if tt.reason != "" {
+				if errors.Reason(err) != tt.reason {
+					t.Fatalf("err = %v, want reason %s", err, tt.reason)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if id != tt.want || fromHeader != tt.fromHeader {
+				t.Errorf("tenant = %q (header %v), want %q (header %v)", id, fromHeader, tt.want, tt.fromHeader)
+			}
+		})
+	}
+}
+
+func TestValid(t *testing.T) {
+	for id, want := range map[string]bool{
+		"acme":                   true,
+		"acme_01-eu":             true,
+		"":                       false,
+		"acme.eu":                false,
+		"acme/../x":              false,
+		string(make([]byte, 64)): false,
+	} {
+		if got := Valid(id); got != want {
+			t.Errorf("Valid(%q) = %v, want %v", id, got, want)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package ttlcache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var requests, _ = otel.Meter("ttlcache").Int64Counter(
+	"cache.requests",
+	metric.WithDescription("Number of cache lookups by result: hit, stale, miss or rejected"),
+)
+
+// ErrNotExist Guard 判断 key 不存在，未调用 loader
+var ErrNotExist = errors.New("ttlcache: key does not exist")
+
+// Guard 加载前判断 key 是否可能存在，如 sketch.Bloom[string]，用于拦截不存在的 key，防止缓存穿透
+type Guard interface {
+	MightContain(ctx context.Context, key string) (bool, error)
+}
+
+// Loader 缓存未命中时加载 key 对应的值，如从配置中心或功能开关服务读取
+type Loader[V any] func(ctx context.Context, key string) (V, error)
+
+// Cache 带过期时间的读穿透缓存
+// 未过期时直接返回；过期后 stale 时间内仍返回旧值并在后台刷新 (stale-while-revalidate)；
+// 超过 stale 时间后同步加载，同一 key 的并发加载只执行一次
+type Cache[V any] struct {
+	name   string
+	loader Loader[V]
+	ttl    time.Duration
+	stale  time.Duration
+	guard  Guard
+
+	mu      sync.Mutex
+	entries map[string]*entry[V]
+}
+
+// entry 缓存项
+type entry[V any] struct {
+	value   V
+	expire  time.Time
+	ok      bool          // 是否已成功加载过
+	loading chan struct{} // 正在加载时非 nil，加载完成后关闭
+	err     error         // 最近一次同步加载的错误
+}
+
+// New 创建读穿透缓存，name 作为指标的 cache 维度，ttl 为有效期，stale 为过期后仍可返回旧值的时长
+func New[V any](name string, loader Loader[V], ttl, stale time.Duration) *Cache[V] {
+	return &Cache[V]{
+		name:    name,
+		loader:  loader,
+		ttl:     ttl,
+		stale:   stale,
+		entries: make(map[string]*entry[V]),
+	}
+}
+
+// SetGuard 设置加载前的存在性检查，需要在使用前调用
+// 没有可用缓存值时先经过 Guard，判断不存在时返回 ErrNotExist；Guard 出错时照常加载
+func (c *Cache[V]) SetGuard(g Guard) {
+	c.guard = g
+}
+
+// Get 获取 key 对应的值
+func (c *Cache[V]) Get(ctx context.Context, key string) (V, error) {
+	if c.guard != nil && !c.cached(key) {
+		if ok, err := c.guard.MightContain(ctx, key); err == nil && !ok {
+			c.record(ctx, "rejected")
+			var zero V
+			return zero, ErrNotExist
+		}
+	}
+	now := time.Now()
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	if !ok {
+		e = &entry[V]{}
+		c.entries[key] = e
+	}
+	switch {
+	case e.ok && now.Before(e.expire):
+		v := e.value
+		c.mu.Unlock()
+		c.record(ctx, "hit")
+		return v, nil
+	case e.ok && now.Before(e.expire.Add(c.stale)):
+		v := e.value
+		if e.loading == nil {
+			e.loading = make(chan struct{})
+			go c.load(context.WithoutCancel(ctx), key, e)
+		}
+		c.mu.Unlock()
+		c.record(ctx, "stale")
+		return v, nil
+	}
+
+	loading := e.loading
+	if loading == nil {
+		loading = make(chan struct{})
+		e.loading = loading
+		go c.load(context.WithoutCancel(ctx), key, e)
+	}
+	c.mu.Unlock()
+	c.record(ctx, "miss")
+
+	select {
+	case <-loading:
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// 加载失败时旧值仍在 stale 时间内则返回旧值
+	if e.err != nil && (!e.ok || time.Now().After(e.expire.Add(c.stale))) {
+		var zero V
+		return zero, e.err
+	}
+	return e.value, nil
+}
+
+// cached key 是否有未超过 stale 时间的缓存值
+func (c *Cache[V]) cached(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	return ok && e.ok && time.Now().Before(e.expire.Add(c.stale))
+}
+
+// Invalidate 删除指定 key，下次访问时重新加载，用于配置变更回调
+func (c *Cache[V]) Invalidate(keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, k := range keys {
+		delete(c.entries, k)
+	}
+}
+
+// Purge 清空缓存
+func (c *Cache[V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]*entry[V])
+}
+
+// load 加载并更新缓存项，加载失败时保留旧值
+func (c *Cache[V]) load(ctx context.Context, key string, e *entry[V]) {
+	v, err := c.loader(ctx, key)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e.err = err
+	if err == nil {
+		e.value = v
+		e.ok = true
+		e.expire = time.Now().Add(c.ttl)
+	}
+	close(e.loading)
+	e.loading = nil
+}
+
+// record 记录缓存查询结果
+func (c *Cache[V]) record(ctx context.Context, result string) {
+	requests.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("cache", c.name),
+		attribute.String("result", result),
+	))
+}
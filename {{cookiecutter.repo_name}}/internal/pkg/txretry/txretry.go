@@ -0,0 +1,148 @@
+package txretry
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"gorm.io/gorm"
+)
+
+var (
+	retries, _ = otel.Meter("txretry").Int64Counter(
+		"db.tx.retries",
+		metric.WithDescription("Number of transactions retried after a retryable error"),
+	)
+	exhausted, _ = otel.Meter("txretry").Int64Counter(
+		"db.tx.retries_exhausted",
+		metric.WithDescription("Number of transactions that still failed after all retries"),
+	)
+)
+
+// Option 事务重试配置项
+type Option func(*options)
+
+type options struct {
+	attempts int
+	base     time.Duration
+	max      time.Duration
+	name     string
+}
+
+// WithAttempts 最大执行次数，包括第一次，默认 3
+func WithAttempts(n int) Option {
+	return func(o *options) {
+		o.attempts = n
+	}
+}
+
+// WithBackoff 重试退避时间，每次翻倍并加随机抖动，不超过 max，默认 10ms ~ 1s
+func WithBackoff(base, max time.Duration) Option {
+	return func(o *options) {
+		o.base = base
+		o.max = max
+	}
+}
+
+// WithName 事务名称，作为指标的 tx 维度
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
+
+// IsRetryable 判断错误是否可以通过重试整个事务解决：死锁、锁等待超时、序列化失败
+func IsRetryable(err error) bool {
+	return reason(err) != ""
+}
+
+// reason 可重试错误的原因，不可重试时返回空
+func reason(err error) string {
+	var me *mysql.MySQLError
+	if errors.As(err, &me) {
+		switch me.Number {
+		case 1213:
+			return "deadlock"
+		case 1205:
+			return "lock_wait_timeout"
+		}
+		return ""
+	}
+	// PostgreSQL 驱动 (pgx、lib/pq) 的错误都提供 SQLState
+	var se interface{ SQLState() string }
+	if errors.As(err, &se) {
+		switch se.SQLState() {
+		case "40001":
+			return "serialization_failure"
+		case "40P01":
+			return "deadlock"
+		}
+	}
+	return ""
+}
+
+// Do 执行 fn，遇到可重试错误时按退避时间重新执行整个 fn，直到成功、错误不可重试、
+// 达到最大次数或 ctx 结束。fn 必须是完整的事务，重试时不能依赖上一次执行的副作用
+func Do(ctx context.Context, fn func(ctx context.Context) error, opts ...Option) error {
+	o := options{attempts: 3, base: 10 * time.Millisecond, max: time.Second}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	backoff := o.base
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		why := reason(err)
+		if why == "" {
+			return err
+		}
+		attrs := metric.WithAttributes(
+			attribute.String("tx", o.name),
+			attribute.String("reason", why),
+		)
+		if attempt >= o.attempts {
+			exhausted.Add(ctx, 1, attrs)
+			return err
+		}
+		retries.Add(ctx, 1, attrs)
+
+		// 抖动避免冲突的事务同时重试再次冲突
+		wait := backoff/2 + rand.N(backoff/2+1)
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return errors.Join(err, ctx.Err())
+		case <-t.C:
+		}
+		backoff = min(backoff*2, o.max)
+	}
+}
+
+// Transaction 在 GORM 事务中执行 fn，序列化失败或死锁时回滚并重试整个事务
+func Transaction(ctx context.Context, db *gorm.DB, fn func(tx *gorm.DB) error, opts ...Option) error {
+	return Do(ctx, func(ctx context.Context) error {
+		return db.WithContext(ctx).Transaction(fn)
+	}, opts...)
+}
+
+// SQL 在 database/sql 事务中执行 fn，fn 返回错误时回滚，序列化失败或死锁时重试整个事务
+func SQL(ctx context.Context, db *sql.DB, txOpts *sql.TxOptions, fn func(tx *sql.Tx) error, opts ...Option) error {
+	return Do(ctx, func(ctx context.Context) error {
+		tx, err := db.BeginTx(ctx, txOpts)
+		if err != nil {
+			return err
+		}
+		if err := fn(tx); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		return tx.Commit()
+	}, opts...)
+}
@@ -0,0 +1,357 @@
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"{{cookiecutter.module_name}}/internal/conf"
+	"{{cookiecutter.module_name}}/internal/pkg/confenv"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// Prefix 配置值中的 Vault 密钥引用前缀，格式: vault:<path>#<key>
+const Prefix = "vault:"
+
+// checkInterval 租约续期检查间隔
+const checkInterval = 30 * time.Second
+
+// secret 读取到的密钥，动态密钥带有租约
+type secret struct {
+	data      map[string]interface{}
+	leaseID   string
+	lease     time.Duration
+	renewable bool
+	renewed   time.Time
+}
+
+// expired 租约是否已过期
+func (s *secret) expired() bool {
+	return s.lease > 0 && time.Since(s.renewed) >= s.lease
+}
+
+// Provider Vault 密钥提供者，基于 Vault HTTP API
+// 配置加载时将 vault:secret/data/app#db_password 形式的配置值替换为密钥，
+// 同一路径的密钥只读取一次，数据库凭据等动态密钥在后台按租约续期，
+// 租约无法续期而过期时从缓存中移除，下次加载配置时重新申请
+type Provider struct {
+	addr      string
+	namespace string
+	roleID    string
+	secretID  string
+	timeout   time.Duration
+	client    *http.Client
+	log       *log.Helper
+
+	mu        sync.Mutex
+	token     string
+	tokenTTL  time.Duration
+	renewable bool
+	renewed   time.Time
+	secrets   map[string]*secret
+
+	done chan struct{}
+	once sync.Once
+	wg   sync.WaitGroup
+}
+
+// New 创建 Vault 密钥提供者，配置 role_id 时使用 AppRole 登录，并启动后台续期
+func New(c *conf.Remote_Vault, logger log.Logger) (*Provider, error) {
+	if c.Addr == "" || (c.Token == "" && c.RoleId == "") {
+		return nil, errors.New("vault: addr and token or role_id are required")
+	}
+	p := &Provider{
+		addr:      strings.TrimRight(c.Addr, "/"),
+		namespace: c.Namespace,
+		roleID:    c.RoleId,
+		secretID:  c.SecretId,
+		timeout:   3 * time.Second,
+		client:    &http.Client{},
+		log:       log.NewHelper(logger),
+		token:     c.Token,
+		secrets:   make(map[string]*secret),
+		done:      make(chan struct{}),
+	}
+	if c.Timeout != nil {
+		p.timeout = c.Timeout.AsDuration()
+	}
+	if p.roleID != "" {
+		if err := p.login(context.Background()); err != nil {
+			return nil, err
+		}
+	}
+	p.wg.Add(1)
+	go p.run()
+	return p, nil
+}
+
+// Resolve 替换配置中的 Vault 密钥引用，用于 config.WithResolver
+func (p *Provider) Resolve(input map[string]interface{}) error {
+	return confenv.Walk(input, func(s string) (interface{}, error) {
+		ref, ok := strings.CutPrefix(s, Prefix)
+		if !ok {
+			return s, nil
+		}
+		return p.Get(context.Background(), ref)
+	})
+}
+
+// Get 获取密钥，ref 格式为 <path>#<key>，如 secret/data/app#db_password、database/creds/app#password
+// 同时支持 KV v1、KV v2 和动态密钥
+func (p *Provider) Get(ctx context.Context, ref string) (string, error) {
+	path, key, ok := strings.Cut(ref, "#")
+	if !ok || path == "" || key == "" {
+		return "", fmt.Errorf("vault: invalid reference %q, want <path>#<key>", ref)
+	}
+	s, err := p.secret(ctx, path)
+	if err != nil {
+		return "", err
+	}
+	v, ok := s.data[key]
+	if !ok {
+		return "", fmt.Errorf("vault: key %s not found in %s", key, path)
+	}
+	if str, ok := v.(string); ok {
+		return str, nil
+	}
+	return fmt.Sprint(v), nil
+}
+
+// TransitDecrypt 使用 Transit 引擎解密，ciphertext 格式为 vault:v1:...，用于解密 KMS 加密的数据密钥
+func (p *Provider) TransitDecrypt(ctx context.Context, key, ciphertext string) ([]byte, error) {
+	var res struct {
+		Data struct {
+			Plaintext string `json:"plaintext"`
+		} `json:"data"`
+	}
+	if err := p.do(ctx, http.MethodPost, "/v1/transit/decrypt/"+key, map[string]string{"ciphertext": ciphertext}, &res); err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(res.Data.Plaintext)
+}
+
+// Close 停止后台续期
+func (p *Provider) Close() error {
+	p.once.Do(func() {
+		close(p.done)
+		p.wg.Wait()
+	})
+	return nil
+}
+
+// secret 读取路径下的密钥，已缓存且租约有效时直接返回
+func (p *Provider) secret(ctx context.Context, path string) (*secret, error) {
+	p.mu.Lock()
+	s, ok := p.secrets[path]
+	valid := ok && !s.expired()
+	p.mu.Unlock()
+	if valid {
+		return s, nil
+	}
+
+	var res struct {
+		Data          map[string]interface{} `json:"data"`
+		LeaseID       string                 `json:"lease_id"`
+		LeaseDuration int64                  `json:"lease_duration"`
+		Renewable     bool                   `json:"renewable"`
+	}
+	if err := p.do(ctx, http.MethodGet, "/v1/"+strings.TrimPrefix(path, "/"), nil, &res); err != nil {
+		return nil, err
+	}
+	data := res.Data
+	// KV v2 的密钥在 data.data 中
+	if inner, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data["metadata"]; ok {
+			data = inner
+		}
+	}
+	s = &secret{
+		data:      data,
+		leaseID:   res.LeaseID,
+		lease:     time.Duration(res.LeaseDuration) * time.Second,
+		renewable: res.Renewable,
+		renewed:   time.Now(),
+	}
+	// KV 密钥的 lease_duration 只是建议的刷新间隔，没有租约 ID 时不过期
+	if s.leaseID == "" {
+		s.lease = 0
+	}
+	p.mu.Lock()
+	p.secrets[path] = s
+	p.mu.Unlock()
+	return s, nil
+}
+
+// login AppRole 登录获取令牌
+func (p *Provider) login(ctx context.Context) error {
+	body := map[string]string{"role_id": p.roleID, "secret_id": p.secretID}
+	var res struct {
+		Auth struct {
+			ClientToken   string `json:"client_token"`
+			LeaseDuration int64  `json:"lease_duration"`
+			Renewable     bool   `json:"renewable"`
+		} `json:"auth"`
+	}
+	if err := p.do(ctx, http.MethodPost, "/v1/auth/approle/login", body, &res); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.token = res.Auth.ClientToken
+	p.tokenTTL = time.Duration(res.Auth.LeaseDuration) * time.Second
+	p.renewable = res.Auth.Renewable
+	p.renewed = time.Now()
+	return nil
+}
+
+// run 定期续期令牌和动态密钥租约
+func (p *Provider) run() {
+	defer p.wg.Done()
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), p.timeout*4)
+			p.renewToken(ctx)
+			p.renewLeases(ctx)
+			cancel()
+		}
+	}
+}
+
+// renewToken 令牌使用超过 2/3 有效期时续期，AppRole 令牌续期失败时重新登录
+func (p *Provider) renewToken(ctx context.Context) {
+	p.mu.Lock()
+	due := p.tokenTTL > 0 && time.Since(p.renewed) > p.tokenTTL*2/3
+	renewable := p.renewable
+	p.mu.Unlock()
+	if !due {
+		return
+	}
+	if renewable {
+		var res struct {
+			Auth struct {
+				LeaseDuration int64 `json:"lease_duration"`
+			} `json:"auth"`
+		}
+		err := p.do(ctx, http.MethodPost, "/v1/auth/token/renew-self", map[string]string{}, &res)
+		if err == nil {
+			p.mu.Lock()
+			p.tokenTTL = time.Duration(res.Auth.LeaseDuration) * time.Second
+			p.renewed = time.Now()
+			p.mu.Unlock()
+			return
+		}
+		p.log.Warnf("vault: renew token failed: %v", err)
+	}
+	if p.roleID != "" {
+		if err := p.login(ctx); err != nil {
+			p.log.Errorf("vault: login failed: %v", err)
+		}
+	}
+}
+
+// renewLeases 动态密钥使用超过 2/3 租期时续期，不可续期或续期失败且已过期时移出缓存
+func (p *Provider) renewLeases(ctx context.Context) {
+	p.mu.Lock()
+	due := make(map[string]*secret)
+	for path, s := range p.secrets {
+		if s.lease > 0 && time.Since(s.renewed) > s.lease*2/3 {
+			due[path] = s
+		}
+	}
+	p.mu.Unlock()
+
+	for path, s := range due {
+		if s.renewable {
+			var res struct {
+				LeaseDuration int64 `json:"lease_duration"`
+			}
+			err := p.do(ctx, http.MethodPut, "/v1/sys/leases/renew", map[string]string{"lease_id": s.leaseID}, &res)
+			if err == nil {
+				lease := time.Duration(res.LeaseDuration) * time.Second
+				if lease < s.lease {
+					// 接近最大租期，续期时间缩短，到期后需要申请新的凭据
+					p.log.Warnf("vault: lease of %s is approaching max ttl, expires in %s", path, lease)
+				}
+				p.mu.Lock()
+				s.lease = lease
+				s.renewed = time.Now()
+				p.mu.Unlock()
+				continue
+			}
+			p.log.Warnf("vault: renew lease of %s failed: %v", path, err)
+		}
+		p.mu.Lock()
+		expired := s.expired()
+		if expired && p.secrets[path] == s {
+			delete(p.secrets, path)
+		}
+		p.mu.Unlock()
+		if expired {
+			p.log.Warnf("vault: secret %s expired, it will be read again on next config reload", path)
+		}
+	}
+}
+
+// do 发送请求并解析 JSON 响应
+func (p *Provider) do(ctx context.Context, method, api string, body interface{}, out interface{}) error {
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(b)
+	}
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, method, p.addr+api, r)
+	if err != nil {
+		return err
+	}
+	p.mu.Lock()
+	token := p.token
+	p.mu.Unlock()
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if p.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.namespace)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode/100 != 2 {
+		var e struct {
+			Errors []string `json:"errors"`
+		}
+		_ = json.Unmarshal(b, &e)
+		return fmt.Errorf("vault: %s %s: %s %s", method, api, resp.Status, strings.Join(e.Errors, "; "))
+	}
+	if out == nil || len(b) == 0 {
+		return nil
+	}
+	return json.Unmarshal(b, out)
+}
@@ -3,18 +3,53 @@ package server
 import (
 	v1 "{{cookiecutter.module_name}}/api/{{cookiecutter.file_name}}/v1"
 	"{{cookiecutter.module_name}}/internal/conf"
+	"{{cookiecutter.module_name}}/internal/pkg/diagnose"
+	"{{cookiecutter.module_name}}/internal/pkg/graceful"
 	"{{cookiecutter.module_name}}/internal/service"
 	"github.com/go-kratos/kratos/v2/log"
-	"github.com/go-kratos/kratos/v2/middleware/recovery"
+	"github.com/go-kratos/kratos/v2/middleware"
 	"github.com/go-kratos/kratos/v2/transport/grpc"
 )
 
 // NewGRPCServer new a gRPC server.
-func NewGRPCServer(c *conf.Server, {{cookiecutter.service_name}} *service.{{cookiecutter.service_name}}Service, logger log.Logger) *grpc.Server {
+func NewGRPCServer(c *conf.Server, al AccessLog, sl SlowLog, vc VersionCheck, dp Deprecation, to Timeout, dup Duplicate, tn Tenancy, lg LicenseGate, ff FeatureFlags, lb LogBuffer, ss ShutdownStats, dc *diagnose.Collector, {{cookiecutter.service_name}} *service.{{cookiecutter.service_name}}Service, logger log.Logger) (*grpc.Server, error) {
+	var ms = []middleware.Middleware{
+		middleware.Middleware(ss),
+		newRecovery(),
+	}
+	if lb != nil {
+		ms = append(ms, middleware.Middleware(lb))
+	}
+	if dc != nil {
+		ms = append(ms, dc.Middleware())
+	}
+	if al != nil {
+		ms = append(ms, middleware.Middleware(al))
+	}
+	if sl != nil {
+		ms = append(ms, middleware.Middleware(sl))
+	}
+	if vc != nil {
+		ms = append(ms, middleware.Middleware(vc))
+	}
+	if dp != nil {
+		ms = append(ms, middleware.Middleware(dp))
+	}
+	if to != nil {
+		ms = append(ms, middleware.Middleware(to))
+	}
+	if dup != nil {
+		ms = append(ms, middleware.Middleware(dup))
+	}
+	if lg != nil {
+		ms = append(ms, middleware.Middleware(lg))
+	}
+	if tn != nil {
+		ms = append(ms, middleware.Middleware(tn))
+	}
+	ms = append(ms, middleware.Middleware(ff))
 	var opts = []grpc.ServerOption{
-		grpc.Middleware(
-			recovery.Recovery(),
-		),
+		grpc.Middleware(ms...),
 	}
 	if c.Grpc.Network != "" {
 		opts = append(opts, grpc.Network(c.Grpc.Network))
@@ -25,7 +60,18 @@ func NewGRPCServer(c *conf.Server, {{cookiecutter.service_name}} *service.{{cook
 	if c.Grpc.Timeout != nil {
 		opts = append(opts, grpc.Timeout(c.Grpc.Timeout.AsDuration()))
 	}
+	if c.HotRestart && c.Grpc.Addr != "" {
+		network := c.Grpc.Network
+		if network == "" {
+			network = "tcp"
+		}
+		lis, err := graceful.Listen(network, c.Grpc.Addr)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.Listener(lis))
+	}
 	srv := grpc.NewServer(opts...)
 	v1.Register{{cookiecutter.service_name}}Server(srv, {{cookiecutter.service_name}})
-	return srv
+	return srv, nil
 }
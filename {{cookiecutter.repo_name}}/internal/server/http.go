@@ -3,18 +3,61 @@ package server
 import (
 	v1 "{{cookiecutter.module_name}}/api/{{cookiecutter.file_name}}/v1"
 	"{{cookiecutter.module_name}}/internal/conf"
+	"{{cookiecutter.module_name}}/internal/pkg/apidoc"
+	"{{cookiecutter.module_name}}/internal/pkg/confdump"
+	"{{cookiecutter.module_name}}/internal/pkg/diagnose"
+	"{{cookiecutter.module_name}}/internal/pkg/fieldmask"
+	"{{cookiecutter.module_name}}/internal/pkg/graceful"
+	"{{cookiecutter.module_name}}/internal/pkg/localize"
+	"{{cookiecutter.module_name}}/internal/pkg/notify"
+	"{{cookiecutter.module_name}}/internal/pkg/operation"
 	"{{cookiecutter.module_name}}/internal/service"
 	"github.com/go-kratos/kratos/v2/log"
-	"github.com/go-kratos/kratos/v2/middleware/recovery"
+	"github.com/go-kratos/kratos/v2/middleware"
 	"github.com/go-kratos/kratos/v2/transport/http"
 )
 
 // NewHTTPServer new a HTTP server.
-func NewHTTPServer(c *conf.Server, {{cookiecutter.service_name}} *service.{{cookiecutter.service_name}}Service, logger log.Logger) *http.Server {
+func NewHTTPServer(c *conf.Server, al AccessLog, sl SlowLog, vc VersionCheck, dp Deprecation, to Timeout, dup Duplicate, tn Tenancy, lg LicenseGate, ff FeatureFlags, lb LogBuffer, ss ShutdownStats, dc *diagnose.Collector, om *operation.Manager, mr *notify.Renderer, {{cookiecutter.service_name}} *service.{{cookiecutter.service_name}}Service, logger log.Logger) (*http.Server, error) {
+	var ms = []middleware.Middleware{
+		middleware.Middleware(ss),
+		newRecovery(),
+	}
+	if lb != nil {
+		ms = append(ms, middleware.Middleware(lb))
+	}
+	if dc != nil {
+		ms = append(ms, dc.Middleware())
+	}
+	if al != nil {
+		ms = append(ms, middleware.Middleware(al))
+	}
+	if sl != nil {
+		ms = append(ms, middleware.Middleware(sl))
+	}
+	if vc != nil {
+		ms = append(ms, middleware.Middleware(vc))
+	}
+	if dp != nil {
+		ms = append(ms, middleware.Middleware(dp))
+	}
+	if to != nil {
+		ms = append(ms, middleware.Middleware(to))
+	}
+	if dup != nil {
+		ms = append(ms, middleware.Middleware(dup))
+	}
+	if lg != nil {
+		ms = append(ms, middleware.Middleware(lg))
+	}
+	if tn != nil {
+		ms = append(ms, middleware.Middleware(tn))
+	}
+	ms = append(ms, middleware.Middleware(ff))
 	var opts = []http.ServerOption{
-		http.Middleware(
-			recovery.Recovery(),
-		),
+		http.Middleware(ms...),
+		// 枚举和字典字段按请求语言追加显示名称，并按 fields 参数裁剪响应字段
+		http.ResponseEncoder(fieldmask.ResponseEncoder(localize.ResponseEncoder())),
 	}
 	if c.Http.Network != "" {
 		opts = append(opts, http.Network(c.Http.Network))
@@ -25,7 +68,34 @@ func NewHTTPServer(c *conf.Server, {{cookiecutter.service_name}} *service.{{cook
 	if c.Http.Timeout != nil {
 		opts = append(opts, http.Timeout(c.Http.Timeout.AsDuration()))
 	}
+	if c.HotRestart && c.Http.Addr != "" {
+		network := c.Http.Network
+		if network == "" {
+			network = "tcp"
+		}
+		lis, err := graceful.Listen(network, c.Http.Addr)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, http.Listener(lis))
+	}
 	srv := http.NewServer(opts...)
+	if c.Debug.GetEnable() {
+		// 邮件模板预览，使用模板自带的示例数据渲染
+		srv.HandlePrefix("/debug/mail", confdump.Guard(c.Debug.Token, notify.Preview("/debug/mail", mr)))
+	}
+	if c.Docs.GetEnable() {
+		spec := c.Docs.Openapi
+		if spec == "" {
+			spec = "./openapi.yaml"
+		}
+		docs, err := apidoc.New("/docs", spec, c.Docs.BaseUrl, "helloworld.v1.{{cookiecutter.service_name}}")
+		if err != nil {
+			return nil, err
+		}
+		srv.HandlePrefix("/docs", docs)
+	}
+	operation.Register(srv, "/v1", om)
 	v1.Register{{cookiecutter.service_name}}HTTPServer(srv, {{cookiecutter.service_name}})
-	return srv
+	return srv, nil
 }
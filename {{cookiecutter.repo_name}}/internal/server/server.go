@@ -1,8 +1,368 @@
 package server
 
 import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"{{cookiecutter.module_name}}/internal/conf"
+	"{{cookiecutter.module_name}}/internal/pkg/admin"
+	"{{cookiecutter.module_name}}/internal/pkg/confdump"
+	"{{cookiecutter.module_name}}/internal/pkg/diagnose"
+	"{{cookiecutter.module_name}}/internal/pkg/feature"
+	"{{cookiecutter.module_name}}/internal/pkg/license"
+	pkglog "{{cookiecutter.module_name}}/internal/pkg/log"
+	"{{cookiecutter.module_name}}/internal/pkg/metrics"
+	"{{cookiecutter.module_name}}/internal/pkg/middleware/accesslog"
+	"{{cookiecutter.module_name}}/internal/pkg/middleware/deprecation"
+	"{{cookiecutter.module_name}}/internal/pkg/middleware/duplicate"
+	"{{cookiecutter.module_name}}/internal/pkg/middleware/logbuffer"
+	"{{cookiecutter.module_name}}/internal/pkg/middleware/slowlog"
+	"{{cookiecutter.module_name}}/internal/pkg/middleware/timeout"
+	"{{cookiecutter.module_name}}/internal/pkg/middleware/version"
+	"{{cookiecutter.module_name}}/internal/pkg/module"
+	"{{cookiecutter.module_name}}/internal/pkg/operation"
+	"{{cookiecutter.module_name}}/internal/pkg/reload"
+	"{{cookiecutter.module_name}}/internal/pkg/shutdown"
+	"{{cookiecutter.module_name}}/internal/pkg/tenant"
+
+	"github.com/go-kratos/kratos/v2/config"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/middleware/recovery"
 	"github.com/google/wire"
 )
 
 // ProviderSet is server providers.
-var ProviderSet = wire.NewSet(NewHTTPServer, NewGRPCServer)
+var ProviderSet = wire.NewSet(NewAccessLog, NewSlowLog, NewVersionCheck, NewDeprecation, NewTimeout, NewDuplicate, NewTenancy, NewLogBuffer, NewShutdownStats, NewOperationManager, NewDiagnostics, NewModules, NewFeatures, NewFeatureFlags, NewLicense, NewLicenseGate, NewAdminServer, NewMetricsServer, NewHTTPServer, NewGRPCServer)
+
+// newRecovery panic 恢复中间件，panic 同时上报到 Sentry
+func newRecovery() middleware.Middleware {
+	return recovery.Recovery(recovery.WithHandler(func(ctx context.Context, req, err interface{}) error {
+		pkglog.CapturePanic(ctx, req, err)
+		return recovery.ErrUnknownRequest
+	}))
+}
+
+// AccessLog 访问日志中间件，HTTP 和 gRPC 服务共用同一个访问日志文件
+type AccessLog middleware.Middleware
+
+// NewAccessLog 根据配置创建访问日志中间件，未启用时返回 nil
+func NewAccessLog(c *conf.Log) AccessLog {
+	if !c.GetAccess().GetEnable() {
+		return nil
+	}
+	opts := []accesslog.Option{
+		accesslog.WithCaptureBody(c.Access.CaptureBody),
+	}
+	if c.Access.MaxBodySize > 0 {
+		opts = append(opts, accesslog.WithMaxBodySize(int(c.Access.MaxBodySize)))
+	}
+	if len(c.Access.ContentTypes) > 0 {
+		opts = append(opts, accesslog.WithContentTypes(c.Access.ContentTypes...))
+	}
+	if len(c.Access.TrustedProxies) > 0 {
+		opts = append(opts, accesslog.WithTrustedProxies(c.Access.TrustedProxies...))
+	}
+	return AccessLog(accesslog.Server(pkglog.NewAccessLogger(c), opts...))
+}
+
+// SlowLog 慢请求日志中间件
+type SlowLog middleware.Middleware
+
+// NewSlowLog 根据配置创建慢请求日志中间件，未启用时返回 nil
+// 阈值随 log.slow 配置热更新，启用状态变更需要重启
+func NewSlowLog(c *conf.Log, r *reload.Registry) (SlowLog, error) {
+	if !c.GetSlow().GetEnable() {
+		return nil, nil
+	}
+	var threshold atomic.Int64
+	threshold.Store(int64(time.Second))
+	if c.Slow.Threshold != nil {
+		threshold.Store(int64(c.Slow.Threshold.AsDuration()))
+	}
+	if err := r.OnChange("log.slow", func(v config.Value) error {
+		var sc conf.Log_Slow
+		if err := v.Scan(&sc); err != nil {
+			return err
+		}
+		if sc.Threshold != nil {
+			threshold.Store(int64(sc.Threshold.AsDuration()))
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return SlowLog(slowlog.Dynamic(pkglog.NewSlowLogger(c), func() time.Duration {
+		return time.Duration(threshold.Load())
+	})), nil
+}
+
+// VersionCheck API 版本协商中间件
+type VersionCheck middleware.Middleware
+
+// NewVersionCheck 根据配置创建版本协商中间件，未配置时返回 nil
+func NewVersionCheck(c *conf.Server) VersionCheck {
+	if c.ApiVersion == nil {
+		return nil
+	}
+	opts := []version.Option{
+		version.WithMin(c.ApiVersion.Min),
+		version.WithMax(c.ApiVersion.Max),
+		version.WithRequired(c.ApiVersion.Required),
+	}
+	if c.ApiVersion.Header != "" {
+		opts = append(opts, version.WithHeader(c.ApiVersion.Header))
+	}
+	return VersionCheck(version.Server(opts...))
+}
+
+// Deprecation 废弃接口中间件
+type Deprecation middleware.Middleware
+
+// NewDeprecation 创建废弃接口中间件，接口在 proto 中通过 deprecated 和 (api.options.deprecation) 选项声明
+func NewDeprecation(logger log.Logger) Deprecation {
+	return Deprecation(deprecation.Server(logger))
+}
+
+// Timeout 接口延迟预算中间件
+type Timeout middleware.Middleware
+
+// NewTimeout 创建接口延迟预算中间件，接口在 proto 中通过 (api.options.timeout_ms) 选项声明
+func NewTimeout() Timeout {
+	return Timeout(timeout.Server())
+}
+
+// Duplicate 重复请求检测中间件
+type Duplicate middleware.Middleware
+
+// NewDuplicate 根据配置创建重复请求检测中间件，未启用时返回 nil
+func NewDuplicate(c *conf.Server, logger log.Logger) Duplicate {
+	if !c.GetDuplicate().GetEnable() {
+		return nil
+	}
+	opts := []duplicate.Option{
+		duplicate.WithDedup(c.Duplicate.Dedup),
+	}
+	if c.Duplicate.Window != nil {
+		opts = append(opts, duplicate.WithWindow(c.Duplicate.Window.AsDuration()))
+	}
+	return Duplicate(duplicate.Server(logger, opts...))
+}
+
+// Tenancy 租户解析中间件
+type Tenancy middleware.Middleware
+
+// NewTenancy 根据 data.tenancy 配置创建租户解析中间件，未启用时返回 nil
+// 从令牌 claim 读取租户时，用 tenant.WithClaim 接入认证中间件的 claims
+func NewTenancy(c *conf.Data) Tenancy {
+	if !c.GetTenancy().GetEnable() {
+		return nil
+	}
+	opts := []tenant.Option{
+		tenant.WithRequired(c.Tenancy.Required),
+	}
+	if c.Tenancy.Header != "" {
+		opts = append(opts, tenant.WithHeader(c.Tenancy.Header))
+	}
+	return Tenancy(tenant.Server(opts...))
+}
+
+// LogBuffer 请求级日志缓冲中间件
+type LogBuffer middleware.Middleware
+
+// NewLogBuffer 根据配置创建请求级日志缓冲中间件，未启用时返回 nil
+func NewLogBuffer(c *conf.Log, logger log.Logger) LogBuffer {
+	if !c.GetBuffer().GetEnable() {
+		return nil
+	}
+	opts := []logbuffer.Option{
+		logbuffer.WithMaxLines(int(c.Buffer.MaxLines)),
+	}
+	if c.Buffer.LatencyBudget != nil {
+		opts = append(opts, logbuffer.WithLatencyBudget(c.Buffer.LatencyBudget.AsDuration()))
+	}
+	return LogBuffer(logbuffer.Server(logger, opts...))
+}
+
+// ShutdownStats 停机报告的请求统计中间件
+type ShutdownStats middleware.Middleware
+
+// NewShutdownStats 创建停机报告的请求统计中间件
+func NewShutdownStats(r *shutdown.Reporter) ShutdownStats {
+	return ShutdownStats(r.Middleware())
+}
+
+// NewOperationManager 创建长时间运行操作管理器，默认使用内存存储，
+// 多副本部署时替换为 operation.NewRedisStore 以便任意副本都能查询和取消
+func NewOperationManager(c *conf.Server, r *shutdown.Reporter, logger log.Logger) (*operation.Manager, func()) {
+	ttl := 24 * time.Hour
+	if c.Operation.GetTtl() != nil {
+		ttl = c.Operation.Ttl.AsDuration()
+	}
+	m := operation.NewManager(operation.NewMemoryStore(ttl), int(c.Operation.GetWorkers()), int(c.Operation.GetQueueSize()), logger)
+	m.Start()
+	// 停机时等待执行中的操作退出，结果计入停机报告
+	r.Await("operation", func(context.Context) error {
+		m.Stop()
+		return nil
+	})
+	return m, m.Stop
+}
+
+// NewDiagnostics 根据配置创建请求级分配诊断，未启用时返回 nil
+func NewDiagnostics(c *conf.Server, logger log.Logger) (*diagnose.Collector, func()) {
+	if !c.GetDiagnostics().GetEnable() {
+		return nil, func() {}
+	}
+	dc := diagnose.New(c.Diagnostics.SampleRate, int(c.Diagnostics.Top), c.Diagnostics.Interval.AsDuration(), logger)
+	dc.Start()
+	return dc, dc.Stop
+}
+
+// NewModules 创建后台模块开关注册表，暂停的模块随 server.modules 配置热更新
+func NewModules(c *conf.Server, r *reload.Registry, logger log.Logger) (*module.Registry, error) {
+	m := module.NewRegistry(logger)
+	m.SetPaused(c.Modules.GetPaused())
+	if err := r.OnChange("server.modules", func(v config.Value) error {
+		var mc conf.Server_Modules
+		if err := v.Scan(&mc); err != nil {
+			return err
+		}
+		m.SetPaused(mc.Paused)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// NewFeatures 创建功能开关注册表，开关随 server.features 配置热更新，配置了远程开关服务时定期拉取
+// biz 层通过 feature.Bool 等在默认注册表上定义开关
+func NewFeatures(c *conf.Server, r *reload.Registry) (*feature.Registry, func(), error) {
+	fr := feature.Default
+	fr.SetLocal(feature.ToValues(c.Features.GetFlags().AsMap()))
+	if err := r.OnChange("server.features", func(v config.Value) error {
+		var fc conf.Server_Features
+		if err := v.Scan(&fc); err != nil {
+			return err
+		}
+		fr.SetLocal(feature.ToValues(fc.Flags.AsMap()))
+		return nil
+	}); err != nil {
+		return nil, nil, err
+	}
+	if c.Features.GetRemoteUrl() == "" {
+		return fr, func() {}, nil
+	}
+	interval := 30 * time.Second
+	if c.Features.RemoteInterval != nil {
+		interval = c.Features.RemoteInterval.AsDuration()
+	}
+	stop := fr.Watch(feature.NewHTTPProvider(c.Features.RemoteUrl, interval), interval)
+	return fr, stop, nil
+}
+
+// FeatureFlags 功能开关中间件
+type FeatureFlags middleware.Middleware
+
+// NewFeatureFlags 创建功能开关中间件，请求开始时将开关值快照放入 ctx
+func NewFeatureFlags(c *conf.Server, fr *feature.Registry) FeatureFlags {
+	return FeatureFlags(feature.Server(fr, feature.WithOverride(c.Features.GetAllowOverride())))
+}
+
+// NewLicense 根据配置创建授权检查，未启用时返回 nil
+// 启动时检查一次，required 时检查失败则启动失败，之后按 interval 定期复查；
+// 需要其他授权方式时在这里替换为自定义的 license.Checker
+func NewLicense(c *conf.Server, logger log.Logger) (*license.Manager, func(), error) {
+	lc := c.License
+	if !lc.GetEnable() {
+		return nil, func() {}, nil
+	}
+	var checker license.Checker
+	if lc.File != "" {
+		fc, err := license.NewFileChecker(lc.File)
+		if err != nil {
+			return nil, nil, err
+		}
+		checker = fc
+	} else {
+		checker = license.NewServerChecker(lc.ServerUrl, lc.Token, 10*time.Second)
+	}
+	interval, grace := time.Hour, 72*time.Hour
+	if lc.Interval != nil {
+		interval = lc.Interval.AsDuration()
+	}
+	if lc.Grace != nil {
+		grace = lc.Grace.AsDuration()
+	}
+	m := license.NewManager(checker, interval, grace, logger)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := m.Check(ctx); err != nil && lc.Required {
+		return nil, nil, err
+	}
+	m.Start()
+	return m, m.Stop, nil
+}
+
+// LicenseGate 按接口限制授权模块的中间件
+type LicenseGate middleware.Middleware
+
+// NewLicenseGate 根据 server.license.operations 创建授权中间件，未启用授权检查或未配置接口时返回 nil
+func NewLicenseGate(c *conf.Server, m *license.Manager) LicenseGate {
+	if m == nil || len(c.License.GetOperations()) == 0 {
+		return nil
+	}
+	return LicenseGate(license.Server(m, c.License.Operations))
+}
+
+// AdminServer 管理端口服务，未配置端口时 Server 为 nil
+type AdminServer struct {
+	*admin.Server
+}
+
+// NewAdminServer 创建管理端口服务，提供存活和就绪探针（/healthz?detail=1 包含授权状态）、/modules 模块开关、/features 功能开关、/debug/config 脱敏后的生效配置、/debug/config/history 配置快照和回滚，开启诊断时挂载 /debug/alloc
+func NewAdminServer(c *conf.Server, dc *diagnose.Collector, modules *module.Registry, features *feature.Registry, lm *license.Manager, dumper *confdump.Dumper, history *confdump.History, logger log.Logger) AdminServer {
+	if c.Admin.GetAddr() == "" {
+		return AdminServer{}
+	}
+	srv := admin.NewServer(c.Admin.Addr, logger)
+	// 探针以外的管理接口与 debug 接口使用相同的令牌，未配置令牌时只允许本机访问，如 kubectl port-forward
+	token := c.Debug.GetToken()
+	srv.Handle("/modules", confdump.Guard(token, modules))
+	srv.Handle("/modules/", confdump.Guard(token, modules))
+	srv.Handle("/features", confdump.Guard(token, features))
+	if lm != nil {
+		srv.Detail("license", func() interface{} { return lm.Status() })
+	}
+	srv.Handle("/debug/config", confdump.Guard(token, dumper))
+	srv.Handle("/debug/config/history", confdump.Guard(token, history))
+	if dc != nil {
+		srv.Handle("/debug/alloc", confdump.Guard(token, dc))
+	}
+	return AdminServer{srv}
+}
+
+// MetricsServer Prometheus 指标端口服务，未配置端口时 Server 为 nil
+type MetricsServer struct {
+	*admin.Server
+}
+
+// NewMetricsServer 创建 Prometheus 指标端口服务
+func NewMetricsServer(c *conf.Server, logger log.Logger) (MetricsServer, error) {
+	if c.Metrics.GetAddr() == "" {
+		return MetricsServer{}, nil
+	}
+	h, err := metrics.NewHandler()
+	if err != nil {
+		return MetricsServer{}, err
+	}
+	path := c.Metrics.Path
+	if path == "" {
+		path = "/metrics"
+	}
+	srv := admin.NewServer(c.Metrics.Addr, logger)
+	srv.Handle(path, h)
+	return MetricsServer{srv}, nil
+}
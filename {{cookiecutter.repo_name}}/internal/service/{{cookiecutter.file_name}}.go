@@ -6,27 +6,58 @@ import (
 	"github.com/go-kratos/kratos/v2/log"
 	v1 "{{cookiecutter.module_name}}/api/{{cookiecutter.file_name}}/v1"
 	"{{cookiecutter.module_name}}/internal/biz"
+	"{{cookiecutter.module_name}}/internal/pkg/idempotency"
+	"{{cookiecutter.module_name}}/internal/pkg/tenant"
 )
 
 // {{cookiecutter.service_name}}Service is a {{cookiecutter.repo_name}} service.
 type {{cookiecutter.service_name}}Service struct {
 	v1.Unimplemented{{cookiecutter.service_name}}Server
 
-	uc *biz.{{cookiecutter.service_name}}Usecase
-	log *log.Helper
+	uc   *biz.{{cookiecutter.service_name}}Usecase
+	idem idempotency.Store
+	log  *log.Helper
 }
 
 // New{{cookiecutter.service_name}}Service new a {{cookiecutter.repo_name}} service.
-func New{{cookiecutter.service_name}}Service(uc *biz.{{cookiecutter.service_name}}Usecase,logger log.Logger) *{{cookiecutter.service_name}}Service {
-	return &{{cookiecutter.service_name}}Service{uc: uc, log: log.NewHelper(logger)}
+func New{{cookiecutter.service_name}}Service(uc *biz.{{cookiecutter.service_name}}Usecase, idem idempotency.Store, logger log.Logger) *{{cookiecutter.service_name}}Service {
+	return &{{cookiecutter.service_name}}Service{uc: uc, idem: idem, log: log.NewHelper(logger)}
 }
 
 // SayHello implements helloworld.{{cookiecutter.service_name}}Server.
+// Retries carrying the same Idempotency-Key header get the first reply back without creating another record,
+// keys are scoped to the tenant so different tenants may reuse them.
 func (s *{{cookiecutter.service_name}}Service) SayHello(ctx context.Context, in *v1.HelloRequest) (*v1.HelloReply, error) {
 	s.log.WithContext(ctx).Infof("SayHello: %v", in)
-	g, err := s.uc.Create{{cookiecutter.service_name}}(ctx, &biz.{{cookiecutter.service_name}}{Hello: in.Name})
+	var key string
+	if k := idempotency.KeyFromContext(ctx); k != "" {
+		key = "SayHello:" + k
+		if t, ok := tenant.FromContext(ctx); ok {
+			key = t + ":" + key
+		}
+	}
+	return idempotency.Do(ctx, s.idem, key, idempotency.DefaultTTL, func(ctx context.Context) (*v1.HelloReply, error) {
+		g, err := s.uc.Create{{cookiecutter.service_name}}(ctx, &biz.{{cookiecutter.service_name}}{Hello: in.Name})
+		if err != nil {
+			return nil, err
+		}
+		return &v1.HelloReply{Message: "Hello " + g.Hello}, nil
+	})
+}
+
+// Delete{{cookiecutter.service_name}} implements helloworld.{{cookiecutter.service_name}}Server.
+func (s *{{cookiecutter.service_name}}Service) Delete{{cookiecutter.service_name}}(ctx context.Context, in *v1.Delete{{cookiecutter.service_name}}Request) (*v1.Delete{{cookiecutter.service_name}}Reply, error) {
+	if err := s.uc.Delete{{cookiecutter.service_name}}(ctx, in.Id); err != nil {
+		return nil, err
+	}
+	return &v1.Delete{{cookiecutter.service_name}}Reply{}, nil
+}
+
+// Restore{{cookiecutter.service_name}} implements helloworld.{{cookiecutter.service_name}}Server.
+func (s *{{cookiecutter.service_name}}Service) Restore{{cookiecutter.service_name}}(ctx context.Context, in *v1.Restore{{cookiecutter.service_name}}Request) (*v1.Restore{{cookiecutter.service_name}}Reply, error) {
+	g, err := s.uc.Restore{{cookiecutter.service_name}}(ctx, in.Id)
 	if err != nil {
 		return nil, err
 	}
-	return &v1.HelloReply{Message: "Hello " + g.Hello}, nil
-}
\ No newline at end of file
+	return &v1.Restore{{cookiecutter.service_name}}Reply{Id: g.ID, Hello: g.Hello}, nil
+}